@@ -19,9 +19,11 @@ import (
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	"soldr/pkg/app/agent/config"
+	"soldr/pkg/app/agent/endpoints"
 	"soldr/pkg/app/agent/mmodule"
 	readinessChecker "soldr/pkg/app/agent/readiness_checker"
 	"soldr/pkg/app/agent/service"
+	"soldr/pkg/app/agent/spool"
 	"soldr/pkg/app/agent/upgrader"
 	"soldr/pkg/observability"
 	"soldr/pkg/protoagent"
@@ -113,7 +115,12 @@ func (a *Agent) Init(env svc.Environment) (err error) {
 	defer initSpan.End()
 
 	a.module, err = mmodule.New(
-		a.cfg.Connect,
+		&endpoints.Config{
+			Connect:          a.cfg.Connect,
+			StateFile:        a.cfg.EndpointStateFile,
+			FailbackInterval: a.cfg.FailbackInterval,
+		},
+		a.cfg.GetProxyConfig(),
 		a.cfg.AgentID,
 		a.cfg.Version,
 		a.cfg.Service,
@@ -122,6 +129,11 @@ func (a *Agent) Init(env svc.Environment) (err error) {
 		a.svc,
 		a.cfg.MeterConfigClient,
 		a.cfg.TracerConfigClient,
+		&spool.Config{
+			Dir:     a.cfg.SpoolDir,
+			MaxSize: a.cfg.SpoolMaxSize,
+			MaxAge:  a.cfg.SpoolMaxAge,
+		},
 	)
 	if err != nil {
 		err = fmt.Errorf("failed to create new main module: %w", err)
@@ -186,6 +198,15 @@ func (a *Agent) Manage() (string, error) {
 		if a.cfg.Debug {
 			opts = append(opts, "-debug")
 		}
+		if a.cfg.ProxyURL != "" {
+			opts = append(opts, "-proxy", a.cfg.ProxyURL)
+		}
+		if a.cfg.ProxyFromEnv {
+			opts = append(opts, "-proxy_from_env")
+		}
+		if a.cfg.ProxyBypass != "" {
+			opts = append(opts, "-proxy_bypass", a.cfg.ProxyBypass)
+		}
 		if err := configureServiceAutorestart(a.svc, a.cfg); err != nil {
 			return "", err
 		}
@@ -319,7 +340,13 @@ func configureLogging(ctx context.Context, c *config.Config) (func(), error) {
 		}
 	}
 	meterClient := observability.NewHookMeterClient(c.MeterConfigClient)
-	meterProvider, err := observability.NewMeterProvider(
+	// upgrader process must not occupy metrics endpoint of the agent
+	serveMetrics := c.MetricsListen != "" && c.Mode == config.RunningModeAgent
+	newMeterProvider := observability.NewMeterProvider
+	if serveMetrics {
+		newMeterProvider = observability.NewPullableMeterProvider
+	}
+	meterProvider, err := newMeterProvider(
 		ctx,
 		meterClient,
 		serviceName,
@@ -340,7 +367,17 @@ func configureLogging(ctx context.Context, c *config.Config) (func(), error) {
 		c.Version,
 		logLevels,
 	)
+
+	stopMetrics := func() {}
+	if serveMetrics {
+		stopMetrics, err = observability.StartMetricsServer(c.MetricsListen, meterProvider)
+		if err != nil {
+			observability.Observer.Close()
+			return nil, fmt.Errorf("failed to start a metrics endpoint: %w", err)
+		}
+	}
 	return func() {
+		stopMetrics()
 		observability.Observer.Close()
 	}, nil
 }
@@ -10,6 +10,8 @@ import (
 	"os/signal"
 	"path"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -20,6 +22,8 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sync/errgroup"
 
+	"soldr/pkg/app/api/modules"
+	"soldr/pkg/app/api/oidc"
 	"soldr/pkg/app/api/server"
 	"soldr/pkg/app/api/storage"
 	"soldr/pkg/app/api/useraction"
@@ -41,9 +45,15 @@ type Config struct {
 	Log               LogConfig
 	DB                DBConfig
 	Tracing           TracingConfig
+	Metrics           MetricsConfig
 	PublicAPI         PublicAPIConfig
 	EventWorker       EventWorkerConfig
 	ServerEventWorker ServerEventWorkerConfig
+	AuditLog          AuditLogConfig
+	OIDC              OIDCConfig
+	LoginLockout      LoginLockoutConfig
+	Session           SessionConfig
+	ModuleSigning     ModuleSigningConfig
 }
 
 type LogConfig struct {
@@ -72,12 +82,17 @@ type PublicAPIConfig struct {
 	StaticURL       string        `config:"api_static_url"`
 	TemplatesDir    string        `config:"templates_dir"`
 	CertsPath       string        `config:"certs_path"`
+	TrustedProxies  string        `config:"api_trusted_proxies"`
 }
 
 type TracingConfig struct {
 	Addr string `config:"otel_addr"`
 }
 
+type MetricsConfig struct {
+	Listen string `config:"metrics_listen"`
+}
+
 type EventWorkerConfig struct {
 	PollInterval time.Duration `config:"event_worker_poll_interval"`
 }
@@ -86,6 +101,44 @@ type ServerEventWorkerConfig struct {
 	KeepDays int `config:"retention_events"`
 }
 
+type AuditLogConfig struct {
+	KeepDays int `config:"retention_audit_log"`
+}
+
+type SessionConfig struct {
+	IdleTimeout     time.Duration `config:"session_idle_timeout"`
+	AbsoluteTimeout time.Duration `config:"session_absolute_timeout"`
+	// ProtoTokenSecret signs vxproto tokens, the key is derived from the DB encryption key if it's empty
+	ProtoTokenSecret string `config:"session_proto_token_secret"`
+}
+
+type ModuleSigningConfig struct {
+	SigningKey   string `config:"module_signing_key"`
+	ImportPolicy string `config:"module_import_policy"`
+}
+
+type LoginLockoutConfig struct {
+	MaxUserAttempts int           `config:"login_max_user_attempts"`
+	MaxIPAttempts   int           `config:"login_max_ip_attempts"`
+	Window          time.Duration `config:"login_attempts_window"`
+	Duration        time.Duration `config:"login_lockout_duration"`
+}
+
+type OIDCConfig struct {
+	IssuerURL     string `config:"oidc_issuer_url"`
+	ClientID      string `config:"oidc_client_id"`
+	ClientSecret  string `config:"oidc_client_secret"`
+	RedirectURL   string `config:"oidc_redirect_url"`
+	Scopes        string `config:"oidc_scopes"`
+	RoleClaim     string `config:"oidc_role_claim"`
+	RoleMapping   string `config:"oidc_role_mapping"`
+	DefaultRole   string `config:"oidc_default_role"`
+	TenantClaim   string `config:"oidc_tenant_claim"`
+	TenantMapping string `config:"oidc_tenant_mapping"`
+	DefaultTenant string `config:"oidc_default_tenant"`
+	Provisioning  bool   `config:"oidc_provisioning"`
+}
+
 func defaultConfig() Config {
 	return Config{
 		Log: LogConfig{
@@ -113,9 +166,74 @@ func defaultConfig() Config {
 		ServerEventWorker: ServerEventWorkerConfig{
 			KeepDays: 14,
 		},
+		AuditLog: AuditLogConfig{
+			KeepDays: 180,
+		},
+		Session: SessionConfig{
+			IdleTimeout:     time.Hour,
+			AbsoluteTimeout: 3 * time.Hour,
+		},
+		ModuleSigning: ModuleSigningConfig{
+			ImportPolicy: modules.ImportPolicyWarn,
+		},
+		LoginLockout: LoginLockoutConfig{
+			MaxUserAttempts: 5,
+			MaxIPAttempts:   20,
+			Window:          15 * time.Minute,
+			Duration:        15 * time.Minute,
+		},
+		OIDC: OIDCConfig{
+			Scopes:      "email,profile",
+			RoleClaim:   "groups",
+			TenantClaim: "groups",
+		},
 	}
 }
 
+func newOIDCClient(cfg OIDCConfig) (*oidc.Client, error) {
+	if cfg.IssuerURL == "" {
+		return nil, nil
+	}
+	roleMapping, err := oidc.ParseMapping(cfg.RoleMapping)
+	if err != nil {
+		return nil, fmt.Errorf("invalid role mapping: %w", err)
+	}
+	oidcCfg := oidc.Config{
+		IssuerURL:     cfg.IssuerURL,
+		ClientID:      cfg.ClientID,
+		ClientSecret:  cfg.ClientSecret,
+		RedirectURL:   cfg.RedirectURL,
+		RoleClaim:     cfg.RoleClaim,
+		RoleMapping:   make(map[string]uint64, len(roleMapping)),
+		TenantClaim:   cfg.TenantClaim,
+		DefaultTenant: cfg.DefaultTenant,
+		Provisioning:  cfg.Provisioning,
+	}
+	for _, scope := range strings.Split(cfg.Scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			oidcCfg.Scopes = append(oidcCfg.Scopes, scope)
+		}
+	}
+	for value, role := range roleMapping {
+		roleID, err := strconv.ParseUint(role, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid role ID '%s' in role mapping: %w", role, err)
+		}
+		oidcCfg.RoleMapping[value] = roleID
+	}
+	if cfg.DefaultRole != "" {
+		roleID, err := strconv.ParseUint(cfg.DefaultRole, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default role ID '%s': %w", cfg.DefaultRole, err)
+		}
+		oidcCfg.DefaultRoleID = &roleID
+	}
+	if oidcCfg.TenantMapping, err = oidc.ParseMapping(cfg.TenantMapping); err != nil {
+		return nil, fmt.Errorf("invalid tenant mapping: %w", err)
+	}
+	return oidc.NewClient(oidcCfg, nil)
+}
+
 func main() {
 	var printVersion bool
 	flag.BoolVar(&printVersion, "version", false, "Print current version and exit")
@@ -230,7 +348,11 @@ func main() {
 		logrus.WithError(err).Error("could not create meter client")
 		return
 	}
-	meterProvider, err := observability.NewMeterProvider(
+	newMeterProvider := observability.NewMeterProvider
+	if cfg.Metrics.Listen != "" {
+		newMeterProvider = observability.NewPullableMeterProvider
+	}
+	meterProvider, err := newMeterProvider(
 		ctx,
 		meterClient,
 		serviceName,
@@ -264,6 +386,15 @@ func main() {
 	observability.Observer.StartGoRuntimeMetricCollect(serviceName, version.GetBinaryVersion(), attr)
 	defer observability.Observer.Close()
 
+	if cfg.Metrics.Listen != "" {
+		stopMetrics, err := observability.StartMetricsServer(cfg.Metrics.Listen, meterProvider)
+		if err != nil {
+			logrus.WithError(err).Error("could not start metrics endpoint")
+			return
+		}
+		defer stopMetrics()
+	}
+
 	exchanger := events.NewExchanger()
 	eventWorker := events.NewEventPoller(exchanger, cfg.EventWorker.PollInterval, dbWithORM)
 	go func() {
@@ -281,30 +412,101 @@ func main() {
 	// run worker to synchronize events retention policy to all instance DB
 	go worker.SyncRetentionEvents(ctx, dbWithORM, cfg.ServerEventWorker.KeepDays)
 
+	// run worker to re-evaluate membership of agents in rule-based groups
+	go worker.SyncDynamicGroups(ctx, dbWithORM)
+
+	// run worker to rotate user actions in the audit log
+	go worker.SyncRetentionAuditLog(ctx, dbWithORM, cfg.AuditLog.KeepDays)
+
+	// sessions are stored into DB to share them between API replicas
+	sessionStore := storage.NewDBSessionStore(dbWithORM, storage.SessionStoreConfig{
+		IdleTimeout:     cfg.Session.IdleTimeout,
+		AbsoluteTimeout: cfg.Session.AbsoluteTimeout,
+	})
+
+	// run worker to delete expired sessions
+	go worker.SyncExpiredSessions(ctx, sessionStore)
+
+	// vxproto tokens are signed by the key which is shared between API replicas
+	protoTokenKey, err := storage.MakeProtoTokenKey(cfg.Session.ProtoTokenSecret)
+	if err != nil {
+		logrus.WithError(err).Error("error on making the key to sign vxproto tokens")
+		return
+	}
+
+	// failed login attempts are stored into DB to share the lockout between API replicas
+	loginLimiter := storage.NewLoginLimiter(dbWithORM, storage.LoginLockoutConfig{
+		MaxUserAttempts: cfg.LoginLockout.MaxUserAttempts,
+		MaxIPAttempts:   cfg.LoginLockout.MaxIPAttempts,
+		Window:          cfg.LoginLockout.Window,
+		Duration:        cfg.LoginLockout.Duration,
+	})
+
 	uiStaticURL, err := url.Parse(cfg.PublicAPI.StaticURL)
 	if err != nil {
 		logrus.WithError(err).Error("error on parsing URL to redirect requests to the UI static")
 		return
 	}
-	userActionLogger := useraction.NewLogger()
+	userActionWriter := useraction.NewMultiWriter(
+		useraction.NewLogger(),
+		useraction.NewDBWriter(dbWithORM),
+	)
+
+	oidcClient, err := newOIDCClient(cfg.OIDC)
+	if err != nil {
+		logrus.WithError(err).Error("could not create OpenID Connect client")
+		return
+	}
 
-	router := server.NewRouter(
+	var moduleSigner *modules.PackageSigner
+	if cfg.ModuleSigning.SigningKey != "" {
+		moduleSigner, err = modules.NewPackageSigner(cfg.ModuleSigning.SigningKey)
+		if err != nil {
+			logrus.WithError(err).Error("could not create module archives signer")
+			return
+		}
+		logrus.Infof("exported module archives will be signed by key '%s'", moduleSigner.KeyID())
+	}
+	if !modules.IsValidImportPolicy(cfg.ModuleSigning.ImportPolicy) {
+		logrus.Errorf("unknown module import policy '%s'", cfg.ModuleSigning.ImportPolicy)
+		return
+	}
+
+	var trustedProxies []string
+	for _, proxy := range strings.Split(cfg.PublicAPI.TrustedProxies, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
+	}
+
+	router, err := server.NewRouter(
 		server.RouterConfig{
-			BaseURL:      "/api/v1",
-			Debug:        cfg.Debug,
-			UseSSL:       cfg.PublicAPI.UseSSL,
-			StaticPath:   cfg.PublicAPI.StaticPath,
-			StaticURL:    uiStaticURL,
-			TemplatesDir: cfg.PublicAPI.TemplatesDir,
-			CertsPath:    cfg.PublicAPI.CertsPath,
+			BaseURL:            "/api/v1",
+			Debug:              cfg.Debug,
+			UseSSL:             cfg.PublicAPI.UseSSL,
+			StaticPath:         cfg.PublicAPI.StaticPath,
+			StaticURL:          uiStaticURL,
+			TemplatesDir:       cfg.PublicAPI.TemplatesDir,
+			CertsPath:          cfg.PublicAPI.CertsPath,
+			OIDC:               oidcClient,
+			LoginLimiter:       loginLimiter,
+			SessionStore:       sessionStore,
+			TrustedProxies:     trustedProxies,
+			ProtoTokenKey:      protoTokenKey,
+			ModuleSigner:       moduleSigner,
+			ModuleImportPolicy: cfg.ModuleSigning.ImportPolicy,
 		},
 		dbWithORM,
 		exchanger,
-		userActionLogger,
+		userActionWriter,
 		dbConnectionStorage,
 		s3ConnectionStorage,
 		modulesStorage,
 	)
+	if err != nil {
+		logrus.WithError(err).Error("could not create API router")
+		return
+	}
 
 	group, ctx := errgroup.WithContext(ctx)
 	group.Go(func() error {
@@ -153,6 +153,11 @@ func (s *Server) Init(env svc.Environment) (err error) {
 		logger.WithError(err).Error("failed to initialize a certs provider")
 		return err
 	}
+	agentRateLimits, err := vxproto.ParseRateLimits(s.config.AgentRateLimits)
+	if err != nil {
+		logger.WithError(err).Error("failed to parse the agent rate limits")
+		return err
+	}
 	if s.module, err = mmodule.New(
 		s.config.Listen,
 		cl,
@@ -165,6 +170,8 @@ func (s *Server) Init(env svc.Environment) (err error) {
 		s.tracerClient,
 		s.metricsClient,
 		s.config.MaxConcSyncingAgents,
+		agentRateLimits,
+		&s.config.Forwarder,
 		logrus.StandardLogger().WithField("module", "main"),
 	); err != nil {
 		logger.WithError(err).Error("failed to initialize main module")
@@ -501,7 +508,11 @@ func initObserver(server *Server, _ *logrus.Entry) (func(), error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize a tracer provider: %w", err)
 	}
-	meterProvider, err := observability.NewMeterProvider(
+	newMeterProvider := observability.NewMeterProvider
+	if server.config.MetricsListen != "" {
+		newMeterProvider = observability.NewPullableMeterProvider
+	}
+	meterProvider, err := newMeterProvider(
 		ctx,
 		server.metricsClient,
 		serviceName,
@@ -535,7 +546,17 @@ func initObserver(server *Server, _ *logrus.Entry) (func(), error) {
 		server.version,
 		logLevels,
 	)
+
+	stopMetrics := func() {}
+	if server.config.MetricsListen != "" {
+		stopMetrics, err = observability.StartMetricsServer(server.config.MetricsListen, meterProvider)
+		if err != nil {
+			observability.Observer.Close()
+			return nil, fmt.Errorf("failed to start a metrics endpoint: %w", err)
+		}
+	}
 	return func() {
+		stopMetrics()
 		observability.Observer.Close()
 	}, nil
 }
@@ -0,0 +1,24 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS `user_tokens`
+(
+    `id`           int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `hash`         varchar(32) NOT NULL,
+    `user_id`      int(10) unsigned NOT NULL,
+    `name`         varchar(100) NOT NULL,
+    `token_hash`   varchar(64) NOT NULL,
+    `scopes`       json        NOT NULL,
+    `status`       enum('active','revoked') NOT NULL DEFAULT 'active',
+    `expires_at`   datetime             DEFAULT NULL,
+    `last_used_at` datetime             DEFAULT NULL,
+    `created_date` datetime    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `hash_idx` (`hash`),
+    UNIQUE KEY `token_hash_idx` (`token_hash`),
+    KEY        `fkut_user_id` (`user_id`),
+    CONSTRAINT `fkut_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS `user_tokens`;
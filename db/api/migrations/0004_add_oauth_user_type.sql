@@ -0,0 +1,10 @@
+-- +migrate Up
+
+ALTER TABLE `users` MODIFY COLUMN
+    `type` enum('local','oauth') NOT NULL DEFAULT 'local';
+
+-- +migrate Down
+
+DELETE FROM `users` WHERE `type` = 'oauth';
+ALTER TABLE `users` MODIFY COLUMN
+    `type` enum('local') NOT NULL DEFAULT 'local';
@@ -0,0 +1,15 @@
+-- +migrate Up
+
+INSERT
+IGNORE INTO `privileges` (`role_id`, `name`) VALUES
+    (0, "vxapi.roles.api.create"),
+    (0, "vxapi.roles.api.delete"),
+    (0, "vxapi.roles.api.edit");
+
+-- +migrate Down
+
+DELETE FROM `privileges` WHERE `name` IN (
+    "vxapi.roles.api.create",
+    "vxapi.roles.api.delete",
+    "vxapi.roles.api.edit"
+);
@@ -0,0 +1,33 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS `audit_log`
+(
+    `id`                  bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+    `tenant_id`           int(10) unsigned NOT NULL,
+    `user_id`             int(10) unsigned NOT NULL,
+    `user_uuid`           varchar(36)  NOT NULL DEFAULT '',
+    `user_name`           varchar(70)  NOT NULL DEFAULT '',
+    `domain`              varchar(50)  NOT NULL,
+    `object_type`         varchar(50)  NOT NULL,
+    `object_id`           varchar(255) NOT NULL DEFAULT '',
+    `object_display_name` varchar(255) NOT NULL DEFAULT '',
+    `action_code`         varchar(100) NOT NULL,
+    `success`             bool         NOT NULL DEFAULT false,
+    `fail_reason`         varchar(255) NOT NULL DEFAULT '',
+    `created_date`        datetime(3)  NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (`id`),
+    KEY                   `tenant_id_idx` (`tenant_id`),
+    KEY                   `user_id_idx` (`user_id`),
+    KEY                   `created_date_idx` (`created_date`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+INSERT
+IGNORE INTO `privileges` (`role_id`, `name`) VALUES
+    (0, "vxapi.audit.api.view"),
+    (1, "vxapi.audit.api.view");
+
+-- +migrate Down
+
+DELETE FROM `privileges` WHERE `name` = "vxapi.audit.api.view";
+
+DROP TABLE IF EXISTS `audit_log`;
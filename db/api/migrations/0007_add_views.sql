@@ -0,0 +1,21 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS `views`
+(
+    `id`           int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `tenant_id`    int(10) unsigned NOT NULL,
+    `user_id`      int(10) unsigned NOT NULL,
+    `name`         varchar(100) NOT NULL,
+    `type`         enum('agents','events') NOT NULL,
+    `shared`       bool         NOT NULL DEFAULT false,
+    `query`        json         NOT NULL,
+    `created_date` datetime     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY        `tenant_id_idx` (`tenant_id`),
+    KEY        `fkv_user_id` (`user_id`),
+    CONSTRAINT `fkv_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS `views`;
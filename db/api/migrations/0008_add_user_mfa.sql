@@ -0,0 +1,19 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS `user_mfa`
+(
+    `id`             int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `user_id`        int(10) unsigned NOT NULL,
+    `secret`         varchar(255) NOT NULL,
+    `status`         enum('pending','active') NOT NULL DEFAULT 'pending',
+    `recovery_codes` json         NOT NULL,
+    `last_step`      bigint       NOT NULL DEFAULT 0,
+    `created_date`   datetime     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `fkum_user_id` (`user_id`),
+    CONSTRAINT `fkum_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS `user_mfa`;
@@ -0,0 +1,23 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS `user_sessions`
+(
+    `id`             int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `user_id`        int(10) unsigned          DEFAULT NULL,
+    `token_hash`     varchar(64)  NOT NULL,
+    `data`           blob         NOT NULL,
+    `ip`             varchar(50)  NOT NULL DEFAULT '',
+    `user_agent`     varchar(255) NOT NULL DEFAULT '',
+    `last_active_at` datetime     NOT NULL,
+    `expires_at`     datetime     NOT NULL,
+    `created_date`   datetime     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `token_hash_idx` (`token_hash`),
+    KEY        `expires_at_idx` (`expires_at`),
+    KEY        `fkus_user_id` (`user_id`),
+    CONSTRAINT `fkus_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS `user_sessions`;
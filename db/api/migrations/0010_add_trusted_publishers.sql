@@ -0,0 +1,33 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS `trusted_publishers`
+(
+    `id`           int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `tenant_id`    int(10) unsigned NOT NULL,
+    `name`         varchar(100) NOT NULL,
+    `key_id`       varchar(16)  NOT NULL,
+    `public_key`   varchar(44)  NOT NULL,
+    `status`       enum('active','revoked') NOT NULL DEFAULT 'active',
+    `created_date` datetime     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `tenant_key_id_idx` (`tenant_id`, `key_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+INSERT
+IGNORE INTO `privileges` (`role_id`, `name`) VALUES
+    (0, "vxapi.publishers.api.create"),
+    (0, "vxapi.publishers.api.delete"),
+    (0, "vxapi.publishers.api.edit"),
+    (0, "vxapi.publishers.api.view"),
+    (1, "vxapi.publishers.api.view");
+
+-- +migrate Down
+
+DELETE FROM `privileges` WHERE `name` IN (
+    "vxapi.publishers.api.create",
+    "vxapi.publishers.api.delete",
+    "vxapi.publishers.api.edit",
+    "vxapi.publishers.api.view"
+);
+
+DROP TABLE IF EXISTS `trusted_publishers`;
@@ -0,0 +1,15 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS `login_attempts`
+(
+    `lock_key`     varchar(255) NOT NULL,
+    `failures`     int(10) unsigned NOT NULL DEFAULT 0,
+    `first_fail`   datetime     NOT NULL,
+    `locked_until` datetime              DEFAULT NULL,
+    PRIMARY KEY (`lock_key`),
+    KEY            `first_fail_idx` (`first_fail`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS `login_attempts`;
@@ -0,0 +1,9 @@
+-- +migrate Up
+
+ALTER TABLE `groups`
+    ADD COLUMN `rule` varchar(8192) NOT NULL DEFAULT ''
+    AFTER `info`;
+
+-- +migrate Down
+
+ALTER TABLE `groups` DROP COLUMN `rule`;
@@ -0,0 +1,9 @@
+-- +migrate Up
+
+ALTER TABLE `modules`
+    ADD COLUMN `prev_version` varchar(50) NOT NULL DEFAULT ''
+    AFTER `files_checksums`;
+
+-- +migrate Down
+
+ALTER TABLE `modules` DROP COLUMN `prev_version`;
@@ -7,8 +7,12 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
+	"soldr/pkg/app/agent/endpoints"
+	"soldr/pkg/app/agent/spool"
 	obs "soldr/pkg/observability"
+	"soldr/pkg/vxproto"
 )
 
 // PackageVer is semantic version of vxagent
@@ -31,6 +35,15 @@ type Config struct {
 	PPID                int
 	AgentExecutablePath string
 	PreviousConfig      string
+	SpoolDir            string
+	SpoolMaxSize        int64
+	SpoolMaxAge         time.Duration
+	MetricsListen       string
+	FailbackInterval    time.Duration
+	EndpointStateFile   string
+	ProxyURL            string
+	ProxyFromEnv        bool
+	ProxyBypass         string
 
 	MeterConfigClient  *obs.HookClientConfig
 	TracerConfigClient *obs.HookClientConfig
@@ -59,7 +72,8 @@ func GetConfig() (*Config, error) {
 	c := &Config{
 		Version: getVersion(),
 	}
-	flag.StringVar(&c.Connect, "connect", "wss://localhost:8443", "Connection string")
+	flag.StringVar(&c.Connect, "connect", "wss://localhost:8443",
+		"Connection string, comma separated list of server URLs with optional ';weight=N' suffix to failover")
 	flag.StringVar(&c.AgentID, argNameAgentID, "", "Agent ID for connection to server")
 	flag.StringVar(&c.Command, "command", "", `Command to service control (not required):
   install - install the service to the system
@@ -76,6 +90,15 @@ agent - normal agent mode
 upgrader - upgrader mode, used for the agent upgrade`)
 	flag.StringVar(&c.ppidArg, argNamePPID, "", "Upgrader parent process ID")
 	flag.StringVar(&c.AgentExecutablePath, argNameAgentExec, "", "Path to the agent's executable file")
+	flag.StringVar(&c.SpoolDir, "spool_dir", "", "Directory to keep undelivered events (default <basedir>/data/spool)")
+	flag.Int64Var(&c.SpoolMaxSize, "spool_max_size", spool.DefaultMaxSize, "Size limit of undelivered events on disk in bytes")
+	flag.DurationVar(&c.SpoolMaxAge, "spool_max_age", spool.DefaultMaxAge, "Time to keep undelivered events before dropping them")
+	flag.StringVar(&c.MetricsListen, "metrics_listen", "", "Listen IP:Port to serve Prometheus metrics endpoint (disabled if empty)")
+	flag.StringVar(&c.ProxyURL, "proxy", "", "Proxy URL to connect to the server (http, https, socks5 or socks5h scheme)")
+	flag.BoolVar(&c.ProxyFromEnv, "proxy_from_env", false, "Use HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables")
+	flag.StringVar(&c.ProxyBypass, "proxy_bypass", "", "Comma separated list of hosts, domains and CIDRs to connect directly")
+	flag.DurationVar(&c.FailbackInterval, "failback_interval", endpoints.DefaultFailbackInterval,
+		"Time period to check the preferred server availability to switch back to it")
 	flag.Parse()
 
 	if unknownArgs := flag.Args(); len(unknownArgs) != 0 {
@@ -94,10 +117,27 @@ upgrader - upgrader mode, used for the agent upgrade`)
 		c.LogDir = logDir
 	}
 
+	if spoolDir, ok := os.LookupEnv("SPOOL_DIR"); ok {
+		c.SpoolDir = spoolDir
+	}
+	if metricsListen, ok := os.LookupEnv("METRICS_LISTEN"); ok {
+		c.MetricsListen = metricsListen
+	}
+	if proxyURL, ok := os.LookupEnv("PROXY_URL"); ok {
+		c.ProxyURL = proxyURL
+	}
+	if proxyBypass, ok := os.LookupEnv("PROXY_BYPASS"); ok {
+		c.ProxyBypass = proxyBypass
+	}
+
 	if os.Getenv("DEBUG") != "" {
 		c.Debug = true
 	}
 	c.BaseDir = getBaseDir()
+	if c.SpoolDir == "" {
+		c.SpoolDir = filepath.Join(c.BaseDir, "data", "spool")
+	}
+	c.EndpointStateFile = filepath.Join(c.BaseDir, "data", "endpoint.json")
 	if err := checkConfig(c); err != nil {
 		return nil, err
 	}
@@ -274,3 +314,21 @@ func ChangeOrSetArgs(args []string, argsToSet map[ArgName]string) []string {
 	}
 	return args
 }
+
+// GetProxyConfig returns proxy config to connect to the server or nil if proxy isn't configured
+func (c *Config) GetProxyConfig() *vxproto.ProxyConfig {
+	if c.ProxyURL == "" && !c.ProxyFromEnv {
+		return nil
+	}
+	var bypass []string
+	for _, item := range strings.Split(c.ProxyBypass, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			bypass = append(bypass, item)
+		}
+	}
+	return &vxproto.ProxyConfig{
+		URL:             c.ProxyURL,
+		FromEnvironment: c.ProxyFromEnv,
+		Bypass:          bypass,
+	}
+}
@@ -0,0 +1,337 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultFailbackInterval is a default time period to check the preferred server health
+	// while the agent is connected to other one
+	DefaultFailbackInterval = 5 * time.Minute
+	// DefaultMinBackoff is a default delay before the next connection attempt to the failed server
+	DefaultMinBackoff = 5 * time.Second
+	// DefaultMaxBackoff is a default limit of the delay before the next connection attempt
+	DefaultMaxBackoff = 5 * time.Minute
+
+	listSeparator   = ","
+	paramSeparator  = ";"
+	weightParamName = "weight"
+	probeTimeout    = 10 * time.Second
+)
+
+// Config is a set of options to create a pool of the server endpoints
+type Config struct {
+	// Connect is a comma separated list of the server URLs, every URL may have a weight suffix
+	// (e.g. "wss://main:8443;weight=10,wss://standby:8443"), the greatest weight is preferred
+	// and the endpoints with equal weights are tried in the list order
+	Connect string
+	// StateFile is a path to the file to remember the last successful endpoint across restarts
+	StateFile string
+	// FailbackInterval is a time period to check the preferred endpoint health
+	FailbackInterval time.Duration
+	// MinBackoff is a delay before the next connection attempt after the first failure
+	MinBackoff time.Duration
+	// MaxBackoff is a limit of the exponentially growing delay between connection attempts
+	MaxBackoff time.Duration
+}
+
+// Endpoint is a server URL with its weight
+type Endpoint struct {
+	URL    string
+	Weight int
+}
+
+type endpointState struct {
+	Endpoint
+	failures int
+	retryAt  time.Time
+}
+
+type storedState struct {
+	Endpoint  string    `json:"endpoint"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Pool is an ordered set of the server endpoints with health-based failover and failback
+type Pool struct {
+	mx        sync.Mutex
+	endpoints []*endpointState
+	current   int
+	last      int
+	connected bool
+	failback  bool
+
+	stateFile        string
+	failbackInterval time.Duration
+	minBackoff       time.Duration
+	maxBackoff       time.Duration
+
+	now  func() time.Time
+	rand *rand.Rand
+}
+
+// Parse is function to parse the connection string into the list of endpoints which is sorted by preference
+func Parse(connect string) ([]Endpoint, error) {
+	var list []Endpoint
+	for _, item := range strings.Split(connect, listSeparator) {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, paramSeparator)
+		e := Endpoint{URL: strings.TrimSpace(parts[0])}
+		if e.URL == "" {
+			return nil, fmt.Errorf("server URL is empty in the item '%s'", item)
+		}
+		for _, param := range parts[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if name != weightParamName {
+				return nil, fmt.Errorf("unknown parameter '%s' of the server URL '%s'", name, e.URL)
+			}
+			weight, err := strconv.Atoi(value)
+			if err != nil || weight < 0 {
+				return nil, fmt.Errorf("invalid weight '%s' of the server URL '%s'", value, e.URL)
+			}
+			e.Weight = weight
+		}
+		list = append(list, e)
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("server URL list is empty")
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Weight > list[j].Weight
+	})
+	return list, nil
+}
+
+// New is function to create a pool of the server endpoints, the last successful endpoint
+// is restored from the state file and used for the first connection attempt
+func New(cfg *Config) (*Pool, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("endpoints config is nil")
+	}
+	list, err := Parse(cfg.Connect)
+	if err != nil {
+		return nil, err
+	}
+	p := &Pool{
+		endpoints:        make([]*endpointState, 0, len(list)),
+		last:             -1,
+		stateFile:        cfg.StateFile,
+		failbackInterval: cfg.FailbackInterval,
+		minBackoff:       cfg.MinBackoff,
+		maxBackoff:       cfg.MaxBackoff,
+		now:              time.Now,
+		rand:             rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec
+	}
+	if p.failbackInterval <= 0 {
+		p.failbackInterval = DefaultFailbackInterval
+	}
+	if p.minBackoff <= 0 {
+		p.minBackoff = DefaultMinBackoff
+	}
+	if p.maxBackoff < p.minBackoff {
+		p.maxBackoff = DefaultMaxBackoff
+	}
+	for _, e := range list {
+		p.endpoints = append(p.endpoints, &endpointState{Endpoint: e})
+	}
+	if last := p.loadState(); last != "" {
+		for idx, e := range p.endpoints {
+			if e.URL == last {
+				p.last = idx
+				p.current = idx
+				break
+			}
+		}
+	}
+	return p, nil
+}
+
+// Current returns the endpoint which is used for the current or the last connection attempt
+func (p *Pool) Current() string {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	return p.endpoints[p.current].URL
+}
+
+// FailbackInterval returns the time period to check the preferred endpoint health
+func (p *Pool) FailbackInterval() time.Duration {
+	return p.failbackInterval
+}
+
+// Next selects the endpoint for the next connection attempt and returns it with the delay
+// which must be waited before the attempt, the most preferred available endpoint is chosen
+func (p *Pool) Next() (string, time.Duration) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	now := p.now()
+	p.connected = false
+	// the last successful endpoint after restart is tried first if it's available
+	if p.last != -1 {
+		last := p.last
+		p.last = -1
+		if !p.endpoints[last].retryAt.After(now) {
+			p.current = last
+			return p.endpoints[last].URL, 0
+		}
+	}
+
+	next := 0
+	for idx, e := range p.endpoints {
+		if !e.retryAt.After(now) {
+			p.current = idx
+			return e.URL, 0
+		}
+		if e.retryAt.Before(p.endpoints[next].retryAt) {
+			next = idx
+		}
+	}
+	p.current = next
+	return p.endpoints[next].URL, p.endpoints[next].retryAt.Sub(now)
+}
+
+// ReportSuccess marks the current endpoint as healthy and stores it as the last successful one
+func (p *Pool) ReportSuccess() error {
+	p.mx.Lock()
+	e := p.endpoints[p.current]
+	e.failures = 0
+	e.retryAt = time.Time{}
+	p.connected = true
+	p.failback = false
+	endpoint := e.URL
+	p.mx.Unlock()
+
+	return p.storeState(endpoint)
+}
+
+// ReportFailure marks the current endpoint as failed and postpones the next attempt to connect to it
+// with the exponential backoff and jitter, the failure after failback isn't accounted
+func (p *Pool) ReportFailure() {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	p.connected = false
+	if p.failback {
+		p.failback = false
+		return
+	}
+	e := p.endpoints[p.current]
+	e.failures++
+	e.retryAt = p.now().Add(p.backoff(e.failures))
+}
+
+// FailbackCandidate returns the preferred endpoint if the agent is connected to other one
+func (p *Pool) FailbackCandidate() (string, bool) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	if !p.connected || p.current == 0 {
+		return "", false
+	}
+	return p.endpoints[0].URL, true
+}
+
+// Failback resets the state of the more preferred endpoints to use them on the next connection attempt,
+// the caller must close the current connection after that
+func (p *Pool) Failback() {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	for idx := 0; idx < p.current; idx++ {
+		p.endpoints[idx].failures = 0
+		p.endpoints[idx].retryAt = time.Time{}
+	}
+	p.failback = true
+}
+
+func (p *Pool) backoff(failures int) time.Duration {
+	delay := p.maxBackoff
+	if shift := failures - 1; shift < 32 {
+		if d := p.minBackoff << shift; d > 0 && d < p.maxBackoff {
+			delay = d
+		}
+	}
+	// full jitter in the upper half of the delay to spread reconnections of the agents
+	half := delay / 2
+	return half + time.Duration(p.rand.Int63n(int64(half)+1))
+}
+
+func (p *Pool) loadState() string {
+	if p.stateFile == "" {
+		return ""
+	}
+	data, err := os.ReadFile(p.stateFile)
+	if err != nil {
+		return ""
+	}
+	var state storedState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return ""
+	}
+	return state.Endpoint
+}
+
+func (p *Pool) storeState(endpoint string) error {
+	if p.stateFile == "" {
+		return nil
+	}
+	data, err := json.Marshal(storedState{Endpoint: endpoint, UpdatedAt: p.now().UTC()})
+	if err != nil {
+		return fmt.Errorf("failed to marshal the endpoint state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(p.stateFile), 0o700); err != nil {
+		return fmt.Errorf("failed to create the endpoint state directory: %w", err)
+	}
+	tmpFile := p.stateFile + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write the endpoint state: %w", err)
+	}
+	if err := os.Rename(tmpFile, p.stateFile); err != nil {
+		return fmt.Errorf("failed to store the endpoint state: %w", err)
+	}
+	return nil
+}
+
+// DialFunc is a function to open TCP connection to the address (e.g. through proxy)
+type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// Probe checks that the server endpoint accepts TCP connections, the dial function is optional
+func Probe(ctx context.Context, endpoint string, dial DialFunc) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to parse the server URL '%s': %w", endpoint, err)
+	}
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "wss", "https":
+			port = "443"
+		default:
+			port = "80"
+		}
+	}
+	if dial == nil {
+		dialer := &net.Dialer{}
+		dial = dialer.DialContext
+	}
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	conn, err := dial(ctx, "tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return fmt.Errorf("server '%s' is unavailable: %w", endpoint, err)
+	}
+	return conn.Close()
+}
@@ -0,0 +1,117 @@
+package endpoints
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPool(t *testing.T, cfg *Config, now *time.Time) *Pool {
+	t.Helper()
+	p, err := New(cfg)
+	require.NoError(t, err)
+	p.now = func() time.Time { return *now }
+	return p
+}
+
+func TestParse(t *testing.T) {
+	list, err := Parse(" wss://a:8443 , wss://b:8443;weight=10,wss://c:8443;weight=10,")
+	require.NoError(t, err)
+	assert.Equal(t, []Endpoint{
+		{URL: "wss://b:8443", Weight: 10},
+		{URL: "wss://c:8443", Weight: 10},
+		{URL: "wss://a:8443"},
+	}, list)
+
+	for _, connect := range []string{"", " , ", "wss://a:8443;weight=x", "wss://a:8443;weight=-1", "wss://a;prio=1", ";weight=1"} {
+		_, err := Parse(connect)
+		assert.Error(t, err, connect)
+	}
+}
+
+func TestPoolFailover(t *testing.T) {
+	now := time.Now()
+	p := newTestPool(t, &Config{
+		Connect:    "wss://a:8443,wss://b:8443",
+		MinBackoff: 10 * time.Second,
+		MaxBackoff: 40 * time.Second,
+	}, &now)
+
+	endpoint, delay := p.Next()
+	assert.Equal(t, "wss://a:8443", endpoint)
+	assert.Zero(t, delay)
+	p.ReportFailure()
+
+	// the standby server is used immediately while the preferred one is in backoff
+	endpoint, delay = p.Next()
+	assert.Equal(t, "wss://b:8443", endpoint)
+	assert.Zero(t, delay)
+	p.ReportFailure()
+
+	// both servers are failed so the earliest one must be waited with jittered backoff
+	_, delay = p.Next()
+	assert.True(t, delay >= 5*time.Second && delay <= 10*time.Second, delay)
+	for i := 0; i < 10; i++ {
+		p.ReportFailure()
+	}
+	for i := 0; i < 10; i++ {
+		assert.LessOrEqual(t, p.backoff(i+1), 40*time.Second)
+	}
+
+	// preferred server recovers after backoff
+	now = now.Add(time.Hour)
+	endpoint, delay = p.Next()
+	assert.Equal(t, "wss://a:8443", endpoint)
+	assert.Zero(t, delay)
+	require.NoError(t, p.ReportSuccess())
+	_, ok := p.FailbackCandidate()
+	assert.False(t, ok, "connected to the preferred server")
+}
+
+func TestPoolFailbackAndState(t *testing.T) {
+	now := time.Now()
+	cfg := &Config{
+		Connect:   "wss://a:8443;weight=1,wss://b:8443",
+		StateFile: filepath.Join(t.TempDir(), "data", "endpoint.json"),
+	}
+	p := newTestPool(t, cfg, &now)
+	_, _ = p.Next()
+	p.ReportFailure()
+	endpoint, _ := p.Next()
+	require.Equal(t, "wss://b:8443", endpoint)
+	require.NoError(t, p.ReportSuccess())
+
+	preferred, ok := p.FailbackCandidate()
+	require.True(t, ok)
+	assert.Equal(t, "wss://a:8443", preferred)
+	p.Failback()
+	// dropped connection after failback isn't a failure of the standby server
+	p.ReportFailure()
+	assert.Zero(t, p.endpoints[1].failures)
+	endpoint, delay := p.Next()
+	assert.Equal(t, "wss://a:8443", endpoint)
+	assert.Zero(t, delay)
+
+	// the last successful endpoint is used first after restart
+	p = newTestPool(t, cfg, &now)
+	endpoint, _ = p.Next()
+	assert.Equal(t, "wss://b:8443", endpoint)
+	require.NoError(t, p.ReportSuccess())
+	_, ok = p.FailbackCandidate()
+	assert.True(t, ok)
+}
+
+func TestProbe(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+
+	assert.NoError(t, Probe(context.Background(), "wss://"+addr, nil))
+	require.NoError(t, listener.Close())
+	assert.Error(t, Probe(context.Background(), "wss://"+addr, nil))
+}
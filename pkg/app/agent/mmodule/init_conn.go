@@ -43,6 +43,7 @@ func (mm *MainModule) getInitConnectionConfigForVXProto(c *initConnectConfig) (*
 		CommonConfig: &vxproto.CommonConfig{
 			Host:      c.Host,
 			TLSConfig: tlsConfig,
+			Proxy:     mm.proxyConfig,
 		},
 		Type:            c.Type,
 		ProtocolVersion: protocolVersion,
@@ -2,6 +2,7 @@ package mmodule
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"path"
 	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -17,6 +19,8 @@ import (
 	"github.com/vxcontrol/luar"
 	"go.opentelemetry.io/otel/attribute"
 
+	"soldr/pkg/app/agent/endpoints"
+	"soldr/pkg/app/agent/spool"
 	"soldr/pkg/app/api/models"
 	vxcommonErrors "soldr/pkg/errors"
 	"soldr/pkg/hardening/luavm/store/types"
@@ -36,19 +40,20 @@ var protocolVersion = ""
 
 // MainModule is struct which contains full state for agent working
 type MainModule struct {
-	ctx              context.Context
-	cancelCtx        context.CancelFunc
-	proto            vxproto.IVXProto
-	connectionString string
-	agentID          string
-	version          string
-	modules          map[string]*loader.ModuleConfig
-	loader           loader.ILoader
-	msocket          vxproto.IModuleSocket
-	wgReceiver       sync.WaitGroup
-	hasStopped       bool
-	mutexResp        *sync.Mutex
-	stopConnect      func()
+	ctx         context.Context
+	cancelCtx   context.CancelFunc
+	proto       vxproto.IVXProto
+	endpoints   *endpoints.Pool
+	proxyConfig *vxproto.ProxyConfig
+	agentID     string
+	version     string
+	modules     map[string]*loader.ModuleConfig
+	loader      loader.ILoader
+	msocket     vxproto.IModuleSocket
+	wgReceiver  sync.WaitGroup
+	hasStopped  bool
+	mutexResp   *sync.Mutex
+	stopConnect func()
 
 	meterConfigClient  *obs.HookClientConfig
 	tracerConfigClient *obs.HookClientConfig
@@ -56,6 +61,10 @@ type MainModule struct {
 	upgrader   *upgrader
 	upgraderWG sync.WaitGroup
 
+	eventSpool  *spool.Spool
+	replayWG    sync.WaitGroup
+	isReplaying atomic.Bool
+
 	tlsConfigurer         vm.TLSConfigurer
 	connValidator         *connValidator.Validator
 	tunnelEncrypter       tunnel.PackEncryptor
@@ -169,7 +178,8 @@ func (mm *MainModule) recvAction(ctx context.Context, src string, act *vxproto.A
 
 // New is function which constructed MainModule object
 func New(
-	connectionString,
+	endpointsConfig *endpoints.Config,
+	proxyConfig *vxproto.ProxyConfig,
 	agentID,
 	version string,
 	isService bool,
@@ -178,26 +188,34 @@ func New(
 	svc daemon.Daemon,
 	meterConfigClient *obs.HookClientConfig,
 	tracerConfigClient *obs.HookClientConfig,
+	spoolConfig *spool.Config,
 ) (*MainModule, error) {
 	if agentID == "" {
 		agentID = system.MakeAgentID()
 	}
+	pool, err := endpoints.New(endpointsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize the server endpoints: %w", err)
+	}
+	if err := proxyConfig.Valid(); err != nil {
+		return nil, fmt.Errorf("failed to initialize the proxy config: %w", err)
+	}
 	ctx, cancelCtx := context.WithCancel(context.Background())
 	mm := &MainModule{
-		ctx:              ctx,
-		cancelCtx:        cancelCtx,
-		connectionString: connectionString,
-		agentID:          agentID,
-		version:          version,
-		modules:          make(map[string]*loader.ModuleConfig),
-		loader:           loader.New(),
-		mutexResp:        &sync.Mutex{},
+		ctx:         ctx,
+		cancelCtx:   cancelCtx,
+		endpoints:   pool,
+		proxyConfig: proxyConfig,
+		agentID:     agentID,
+		version:     version,
+		modules:     make(map[string]*loader.ModuleConfig),
+		loader:      loader.New(),
+		mutexResp:   &sync.Mutex{},
 
 		meterConfigClient:  meterConfigClient,
 		tracerConfigClient: tracerConfigClient,
 	}
 
-	var err error
 	hardeningVM, err := initializeHardeningVM(logDir, agentID)
 	if err != nil {
 		return nil, err
@@ -207,7 +225,7 @@ func New(
 		return nil, fmt.Errorf("failed to initialize an upgrader for the Main module: %w", err)
 	}
 	mm.tlsConfigurer = hardeningVM
-	mm.tunnelEncrypter, err = tunnel.NewPackEncrypter(&tunnel.Config{
+	mm.tunnelEncrypter, err = tunnel.NewNegotiablePackEncrypter(&tunnel.Config{
 		Simple: &tunnelSimple.Config{},
 	})
 	if err != nil {
@@ -215,9 +233,22 @@ func New(
 	}
 	mm.secureConfigEncryptor = hardeningVM
 	mm.connValidator = connValidator.NewValidator(mm.agentID, mm.version, hardeningVM)
+	if spoolConfig != nil {
+		spoolConfig.Key = getSpoolKeyByAgentID(agentID)
+		mm.eventSpool, err = spool.New(spoolConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize the event spool for the Main module: %w", err)
+		}
+	}
 	return mm, nil
 }
 
+func getSpoolKeyByAgentID(agentID string) []byte {
+	const salt = "vxagent_event_spool"
+	key := sha256.Sum256([]byte(vm.GetKeyByAgentID(agentID) + salt))
+	return key[:]
+}
+
 func initializeHardeningVM(logDir, agentID string) (vm.VM, error) {
 	luaVM, err := vm.NewVM(&vm.VMConfig{
 		StoreConfig: &types.Config{
@@ -281,6 +312,12 @@ func (mm *MainModule) Start() (err error) {
 		err = fmt.Errorf("failed to start Dumper metric collect: %w", err)
 		return
 	}
+	if mm.eventSpool != nil {
+		if err = obs.Observer.StartDumperMetricCollect(mm.eventSpool, serviceName, mm.version, attr); err != nil {
+			err = fmt.Errorf("failed to start event spool metric collect: %w", err)
+			return
+		}
+	}
 
 	// run main handler of packets
 	mm.wgReceiver.Add(1)
@@ -291,18 +328,11 @@ func (mm *MainModule) Start() (err error) {
 	defer logrus.WithContext(startCtx).Debug("vxagent: main module was stopped")
 
 	startSpan.End()
-	config := map[string]string{
-		"id":         mm.agentID,
-		"token":      "",
-		"connection": mm.connectionString,
-	}
-	return mm.connect(config)
+	return mm.connect()
 }
 
-const connectCooldownSeconds = 60
-
-func (mm *MainModule) connect(config map[string]string) error {
-	connect := func(ctx context.Context) error {
+func (mm *MainModule) connect() error {
+	connect := func(ctx context.Context, config map[string]string) error {
 		err := mm.performConnection(ctx, config)
 		if errors.Is(err, vxcommonErrors.ErrConnectionInitializationRequired) {
 			logrus.WithContext(ctx).WithError(err).
@@ -317,6 +347,21 @@ func (mm *MainModule) connect(config map[string]string) error {
 		return err
 	}
 	for {
+		endpoint, delay := mm.endpoints.Next()
+		if delay > 0 {
+			logrus.WithContext(mm.ctx).Infof("waiting %s before connecting to the server: %s", delay, endpoint)
+			select {
+			case <-time.After(delay):
+			case <-mm.ctx.Done():
+				return nil
+			}
+		}
+		config := map[string]string{
+			"id":         mm.agentID,
+			"token":      "",
+			"connection": endpoint,
+		}
+
 		ctx, cancelCtx := context.WithCancel(mm.ctx)
 		connectCtx, connectSpan := obs.Observer.NewSpan(ctx, obs.SpanKindClient, "connect_agent")
 		connectCtx = context.WithValue(connectCtx, obs.VXProtoAgentConnect, func() {
@@ -328,8 +373,9 @@ func (mm *MainModule) connect(config map[string]string) error {
 			cancelCtx()
 			return fmt.Errorf("vxproto is not set")
 		}
-		logger.Infof("connecting to the server: %s", mm.connectionString)
-		err := connect(connectCtx)
+		logger.Infof("connecting to the server: %s", endpoint)
+		go mm.watchFailback(ctx, cancelCtx)
+		err := connect(connectCtx, config)
 		if !mm.hasStopped {
 			logger.WithError(err).Warn("vxagent: try reconnect")
 			connectSpan.End()
@@ -339,12 +385,46 @@ func (mm *MainModule) connect(config map[string]string) error {
 			return nil
 		}
 		cancelCtx()
-		for i := 0; i < connectCooldownSeconds; i++ {
-			if mm.hasStopped {
-				return nil
-			}
-			time.Sleep(time.Second)
+		mm.endpoints.ReportFailure()
+	}
+}
+
+func (mm *MainModule) probeEndpoint(ctx context.Context, endpoint string) error {
+	u, err := mm.parseURLString(endpoint)
+	if err != nil {
+		return err
+	}
+	dial, err := vxproto.NewProxyDialContext(mm.proxyConfig, u)
+	if err != nil {
+		return err
+	}
+	return endpoints.Probe(ctx, u.String(), dial)
+}
+
+// watchFailback is a function to check the preferred server health periodically while the agent
+// is connected to other one and to drop the current connection to switch back to the preferred server
+func (mm *MainModule) watchFailback(ctx context.Context, dropConnection context.CancelFunc) {
+	ticker := time.NewTicker(mm.endpoints.FailbackInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		preferred, ok := mm.endpoints.FailbackCandidate()
+		if !ok {
+			continue
 		}
+		logger := logrus.WithContext(ctx).WithField("endpoint", preferred)
+		if err := mm.probeEndpoint(ctx, preferred); err != nil {
+			logger.WithError(err).Debug("vxagent: preferred server is still unavailable")
+			continue
+		}
+		logger.Info("vxagent: preferred server is available, switching back to it")
+		mm.endpoints.Failback()
+		dropConnection()
+		return
 	}
 }
 
@@ -445,6 +525,7 @@ func (mm *MainModule) getConfig(c map[string]string) (*vxproto.ClientConfig, err
 			CommonConfig: &vxproto.CommonConfig{
 				Host:      connConf.Host,
 				TLSConfig: tlsConfig,
+				Proxy:     mm.proxyConfig,
 			},
 			ProtocolVersion: protocolVersion,
 		},
@@ -525,6 +606,7 @@ func (mm *MainModule) Stop(stopReason string) (err error) {
 	mm.msocket = nil
 
 	mm.upgraderWG.Wait()
+	mm.replayWG.Wait()
 
 	return nil
 }
@@ -631,18 +713,37 @@ func (mm *MainModule) RegisterLuaAPI(state *lua.State, config *loader.ModuleConf
 				log.Warn("failed to get agent ID from arguments")
 				return false
 			}
+			// events are charged to the actions budget of the module socket because they are sent by the main module
+			if ms := mm.proto.GetModule(mname, gid); ms != nil {
+				if err := ms.CheckRateLimit(vxproto.PTAction); err != nil {
+					log.WithError(err).Warn("failed to push event")
+					return false
+				}
+			}
 
-			err := mm.sendAction(eventCtx, pushEvent, &protoagent.ActionPushEvent{
-				ModuleName: &mname,
-				GroupId:    &gid,
-				PolicyId:   &pid,
-				EventInfo:  &info,
-			})
-			if err != nil {
-				// TODO: here need persist event and retry sending it later
-				log.WithError(err).Error("failed to send event to server side")
+			event := &spool.Event{
+				ModuleName: mname,
+				GroupID:    gid,
+				PolicyID:   pid,
+				EventInfo:  info,
+			}
+			// keep the order of events: while the spool is not empty new events go after the spooled ones
+			if mm.eventSpool == nil || mm.eventSpool.Len() == 0 {
+				err := mm.sendEvent(eventCtx, event)
+				if err == nil {
+					return true
+				}
+				if mm.eventSpool == nil {
+					log.WithError(err).Error("failed to send event to server side")
+					return false
+				}
+				log.WithError(err).Warn("failed to send event to server side, it will be spooled")
+			}
+			if err := mm.eventSpool.Push(event); err != nil {
+				log.WithError(err).Error("failed to put event to the spool")
 				return false
 			}
+			mm.replayEvents()
 
 			return true
 		},
@@ -673,7 +774,7 @@ func (mm *MainModule) RegisterLuaAPI(state *lua.State, config *loader.ModuleConf
 		return fmt.Errorf("failed to register the metrics gatherer for modules: %w", err)
 	}
 
-	if u, err := mm.parseURLString(mm.connectionString); err != nil {
+	if u, err := mm.parseURLString(mm.endpoints.Current()); err != nil {
 		logrus.WithContext(state.Context()).WithError(err).Error("failed to prepare sconn")
 	} else {
 		ips, err := net.LookupIP(u.Hostname())
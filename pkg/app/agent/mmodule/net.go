@@ -8,6 +8,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/proto"
 
+	"soldr/pkg/app/agent/spool"
 	"soldr/pkg/loader"
 	obs "soldr/pkg/observability"
 	"soldr/pkg/protoagent"
@@ -186,7 +187,7 @@ func (mm *MainModule) responseAgent(ctx context.Context, dst string, msgType pro
 }
 
 func (mm *MainModule) sendInformation(ctx context.Context, dst string) error {
-	agentInfo, err := system.GetAgentInfo(ctx)
+	agentInfo, err := system.GetAgentInfoWithInventory(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get the agent info: %w", err)
 	}
@@ -242,6 +243,44 @@ func (mm *MainModule) sendAction(ctx context.Context, name string, data proto.Me
 	return nil
 }
 
+func (mm *MainModule) sendEvent(ctx context.Context, e *spool.Event) error {
+	return mm.sendAction(ctx, "push_event", &protoagent.ActionPushEvent{
+		ModuleName: utils.GetRef(e.ModuleName),
+		GroupId:    utils.GetRef(e.GroupID),
+		PolicyId:   utils.GetRef(e.PolicyID),
+		EventInfo:  utils.GetRef(e.EventInfo),
+	})
+}
+
+// replayEvents is function which sends spooled events to server in background
+func (mm *MainModule) replayEvents() {
+	if mm.eventSpool == nil || mm.eventSpool.Len() == 0 || mm.hasStopped {
+		return
+	}
+	if !mm.isReplaying.CompareAndSwap(false, true) {
+		return
+	}
+	mm.replayWG.Add(1)
+	go func() {
+		defer mm.replayWG.Done()
+		defer mm.isReplaying.Store(false)
+
+		replayCtx, replaySpan := obs.Observer.NewSpan(mm.ctx, obs.SpanKindInternal, "replay_events")
+		defer replaySpan.End()
+
+		logger := logrus.WithContext(replayCtx)
+		replayed, err := mm.eventSpool.Replay(replayCtx, mm.sendEvent)
+		if err != nil {
+			logger.WithError(err).WithField("replayed", replayed).
+				Warn("vxagent: failed to replay spooled events, it will be retried on next connection")
+			return
+		}
+		if replayed != 0 {
+			logger.WithField("replayed", replayed).Info("vxagent: spooled events were delivered to server")
+		}
+	}()
+}
+
 func (mm *MainModule) serveStartModules(ctx context.Context, dst string, data []byte) (err error) {
 	defer func() {
 		if errSend := mm.sendStatusModules(ctx, dst); errSend != nil {
@@ -555,6 +594,10 @@ func (mm *MainModule) recvPacket() error {
 			switch msg.MsgType {
 			case vxproto.AgentConnected:
 				getAgentEntry(packetCtx, msg.AgentInfo).Info("vxagent: agent connected")
+				if err := mm.endpoints.ReportSuccess(); err != nil {
+					logrus.WithContext(packetCtx).WithError(err).Warn("vxagent: failed to store the last successful endpoint")
+				}
+				mm.replayEvents()
 			case vxproto.AgentDisconnected:
 				getAgentEntry(packetCtx, msg.AgentInfo).Info("vxagent: agent disconnected")
 			case vxproto.StopModule:
@@ -0,0 +1,312 @@
+package spool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"soldr/pkg/crypto"
+)
+
+const (
+	// DefaultMaxSize is a default limit of the spool size on disk in bytes
+	DefaultMaxSize int64 = 64 * 1024 * 1024
+	// DefaultMaxAge is a default time to keep undelivered events
+	DefaultMaxAge = 7 * 24 * time.Hour
+
+	recordExt     = ".evt"
+	tempRecordExt = ".tmp"
+	seqNameLen    = 20
+)
+
+// Config is a set of options to create an event spool
+type Config struct {
+	// Dir is a directory to keep the spooled events in
+	Dir string
+	// MaxSize is a limit of the total size of the spooled events on disk in bytes
+	MaxSize int64
+	// MaxAge is a maximum time to keep an undelivered event
+	MaxAge time.Duration
+	// Key is a 32 bytes key to encrypt the spooled events with AES-GCM
+	Key []byte
+}
+
+// Event is a module event that was not delivered to the server side
+type Event struct {
+	ModuleName string    `json:"module_name"`
+	GroupID    string    `json:"group_id"`
+	PolicyID   string    `json:"policy_id"`
+	EventInfo  string    `json:"event_info"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// SendFunc is a callback to deliver a spooled event to the server side
+type SendFunc func(ctx context.Context, e *Event) error
+
+type record struct {
+	seq  uint64
+	size int64
+}
+
+// Stats is a set of counters of the spool state
+type Stats struct {
+	Spooled          atomic.Int64
+	Replayed         atomic.Int64
+	DroppedOverflow  atomic.Int64
+	DroppedExpired   atomic.Int64
+	DroppedMalformed atomic.Int64
+}
+
+// Spool is a bounded encrypted on-disk FIFO queue of the agent events
+type Spool struct {
+	dir       string
+	maxSize   int64
+	maxAge    time.Duration
+	encryptor crypto.IEncryptor
+
+	mx      sync.Mutex
+	records []record
+	size    int64
+	nextSeq uint64
+
+	replayMx sync.Mutex
+	stats    Stats
+}
+
+// New is a function to open the event spool and restore its state from disk
+func New(c *Config) (*Spool, error) {
+	if c == nil {
+		return nil, fmt.Errorf("spool config is nil")
+	}
+	if c.Dir == "" {
+		return nil, fmt.Errorf("spool directory is not set")
+	}
+	if err := os.MkdirAll(c.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create the spool directory %s: %w", c.Dir, err)
+	}
+	encryptor, err := crypto.NewAESEncryptor(func() ([]byte, error) {
+		if len(c.Key) != 32 {
+			return nil, fmt.Errorf("invalid spool key length: %d", len(c.Key))
+		}
+		return c.Key, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize the spool encryptor: %w", err)
+	}
+	s := &Spool{
+		dir:       c.Dir,
+		maxSize:   c.MaxSize,
+		maxAge:    c.MaxAge,
+		encryptor: encryptor,
+		nextSeq:   1,
+	}
+	if s.maxSize <= 0 {
+		s.maxSize = DefaultMaxSize
+	}
+	if s.maxAge <= 0 {
+		s.maxAge = DefaultMaxAge
+	}
+	if err := s.restore(); err != nil {
+		return nil, fmt.Errorf("failed to restore the spool state: %w", err)
+	}
+	return s, nil
+}
+
+func (s *Spool) restore() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read the spool directory %s: %w", s.dir, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if strings.HasSuffix(name, tempRecordExt) {
+			// partially written record from the previous run
+			_ = os.Remove(filepath.Join(s.dir, name))
+			continue
+		}
+		if !strings.HasSuffix(name, recordExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, recordExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		s.records = append(s.records, record{seq: seq, size: info.Size()})
+		s.size += info.Size()
+		if seq >= s.nextSeq {
+			s.nextSeq = seq + 1
+		}
+	}
+	sort.Slice(s.records, func(i, j int) bool {
+		return s.records[i].seq < s.records[j].seq
+	})
+	return nil
+}
+
+func (s *Spool) recordPath(seq uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%0*d%s", seqNameLen, seq, recordExt))
+}
+
+// Push is a function to persist the event at the tail of the spool,
+// the oldest events are dropped if the spool size limit is exceeded
+func (s *Spool) Push(e *Event) error {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the event: %w", err)
+	}
+	data, err = s.encryptor.Encrypt(data)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt the event: %w", err)
+	}
+	size := int64(len(data))
+	if size > s.maxSize {
+		s.stats.DroppedOverflow.Add(1)
+		return fmt.Errorf("event size %d exceeds the spool size limit %d", size, s.maxSize)
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	for len(s.records) != 0 && s.size+size > s.maxSize {
+		s.dropHeadI()
+		s.stats.DroppedOverflow.Add(1)
+	}
+
+	seq := s.nextSeq
+	path := s.recordPath(seq)
+	tmpPath := path + tempRecordExt
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write the event record: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to commit the event record: %w", err)
+	}
+	s.nextSeq++
+	s.records = append(s.records, record{seq: seq, size: size})
+	s.size += size
+	s.stats.Spooled.Add(1)
+	return nil
+}
+
+// dropHeadI is an internal function to remove the oldest record without locking
+func (s *Spool) dropHeadI() {
+	head := s.records[0]
+	_ = os.Remove(s.recordPath(head.seq))
+	s.records = s.records[1:]
+	s.size -= head.size
+}
+
+func (s *Spool) peek() (*record, bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if len(s.records) == 0 {
+		return nil, false
+	}
+	head := s.records[0]
+	return &head, true
+}
+
+func (s *Spool) pop(seq uint64) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if len(s.records) != 0 && s.records[0].seq == seq {
+		s.dropHeadI()
+	}
+}
+
+func (s *Spool) read(rec *record) (*Event, error) {
+	data, err := os.ReadFile(s.recordPath(rec.seq))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the event record: %w", err)
+	}
+	data, err = s.encryptor.Decrypt(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the event record: %w", err)
+	}
+	var e Event
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the event record: %w", err)
+	}
+	return &e, nil
+}
+
+// Replay is a function to deliver the spooled events in the order of pushing,
+// replaying stops on the first delivery error to keep the order of events
+func (s *Spool) Replay(ctx context.Context, send SendFunc) (int, error) {
+	s.replayMx.Lock()
+	defer s.replayMx.Unlock()
+
+	var replayed int
+	for {
+		if err := ctx.Err(); err != nil {
+			return replayed, err
+		}
+		rec, ok := s.peek()
+		if !ok {
+			return replayed, nil
+		}
+		e, err := s.read(rec)
+		if err != nil {
+			s.pop(rec.seq)
+			s.stats.DroppedMalformed.Add(1)
+			continue
+		}
+		if time.Since(e.CreatedAt) > s.maxAge {
+			s.pop(rec.seq)
+			s.stats.DroppedExpired.Add(1)
+			continue
+		}
+		if err := send(ctx, e); err != nil {
+			return replayed, err
+		}
+		s.pop(rec.seq)
+		s.stats.Replayed.Add(1)
+		replayed++
+	}
+}
+
+// Len is a function to get the number of the spooled events
+func (s *Spool) Len() int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return len(s.records)
+}
+
+// Size is a function to get the total size of the spooled events on disk
+func (s *Spool) Size() int64 {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.size
+}
+
+// DumpStats is a function to export the spool counters to the metrics collector
+func (s *Spool) DumpStats() (map[string]float64, error) {
+	statsMap := make(map[string]float64, 7)
+	statsMap["event_spool_spooled"] = float64(s.stats.Spooled.Load())
+	statsMap["event_spool_replayed"] = float64(s.stats.Replayed.Load())
+	statsMap["event_spool_dropped_overflow"] = float64(s.stats.DroppedOverflow.Load())
+	statsMap["event_spool_dropped_expired"] = float64(s.stats.DroppedExpired.Load())
+	statsMap["event_spool_dropped_malformed"] = float64(s.stats.DroppedMalformed.Load())
+	statsMap["event_spool_pending"] = float64(s.Len())
+	statsMap["event_spool_size_bytes"] = float64(s.Size())
+	return statsMap, nil
+}
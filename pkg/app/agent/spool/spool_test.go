@@ -0,0 +1,139 @@
+package spool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestSpool(t *testing.T, dir string, maxSize int64) *Spool {
+	t.Helper()
+	s, err := New(&Config{
+		Dir:     dir,
+		MaxSize: maxSize,
+		Key:     bytes.Repeat([]byte{0x42}, 32),
+	})
+	if err != nil {
+		t.Fatalf("failed to create the spool: %v", err)
+	}
+	return s
+}
+
+func pushEvents(t *testing.T, s *Spool, count int) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		if err := s.Push(&Event{ModuleName: "test", EventInfo: fmt.Sprintf("event_%d", i)}); err != nil {
+			t.Fatalf("failed to push the event %d: %v", i, err)
+		}
+	}
+}
+
+func TestSpoolReplayKeepsOrder(t *testing.T) {
+	dir := t.TempDir()
+	s := newTestSpool(t, dir, 0)
+	pushEvents(t, s, 5)
+
+	// reopen the spool to check that the state is restored from disk
+	s = newTestSpool(t, dir, 0)
+	if s.Len() != 5 {
+		t.Fatalf("expected 5 spooled events after reopening, got %d", s.Len())
+	}
+
+	var got []string
+	replayed, err := s.Replay(context.Background(), func(_ context.Context, e *Event) error {
+		got = append(got, e.EventInfo)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected replay error: %v", err)
+	}
+	if replayed != 5 || s.Len() != 0 {
+		t.Fatalf("expected all events to be replayed, replayed %d, left %d", replayed, s.Len())
+	}
+	for i, info := range got {
+		if expected := fmt.Sprintf("event_%d", i); info != expected {
+			t.Fatalf("unexpected event order: expected %s, got %s", expected, info)
+		}
+	}
+}
+
+func TestSpoolReplayStopsOnError(t *testing.T) {
+	s := newTestSpool(t, t.TempDir(), 0)
+	pushEvents(t, s, 3)
+
+	errSend := errors.New("server is unreachable")
+	calls := 0
+	replayed, err := s.Replay(context.Background(), func(_ context.Context, e *Event) error {
+		calls++
+		if calls == 2 {
+			return errSend
+		}
+		return nil
+	})
+	if !errors.Is(err, errSend) {
+		t.Fatalf("expected send error, got %v", err)
+	}
+	if replayed != 1 || s.Len() != 2 {
+		t.Fatalf("expected 1 replayed and 2 left events, got %d and %d", replayed, s.Len())
+	}
+}
+
+func TestSpoolDropsOldestOnOverflow(t *testing.T) {
+	dir := t.TempDir()
+	s := newTestSpool(t, dir, 1024)
+	pushEvents(t, s, 50)
+
+	if s.Size() > 1024 {
+		t.Fatalf("spool size %d exceeds the limit", s.Size())
+	}
+	if s.stats.DroppedOverflow.Load() == 0 {
+		t.Fatal("expected some events to be dropped on overflow")
+	}
+	var first string
+	_, _ = s.Replay(context.Background(), func(_ context.Context, e *Event) error {
+		if first == "" {
+			first = e.EventInfo
+		}
+		return nil
+	})
+	if first == "event_0" {
+		t.Fatal("expected the oldest event to be dropped")
+	}
+}
+
+func TestSpoolDropsExpiredAndMalformed(t *testing.T) {
+	dir := t.TempDir()
+	s := newTestSpool(t, dir, 0)
+	if err := s.Push(&Event{EventInfo: "expired", CreatedAt: time.Now().Add(-2 * DefaultMaxAge)}); err != nil {
+		t.Fatalf("failed to push the event: %v", err)
+	}
+	pushEvents(t, s, 1)
+	if err := os.WriteFile(s.recordPath(s.records[1].seq), []byte("garbage"), 0o600); err != nil {
+		t.Fatalf("failed to corrupt the record: %v", err)
+	}
+	pushEvents(t, s, 1)
+	if err := os.WriteFile(filepath.Join(dir, "00000000000000000099.evt.tmp"), nil, 0o600); err != nil {
+		t.Fatalf("failed to write the temp record: %v", err)
+	}
+
+	replayed, err := s.Replay(context.Background(), func(_ context.Context, e *Event) error {
+		return nil
+	})
+	if err != nil || replayed != 1 {
+		t.Fatalf("expected 1 replayed event, got %d (%v)", replayed, err)
+	}
+	stats, _ := s.DumpStats()
+	if stats["event_spool_dropped_expired"] != 1 || stats["event_spool_dropped_malformed"] != 1 {
+		t.Fatalf("unexpected drop counters: %v", stats)
+	}
+
+	newTestSpool(t, dir, 0)
+	if _, err := os.Stat(filepath.Join(dir, "00000000000000000099.evt.tmp")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("expected the temp record to be removed on restore")
+	}
+}
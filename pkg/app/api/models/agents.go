@@ -11,7 +11,7 @@ import (
 // AgentOS is model to contain agent OS information
 type AgentOS struct {
 	Type string `form:"type" json:"type" validate:"oneof=windows linux darwin,required"`
-	Arch string `form:"arch" json:"arch" validate:"oneof=386 amd64,required"`
+	Arch string `form:"arch" json:"arch" validate:"vxarch,required"`
 	Name string `form:"name" json:"name" validate:"max=255,required"`
 }
 
@@ -42,12 +42,100 @@ func (an AgentNet) Valid() error {
 	return validate.Struct(an)
 }
 
+// AgentCPU is model to contain agent CPU information
+type AgentCPU struct {
+	Model   string `form:"model,omitempty" json:"model,omitempty" validate:"max=255"`
+	Cores   uint32 `form:"cores,omitempty" json:"cores,omitempty"`
+	Threads uint32 `form:"threads,omitempty" json:"threads,omitempty"`
+}
+
+// Valid is function to control input/output data
+func (ac AgentCPU) Valid() error {
+	return validate.Struct(ac)
+}
+
+// AgentMemory is model to contain agent memory information in bytes
+type AgentMemory struct {
+	Total     uint64 `form:"total,omitempty" json:"total,omitempty"`
+	Available uint64 `form:"available,omitempty" json:"available,omitempty"`
+}
+
+// Valid is function to control input/output data
+func (am AgentMemory) Valid() error {
+	return validate.Struct(am)
+}
+
+// AgentDisk is model to contain agent disk partition information, sizes are in bytes
+type AgentDisk struct {
+	Mountpoint string `form:"mountpoint" json:"mountpoint" validate:"required"`
+	Device     string `form:"device,omitempty" json:"device,omitempty"`
+	Fstype     string `form:"fstype,omitempty" json:"fstype,omitempty"`
+	Total      uint64 `form:"total,omitempty" json:"total,omitempty"`
+	Free       uint64 `form:"free,omitempty" json:"free,omitempty"`
+}
+
+// Valid is function to control input/output data
+func (ad AgentDisk) Valid() error {
+	return validate.Struct(ad)
+}
+
+// AgentInterface is model to contain agent network interface information
+type AgentInterface struct {
+	Name string   `form:"name" json:"name" validate:"required"`
+	MAC  string   `form:"mac,omitempty" json:"mac,omitempty" validate:"omitempty,mac"`
+	IPs  []string `form:"ips,omitempty" json:"ips,omitempty" validate:"omitempty"`
+}
+
+// Valid is function to control input/output data
+func (ai AgentInterface) Valid() error {
+	return validate.Struct(ai)
+}
+
+// AgentHardware is model to contain agent hardware information
+type AgentHardware struct {
+	CPU        *AgentCPU        `form:"cpu,omitempty" json:"cpu,omitempty" validate:"omitempty,valid"`
+	Memory     *AgentMemory     `form:"memory,omitempty" json:"memory,omitempty" validate:"omitempty,valid"`
+	Disks      []AgentDisk      `form:"disks,omitempty" json:"disks,omitempty" validate:"omitempty,dive,valid"`
+	Interfaces []AgentInterface `form:"interfaces,omitempty" json:"interfaces,omitempty" validate:"omitempty,dive,valid"`
+}
+
+// Valid is function to control input/output data
+func (ah AgentHardware) Valid() error {
+	return validate.Struct(ah)
+}
+
+// AgentPackage is model to contain installed software package information
+type AgentPackage struct {
+	Name    string `form:"name" json:"name" validate:"required"`
+	Version string `form:"version,omitempty" json:"version,omitempty"`
+	Source  string `form:"source,omitempty" json:"source,omitempty" validate:"omitempty,oneof=dpkg rpm registry applications"`
+}
+
+// Valid is function to control input/output data
+func (ap AgentPackage) Valid() error {
+	return validate.Struct(ap)
+}
+
+// AgentService is model to contain running service information
+type AgentService struct {
+	Name   string `form:"name" json:"name" validate:"required"`
+	Status string `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// Valid is function to control input/output data
+func (as AgentService) Valid() error {
+	return validate.Struct(as)
+}
+
 // AgentInfo is model to contain general agent information
 type AgentInfo struct {
-	OS    AgentOS     `form:"os" json:"os" validate:"required,valid"`
-	Net   AgentNet    `form:"net" json:"net" validate:"required,valid"`
-	Users []AgentUser `form:"users" json:"users" validate:"required"`
-	Tags  []string    `form:"tags" json:"tags" validate:"solid_ru,max=20,unique,required"`
+	OS       AgentOS        `form:"os" json:"os" validate:"required,valid"`
+	Net      AgentNet       `form:"net" json:"net" validate:"required,valid"`
+	Users    []AgentUser    `form:"users" json:"users" validate:"required"`
+	Tags     []string       `form:"tags" json:"tags" validate:"solid_ru,max=20,unique,required"`
+	Hardware *AgentHardware `form:"hardware,omitempty" json:"hardware,omitempty" validate:"omitempty,valid"`
+	Packages []AgentPackage `form:"packages,omitempty" json:"packages,omitempty" validate:"omitempty,dive,valid"`
+	Services []AgentService `form:"services,omitempty" json:"services,omitempty" validate:"omitempty,dive,valid"`
 }
 
 // Valid is function to control input/output data
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// AuditLog is model to contain user action record from the audit log
+type AuditLog struct {
+	ID                uint64    `form:"id" json:"id" validate:"min=0,numeric" gorm:"type:BIGINT(20) UNSIGNED;NOT NULL;PRIMARY_KEY;AUTO_INCREMENT"`
+	TenantID          uint64    `form:"tenant_id" json:"tenant_id" validate:"min=0,numeric" gorm:"type:INT(10) UNSIGNED;NOT NULL"`
+	UserID            uint64    `form:"user_id" json:"user_id" validate:"min=0,numeric" gorm:"type:INT(10) UNSIGNED;NOT NULL"`
+	UserUUID          string    `form:"user_uuid" json:"user_uuid" validate:"max=36,omitempty" gorm:"type:VARCHAR(36);NOT NULL;default:''"`
+	UserName          string    `form:"user_name" json:"user_name" validate:"max=70,omitempty" gorm:"type:VARCHAR(70);NOT NULL;default:''"`
+	Domain            string    `form:"domain" json:"domain" validate:"max=50,required" gorm:"type:VARCHAR(50);NOT NULL"`
+	ObjectType        string    `form:"object_type" json:"object_type" validate:"max=50,required" gorm:"type:VARCHAR(50);NOT NULL"`
+	ObjectID          string    `form:"object_id" json:"object_id" validate:"max=255,omitempty" gorm:"type:VARCHAR(255);NOT NULL;default:''"`
+	ObjectDisplayName string    `form:"object_display_name" json:"object_display_name" validate:"max=255,omitempty" gorm:"type:VARCHAR(255);NOT NULL;default:''"`
+	ActionCode        string    `form:"action_code" json:"action_code" validate:"max=100,required" gorm:"type:VARCHAR(100);NOT NULL"`
+	Success           bool      `form:"success" json:"success" gorm:"type:BOOL;NOT NULL;default:false"`
+	FailReason        string    `form:"fail_reason" json:"fail_reason" validate:"max=255,omitempty" gorm:"type:VARCHAR(255);NOT NULL;default:''"`
+	CreatedDate       time.Time `form:"created_date" json:"created_date" validate:"required" gorm:"type:DATETIME(3);NOT NULL;default:CURRENT_TIMESTAMP(3)"`
+}
+
+// TableName returns the table name string to guaranty use correct table
+func (al *AuditLog) TableName() string {
+	return "audit_log"
+}
+
+// Valid is function to control input/output data
+func (al AuditLog) Valid() error {
+	return validate.Struct(al)
+}
+
+// Validate is function to use callback to control input/output data
+func (al AuditLog) Validate(db *gorm.DB) {
+	if err := al.Valid(); err != nil {
+		db.AddError(err)
+	}
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -78,6 +79,38 @@ func (bi *BinaryInfo) Scan(input interface{}) error {
 	return scanFromJSON(input, bi)
 }
 
+// GetPlatforms returns unique list of agent platforms in format "os:arch" which are contained into the binary,
+// agent file path has format "vxagent/{version}/{os}/{arch}/{file}"
+func (bi BinaryInfo) GetPlatforms() []string {
+	var platforms []string
+	for _, file := range bi.Files {
+		parts := strings.Split(file, "/")
+		if len(parts) != 5 || parts[0] != "vxagent" {
+			continue
+		}
+		platform := parts[2] + ":" + parts[3]
+		if !stringInSlice(platform, platforms) {
+			platforms = append(platforms, platform)
+		}
+	}
+	return platforms
+}
+
+// HasPlatform returns true if the binary contains agent files for the OS type and arch
+func (bi BinaryInfo) HasPlatform(osType, osArch string) bool {
+	return stringInSlice(osType+":"+osArch, bi.GetPlatforms())
+}
+
+// BinariesHavePlatform returns true if any binary of the catalogue contains agent files for the OS type and arch
+func BinariesHavePlatform(infos []BinaryInfo, osType, osArch string) bool {
+	for _, info := range infos {
+		if info.HasPlatform(osType, osArch) {
+			return true
+		}
+	}
+	return false
+}
+
 // Binary is model to contain information about system binaries from global S3 bucket
 type Binary struct {
 	ID         uint64     `form:"id" json:"id" validate:"min=0,numeric" gorm:"type:INT(10) UNSIGNED;NOT NULL;PRIMARY_KEY;AUTO_INCREMENT"`
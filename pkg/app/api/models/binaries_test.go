@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinaryInfoPlatforms(t *testing.T) {
+	bi := BinaryInfo{
+		Files: []string{
+			"vxagent/1.0.0.0/linux/amd64/vxagent",
+			"vxagent/1.0.0.0/linux/amd64/vxagent.deb",
+			"vxagent/1.0.0.0/linux/arm64/vxagent",
+			"vxagent/1.0.0.0/windows/386/vxagent.exe",
+			"vxagent/1.0.0.0/vxagent",
+		},
+	}
+	assert.Equal(t, []string{"linux:amd64", "linux:arm64", "windows:386"}, bi.GetPlatforms())
+	assert.True(t, bi.HasPlatform("linux", "arm64"))
+	assert.False(t, bi.HasPlatform("darwin", "arm64"))
+
+	other := BinaryInfo{Files: []string{"vxagent/1.1.0.0/darwin/arm64/vxagent"}}
+	assert.True(t, BinariesHavePlatform([]BinaryInfo{bi, other}, "darwin", "arm64"))
+	assert.False(t, BinariesHavePlatform([]BinaryInfo{bi, other}, "darwin", "amd64"))
+	assert.False(t, BinariesHavePlatform(nil, "linux", "amd64"))
+}
+
+func TestAgentOSArch(t *testing.T) {
+	for _, arch := range []string{"386", "amd64", "arm64", "ppc64le"} {
+		assert.NoError(t, AgentOS{Type: "linux", Arch: arch, Name: "Ubuntu"}.Valid(), arch)
+	}
+	for _, arch := range []string{"", "a", "ARM64", "arm/64", "verylongarchitecture"} {
+		assert.Error(t, AgentOS{Type: "linux", Arch: arch, Name: "Ubuntu"}.Valid(), arch)
+	}
+
+	assert.NoError(t, ModuleInfoOS{"linux": {"amd64", "arm64"}}.Valid())
+	assert.Error(t, ModuleInfoOS{"linux": {"arm 64"}}.Valid())
+}
@@ -0,0 +1,214 @@
+package models
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+
+	"soldr/pkg/crypto"
+)
+
+// BundleVersion is a current version of the policies bundle format
+const BundleVersion = 1
+
+// BundleMinPassphraseLength is a minimal length of the passphrase to encrypt secure config of the bundle
+const BundleMinPassphraseLength = 8
+
+const (
+	bundleKDFScrypt            = "scrypt"
+	bundleSaltSize             = 16
+	bundleKeySize              = 32
+	bundleEncryptedValuePrefix = "bundle"
+)
+
+// ErrBundleInvalidPassphrase is returned if secure config of the bundle can't be decrypted by the passphrase
+var ErrBundleInvalidPassphrase = errors.New("invalid passphrase of the bundle secure config")
+
+// BundleModule is model to contain policy module of the bundle which is linked by system module version
+type BundleModule struct {
+	Name                string             `form:"name" json:"name" validate:"required,max=255,solid"`
+	Version             string             `form:"version" json:"version" validate:"required,semver"`
+	Status              string             `form:"status" json:"status" validate:"oneof=joined inactive,required"`
+	CurrentConfig       ModuleConfig       `form:"current_config" json:"current_config" validate:"required,valid"`
+	SecureCurrentConfig ModuleSecureConfig `form:"secure_current_config,omitempty" json:"secure_current_config,omitempty" validate:"omitempty,valid"`
+	CurrentActionConfig ActionConfig       `form:"current_action_config" json:"current_action_config" validate:"required,valid"`
+	CurrentEventConfig  EventConfig        `form:"current_event_config" json:"current_event_config" validate:"required,valid"`
+	DynamicDependencies Dependencies       `form:"dynamic_dependencies" json:"dynamic_dependencies" validate:"required,valid"`
+}
+
+// Valid is function to control input/output data
+func (bm BundleModule) Valid() error {
+	return validate.Struct(bm)
+}
+
+// BundlePolicy is model to contain policy of the bundle with its modules
+type BundlePolicy struct {
+	Hash    string         `form:"hash" json:"hash" validate:"len=32,hexadecimal,lowercase,required"`
+	Info    PolicyInfo     `form:"info" json:"info" validate:"required,valid"`
+	Modules []BundleModule `form:"modules" json:"modules" validate:"required,dive,valid"`
+}
+
+// Valid is function to control input/output data
+func (bp BundlePolicy) Valid() error {
+	return validate.Struct(bp)
+}
+
+// BundleGroup is model to contain group of the bundle with links to the bundle policies
+type BundleGroup struct {
+	Hash     string    `form:"hash" json:"hash" validate:"len=32,hexadecimal,lowercase,required"`
+	Info     GroupInfo `form:"info" json:"info" validate:"required,valid"`
+	Rule     string    `form:"rule" json:"rule" validate:"max=8192"`
+	Policies []string  `form:"policies" json:"policies" validate:"required,unique,dive,len=32,hexadecimal,lowercase,required"`
+}
+
+// Valid is function to control input/output data
+func (bg BundleGroup) Valid() error {
+	return validate.Struct(bg)
+}
+
+// BundleEncryption is model to contain parameters of the key which is derived from the passphrase
+// to encrypt secure config of the bundle modules
+type BundleEncryption struct {
+	KDF  string `form:"kdf" json:"kdf" validate:"oneof=scrypt,required"`
+	Salt []byte `form:"salt" json:"salt" validate:"len=16"`
+}
+
+// Valid is function to control input/output data
+func (be BundleEncryption) Valid() error {
+	return validate.Struct(be)
+}
+
+func (be BundleEncryption) getEncryptor(passphrase string) (crypto.IDBConfigEncryptor, error) {
+	encryptor, err := crypto.NewAESEncryptor(func() ([]byte, error) {
+		return scrypt.Key([]byte(passphrase), be.Salt, 1<<15, 8, 1, bundleKeySize)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return crypto.NewDBConfigEncryptor(encryptor, bundleEncryptedValuePrefix), nil
+}
+
+// Bundle is model to contain portable configuration of policies, their modules and optional groups
+// to move it between services, secure config is encrypted by the passphrase which is set on export
+// and it's re-encrypted by the target service key on import
+type Bundle struct {
+	Version    int               `form:"version" json:"version" validate:"required,min=1"`
+	Created    time.Time         `form:"created" json:"created" validate:"required"`
+	Encryption *BundleEncryption `form:"encryption,omitempty" json:"encryption,omitempty" validate:"omitempty,valid"`
+	Policies   []BundlePolicy    `form:"policies" json:"policies" validate:"required,min=1,dive,valid"`
+	Groups     []BundleGroup     `form:"groups,omitempty" json:"groups,omitempty" validate:"omitempty,dive,valid"`
+}
+
+// Valid is function to control input/output data
+func (b Bundle) Valid() error {
+	if err := validate.Struct(b); err != nil {
+		return err
+	}
+	if b.Version != BundleVersion {
+		return fmt.Errorf("unsupported bundle version %d", b.Version)
+	}
+	if b.HasSecureConfig() && b.Encryption == nil {
+		return fmt.Errorf("secure config of the bundle must be encrypted")
+	}
+
+	policies := make(map[string]struct{}, len(b.Policies))
+	for _, policy := range b.Policies {
+		if _, ok := policies[policy.Hash]; ok {
+			return fmt.Errorf("duplicate policy '%s' in the bundle", policy.Hash)
+		}
+		policies[policy.Hash] = struct{}{}
+		modules := make(map[string]struct{}, len(policy.Modules))
+		for _, module := range policy.Modules {
+			if _, ok := modules[module.Name]; ok {
+				return fmt.Errorf("duplicate module '%s' in the policy '%s'", module.Name, policy.Hash)
+			}
+			modules[module.Name] = struct{}{}
+		}
+	}
+
+	groups := make(map[string]struct{}, len(b.Groups))
+	for _, group := range b.Groups {
+		if _, ok := groups[group.Hash]; ok {
+			return fmt.Errorf("duplicate group '%s' in the bundle", group.Hash)
+		}
+		groups[group.Hash] = struct{}{}
+		for _, hash := range group.Policies {
+			if _, ok := policies[hash]; !ok {
+				return fmt.Errorf("group '%s' is linked to unknown policy '%s'", group.Hash, hash)
+			}
+		}
+	}
+
+	return nil
+}
+
+// HasSecureConfig returns true if any module of the bundle contains secure config values
+func (b Bundle) HasSecureConfig() bool {
+	for _, policy := range b.Policies {
+		for _, module := range policy.Modules {
+			if len(module.SecureCurrentConfig) != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// EncryptSecureConfig encrypts secure config of the bundle modules by the key which is derived from the passphrase
+func (b *Bundle) EncryptSecureConfig(passphrase string) error {
+	if !b.HasSecureConfig() {
+		return nil
+	}
+	if len(passphrase) < BundleMinPassphraseLength {
+		return fmt.Errorf("passphrase must be at least %d characters long", BundleMinPassphraseLength)
+	}
+
+	salt := make([]byte, bundleSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to make the bundle key salt: %w", err)
+	}
+	encryption := &BundleEncryption{KDF: bundleKDFScrypt, Salt: salt}
+	encryptor, err := encryption.getEncryptor(passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to make the bundle encryptor: %w", err)
+	}
+	for _, policy := range b.Policies {
+		for _, module := range policy.Modules {
+			if err := EncryptSecureConfig(encryptor, module.SecureCurrentConfig); err != nil {
+				return fmt.Errorf("failed to encrypt secure config of module '%s': %w", module.Name, err)
+			}
+		}
+	}
+	b.Encryption = encryption
+
+	return nil
+}
+
+// DecryptSecureConfig decrypts secure config of the bundle modules by the passphrase which was set on export,
+// ErrBundleInvalidPassphrase is returned if the secure config can't be decrypted
+func (b *Bundle) DecryptSecureConfig(passphrase string) error {
+	if !b.HasSecureConfig() {
+		return nil
+	}
+	if b.Encryption == nil {
+		return fmt.Errorf("secure config of the bundle isn't encrypted")
+	}
+
+	encryptor, err := b.Encryption.getEncryptor(passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to make the bundle encryptor: %w", err)
+	}
+	for _, policy := range b.Policies {
+		for _, module := range policy.Modules {
+			if err := DecryptSecureConfig(encryptor, module.SecureCurrentConfig); err != nil {
+				return fmt.Errorf("%w: module '%s': %v", ErrBundleInvalidPassphrase, module.Name, err)
+			}
+		}
+	}
+	b.Encryption = nil
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleValid(t *testing.T) {
+	const (
+		policyHash = "0123456789abcdef0123456789abcdef"
+		groupHash  = "fedcba9876543210fedcba9876543210"
+	)
+	makeBundle := func() Bundle {
+		return Bundle{
+			Version: BundleVersion,
+			Created: time.Now(),
+			Policies: []BundlePolicy{{
+				Hash: policyHash,
+				Info: PolicyInfo{Name: PolicyItemLocale{Ru: "Политика", En: "Policy"}, Tags: []string{}},
+				Modules: []BundleModule{{
+					Name:                "test_module",
+					Version:             "1.0.0",
+					Status:              "joined",
+					CurrentConfig:       ModuleConfig{"timeout": 10},
+					CurrentActionConfig: ActionConfig{},
+					CurrentEventConfig:  EventConfig{},
+					DynamicDependencies: Dependencies{},
+				}},
+			}},
+			Groups: []BundleGroup{{
+				Hash:     groupHash,
+				Info:     GroupInfo{Name: GroupItemLocale{Ru: "Группа", En: "Group"}, Tags: []string{}},
+				Policies: []string{policyHash},
+			}},
+		}
+	}
+
+	bundle := makeBundle()
+	assert.NoError(t, bundle.Valid())
+	assert.False(t, bundle.HasSecureConfig())
+
+	serverOnly := true
+	bundle.Policies[0].Modules[0].SecureCurrentConfig = ModuleSecureConfig{
+		"token": {ServerOnly: &serverOnly, Value: "secret"},
+	}
+	assert.True(t, bundle.HasSecureConfig())
+	assert.Error(t, bundle.Valid(), "plain text secure config must be rejected")
+	assert.Error(t, bundle.EncryptSecureConfig("short"), "short passphrase must be rejected")
+	require.NoError(t, bundle.EncryptSecureConfig("bundle passphrase"))
+	assert.NoError(t, bundle.Valid())
+
+	bundle = makeBundle()
+	bundle.Version = BundleVersion + 1
+	assert.Error(t, bundle.Valid(), "unknown bundle version must be rejected")
+
+	bundle = makeBundle()
+	bundle.Policies = append(bundle.Policies, bundle.Policies[0])
+	assert.Error(t, bundle.Valid(), "duplicate policies must be rejected")
+
+	bundle = makeBundle()
+	bundle.Policies[0].Modules = append(bundle.Policies[0].Modules, bundle.Policies[0].Modules[0])
+	assert.Error(t, bundle.Valid(), "duplicate modules must be rejected")
+
+	bundle = makeBundle()
+	bundle.Groups[0].Policies = []string{groupHash}
+	assert.Error(t, bundle.Valid(), "links to policies out of the bundle must be rejected")
+
+	bundle = makeBundle()
+	bundle.Policies[0].Modules[0].Version = "latest"
+	assert.Error(t, bundle.Valid())
+}
+
+func TestBundleSecureConfig(t *testing.T) {
+	serverOnly := true
+	makeBundle := func() Bundle {
+		return Bundle{Policies: []BundlePolicy{{Modules: []BundleModule{
+			{Name: "plain_module"},
+			{Name: "secure_module", SecureCurrentConfig: ModuleSecureConfig{
+				"token":   {ServerOnly: &serverOnly, Value: "secret"},
+				"retries": {ServerOnly: &serverOnly, Value: float64(3)},
+			}},
+		}}}}
+	}
+	secureConfig := makeBundle().Policies[0].Modules[1].SecureCurrentConfig
+
+	bundle := makeBundle()
+	require.NoError(t, bundle.EncryptSecureConfig("bundle passphrase"))
+	require.NotNil(t, bundle.Encryption)
+	assert.Equal(t, "scrypt", bundle.Encryption.KDF)
+	assert.Len(t, bundle.Encryption.Salt, 16)
+	encrypted := bundle.Policies[0].Modules[1].SecureCurrentConfig
+	for name, param := range encrypted {
+		value, ok := param.Value.(string)
+		require.True(t, ok)
+		assert.True(t, strings.HasPrefix(value, "bundle."), "parameter '%s' must be encrypted", name)
+		assert.NotContains(t, value, "secret")
+	}
+
+	wrong := bundle
+	wrong.Policies = []BundlePolicy{{Modules: []BundleModule{{Name: "secure_module", SecureCurrentConfig: ModuleSecureConfig{}}}}}
+	for name, param := range encrypted {
+		wrong.Policies[0].Modules[0].SecureCurrentConfig[name] = param
+	}
+	assert.ErrorIs(t, wrong.DecryptSecureConfig("wrong passphrase"), ErrBundleInvalidPassphrase)
+
+	require.NoError(t, bundle.DecryptSecureConfig("bundle passphrase"))
+	assert.Nil(t, bundle.Encryption)
+	assert.Equal(t, secureConfig, bundle.Policies[0].Modules[1].SecureCurrentConfig)
+
+	plain := makeBundle()
+	plain.Policies[0].Modules = plain.Policies[0].Modules[:1]
+	require.NoError(t, plain.EncryptSecureConfig(""), "bundle without secure config isn't encrypted")
+	assert.Nil(t, plain.Encryption)
+}
@@ -59,6 +59,23 @@ func (e Event) Validate(db *gorm.DB) {
 	}
 }
 
+// EventExport is model to contain event data with names of linked objects to export it
+type EventExport struct {
+	AgentHash  string `form:"agent_hash" json:"agent_hash" validate:"omitempty"`
+	AgentName  string `form:"agent_name" json:"agent_name" validate:"omitempty"`
+	GroupHash  string `form:"group_hash" json:"group_hash" validate:"omitempty"`
+	GroupName  string `form:"group_name" json:"group_name" validate:"omitempty"`
+	ModuleName string `form:"module_name" json:"module_name" validate:"omitempty"`
+	PolicyHash string `form:"policy_hash" json:"policy_hash" validate:"omitempty"`
+	PolicyName string `form:"policy_name" json:"policy_name" validate:"omitempty"`
+	Event      `form:"" json:""`
+}
+
+// Valid is function to control input/output data
+func (ee EventExport) Valid() error {
+	return ee.Event.Valid()
+}
+
 // EventModule is model to contain event data linked with module agent
 type EventModule struct {
 	Module ModuleAShort `form:"module,omitempty" json:"module,omitempty" gorm:"association_autoupdate:false;association_autocreate:false"`
@@ -47,6 +47,7 @@ type Group struct {
 	ID          uint64     `form:"id" json:"id" validate:"min=0,numeric" gorm:"type:INT(10) UNSIGNED;NOT NULL;PRIMARY_KEY;AUTO_INCREMENT"`
 	Hash        string     `form:"hash" json:"hash" validate:"len=32,hexadecimal,lowercase,required" gorm:"type:VARCHAR(32);NOT NULL"`
 	Info        GroupInfo  `form:"info" json:"info" validate:"required,valid" gorm:"type:JSON;NOT NULL"`
+	Rule        string     `form:"rule" json:"rule" validate:"max=8192" gorm:"type:VARCHAR(8192);NOT NULL;default:''"`
 	CreatedDate time.Time  `form:"created_date,omitempty" json:"created_date,omitempty" validate:"omitempty" gorm:"type:DATETIME;NOT NULL;default:CURRENT_TIMESTAMP"`
 	UpdatedAt   time.Time  `form:"updated_at" json:"updated_at"`
 	DeletedAt   *time.Time `form:"deleted_at,omitempty" json:"deleted_at,omitempty" sql:"index"`
@@ -74,6 +75,11 @@ func (g *Group) BeforeDelete(db *gorm.DB) error {
 	return err
 }
 
+// IsDynamic returns true if agents membership of the group is driven by the rule
+func (g Group) IsDynamic() bool {
+	return g.Rule != ""
+}
+
 // Valid is function to control input/output data
 func (g Group) Valid() error {
 	return validate.Struct(g)
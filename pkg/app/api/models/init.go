@@ -23,6 +23,7 @@ const (
 	semverexRegexString = "^(v)?[0-9]+\\.[0-9]+(\\.[0-9]+)?(\\.[0-9]+)?(-[a-zA-Z0-9]+)?$"
 	locKeyRegexString   = "^[A-Z][a-zA-Z0-9]*(\\.[A-Z][a-zA-Z0-9]*)*$"
 	actKeyRegexString   = "^[a-z0-9_\\-]+\\.[a-zA-Z0-9_]+$"
+	archRegexString     = "^[a-z0-9]{2,10}$"
 )
 
 var (
@@ -1042,9 +1043,11 @@ func init() {
 	validate.RegisterValidation("semverex", templateValidatorString(semverexRegexString))
 	validate.RegisterValidation("lockey", templateValidatorString(locKeyRegexString))
 	validate.RegisterValidation("actkey", templateValidatorString(actKeyRegexString))
+	validate.RegisterValidation("vxarch", templateValidatorString(archRegexString))
 	validate.RegisterValidation("stpass", strongPasswordValidatorString())
 	validate.RegisterValidation("vmail", emailValidatorString())
 	validate.RegisterValidation("valid", deepValidator())
+	validate.RegisterValidation("vxpriv", privilegeValidator)
 	validate.RegisterStructValidation(binaryInfoStructValidator, BinaryInfo{})
 	validate.RegisterStructValidation(eventConfigItemStructValidator, EventConfigItem{})
 	validate.RegisterStructValidation(systemModuleStructValidator, ModuleS{})
@@ -1063,9 +1066,34 @@ func init() {
 	_, _ = reflect.ValueOf(UserRoleTenant{}).Interface().(IValid)
 
 	_, _ = reflect.ValueOf(Role{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(AuditLog{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(Privilege{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(PrivilegesList{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(RolePrivileges{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(RolePrivilegesPatch{}).Interface().(IValid)
 
 	_, _ = reflect.ValueOf(Tenant{}).Interface().(IValid)
 
+	_, _ = reflect.ValueOf(ViewQuery{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(View{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(ViewCreate{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(ViewPatch{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(UserMFARecoveryCodes{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(UserMFA{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(UserMFAStatus{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(UserMFASecret{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(UserMFARecovery{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(UserSession{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(UserSessionInfo{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(MFACode{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(BundleModule{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(BundlePolicy{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(BundleGroup{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(Bundle{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(TrustedPublisher{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(TrustedPublisherCreate{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(TrustedPublisherPatch{}).Interface().(IValid)
+
 	_, _ = reflect.ValueOf(ServiceInfoDB{}).Interface().(IValid)
 	_, _ = reflect.ValueOf(ServiceInfoS3{}).Interface().(IValid)
 	_, _ = reflect.ValueOf(ServiceInfoServer{}).Interface().(IValid)
@@ -1101,6 +1129,13 @@ func init() {
 	_, _ = reflect.ValueOf(AgentOS{}).Interface().(IValid)
 	_, _ = reflect.ValueOf(AgentNet{}).Interface().(IValid)
 	_, _ = reflect.ValueOf(AgentUser{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(AgentCPU{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(AgentMemory{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(AgentDisk{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(AgentInterface{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(AgentHardware{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(AgentPackage{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(AgentService{}).Interface().(IValid)
 	_, _ = reflect.ValueOf(AgentInfo{}).Interface().(IValid)
 	_, _ = reflect.ValueOf(Agent{}).Interface().(IValid)
 	_, _ = reflect.ValueOf(AgentGroup{}).Interface().(IValid)
@@ -1123,6 +1158,7 @@ func init() {
 
 	_, _ = reflect.ValueOf(EventInfo{}).Interface().(IValid)
 	_, _ = reflect.ValueOf(Event{}).Interface().(IValid)
+	_, _ = reflect.ValueOf(EventExport{}).Interface().(IValid)
 	_, _ = reflect.ValueOf(EventModule{}).Interface().(IValid)
 	_, _ = reflect.ValueOf(EventAgent{}).Interface().(IValid)
 	_, _ = reflect.ValueOf(EventModuleAgent{}).Interface().(IValid)
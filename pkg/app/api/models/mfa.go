@@ -0,0 +1,150 @@
+package models
+
+import (
+	"crypto/subtle"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"soldr/pkg/app/api/utils/mfa"
+	"soldr/pkg/crypto"
+)
+
+// UserMFARecoveryCodes is a list of hashes of unused recovery codes
+type UserMFARecoveryCodes []string
+
+// Valid is function to control input/output data
+func (umrc UserMFARecoveryCodes) Valid() error {
+	return validate.Var(umrc, "omitempty,unique,dive,len=64,hexadecimal,lowercase,required")
+}
+
+// Value is interface function to return current value to store to DB
+func (umrc UserMFARecoveryCodes) Value() (driver.Value, error) {
+	if umrc == nil {
+		umrc = UserMFARecoveryCodes{}
+	}
+	b, err := json.Marshal(umrc)
+	return string(b), err
+}
+
+// Scan is interface function to parse DB value when getting from DB
+func (umrc *UserMFARecoveryCodes) Scan(input interface{}) error {
+	return scanFromJSON(input, umrc)
+}
+
+// UserMFA is model to contain TOTP multi-factor authentication settings of the user,
+// the secret is stored in encrypted form only
+type UserMFA struct {
+	ID            uint64               `form:"id" json:"id" validate:"min=0,numeric" gorm:"type:INT(10) UNSIGNED;NOT NULL;PRIMARY_KEY;AUTO_INCREMENT"`
+	UserID        uint64               `form:"user_id" json:"user_id" validate:"min=0,numeric" gorm:"type:INT(10) UNSIGNED;NOT NULL"`
+	Secret        string               `form:"-" json:"-" validate:"max=255,required" gorm:"type:VARCHAR(255);NOT NULL"`
+	Status        string               `form:"status" json:"status" validate:"oneof=pending active,required" gorm:"type:ENUM('pending','active');NOT NULL"`
+	RecoveryCodes UserMFARecoveryCodes `form:"-" json:"-" validate:"valid" gorm:"type:JSON;NOT NULL"`
+	LastStep      int64                `form:"-" json:"-" validate:"min=0" gorm:"type:BIGINT;NOT NULL;default:0"`
+	CreatedDate   time.Time            `form:"created_date,omitempty" json:"created_date,omitempty" validate:"omitempty" gorm:"type:DATETIME;NOT NULL;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name string to guaranty use correct table
+func (um *UserMFA) TableName() string {
+	return "user_mfa"
+}
+
+// Valid is function to control input/output data
+func (um UserMFA) Valid() error {
+	return validate.Struct(um)
+}
+
+// Validate is function to use callback to control input/output data
+func (um UserMFA) Validate(db *gorm.DB) {
+	if err := um.Valid(); err != nil {
+		db.AddError(err)
+	}
+}
+
+// VerifyTOTP checks TOTP code and marks its time step as used to prevent replay,
+// so the model must be saved after successful verification
+func (um *UserMFA) VerifyTOTP(encryptor crypto.IDBConfigEncryptor, code string, now time.Time) (bool, error) {
+	secret, err := encryptor.DecryptValue(um.Secret)
+	if err != nil {
+		return false, fmt.Errorf("failed to decrypt TOTP secret: %w", err)
+	}
+	step, ok, err := mfa.ValidateCode(string(secret), code, now)
+	if err != nil || !ok || step <= um.LastStep {
+		return false, err
+	}
+	um.LastStep = step
+	return true, nil
+}
+
+// VerifyRecoveryCode checks recovery code and removes it from the list because it can be used only once,
+// so the model must be saved after successful verification
+func (um *UserMFA) VerifyRecoveryCode(code string) bool {
+	hash := mfa.HashRecoveryCode(code)
+	for idx, value := range um.RecoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(value), []byte(hash)) == 1 {
+			codes := make(UserMFARecoveryCodes, 0, len(um.RecoveryCodes)-1)
+			codes = append(codes, um.RecoveryCodes[:idx]...)
+			um.RecoveryCodes = append(codes, um.RecoveryCodes[idx+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyCode checks TOTP code or one of recovery codes of the active MFA settings
+func (um *UserMFA) VerifyCode(encryptor crypto.IDBConfigEncryptor, code string, now time.Time) (bool, error) {
+	if len(code) == mfa.Digits {
+		return um.VerifyTOTP(encryptor, code, now)
+	}
+	return um.VerifyRecoveryCode(code), nil
+}
+
+// UserMFAStatus is model to return current state of multi-factor authentication of the user
+type UserMFAStatus struct {
+	Status            string `form:"status" json:"status" validate:"oneof=disabled pending active,required"`
+	RecoveryCodesLeft int    `form:"recovery_codes_left" json:"recovery_codes_left" validate:"min=0"`
+}
+
+// Valid is function to control input/output data
+func (ums UserMFAStatus) Valid() error {
+	return validate.Struct(ums)
+}
+
+// UserMFASecret is model to return TOTP secret once on enrollment to add it into authenticator application
+type UserMFASecret struct {
+	Secret string `form:"secret" json:"secret" validate:"required"`
+	URI    string `form:"uri" json:"uri" validate:"required"`
+}
+
+// Valid is function to control input/output data
+func (ums UserMFASecret) Valid() error {
+	return validate.Struct(ums)
+}
+
+// UserMFARecovery is model to return recovery codes once on MFA activation
+type UserMFARecovery struct {
+	RecoveryCodes []string `form:"recovery_codes" json:"recovery_codes" validate:"required"`
+}
+
+// Valid is function to control input/output data
+func (umr UserMFARecovery) Valid() error {
+	return validate.Struct(umr)
+}
+
+// MFACode is model to contain TOTP code or recovery code to confirm MFA action or login
+type MFACode struct {
+	Code string `form:"code" json:"code" validate:"min=6,max=20,printascii,required"`
+}
+
+// Valid is function to control input/output data
+func (mc MFACode) Valid() error {
+	return validate.Struct(mc)
+}
+
+// LoginStatus is model to return result of the first login step
+type LoginStatus struct {
+	MFARequired bool `form:"mfa_required" json:"mfa_required"`
+}
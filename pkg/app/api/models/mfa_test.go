@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soldr/pkg/app/api/utils/mfa"
+)
+
+func TestUserMFAVerifyCode(t *testing.T) {
+	secret, err := mfa.GenerateSecret()
+	require.NoError(t, err)
+	encSecret, err := validationEncryptor.EncryptValue([]byte(secret))
+	require.NoError(t, err)
+	codes, hashes, err := mfa.GenerateRecoveryCodes(2)
+	require.NoError(t, err)
+
+	userMFA := UserMFA{
+		UserID:        1,
+		Secret:        encSecret,
+		Status:        "active",
+		RecoveryCodes: hashes,
+	}
+	require.NoError(t, userMFA.Valid())
+
+	now := time.Now()
+	code, err := mfa.GenerateCode(secret, mfa.GetStep(now))
+	require.NoError(t, err)
+
+	ok, err := userMFA.VerifyCode(validationEncryptor, code, now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, mfa.GetStep(now), userMFA.LastStep)
+
+	ok, err = userMFA.VerifyCode(validationEncryptor, code, now)
+	require.NoError(t, err)
+	assert.False(t, ok, "used TOTP code must be rejected")
+
+	ok, err = userMFA.VerifyCode(validationEncryptor, codes[1], now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, UserMFARecoveryCodes{hashes[0]}, userMFA.RecoveryCodes)
+
+	ok, err = userMFA.VerifyCode(validationEncryptor, codes[1], now)
+	require.NoError(t, err)
+	assert.False(t, ok, "used recovery code must be rejected")
+}
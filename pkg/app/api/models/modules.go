@@ -496,12 +496,12 @@ func (l *Locale) Scan(input interface{}) error {
 }
 
 // ModuleInfoOS is a proprietary structure to contain module OS list
-// E.x. {"windows": ["386", "amd64"], ...}
+// E.x. {"windows": ["386", "amd64"], "linux": ["386", "amd64", "arm64"], ...}
 type ModuleInfoOS map[string][]string
 
 // Valid is function to control input/output data
 func (mios ModuleInfoOS) Valid() error {
-	if err := validate.Var(mios, "min=1,dive,keys,oneof=windows linux darwin,endkeys,min=1,unique,required,dive,oneof=386 amd64"); err != nil {
+	if err := validate.Var(mios, "min=1,dive,keys,oneof=windows linux darwin,endkeys,min=1,unique,required,dive,vxarch"); err != nil {
 		return err
 	}
 	return nil
@@ -825,6 +825,7 @@ type ModuleA struct {
 	LastUpdate          time.Time          `form:"last_update,omitempty" json:"last_update,omitempty" validate:"omitempty" gorm:"type:DATETIME;NOT NULL;default:CURRENT_TIMESTAMP"`
 	DeletedAt           *time.Time         `form:"deleted_at,omitempty" json:"deleted_at,omitempty" sql:"index"`
 	FilesChecksums      FilesChecksumsMap  `form:"files_checksums,omitempty" json:"files_checksums,omitempty" validate:"omitempty" gorm:"type:JSON;NOT NULL"`
+	PrevVersion         string             `form:"prev_version,omitempty" json:"prev_version,omitempty" validate:"omitempty,semver" gorm:"type:VARCHAR(50);NOT NULL;default:''"`
 }
 
 // TableName returns the table name string to guaranty use correct table
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// TrustedPublisher is model to contain Ed25519 public key of the publisher whose signed module archives are trusted on import
+type TrustedPublisher struct {
+	ID          uint64    `form:"id" json:"id" validate:"min=0,numeric" gorm:"type:INT(10) UNSIGNED;NOT NULL;PRIMARY_KEY;AUTO_INCREMENT"`
+	TenantID    uint64    `form:"tenant_id" json:"tenant_id" validate:"min=0,numeric" gorm:"type:INT(10) UNSIGNED;NOT NULL"`
+	Name        string    `form:"name" json:"name" validate:"max=100,required" gorm:"type:VARCHAR(100);NOT NULL"`
+	KeyID       string    `form:"key_id" json:"key_id" validate:"len=16,hexadecimal,lowercase,required" gorm:"type:VARCHAR(16);NOT NULL"`
+	PublicKey   string    `form:"public_key" json:"public_key" validate:"len=44,base64,required" gorm:"type:VARCHAR(44);NOT NULL"`
+	Status      string    `form:"status" json:"status" validate:"oneof=active revoked,required" gorm:"type:ENUM('active','revoked');NOT NULL"`
+	CreatedDate time.Time `form:"created_date,omitempty" json:"created_date,omitempty" validate:"omitempty" gorm:"type:DATETIME;NOT NULL;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name string to guaranty use correct table
+func (tp *TrustedPublisher) TableName() string {
+	return "trusted_publishers"
+}
+
+// Valid is function to control input/output data
+func (tp TrustedPublisher) Valid() error {
+	return validate.Struct(tp)
+}
+
+// Validate is function to use callback to control input/output data
+func (tp TrustedPublisher) Validate(db *gorm.DB) {
+	if err := tp.Valid(); err != nil {
+		db.AddError(err)
+	}
+}
+
+// TrustedPublisherCreate is model to contain trusted publisher information on creating procedure
+type TrustedPublisherCreate struct {
+	Name      string `form:"name" json:"name" validate:"max=100,required"`
+	PublicKey string `form:"public_key" json:"public_key" validate:"len=44,base64,required"`
+}
+
+// Valid is function to control input/output data
+func (tpc TrustedPublisherCreate) Valid() error {
+	return validate.Struct(tpc)
+}
+
+// TrustedPublisherPatch is model to contain trusted publisher information on updating procedure
+type TrustedPublisherPatch struct {
+	Name   string `form:"name" json:"name" validate:"max=100,required"`
+	Status string `form:"status" json:"status" validate:"oneof=active revoked,required"`
+}
+
+// Valid is function to control input/output data
+func (tpp TrustedPublisherPatch) Valid() error {
+	return validate.Struct(tpp)
+}
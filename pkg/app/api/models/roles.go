@@ -1,6 +1,9 @@
 package models
 
-import "github.com/jinzhu/gorm"
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/jinzhu/gorm"
+)
 
 const (
 	// RoleSAdmin is an SAdmin role
@@ -13,6 +16,84 @@ const (
 	RoleExternal = 100
 )
 
+// KnownPrivileges is a list of all privileges which are checked by API handlers
+var KnownPrivileges = []string{
+	"vxapi.agents.api.create",
+	"vxapi.agents.api.delete",
+	"vxapi.agents.api.edit",
+	"vxapi.agents.api.view",
+	"vxapi.agents.downloads",
+	"vxapi.audit.api.view",
+	"vxapi.groups.api.create",
+	"vxapi.groups.api.delete",
+	"vxapi.groups.api.edit",
+	"vxapi.groups.api.view",
+	"vxapi.modules.api.create",
+	"vxapi.modules.api.delete",
+	"vxapi.modules.api.edit",
+	"vxapi.modules.api.view",
+	"vxapi.modules.control.export",
+	"vxapi.modules.control.import",
+	"vxapi.modules.events",
+	"vxapi.modules.interactive",
+	"vxapi.modules.secure-config.edit",
+	"vxapi.modules.secure-config.view",
+	"vxapi.policies.api.create",
+	"vxapi.policies.api.delete",
+	"vxapi.policies.api.edit",
+	"vxapi.policies.api.view",
+	"vxapi.policies.control.link",
+	"vxapi.publishers.api.create",
+	"vxapi.publishers.api.delete",
+	"vxapi.publishers.api.edit",
+	"vxapi.publishers.api.view",
+	"vxapi.roles.api.create",
+	"vxapi.roles.api.delete",
+	"vxapi.roles.api.edit",
+	"vxapi.roles.api.view",
+	"vxapi.services.api.create",
+	"vxapi.services.api.delete",
+	"vxapi.services.api.edit",
+	"vxapi.services.api.view",
+	"vxapi.system.control.update",
+	"vxapi.system.logging.control",
+	"vxapi.system.monitoring.control",
+	"vxapi.templates.create",
+	"vxapi.templates.delete",
+	"vxapi.templates.view",
+	"vxapi.tenants.api.create",
+	"vxapi.tenants.api.delete",
+	"vxapi.tenants.api.edit",
+	"vxapi.tenants.api.view",
+	"vxapi.users.api.create",
+	"vxapi.users.api.delete",
+	"vxapi.users.api.edit",
+	"vxapi.users.api.view",
+}
+
+// IsBuiltinRole returns true for the roles which are created by initial migration
+func IsBuiltinRole(rid uint64) bool {
+	switch rid {
+	case RoleSAdmin, RoleAdmin, RoleUser, RoleExternal:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetRoleScope returns built-in role ID which defines data access scope of the role,
+// custom roles are scoped as User role and have access to own tenant data only
+func GetRoleScope(rid uint64) uint64 {
+	if IsBuiltinRole(rid) {
+		return rid
+	}
+	return RoleUser
+}
+
+func privilegeValidator(fl validator.FieldLevel) bool {
+	return stringInSlice(fl.Field().String(), KnownPrivileges)
+}
+
 // Role is model to contain user role information
 type Role struct {
 	ID   uint64 `form:"id" json:"id" validate:"min=0,numeric" gorm:"type:INT(10) UNSIGNED;NOT NULL;PRIMARY_KEY;AUTO_INCREMENT"`
@@ -35,3 +116,59 @@ func (r Role) Validate(db *gorm.DB) {
 		db.AddError(err)
 	}
 }
+
+// Privilege is model to contain privilege information which is granted to the role
+type Privilege struct {
+	ID     uint64 `form:"id" json:"id" validate:"min=0,numeric" gorm:"type:INT(10) UNSIGNED;NOT NULL;PRIMARY_KEY;AUTO_INCREMENT"`
+	RoleID uint64 `form:"role_id" json:"role_id" validate:"min=0,numeric" gorm:"type:INT(10) UNSIGNED;NOT NULL"`
+	Name   string `form:"name" json:"name" validate:"max=100,vxpriv,required" gorm:"type:VARCHAR(100);NOT NULL"`
+}
+
+// TableName returns the table name string to guaranty use correct table
+func (p *Privilege) TableName() string {
+	return "privileges"
+}
+
+// Valid is function to control input/output data
+func (p Privilege) Valid() error {
+	return validate.Struct(p)
+}
+
+// Validate is function to use callback to control input/output data
+func (p Privilege) Validate(db *gorm.DB) {
+	if err := p.Valid(); err != nil {
+		db.AddError(err)
+	}
+}
+
+// PrivilegesList is a list of known privilege names
+type PrivilegesList []string
+
+// Valid is function to control input/output data
+func (pl PrivilegesList) Valid() error {
+	return validate.Var(pl, "unique,dive,vxpriv,required")
+}
+
+// RolePrivileges is model to contain user role information with granted privileges
+type RolePrivileges struct {
+	Privileges PrivilegesList `form:"privileges" json:"privileges" validate:"valid"`
+	Role       `form:"" json:""`
+}
+
+// Valid is function to control input/output data
+func (rp RolePrivileges) Valid() error {
+	if err := rp.Role.Valid(); err != nil {
+		return err
+	}
+	return validate.Struct(rp)
+}
+
+// RolePrivilegesPatch is model to contain privileges list to replace role privileges
+type RolePrivilegesPatch struct {
+	Privileges PrivilegesList `form:"privileges" json:"privileges" validate:"valid"`
+}
+
+// Valid is function to control input/output data
+func (rpp RolePrivilegesPatch) Valid() error {
+	return validate.Struct(rpp)
+}
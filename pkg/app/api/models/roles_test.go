@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivilegesListValid(t *testing.T) {
+	assert.NoError(t, PrivilegesList{}.Valid())
+	assert.NoError(t, PrivilegesList{"vxapi.agents.api.view", "vxapi.roles.api.edit"}.Valid())
+	assert.Error(t, PrivilegesList{"vxapi.agents.api.view", "vxapi.agents.api.view"}.Valid(), "duplicates must be rejected")
+	assert.Error(t, PrivilegesList{"vxapi.agents.api.unknown"}.Valid(), "unknown privileges must be rejected")
+
+	role := RolePrivileges{
+		Role:       Role{ID: 101, Name: "Analyst"},
+		Privileges: PrivilegesList{"vxapi.unknown"},
+	}
+	assert.Error(t, role.Valid())
+	role.Privileges = PrivilegesList{"vxapi.agents.api.view"}
+	assert.NoError(t, role.Valid())
+}
+
+func TestGetRoleScope(t *testing.T) {
+	assert.Equal(t, uint64(RoleSAdmin), GetRoleScope(RoleSAdmin))
+	assert.Equal(t, uint64(RoleAdmin), GetRoleScope(RoleAdmin))
+	assert.Equal(t, uint64(RoleExternal), GetRoleScope(RoleExternal))
+	assert.Equal(t, uint64(RoleUser), GetRoleScope(101))
+	assert.False(t, IsBuiltinRole(101))
+}
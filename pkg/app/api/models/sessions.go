@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// UserSession is model to contain server-side session of the user which is linked by the cookie,
+// session values are stored in serialized form and are never returned by API
+type UserSession struct {
+	ID           uint64    `form:"id" json:"id" validate:"min=0,numeric" gorm:"type:INT(10) UNSIGNED;NOT NULL;PRIMARY_KEY;AUTO_INCREMENT"`
+	UserID       *uint64   `form:"user_id,omitempty" json:"user_id,omitempty" validate:"omitempty,min=0,numeric" gorm:"type:INT(10) UNSIGNED;default:NULL"`
+	TokenHash    string    `form:"-" json:"-" validate:"len=64,hexadecimal,lowercase,required" gorm:"type:VARCHAR(64);NOT NULL"`
+	Data         []byte    `form:"-" json:"-" validate:"omitempty" gorm:"type:BLOB;NOT NULL"`
+	IP           string    `form:"ip" json:"ip" validate:"max=50" gorm:"type:VARCHAR(50);NOT NULL"`
+	UserAgent    string    `form:"user_agent" json:"user_agent" validate:"max=255" gorm:"type:VARCHAR(255);NOT NULL"`
+	LastActiveAt time.Time `form:"last_active_at" json:"last_active_at" validate:"omitempty" gorm:"type:DATETIME;NOT NULL"`
+	ExpiresAt    time.Time `form:"expires_at" json:"expires_at" validate:"omitempty" gorm:"type:DATETIME;NOT NULL"`
+	CreatedDate  time.Time `form:"created_date,omitempty" json:"created_date,omitempty" validate:"omitempty" gorm:"type:DATETIME;NOT NULL;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name string to guaranty use correct table
+func (us *UserSession) TableName() string {
+	return "user_sessions"
+}
+
+// Valid is function to control input/output data
+func (us UserSession) Valid() error {
+	return validate.Struct(us)
+}
+
+// Validate is function to use callback to control input/output data
+func (us UserSession) Validate(db *gorm.DB) {
+	if err := us.Valid(); err != nil {
+		db.AddError(err)
+	}
+}
+
+// IsExpired returns true if the session reached absolute timeout or it was inactive more than idle timeout
+func (us UserSession) IsExpired(now time.Time, idleTimeout time.Duration) bool {
+	if !us.ExpiresAt.After(now) {
+		return true
+	}
+	return idleTimeout > 0 && now.Sub(us.LastActiveAt) > idleTimeout
+}
+
+// UserSessionInfo is model to return the user session with the flag of the session of current request
+type UserSessionInfo struct {
+	Current     bool `form:"current" json:"current"`
+	UserSession `form:"" json:""`
+}
+
+// Valid is function to control input/output data
+func (usi UserSessionInfo) Valid() error {
+	return usi.UserSession.Valid()
+}
+
+// LoginAttempts is model to contain failed login attempts and lockout state by the user or by the source IP,
+// it's shared between all API replicas via DB
+type LoginAttempts struct {
+	LockKey     string     `form:"lock_key" json:"lock_key" validate:"max=255,required" gorm:"type:VARCHAR(255);NOT NULL;PRIMARY_KEY"`
+	Failures    int        `form:"failures" json:"failures" validate:"min=0" gorm:"type:INT(10) UNSIGNED;NOT NULL"`
+	FirstFail   time.Time  `form:"first_fail" json:"first_fail" validate:"omitempty" gorm:"type:DATETIME;NOT NULL"`
+	LockedUntil *time.Time `form:"locked_until,omitempty" json:"locked_until,omitempty" validate:"omitempty" gorm:"type:DATETIME;default:NULL"`
+}
+
+// TableName returns the table name string to guaranty use correct table
+func (la *LoginAttempts) TableName() string {
+	return "login_attempts"
+}
+
+// IsLocked returns true if the key is locked at the time
+func (la LoginAttempts) IsLocked(now time.Time) bool {
+	return la.LockedUntil != nil && la.LockedUntil.After(now)
+}
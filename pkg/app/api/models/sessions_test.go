@@ -0,0 +1,45 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserSessionIsExpired(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		session UserSession
+		idle    time.Duration
+		expired bool
+	}{
+		{
+			name:    "active",
+			session: UserSession{LastActiveAt: now.Add(-time.Minute), ExpiresAt: now.Add(time.Hour)},
+			idle:    time.Hour,
+		},
+		{
+			name:    "absolute timeout",
+			session: UserSession{LastActiveAt: now, ExpiresAt: now},
+			idle:    time.Hour,
+			expired: true,
+		},
+		{
+			name:    "idle timeout",
+			session: UserSession{LastActiveAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(time.Hour)},
+			idle:    time.Hour,
+			expired: true,
+		},
+		{
+			name:    "idle timeout is disabled",
+			session: UserSession{LastActiveAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(time.Hour)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expired, tt.session.IsExpired(now, tt.idle))
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// UserTokenScopes is a list of privileges which are granted to the user token
+type UserTokenScopes []string
+
+// Valid is function to control input/output data
+func (uts UserTokenScopes) Valid() error {
+	return validate.Var(uts, "required,unique,dive,max=100,printascii,required")
+}
+
+// Value is interface function to return current value to store to DB
+func (uts UserTokenScopes) Value() (driver.Value, error) {
+	b, err := json.Marshal(uts)
+	return string(b), err
+}
+
+// Scan is interface function to parse DB value when getting from DB
+func (uts *UserTokenScopes) Scan(input interface{}) error {
+	return scanFromJSON(input, uts)
+}
+
+// UserToken is model to contain personal API token information of the user
+type UserToken struct {
+	ID          uint64          `form:"id" json:"id" validate:"min=0,numeric" gorm:"type:INT(10) UNSIGNED;NOT NULL;PRIMARY_KEY;AUTO_INCREMENT"`
+	Hash        string          `form:"hash" json:"hash" validate:"len=32,hexadecimal,lowercase,required" gorm:"type:VARCHAR(32);NOT NULL"`
+	UserID      uint64          `form:"user_id" json:"user_id" validate:"min=0,numeric" gorm:"type:INT(10) UNSIGNED;NOT NULL"`
+	Name        string          `form:"name" json:"name" validate:"max=100,required" gorm:"type:VARCHAR(100);NOT NULL"`
+	TokenHash   string          `form:"-" json:"-" validate:"len=64,hexadecimal,lowercase,required" gorm:"type:VARCHAR(64);NOT NULL"`
+	Scopes      UserTokenScopes `form:"scopes" json:"scopes" validate:"required,valid" gorm:"type:JSON;NOT NULL"`
+	Status      string          `form:"status" json:"status" validate:"oneof=active revoked,required" gorm:"type:ENUM('active','revoked');NOT NULL"`
+	ExpiresAt   *time.Time      `form:"expires_at,omitempty" json:"expires_at,omitempty" validate:"omitempty" gorm:"type:DATETIME;default:NULL"`
+	LastUsedAt  *time.Time      `form:"last_used_at,omitempty" json:"last_used_at,omitempty" validate:"omitempty" gorm:"type:DATETIME;default:NULL"`
+	CreatedDate time.Time       `form:"created_date,omitempty" json:"created_date,omitempty" validate:"omitempty" gorm:"type:DATETIME;NOT NULL;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name string to guaranty use correct table
+func (ut *UserToken) TableName() string {
+	return "user_tokens"
+}
+
+// Valid is function to control input/output data
+func (ut UserToken) Valid() error {
+	return validate.Struct(ut)
+}
+
+// Validate is function to use callback to control input/output data
+func (ut UserToken) Validate(db *gorm.DB) {
+	if err := ut.Valid(); err != nil {
+		db.AddError(err)
+	}
+}
+
+// IsExpired returns true if the user token can't be used anymore by the expiration time
+func (ut UserToken) IsExpired(now time.Time) bool {
+	return ut.ExpiresAt != nil && !ut.ExpiresAt.After(now)
+}
+
+// UserTokenSecret is model to return the user token value once after creating
+type UserTokenSecret struct {
+	Token     string `form:"token" json:"token" validate:"required"`
+	UserToken `form:"" json:""`
+}
+
+// Valid is function to control input/output data
+func (uts UserTokenSecret) Valid() error {
+	if err := uts.UserToken.Valid(); err != nil {
+		return err
+	}
+	return validate.Struct(uts)
+}
+
+// UserTokenCreate is model to contain user token information on creating procedure
+type UserTokenCreate struct {
+	Name      string          `form:"name" json:"name" validate:"max=100,required"`
+	Scopes    UserTokenScopes `form:"scopes" json:"scopes" validate:"required,valid"`
+	ExpiresAt *time.Time      `form:"expires_at,omitempty" json:"expires_at,omitempty" validate:"omitempty"`
+}
+
+// Valid is function to control input/output data
+func (utc UserTokenCreate) Valid() error {
+	return validate.Struct(utc)
+}
+
+// UserTokenPatch is model to contain user token information on updating procedure
+type UserTokenPatch struct {
+	Name   string          `form:"name" json:"name" validate:"max=100,required"`
+	Scopes UserTokenScopes `form:"scopes" json:"scopes" validate:"required,valid"`
+	Status string          `form:"status" json:"status" validate:"oneof=active revoked,required"`
+}
+
+// Valid is function to control input/output data
+func (utp UserTokenPatch) Valid() error {
+	return validate.Struct(utp)
+}
@@ -84,6 +84,30 @@ func (sin Login) Validate(db *gorm.DB) {
 	}
 }
 
+// Authorize is model to contain params to start login procedure via OpenID Connect provider
+type Authorize struct {
+	ReturnURI string `form:"return_uri" json:"return_uri" validate:"max=2048,omitempty"`
+	Service   string `form:"service" json:"service" validate:"omitempty,len=32,hexadecimal,lowercase"`
+}
+
+// Valid is function to control input/output data
+func (a Authorize) Valid() error {
+	return validate.Struct(a)
+}
+
+// LoginCallback is model to contain params of the login callback from OpenID Connect provider
+type LoginCallback struct {
+	Code             string `form:"code" json:"code" validate:"max=2048,required_without=Error"`
+	State            string `form:"state" json:"state" validate:"max=256,required"`
+	Error            string `form:"error" json:"error" validate:"max=256,omitempty"`
+	ErrorDescription string `form:"error_description" json:"error_description" validate:"max=1024,omitempty"`
+}
+
+// Valid is function to control input/output data
+func (lc LoginCallback) Valid() error {
+	return validate.Struct(lc)
+}
+
 // PermissionsService is model to contain service permissions for current user
 type PermissionsService struct {
 	Roles      []string `json:"roles" validate:"required,dive,printascii,required"`
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// maxViewQuerySize is a maximum size of serialized table query into the view
+const maxViewQuerySize = 64 * 1024
+
+// ViewQuery is a serialized table query (filters, sorting, grouping and filter expression) of the view
+type ViewQuery json.RawMessage
+
+// Valid is function to control input/output data
+func (vq ViewQuery) Valid() error {
+	if len(vq) > maxViewQuerySize {
+		return fmt.Errorf("view query size exceeds %d bytes", maxViewQuerySize)
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(vq), []byte("{")) || !json.Valid(vq) {
+		return errors.New("view query must be a JSON object")
+	}
+	return nil
+}
+
+// MarshalJSON is interface function to keep query as JSON object into the response
+func (vq ViewQuery) MarshalJSON() ([]byte, error) {
+	if len(vq) == 0 {
+		return []byte("null"), nil
+	}
+	return json.RawMessage(vq).MarshalJSON()
+}
+
+// UnmarshalJSON is interface function to get query as JSON object from the request
+func (vq *ViewQuery) UnmarshalJSON(data []byte) error {
+	if vq == nil {
+		return errors.New("ViewQuery: UnmarshalJSON on nil pointer")
+	}
+	*vq = append((*vq)[0:0], data...)
+	return nil
+}
+
+// Value is interface function to return current value to store to DB
+func (vq ViewQuery) Value() (driver.Value, error) {
+	return string(vq), nil
+}
+
+// Scan is interface function to parse DB value when getting from DB
+func (vq *ViewQuery) Scan(input interface{}) error {
+	switch v := input.(type) {
+	case string:
+		*vq = ViewQuery(v)
+	case []byte:
+		*vq = append((*vq)[0:0], v...)
+	default:
+		return fmt.Errorf("unsupported type of input value to scan")
+	}
+	return nil
+}
+
+// View is model to contain saved search of agents or events which is stored as a table query
+type View struct {
+	ID          uint64    `form:"id" json:"id" validate:"min=0,numeric" gorm:"type:INT(10) UNSIGNED;NOT NULL;PRIMARY_KEY;AUTO_INCREMENT"`
+	TenantID    uint64    `form:"tenant_id" json:"tenant_id" validate:"min=0,numeric" gorm:"type:INT(10) UNSIGNED;NOT NULL"`
+	UserID      uint64    `form:"user_id" json:"user_id" validate:"min=0,numeric" gorm:"type:INT(10) UNSIGNED;NOT NULL"`
+	Name        string    `form:"name" json:"name" validate:"max=100,required" gorm:"type:VARCHAR(100);NOT NULL"`
+	Type        string    `form:"type" json:"type" validate:"oneof=agents events,required" gorm:"type:ENUM('agents','events');NOT NULL"`
+	Shared      bool      `form:"shared" json:"shared" validate:"omitempty" gorm:"type:BOOL;NOT NULL;default:false"`
+	Query       ViewQuery `form:"query" json:"query" validate:"required,valid" gorm:"type:JSON;NOT NULL" swaggertype:"object"`
+	CreatedDate time.Time `form:"created_date,omitempty" json:"created_date,omitempty" validate:"omitempty" gorm:"type:DATETIME;NOT NULL;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name string to guaranty use correct table
+func (v *View) TableName() string {
+	return "views"
+}
+
+// Valid is function to control input/output data
+func (v View) Valid() error {
+	return validate.Struct(v)
+}
+
+// Validate is function to use callback to control input/output data
+func (v View) Validate(db *gorm.DB) {
+	if err := v.Valid(); err != nil {
+		db.AddError(err)
+	}
+}
+
+// ViewCreate is model to contain view information on creating procedure
+type ViewCreate struct {
+	Name   string    `form:"name" json:"name" validate:"max=100,required"`
+	Type   string    `form:"type" json:"type" validate:"oneof=agents events,required"`
+	Shared bool      `form:"shared" json:"shared" validate:"omitempty"`
+	Query  ViewQuery `form:"query" json:"query" validate:"required,valid" swaggertype:"object"`
+}
+
+// Valid is function to control input/output data
+func (vc ViewCreate) Valid() error {
+	return validate.Struct(vc)
+}
+
+// ViewPatch is model to contain view information on updating procedure
+type ViewPatch struct {
+	Name   string    `form:"name" json:"name" validate:"max=100,required"`
+	Shared bool      `form:"shared" json:"shared" validate:"omitempty"`
+	Query  ViewQuery `form:"query" json:"query" validate:"required,valid" swaggertype:"object"`
+}
+
+// Valid is function to control input/output data
+func (vp ViewPatch) Valid() error {
+	return validate.Struct(vp)
+}
@@ -0,0 +1,202 @@
+package modules
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"soldr/pkg/app/api/models"
+)
+
+const (
+	DiffKindAdded   = "added"
+	DiffKindRemoved = "removed"
+	DiffKindChanged = "changed"
+)
+
+// DiffItem is a single difference between JSON documents of two module versions
+type DiffItem struct {
+	Path string      `json:"path"`
+	Kind string      `json:"kind" enums:"added,removed,changed"`
+	From interface{} `json:"from,omitempty" swaggertype:"object"`
+	To   interface{} `json:"to,omitempty" swaggertype:"object"`
+}
+
+// FileDiffItem is a difference of module file between two module versions by SHA256 checksum
+type FileDiffItem struct {
+	Path string `json:"path"`
+	Kind string `json:"kind" enums:"added,removed,changed"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// VersionsDiff is structured difference between two versions of the module
+type VersionsDiff struct {
+	Name                string         `json:"name"`
+	From                string         `json:"from"`
+	To                  string         `json:"to"`
+	ConfigSchema        []DiffItem     `json:"config_schema"`
+	DefaultConfig       []DiffItem     `json:"default_config"`
+	EventConfigSchema   []DiffItem     `json:"event_config_schema"`
+	DefaultEventConfig  []DiffItem     `json:"default_event_config"`
+	ActionConfigSchema  []DiffItem     `json:"action_config_schema"`
+	DefaultActionConfig []DiffItem     `json:"default_action_config"`
+	Locale              []DiffItem     `json:"locale"`
+	Files               []FileDiffItem `json:"files"`
+}
+
+// DiffModulesVersions is function to compare two versions of the system module,
+// files are compared by checksums which are calculated by GetModuleSFilesChecksums
+func DiffModulesVersions(
+	from, to *models.ModuleS,
+	fromFiles, toFiles models.FilesChecksumsMap,
+) (*VersionsDiff, error) {
+	var err error
+	diff := &VersionsDiff{
+		Name:  to.Info.Name,
+		From:  from.Info.Version.String(),
+		To:    to.Info.Version.String(),
+		Files: DiffFilesChecksums(fromFiles, toFiles),
+	}
+
+	parts := []struct {
+		result   *[]DiffItem
+		from, to interface{}
+	}{
+		{&diff.ConfigSchema, from.ConfigSchema, to.ConfigSchema},
+		{&diff.DefaultConfig, from.DefaultConfig, to.DefaultConfig},
+		{&diff.EventConfigSchema, from.EventConfigSchema, to.EventConfigSchema},
+		{&diff.DefaultEventConfig, from.DefaultEventConfig, to.DefaultEventConfig},
+		{&diff.ActionConfigSchema, from.ActionConfigSchema, to.ActionConfigSchema},
+		{&diff.DefaultActionConfig, from.DefaultActionConfig, to.DefaultActionConfig},
+		{&diff.Locale, from.Locale, to.Locale},
+	}
+	for _, part := range parts {
+		if *part.result, err = DiffJSON(part.from, part.to); err != nil {
+			return nil, err
+		}
+	}
+
+	return diff, nil
+}
+
+// DiffJSON is function to compare two values by their JSON representation,
+// the result is sorted by path of the changed value e.g. $.properties.timeout.default
+func DiffJSON(from, to interface{}) ([]DiffItem, error) {
+	toRawJSON := func(v interface{}) (interface{}, error) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal value to compare: %w", err)
+		}
+		var raw interface{}
+		if err = json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal value to compare: %w", err)
+		}
+		return raw, nil
+	}
+
+	rawFrom, err := toRawJSON(from)
+	if err != nil {
+		return nil, err
+	}
+	rawTo, err := toRawJSON(to)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]DiffItem, 0)
+	diffJSONValues("$", rawFrom, rawTo, &items)
+	return items, nil
+}
+
+func diffJSONValues(path string, from, to interface{}, items *[]DiffItem) {
+	switch tfrom := from.(type) {
+	case map[string]interface{}:
+		if tto, ok := to.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(tfrom)+len(tto))
+			for key := range tfrom {
+				keys = append(keys, key)
+			}
+			for key := range tto {
+				if _, ok := tfrom[key]; !ok {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				vfrom, okFrom := tfrom[key]
+				vto, okTo := tto[key]
+				kpath := path + "." + key
+				switch {
+				case !okFrom:
+					*items = append(*items, DiffItem{Path: kpath, Kind: DiffKindAdded, To: vto})
+				case !okTo:
+					*items = append(*items, DiffItem{Path: kpath, Kind: DiffKindRemoved, From: vfrom})
+				default:
+					diffJSONValues(kpath, vfrom, vto, items)
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if tto, ok := to.([]interface{}); ok {
+			for idx := 0; idx < len(tfrom) || idx < len(tto); idx++ {
+				ipath := path + "[" + strconv.Itoa(idx) + "]"
+				switch {
+				case idx >= len(tfrom):
+					*items = append(*items, DiffItem{Path: ipath, Kind: DiffKindAdded, To: tto[idx]})
+				case idx >= len(tto):
+					*items = append(*items, DiffItem{Path: ipath, Kind: DiffKindRemoved, From: tfrom[idx]})
+				default:
+					diffJSONValues(ipath, tfrom[idx], tto[idx], items)
+				}
+			}
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*items = append(*items, DiffItem{Path: path, Kind: DiffKindChanged, From: from, To: to})
+	}
+}
+
+// DiffFilesChecksums is function to compare module files by checksums, the result is sorted by file path
+func DiffFilesChecksums(from, to models.FilesChecksumsMap) []FileDiffItem {
+	items := make([]FileDiffItem, 0)
+	for path, cfrom := range from {
+		if cto, ok := to[path]; !ok {
+			items = append(items, FileDiffItem{Path: path, Kind: DiffKindRemoved, From: cfrom.Sha256})
+		} else if cfrom.Sha256 != cto.Sha256 {
+			items = append(items, FileDiffItem{Path: path, Kind: DiffKindChanged, From: cfrom.Sha256, To: cto.Sha256})
+		}
+	}
+	for path, cto := range to {
+		if _, ok := from[path]; !ok {
+			items = append(items, FileDiffItem{Path: path, Kind: DiffKindAdded, To: cto.Sha256})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Path < items[j].Path
+	})
+	return items
+}
+
+// GetModuleSFilesChecksums is function to calculate checksums of system module files from global S3,
+// paths of files are prefixed by module part directory e.g. /cmodule/main.lua
+func GetModuleSFilesChecksums(mi *models.ModuleInfo) (models.FilesChecksumsMap, error) {
+	template, err := LoadModuleSFromGlobalS3(mi)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	for dir, dfiles := range template {
+		for path, data := range dfiles {
+			files[joinPath("/", dir, path)] = data
+		}
+	}
+
+	return CalcFilesChecksums(files), nil
+}
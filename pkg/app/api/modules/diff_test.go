@@ -0,0 +1,64 @@
+package modules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffJSON(t *testing.T) {
+	from := map[string]interface{}{
+		"timeout": 10,
+		"mode":    "fast",
+		"list":    []interface{}{"a", "b"},
+		"nested":  map[string]interface{}{"key": true},
+	}
+	to := map[string]interface{}{
+		"timeout": 20,
+		"list":    []interface{}{"a", "c", "d"},
+		"nested":  map[string]interface{}{"key": true},
+		"level":   "info",
+	}
+
+	items, err := DiffJSON(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, []DiffItem{
+		{Path: "$.level", Kind: DiffKindAdded, To: "info"},
+		{Path: "$.list[1]", Kind: DiffKindChanged, From: "b", To: "c"},
+		{Path: "$.list[2]", Kind: DiffKindAdded, To: "d"},
+		{Path: "$.mode", Kind: DiffKindRemoved, From: "fast"},
+		{Path: "$.timeout", Kind: DiffKindChanged, From: float64(10), To: float64(20)},
+	}, items)
+
+	items, err = DiffJSON(from, from)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+	assert.NotNil(t, items)
+}
+
+func TestDiffFilesChecksums(t *testing.T) {
+	from := CalcFilesChecksums(map[string][]byte{
+		"/cmodule/main.lua": []byte("v1"),
+		"/smodule/main.lua": []byte("v1"),
+		"/bmodule/main.vue": []byte("v1"),
+	})
+	to := CalcFilesChecksums(map[string][]byte{
+		"/cmodule/main.lua":  []byte("v2"),
+		"/smodule/main.lua":  []byte("v1"),
+		"/cmodule/utils.lua": []byte("v2"),
+	})
+
+	items := DiffFilesChecksums(from, to)
+	assert.Equal(t, []FileDiffItem{
+		{Path: "/bmodule/main.vue", Kind: DiffKindRemoved, From: from["/bmodule/main.vue"].Sha256},
+		{
+			Path: "/cmodule/main.lua",
+			Kind: DiffKindChanged,
+			From: from["/cmodule/main.lua"].Sha256,
+			To:   to["/cmodule/main.lua"].Sha256,
+		},
+		{Path: "/cmodule/utils.lua", Kind: DiffKindAdded, To: to["/cmodule/utils.lua"].Sha256},
+	}, items)
+	assert.Empty(t, DiffFilesChecksums(to, to))
+}
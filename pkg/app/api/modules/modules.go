@@ -341,6 +341,7 @@ func MergeModuleAConfigFromModuleS(moduleA *models.ModuleA, moduleS *models.Modu
 	moduleR.Status = moduleA.Status
 	moduleR.JoinDate = moduleA.JoinDate
 	moduleR.LastUpdate = moduleA.LastUpdate
+	moduleR.PrevVersion = moduleA.PrevVersion
 
 	// merge current and default config
 	moduleR.CurrentConfig = mergeModuleACurrentConfig(
@@ -0,0 +1,214 @@
+package modules
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"soldr/pkg/app/api/models"
+)
+
+const (
+	// ManifestFileName is a name of the file into the root of module archive with checksums of all archive files
+	ManifestFileName = "manifest.json"
+	// SignatureFileName is a name of the file into the root of module archive with detached Ed25519 signature
+	// of the manifest file which is encoded by base64
+	SignatureFileName = "manifest.sig"
+	// ManifestVersion is a current version of the manifest format
+	ManifestVersion = 1
+)
+
+const (
+	ImportPolicyRequire = "require"
+	ImportPolicyWarn    = "warn"
+	ImportPolicyAllow   = "allow"
+)
+
+const (
+	SignatureStatusValid    = "valid"
+	SignatureStatusUnsigned = "unsigned"
+	SignatureStatusInvalid  = "invalid"
+	SignatureStatusSkipped  = "skipped"
+)
+
+var (
+	ErrPackageUnsigned          = errors.New("module archive is not signed")
+	ErrPackageInvalidSignature  = errors.New("module archive signature is invalid")
+	ErrPackageUntrustedKey      = errors.New("module archive is signed by untrusted key")
+	ErrPackageManifestMismatch  = errors.New("module archive files don't match the manifest")
+	ErrPackageInvalidPublicKey  = errors.New("invalid Ed25519 public key")
+	ErrPackageInvalidSigningKey = errors.New("invalid Ed25519 signing key")
+)
+
+// PackageManifest is a list of checksums of all module archive files which is signed by the publisher
+type PackageManifest struct {
+	Version int                      `json:"version"`
+	Module  string                   `json:"module"`
+	KeyID   string                   `json:"key_id"`
+	Created time.Time                `json:"created"`
+	Files   models.FilesChecksumsMap `json:"files"`
+}
+
+// PackageSigner is a signer of module archives by Ed25519 private key of the publisher
+type PackageSigner struct {
+	key   ed25519.PrivateKey
+	keyID string
+}
+
+// NewPackageSigner returns signer by Ed25519 seed or private key which is encoded by base64
+func NewPackageSigner(value string) (*PackageSigner, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPackageInvalidSigningKey, err)
+	}
+
+	var key ed25519.PrivateKey
+	switch len(data) {
+	case ed25519.SeedSize:
+		key = ed25519.NewKeyFromSeed(data)
+	case ed25519.PrivateKeySize:
+		key = ed25519.PrivateKey(data)
+	default:
+		return nil, fmt.Errorf("%w: unexpected key size %d", ErrPackageInvalidSigningKey, len(data))
+	}
+
+	return &PackageSigner{
+		key:   key,
+		keyID: GetPublicKeyID(key.Public().(ed25519.PublicKey)),
+	}, nil
+}
+
+// KeyID returns identifier of the signing key which is put to the manifest
+func (ps *PackageSigner) KeyID() string {
+	return ps.keyID
+}
+
+// PublicKey returns public part of the signing key encoded by base64 to register it as trusted publisher
+func (ps *PackageSigner) PublicKey() string {
+	return base64.StdEncoding.EncodeToString(ps.key.Public().(ed25519.PublicKey))
+}
+
+// Sign returns manifest of the module archive files and detached signature of this manifest,
+// they must be stored into the archive by ManifestPath and SignaturePath
+func (ps *PackageSigner) Sign(moduleName string, files map[string][]byte) ([]byte, []byte, error) {
+	manifest := PackageManifest{
+		Version: ManifestVersion,
+		Module:  moduleName,
+		KeyID:   ps.keyID,
+		Created: time.Now().UTC(),
+		Files:   CalcFilesChecksums(files),
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build module archive manifest: %w", err)
+	}
+
+	signature := ed25519.Sign(ps.key, data)
+	return data, []byte(base64.StdEncoding.EncodeToString(signature)), nil
+}
+
+// GetPublicKeyID returns identifier of Ed25519 public key as a prefix of SHA256 hash of the key
+func GetPublicKeyID(key ed25519.PublicKey) string {
+	hash := sha256.Sum256(key)
+	return hex.EncodeToString(hash[:8])
+}
+
+// ParsePublicKey returns Ed25519 public key which is encoded by base64
+func ParsePublicKey(value string) (ed25519.PublicKey, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPackageInvalidPublicKey, err)
+	}
+	if len(data) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: unexpected key size %d", ErrPackageInvalidPublicKey, len(data))
+	}
+	return ed25519.PublicKey(data), nil
+}
+
+// IsValidImportPolicy returns true if the policy is known policy of signature check on module import
+func IsValidImportPolicy(policy string) bool {
+	switch policy {
+	case ImportPolicyRequire, ImportPolicyWarn, ImportPolicyAllow:
+		return true
+	default:
+		return false
+	}
+}
+
+// ManifestPath returns path of the manifest file into the module archive
+func ManifestPath(moduleName string) string {
+	return moduleName + "/" + ManifestFileName
+}
+
+// SignaturePath returns path of the manifest signature file into the module archive
+func SignaturePath(moduleName string) string {
+	return moduleName + "/" + SignatureFileName
+}
+
+// VerifyPackage is function to check signature of the module archive manifest by the key which is returned
+// by getKey callback and to check that archive files are equal to the manifest files,
+// files must contain all archive files including the manifest and signature ones
+func VerifyPackage(
+	moduleName string,
+	files map[string][]byte,
+	getKey func(keyID string) (ed25519.PublicKey, error),
+) (*PackageManifest, error) {
+	data, okManifest := files[ManifestPath(moduleName)]
+	signature, okSignature := files[SignaturePath(moduleName)]
+	if !okManifest && !okSignature {
+		return nil, ErrPackageUnsigned
+	} else if !okManifest || !okSignature {
+		return nil, fmt.Errorf("%w: manifest or signature file is missing", ErrPackageInvalidSignature)
+	}
+
+	var manifest PackageManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse manifest: %v", ErrPackageInvalidSignature, err)
+	}
+	if manifest.Version != ManifestVersion {
+		return nil, fmt.Errorf("%w: unsupported manifest version %d", ErrPackageInvalidSignature, manifest.Version)
+	}
+	if manifest.Module != moduleName {
+		return nil, fmt.Errorf("%w: manifest is made for module '%s'", ErrPackageInvalidSignature, manifest.Module)
+	}
+
+	key, err := getKey(manifest.KeyID)
+	if err != nil {
+		return nil, fmt.Errorf("%w '%s': %v", ErrPackageUntrustedKey, manifest.KeyID, err)
+	}
+	sign, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode signature: %v", ErrPackageInvalidSignature, err)
+	}
+	if !ed25519.Verify(key, data, sign) {
+		return nil, ErrPackageInvalidSignature
+	}
+
+	var mismatch []string
+	for path, fdata := range files {
+		if path == ManifestPath(moduleName) || path == SignaturePath(moduleName) {
+			continue
+		}
+		if chsm, ok := manifest.Files[path]; !ok || chsm.Sha256 != calcChecksum(fdata) {
+			mismatch = append(mismatch, path)
+		}
+	}
+	for path := range manifest.Files {
+		if _, ok := files[path]; !ok {
+			mismatch = append(mismatch, path)
+		}
+	}
+	if len(mismatch) != 0 {
+		sort.Strings(mismatch)
+		return nil, fmt.Errorf("%w: %s", ErrPackageManifestMismatch, strings.Join(mismatch, ", "))
+	}
+
+	return &manifest, nil
+}
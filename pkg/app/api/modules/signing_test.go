@@ -0,0 +1,106 @@
+package modules
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPackageSigner(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	bySeed, err := NewPackageSigner(base64.StdEncoding.EncodeToString(seed))
+	require.NoError(t, err)
+
+	key := ed25519.NewKeyFromSeed(seed)
+	byKey, err := NewPackageSigner(base64.StdEncoding.EncodeToString(key))
+	require.NoError(t, err)
+	assert.Equal(t, bySeed.KeyID(), byKey.KeyID())
+	assert.Equal(t, bySeed.PublicKey(), byKey.PublicKey())
+	assert.Len(t, bySeed.KeyID(), 16)
+
+	pub, err := ParsePublicKey(bySeed.PublicKey())
+	require.NoError(t, err)
+	assert.Equal(t, bySeed.KeyID(), GetPublicKeyID(pub))
+
+	_, err = NewPackageSigner("not a key")
+	assert.ErrorIs(t, err, ErrPackageInvalidSigningKey)
+	_, err = NewPackageSigner(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.ErrorIs(t, err, ErrPackageInvalidSigningKey)
+	_, err = ParsePublicKey(base64.StdEncoding.EncodeToString(seed[:16]))
+	assert.ErrorIs(t, err, ErrPackageInvalidPublicKey)
+}
+
+func TestVerifyPackage(t *testing.T) {
+	signer, err := NewPackageSigner(base64.StdEncoding.EncodeToString(make([]byte, ed25519.SeedSize)))
+	require.NoError(t, err)
+	trusted := func(keyID string) (ed25519.PublicKey, error) {
+		if keyID != signer.KeyID() {
+			return nil, errors.New("key not found")
+		}
+		return ParsePublicKey(signer.PublicKey())
+	}
+	makeFiles := func() map[string][]byte {
+		files := map[string][]byte{
+			"test/1.0.0/cmodule/main.lua":    []byte("return true"),
+			"test/1.0.0/config/info.json":    []byte(`{"name":"test"}`),
+			"test/1.0.0/smodule/handler.lua": []byte("return false"),
+		}
+		manifest, signature, err := signer.Sign("test", files)
+		require.NoError(t, err)
+		files[ManifestPath("test")] = manifest
+		files[SignaturePath("test")] = signature
+		return files
+	}
+
+	manifest, err := VerifyPackage("test", makeFiles(), trusted)
+	require.NoError(t, err)
+	assert.Equal(t, signer.KeyID(), manifest.KeyID)
+	assert.Len(t, manifest.Files, 3)
+
+	_, err = VerifyPackage("test", map[string][]byte{"test/1.0.0/cmodule/main.lua": nil}, trusted)
+	assert.ErrorIs(t, err, ErrPackageUnsigned)
+
+	files := makeFiles()
+	delete(files, SignaturePath("test"))
+	_, err = VerifyPackage("test", files, trusted)
+	assert.ErrorIs(t, err, ErrPackageInvalidSignature)
+
+	_, err = VerifyPackage("other", makeFiles(), trusted)
+	assert.ErrorIs(t, err, ErrPackageUnsigned)
+
+	files = makeFiles()
+	files["test/1.0.0/cmodule/main.lua"] = []byte("os.execute('id')")
+	_, err = VerifyPackage("test", files, trusted)
+	assert.ErrorIs(t, err, ErrPackageManifestMismatch)
+
+	files = makeFiles()
+	files["test/1.0.0/cmodule/extra.lua"] = []byte("return true")
+	_, err = VerifyPackage("test", files, trusted)
+	assert.ErrorIs(t, err, ErrPackageManifestMismatch)
+
+	files = makeFiles()
+	delete(files, "test/1.0.0/smodule/handler.lua")
+	_, err = VerifyPackage("test", files, trusted)
+	assert.ErrorIs(t, err, ErrPackageManifestMismatch)
+
+	files = makeFiles()
+	files[ManifestPath("test")] = append(files[ManifestPath("test")], ' ')
+	_, err = VerifyPackage("test", files, trusted)
+	assert.ErrorIs(t, err, ErrPackageInvalidSignature)
+
+	_, err = VerifyPackage("test", makeFiles(), func(string) (ed25519.PublicKey, error) {
+		return nil, errors.New("key is revoked")
+	})
+	assert.ErrorIs(t, err, ErrPackageUntrustedKey)
+
+	other, err := NewPackageSigner(base64.StdEncoding.EncodeToString(make([]byte, ed25519.PrivateKeySize)))
+	require.NoError(t, err)
+	_, err = VerifyPackage("test", makeFiles(), func(string) (ed25519.PublicKey, error) {
+		return ParsePublicKey(other.PublicKey())
+	})
+	assert.ErrorIs(t, err, ErrPackageInvalidSignature)
+}
@@ -0,0 +1,56 @@
+package oidc
+
+// Claims is a set of verified ID token claims
+type Claims map[string]interface{}
+
+// String returns claim value as a string or empty string if the claim is not a string
+func (c Claims) String(name string) string {
+	value, _ := c[name].(string)
+	return value
+}
+
+// Strings returns claim value as a list of strings, it supports both a single string and an array
+func (c Claims) Strings(name string) []string {
+	switch value := c[name].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	case []string:
+		return value
+	default:
+		return nil
+	}
+}
+
+// Email returns user mail from the claims, mail is returned only if the provider
+// marks it as verified, some providers set email_verified claim as a string
+func (c Claims) Email() string {
+	switch verified := c["email_verified"].(type) {
+	case bool:
+		if verified {
+			return c.String("email")
+		}
+	case string:
+		if verified == "true" {
+			return c.String("email")
+		}
+	}
+	return ""
+}
+
+// Name returns user display name from the claims with fallback to the mail
+func (c Claims) Name() string {
+	for _, claim := range []string{"name", "preferred_username", "email"} {
+		if name := c.String(claim); name != "" {
+			return name
+		}
+	}
+	return c.String("sub")
+}
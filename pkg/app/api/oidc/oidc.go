@@ -0,0 +1,329 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	discoveryPath  = "/.well-known/openid-configuration"
+	requestTimeout = 30 * time.Second
+	maxBodySize    = 1024 * 1024
+)
+
+var (
+	// ErrInvalidNonce is returned when the nonce claim of the ID token doesn't match to the authorization request
+	ErrInvalidNonce = errors.New("id token nonce mismatch")
+	// ErrTokenExpired is returned when the ID token is expired
+	ErrTokenExpired = errors.New("id token is expired")
+	// ErrClaimNotMapped is returned when there is no mapping for the user claims
+	ErrClaimNotMapped = errors.New("user claims are not mapped")
+)
+
+// Config is a set of options to authorize users via OpenID Connect provider
+type Config struct {
+	// IssuerURL is an URL of the provider to discover its endpoints
+	IssuerURL string
+	// ClientID is an identifier of the client registered on the provider side
+	ClientID string
+	// ClientSecret is a secret of the client, it could be empty for public clients
+	ClientSecret string
+	// RedirectURL is an external URL of the login callback endpoint
+	RedirectURL string
+	// Scopes is a list of requested scopes in addition to the openid one
+	Scopes []string
+	// RoleClaim is a name of the claim to map user role from
+	RoleClaim string
+	// RoleMapping is a map of the role claim values to role IDs
+	RoleMapping map[string]uint64
+	// DefaultRoleID is a role ID to use when the role claim is not mapped, nil means deny login
+	DefaultRoleID *uint64
+	// TenantClaim is a name of the claim to map user tenant from
+	TenantClaim string
+	// TenantMapping is a map of the tenant claim values to tenant hashes
+	TenantMapping map[string]string
+	// DefaultTenant is a tenant hash to use when the tenant claim is not mapped, empty means deny login
+	DefaultTenant string
+	// Provisioning enables creating users on the first login
+	Provisioning bool
+}
+
+// IsEnabled returns true if the provider is configured
+func (c *Config) IsEnabled() bool {
+	return c != nil && c.IssuerURL != "" && c.ClientID != "" && c.RedirectURL != ""
+}
+
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Token is a set of tokens which are returned by the provider on code exchanging
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// Client is an OpenID Connect relying party which implements authorization code flow with PKCE,
+// the provider metadata and signing keys are discovered lazily and cached
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+
+	mx       sync.RWMutex
+	metadata *providerMetadata
+	keys     map[string]interface{}
+}
+
+// NewClient is a function to create OpenID Connect client from the configuration
+func NewClient(cfg Config, httpClient *http.Client) (*Client, error) {
+	if !cfg.IsEnabled() {
+		return nil, fmt.Errorf("issuer URL, client ID and redirect URL must be set")
+	}
+	if _, err := url.Parse(cfg.RedirectURL); err != nil {
+		return nil, fmt.Errorf("invalid redirect URL: %w", err)
+	}
+	if cfg.DefaultRoleID == nil && len(cfg.RoleMapping) == 0 {
+		return nil, fmt.Errorf("role mapping or default role must be set")
+	}
+	if cfg.DefaultTenant == "" && len(cfg.TenantMapping) == 0 {
+		return nil, fmt.Errorf("tenant mapping or default tenant must be set")
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: requestTimeout}
+	}
+	cfg.IssuerURL = strings.TrimSuffix(cfg.IssuerURL, "/")
+	return &Client{
+		cfg:        cfg,
+		httpClient: httpClient,
+		keys:       make(map[string]interface{}),
+	}, nil
+}
+
+// IsProvisioningEnabled returns true if unknown users should be created on login
+func (c *Client) IsProvisioningEnabled() bool {
+	return c.cfg.Provisioning
+}
+
+func (c *Client) getJSON(ctx context.Context, endpoint string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status %d from %s", resp.StatusCode, endpoint)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxBodySize)).Decode(out)
+}
+
+func (c *Client) discover(ctx context.Context) (*providerMetadata, error) {
+	c.mx.RLock()
+	metadata := c.metadata
+	c.mx.RUnlock()
+	if metadata != nil {
+		return metadata, nil
+	}
+
+	var md providerMetadata
+	if err := c.getJSON(ctx, c.cfg.IssuerURL+discoveryPath, &md); err != nil {
+		return nil, fmt.Errorf("failed to discover provider metadata: %w", err)
+	}
+	if strings.TrimSuffix(md.Issuer, "/") != c.cfg.IssuerURL {
+		return nil, fmt.Errorf("provider issuer '%s' doesn't match configured one", md.Issuer)
+	}
+	if md.AuthorizationEndpoint == "" || md.TokenEndpoint == "" || md.JWKSURI == "" {
+		return nil, fmt.Errorf("provider metadata is incomplete")
+	}
+
+	c.mx.Lock()
+	c.metadata = &md
+	c.mx.Unlock()
+	return &md, nil
+}
+
+// AuthCodeURL is a function to make URL to redirect user to the provider authorization endpoint
+func (c *Client) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	md, err := c.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	authURL, err := url.Parse(md.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+	scopes := append([]string{"openid"}, c.cfg.Scopes...)
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", c.cfg.ClientID)
+	query.Set("redirect_uri", c.cfg.RedirectURL)
+	query.Set("scope", strings.Join(scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", MakeCodeChallenge(verifier))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+	return authURL.String(), nil
+}
+
+// Exchange is a function to exchange authorization code to the tokens on the provider token endpoint
+func (c *Client) Exchange(ctx context.Context, code, verifier string) (*Token, error) {
+	md, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.cfg.RedirectURL},
+		"client_id":     {c.cfg.ClientID},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, md.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if c.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.cfg.ClientID), url.QueryEscape(c.cfg.ClientSecret))
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request token endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint returned status %d: %s", resp.StatusCode, body)
+	}
+	var token Token
+	if err = json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("token response doesn't contain id_token")
+	}
+	return &token, nil
+}
+
+// VerifyIDToken is a function to check the ID token signature, issuer, audience, expiration and nonce
+func (c *Client) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (Claims, error) {
+	md, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{
+		"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512",
+	}))
+	claims := jwt.MapClaims{}
+	_, err = parser.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return c.getKey(ctx, md.JWKSURI, kid)
+	})
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return nil, ErrTokenExpired
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to verify id token: %w", err)
+	}
+	if !claims.VerifyIssuer(md.Issuer, true) {
+		return nil, fmt.Errorf("id token issuer mismatch")
+	}
+	if !claims.VerifyAudience(c.cfg.ClientID, true) {
+		return nil, fmt.Errorf("id token audience mismatch")
+	}
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return nil, ErrInvalidNonce
+	}
+	return Claims(claims), nil
+}
+
+// MapRole is a function to get user role ID from the claims, the most privileged mapped role is used
+func (c *Client) MapRole(claims Claims) (uint64, error) {
+	var (
+		roleID uint64
+		found  bool
+	)
+	for _, value := range claims.Strings(c.cfg.RoleClaim) {
+		if id, ok := c.cfg.RoleMapping[value]; ok && (!found || id < roleID) {
+			roleID, found = id, true
+		}
+	}
+	if found {
+		return roleID, nil
+	}
+	if c.cfg.DefaultRoleID != nil {
+		return *c.cfg.DefaultRoleID, nil
+	}
+	return 0, fmt.Errorf("%w: role claim '%s'", ErrClaimNotMapped, c.cfg.RoleClaim)
+}
+
+// MapTenant is a function to get user tenant hash from the claims, the first mapped value is used
+func (c *Client) MapTenant(claims Claims) (string, error) {
+	for _, value := range claims.Strings(c.cfg.TenantClaim) {
+		if hash, ok := c.cfg.TenantMapping[value]; ok {
+			return hash, nil
+		}
+	}
+	if c.cfg.DefaultTenant != "" {
+		return c.cfg.DefaultTenant, nil
+	}
+	return "", fmt.Errorf("%w: tenant claim '%s'", ErrClaimNotMapped, c.cfg.TenantClaim)
+}
+
+// MakeRandomString is a function to generate URL safe random string for state, nonce and code verifier
+func MakeRandomString() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// MakeCodeChallenge is a function to make PKCE code challenge by S256 method
+func MakeCodeChallenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// ParseMapping is a function to parse mapping from string like "value1=target1,value2=target2"
+func ParseMapping(value string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		idx := strings.LastIndex(item, "=")
+		if idx <= 0 || idx == len(item)-1 {
+			return nil, fmt.Errorf("invalid mapping item '%s'", item)
+		}
+		mapping[strings.TrimSpace(item[:idx])] = strings.TrimSpace(item[idx+1:])
+	}
+	return mapping, nil
+}
@@ -0,0 +1,268 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClientID    = "soldr"
+	testRedirectURL = "https://soldr.local/api/v1/auth/login-callback"
+	testKeyID       = "key1"
+)
+
+type authRequest struct {
+	challenge string
+	nonce     string
+}
+
+// mockProvider is a minimal OpenID Connect provider which issues codes without user interaction
+type mockProvider struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	claims jwt.MapClaims
+
+	mx    sync.Mutex
+	codes map[string]authRequest
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := &mockProvider{
+		t:     t,
+		key:   key,
+		codes: make(map[string]authRequest),
+		claims: jwt.MapClaims{
+			"sub":            "user1",
+			"email":          "user1@soldr.local",
+			"email_verified": true,
+			"name":           "User One",
+			"groups":         []string{"soldr-users", "soldr-admins", "tenant-a"},
+		},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, p.discovery)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	mux.HandleFunc("/jwks", p.jwks)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *mockProvider) discovery(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(providerMetadata{
+		Issuer:                p.server.URL,
+		AuthorizationEndpoint: p.server.URL + "/authorize",
+		TokenEndpoint:         p.server.URL + "/token",
+		JWKSURI:               p.server.URL + "/jwks",
+	})
+}
+
+func (p *mockProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != testClientID || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	code, _ := MakeRandomString()
+	p.mx.Lock()
+	p.codes[code] = authRequest{challenge: query.Get("code_challenge"), nonce: query.Get("nonce")}
+	p.mx.Unlock()
+
+	redirectURL, _ := url.Parse(query.Get("redirect_uri"))
+	values := redirectURL.Query()
+	values.Set("code", code)
+	values.Set("state", query.Get("state"))
+	redirectURL.RawQuery = values.Encode()
+	http.Redirect(w, r, redirectURL.String(), http.StatusFound)
+}
+
+func (p *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	p.mx.Lock()
+	req, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mx.Unlock()
+	if !ok || MakeCodeChallenge(r.PostForm.Get("code_verifier")) != req.challenge {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(Token{
+		AccessToken: "access",
+		TokenType:   "Bearer",
+		IDToken:     p.makeIDToken(req.nonce, time.Now().Add(time.Hour)),
+		ExpiresIn:   3600,
+	})
+}
+
+func (p *mockProvider) makeIDToken(nonce string, exp time.Time) string {
+	claims := jwt.MapClaims{
+		"iss":   p.server.URL,
+		"aud":   testClientID,
+		"iat":   time.Now().Unix(),
+		"exp":   exp.Unix(),
+		"nonce": nonce,
+	}
+	for k, v := range p.claims {
+		claims[k] = v
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testKeyID
+	signed, err := token.SignedString(p.key)
+	require.NoError(p.t, err)
+	return signed
+}
+
+func (p *mockProvider) jwks(w http.ResponseWriter, _ *http.Request) {
+	pub := p.key.PublicKey
+	_ = json.NewEncoder(w).Encode(jsonWebKeySet{Keys: []jsonWebKey{{
+		Kty: "RSA",
+		Kid: testKeyID,
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+	}}})
+}
+
+func newTestClient(t *testing.T, p *mockProvider) *Client {
+	t.Helper()
+	defaultRoleID := uint64(100)
+	client, err := NewClient(Config{
+		IssuerURL:     p.server.URL,
+		ClientID:      testClientID,
+		RedirectURL:   testRedirectURL,
+		Scopes:        []string{"email", "profile"},
+		RoleClaim:     "groups",
+		RoleMapping:   map[string]uint64{"soldr-admins": 1, "soldr-users": 2},
+		DefaultRoleID: &defaultRoleID,
+		TenantClaim:   "groups",
+		TenantMapping: map[string]string{"tenant-a": "00000000000000000000000000000001"},
+	}, nil)
+	require.NoError(t, err)
+	return client
+}
+
+// authorizeCode goes through the provider authorization endpoint and returns issued code from the callback URL
+func authorizeCode(t *testing.T, authURL, state string) string {
+	t.Helper()
+	httpClient := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := httpClient.Get(authURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	callbackURL, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, state, callbackURL.Query().Get("state"))
+	return callbackURL.Query().Get("code")
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	p := newMockProvider(t)
+	client := newTestClient(t, p)
+	ctx := context.Background()
+
+	authURL, err := client.AuthCodeURL(ctx, "state1", "nonce1", "verifier1")
+	require.NoError(t, err)
+	code := authorizeCode(t, authURL, "state1")
+
+	_, err = client.Exchange(ctx, code, "wrong_verifier")
+	assert.Error(t, err, "code must not be exchanged with wrong verifier")
+
+	authURL, err = client.AuthCodeURL(ctx, "state2", "nonce2", "verifier2")
+	require.NoError(t, err)
+	code = authorizeCode(t, authURL, "state2")
+
+	token, err := client.Exchange(ctx, code, "verifier2")
+	require.NoError(t, err)
+
+	_, err = client.VerifyIDToken(ctx, token.IDToken, "nonce1")
+	assert.True(t, errors.Is(err, ErrInvalidNonce))
+
+	claims, err := client.VerifyIDToken(ctx, token.IDToken, "nonce2")
+	require.NoError(t, err)
+	assert.Equal(t, "user1@soldr.local", claims.Email())
+	assert.Equal(t, "User One", claims.Name())
+
+	roleID, err := client.MapRole(claims)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), roleID, "the most privileged role must be chosen")
+
+	tenantHash, err := client.MapTenant(claims)
+	require.NoError(t, err)
+	assert.Equal(t, "00000000000000000000000000000001", tenantHash)
+}
+
+func TestVerifyIDTokenFailures(t *testing.T) {
+	p := newMockProvider(t)
+	client := newTestClient(t, p)
+	ctx := context.Background()
+
+	expired := p.makeIDToken("nonce", time.Now().Add(-time.Minute))
+	_, err := client.VerifyIDToken(ctx, expired, "nonce")
+	assert.True(t, errors.Is(err, ErrTokenExpired))
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p.key = otherKey
+	forged := p.makeIDToken("nonce", time.Now().Add(time.Hour))
+	_, err = client.VerifyIDToken(ctx, forged, "nonce")
+	assert.Error(t, err, "token signed by unknown key must be rejected")
+
+	hs := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"aud": testClientID, "nonce": "nonce"})
+	hs.Header["kid"] = testKeyID
+	signed, err := hs.SignedString([]byte("secret"))
+	require.NoError(t, err)
+	_, err = client.VerifyIDToken(ctx, signed, "nonce")
+	assert.Error(t, err, "symmetric signing methods must be rejected")
+}
+
+func TestClaimsMapping(t *testing.T) {
+	p := newMockProvider(t)
+	client := newTestClient(t, p)
+
+	roleID, err := client.MapRole(Claims{"groups": []interface{}{"unknown"}})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), roleID, "default role must be used")
+
+	_, err = client.MapTenant(Claims{"groups": "unknown"})
+	assert.True(t, errors.Is(err, ErrClaimNotMapped))
+
+	assert.Empty(t, Claims{"email": "a@b.c", "email_verified": false}.Email())
+	assert.Empty(t, Claims{"email": "a@b.c"}.Email(), "mail without email_verified claim must be rejected")
+	assert.Equal(t, "a@b.c", Claims{"email": "a@b.c", "email_verified": "true"}.Email())
+
+	mapping, err := ParseMapping(" admins=1, cn=users=2 ,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"admins": "1", "cn=users": "2"}, mapping)
+
+	_, err = ParseMapping("admins")
+	assert.Error(t, err)
+}
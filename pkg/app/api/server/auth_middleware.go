@@ -4,12 +4,16 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
+	"soldr/pkg/app/api/models"
 	"soldr/pkg/app/api/server/private"
 	"soldr/pkg/app/api/server/response"
+	"soldr/pkg/app/api/storage"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
 )
 
 type authResult int
@@ -23,18 +27,23 @@ const (
 
 type AuthMiddleware struct {
 	connectionTypeRegexp *regexp.Regexp
+	db                   *gorm.DB
+	protoTokenKey        []byte
 }
 
-func NewAuthMiddleware(baseURL string) *AuthMiddleware {
+func NewAuthMiddleware(baseURL string, db *gorm.DB, protoTokenKey []byte) *AuthMiddleware {
 	return &AuthMiddleware{
 		connectionTypeRegexp: regexp.MustCompile(
 			fmt.Sprintf("%s/vxpws/(aggregate|browser|external)/.*", baseURL),
 		),
+		db:            db,
+		protoTokenKey: protoTokenKey,
 	}
 }
 
 func (p *AuthMiddleware) AuthRequired(c *gin.Context) {
 	p.tryAuth(c,
+		p.tryUserTokenAuthentication,
 		p.tryUserCookieAuthentication,
 	)
 }
@@ -111,6 +120,75 @@ func (p *AuthMiddleware) tryUserCookieAuthentication(c *gin.Context) authResult
 	return authResultOk
 }
 
+func getBearerToken(c *gin.Context) string {
+	authHeader := c.Request.Header.Get("Authorization")
+	if !strings.HasPrefix(authHeader, "Bearer ") {
+		return ""
+	}
+	return authHeader[7:]
+}
+
+func (p *AuthMiddleware) tryUserTokenAuthentication(c *gin.Context) authResult {
+	token := getBearerToken(c)
+	if !strings.HasPrefix(token, storage.UserTokenPrefix) {
+		return authResultSkip
+	}
+
+	now := time.Now()
+	var userToken models.UserToken
+	err := p.db.Take(&userToken, "token_hash = ? AND status = 'active'", storage.MakeUserTokenSecretHash(token)).Error
+	if err != nil || userToken.IsExpired(now) {
+		return authResultFail
+	}
+
+	var user models.User
+	if err = p.db.Take(&user, "id = ?", userToken.UserID).Error; err != nil {
+		return authResultFail
+	} else if user.Status != "active" {
+		return authResultFail
+	}
+
+	var service models.Service
+	err = p.db.Order("name ASC").Take(&service, "tenant_id = ? AND status = 'active'", user.TenantID).Error
+	if err != nil {
+		return authResultFail
+	}
+
+	// token scopes are limited by current user role privileges because the role could be changed
+	var privs []string
+	err = p.db.Table("privileges").
+		Where("role_id = ? AND name IN (?)", user.RoleID, []string(userToken.Scopes)).
+		Pluck("name", &privs).Error
+	if err != nil {
+		return authResultFail
+	}
+
+	uuid, err := storage.MakeUuidStrFromHash(user.Hash)
+	if err != nil {
+		return authResultFail
+	}
+
+	p.db.Model(&userToken).UpdateColumn("last_used_at", now)
+
+	exp := now.Add(time.Hour).Unix()
+	if userToken.ExpiresAt != nil {
+		exp = userToken.ExpiresAt.Unix()
+	}
+
+	c.Set("prm", privs)
+	c.Set("uid", user.ID)
+	c.Set("rid", user.RoleID)
+	c.Set("sid", service.ID)
+	c.Set("tid", user.TenantID)
+	c.Set("exp", exp)
+	c.Set("gtm", now.Unix())
+	c.Set("uname", user.Name)
+	c.Set("uuid", uuid)
+	c.Set("svc", service.Hash)
+
+	return authResultOk
+}
+
 const privilegeInteractive = "vxapi.modules.interactive"
 
 func (p *AuthMiddleware) tryProtoCookieAuthentication(c *gin.Context) authResult {
@@ -168,20 +246,12 @@ func (p *AuthMiddleware) tryProtoCookieAuthentication(c *gin.Context) authResult
 }
 
 func (p *AuthMiddleware) tryProtoTokenAuthentication(c *gin.Context) authResult {
-	authHeader := c.Request.Header.Get("Authorization")
-	if authHeader == "" {
-		return authResultSkip
-	}
-
-	if !strings.HasPrefix(authHeader, "Bearer ") {
-		return authResultSkip
-	}
-	token := authHeader[7:]
-	if token == "" {
+	token := getBearerToken(c)
+	if token == "" || strings.HasPrefix(token, storage.UserTokenPrefix) {
 		return authResultSkip
 	}
 
-	claims, err := private.ValidateToken(token)
+	claims, err := private.ValidateToken(token, p.protoTokenKey)
 	if err != nil {
 		return authResultFail
 	}
@@ -18,6 +18,7 @@ import (
 
 	"soldr/pkg/app/api/models"
 	"soldr/pkg/app/api/server/response"
+	"soldr/pkg/app/api/storage"
 	"soldr/pkg/app/api/utils/dbencryptor"
 	obs "soldr/pkg/observability"
 )
@@ -128,6 +129,14 @@ func setServiceInfo(db *gorm.DB) gin.HandlerFunc {
 	}
 }
 
+// setRequestIP passes client IP which is resolved with trusted proxies to the session store
+func setRequestIP() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = storage.WithRequestIP(c.Request, c.ClientIP())
+		c.Next()
+	}
+}
+
 func WithLogger(service string) gin.HandlerFunc {
 	propagators := otel.GetTextMapPropagator()
 	return func(c *gin.Context) {
@@ -19,12 +19,14 @@ import (
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestAuthTokenProtoRequiredAuthWithCookie(t *testing.T) {
-	authMiddleware := NewAuthMiddleware("/base/url")
+	authMiddleware := NewAuthMiddleware("/base/url", nil, testProtoTokenKey)
 
 	t.Run("test URL", func(t *testing.T) {
 		server := newTestServer(t, "/test", authMiddleware.AuthTokenProtoRequired)
@@ -76,7 +78,7 @@ func TestAuthTokenProtoRequiredAuthWithCookie(t *testing.T) {
 }
 
 func TestAuthTokenProtoRequiredAuthWithToken(t *testing.T) {
-	authMiddleware := NewAuthMiddleware("/base/url")
+	authMiddleware := NewAuthMiddleware("/base/url", nil, testProtoTokenKey)
 
 	for _, kind := range []string{"aggregate", "browser", "external"} {
 		t.Run(kind+" type", func(t *testing.T) {
@@ -115,8 +117,28 @@ func TestAuthTokenProtoRequiredAuthWithToken(t *testing.T) {
 	}
 }
 
+func TestAuthTokenProtoRequiredSharedKey(t *testing.T) {
+	issuer := newTestServer(t, "/test", NewAuthMiddleware("/base/url", nil, testProtoTokenKey).AuthTokenProtoRequired)
+	defer issuer.Close()
+	issuer.Authorize(t, []string{"vxapi.modules.interactive"})
+	token := issuer.GetToken(t, "browser")
+	require.NotEmpty(t, token)
+
+	// token is issued by one API replica and it's checked by another one
+	replicaKey := append([]byte{}, testProtoTokenKey...)
+	replica := newTestServer(t, "/test", NewAuthMiddleware("/base/url", nil, replicaKey).AuthTokenProtoRequired)
+	defer replica.Close()
+	assert.True(t, replica.CallAndGetStatus(t, "Bearer "+token))
+
+	otherKey, err := storage.MakeProtoTokenKey("other secret")
+	require.NoError(t, err)
+	other := newTestServer(t, "/test", NewAuthMiddleware("/base/url", nil, otherKey).AuthTokenProtoRequired)
+	defer other.Close()
+	assert.False(t, other.CallAndGetStatus(t, "Bearer "+token))
+}
+
 func TestAuthRequiredAuthWithCookie(t *testing.T) {
-	authMiddleware := NewAuthMiddleware("/base/url")
+	authMiddleware := NewAuthMiddleware("/base/url", nil, testProtoTokenKey)
 
 	server := newTestServer(t, "/test", authMiddleware.AuthRequired)
 	defer server.Close()
@@ -141,6 +163,99 @@ func TestAuthRequiredAuthWithCookie(t *testing.T) {
 	assert.True(t, server.CallAndGetStatus(t))
 }
 
+func newTestTokensDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	for _, query := range []string{
+		`CREATE TABLE users (id INTEGER PRIMARY KEY, mail TEXT, name TEXT, status TEXT, type TEXT,
+			role_id INTEGER, tenant_id INTEGER, hash TEXT, password_change_required BOOL)`,
+		`CREATE TABLE services (id INTEGER PRIMARY KEY, name TEXT, hash TEXT, type TEXT, status TEXT,
+			tenant_id INTEGER, info TEXT)`,
+		`CREATE TABLE privileges (id INTEGER PRIMARY KEY, role_id INTEGER, name TEXT)`,
+		`CREATE TABLE user_tokens (id INTEGER PRIMARY KEY, hash TEXT, user_id INTEGER, name TEXT,
+			token_hash TEXT, scopes TEXT, status TEXT, expires_at DATETIME, last_used_at DATETIME,
+			created_date DATETIME)`,
+		`INSERT INTO users VALUES (1, 'user@example.com', 'name1', 'active', 'local', 2, 3,
+			'00112233445566778899aabbccddeeff', 0)`,
+		`INSERT INTO services VALUES (4, 'svc', '0123456789abcdef0123456789abcdef', 'vxmonitor', 'active', 3, '{}')`,
+		`INSERT INTO privileges VALUES (1, 2, 'vxapi.agents.api.view')`,
+		`INSERT INTO privileges VALUES (2, 1, 'vxapi.agents.api.edit')`,
+	} {
+		require.NoError(t, db.Exec(query).Error)
+	}
+	return db
+}
+
+func addTestUserToken(t *testing.T, db *gorm.DB, status string, scopes string, expiresAt *time.Time) string {
+	t.Helper()
+	token, tokenHash, err := storage.MakeUserToken()
+	require.NoError(t, err)
+	err = db.Exec(`INSERT INTO user_tokens (hash, user_id, name, token_hash, scopes, status, expires_at, created_date)
+		VALUES (?, 1, 'token', ?, ?, ?, ?, ?)`,
+		storage.MakeUserTokenHash(token), tokenHash, scopes, status, expiresAt, time.Now()).Error
+	require.NoError(t, err)
+	return token
+}
+
+func TestAuthRequiredAuthWithUserToken(t *testing.T) {
+	db := newTestTokensDB(t)
+	defer db.Close()
+
+	authMiddleware := NewAuthMiddleware("/base/url", db, testProtoTokenKey)
+	server := newTestServer(t, "/test", authMiddleware.AuthRequired)
+	defer server.Close()
+
+	expired := time.Now().Add(-time.Minute)
+	valid := time.Now().Add(time.Hour)
+	scopes := `["vxapi.agents.api.view","vxapi.agents.api.edit"]`
+
+	t.Run("valid token", func(t *testing.T) {
+		token := addTestUserToken(t, db, "active", scopes, &valid)
+		server.SetSessionCheckFunc(func(t *testing.T, c *gin.Context) {
+			t.Helper()
+			assert.Equal(t, uint64(1), c.GetUint64("uid"))
+			assert.Equal(t, uint64(2), c.GetUint64("rid"))
+			assert.Equal(t, uint64(3), c.GetUint64("tid"))
+			assert.Equal(t, uint64(4), c.GetUint64("sid"))
+			assert.Equal(t, "0123456789abcdef0123456789abcdef", c.GetString("svc"))
+			assert.Equal(t, "name1", c.GetString("uname"))
+			assert.Equal(t, "00112233-4455-6677-8899-aabbccddeeff", c.GetString("uuid"))
+			assert.Equal(t, valid.Unix(), c.GetInt64("exp"))
+		})
+		assert.True(t, server.CallAndGetStatus(t, "Bearer "+token))
+	})
+
+	t.Run("scopes are limited by role privileges", func(t *testing.T) {
+		token := addTestUserToken(t, db, "active", scopes, nil)
+		server.SetSessionCheckFunc(func(t *testing.T, c *gin.Context) {
+			t.Helper()
+			assert.Equal(t, []string{"vxapi.agents.api.view"}, c.GetStringSlice("prm"))
+		})
+		assert.True(t, server.CallAndGetStatus(t, "Bearer "+token))
+	})
+
+	server.SetSessionCheckFunc(nil)
+
+	t.Run("expired token", func(t *testing.T) {
+		token := addTestUserToken(t, db, "active", scopes, &expired)
+		assert.False(t, server.CallAndGetStatus(t, "Bearer "+token))
+	})
+
+	t.Run("revoked token", func(t *testing.T) {
+		token := addTestUserToken(t, db, "revoked", scopes, &valid)
+		assert.False(t, server.CallAndGetStatus(t, "Bearer "+token))
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		token, _, err := storage.MakeUserToken()
+		require.NoError(t, err)
+		assert.False(t, server.CallAndGetStatus(t, "Bearer "+token))
+	})
+}
+
+var testProtoTokenKey = []byte("test proto token key")
+
 type testServer struct {
 	testEndpoint     string
 	client           *http.Client
@@ -196,7 +311,7 @@ func newTestServer(t *testing.T, testEndpoint string, middlewares ...gin.Handler
 		token, err := private.MakeToken(c, &models.ProtoAuthTokenRequest{
 			TTL:  3600,
 			Type: cpt,
-		})
+		}, testProtoTokenKey)
 		require.NoError(t, err)
 		c.Writer.Write([]byte(token))
 	})
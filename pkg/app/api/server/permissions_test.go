@@ -8,7 +8,7 @@ import (
 )
 
 func TestPrivilegesRequiredPatchAgents(t *testing.T) {
-	authMiddleware := NewAuthMiddleware("/base/url")
+	authMiddleware := NewAuthMiddleware("/base/url", nil, testProtoTokenKey)
 	server := newTestServer(t, "/test", authMiddleware.AuthRequired, privilegesRequiredPatchAgents())
 	defer server.Close()
 
@@ -53,7 +53,7 @@ func TestPrivilegesRequiredPatchAgents(t *testing.T) {
 }
 
 func TestPrivilegesRequired(t *testing.T) {
-	authMiddleware := NewAuthMiddleware("/base/url")
+	authMiddleware := NewAuthMiddleware("/base/url", nil, testProtoTokenKey)
 	server := newTestServer(t, "/test", authMiddleware.AuthRequired, privilegesRequired("priv1", "priv2"))
 	defer server.Close()
 
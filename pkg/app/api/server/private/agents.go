@@ -2,6 +2,7 @@ package private
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -45,7 +46,7 @@ type agent struct {
 type agentInfo struct {
 	Name string `json:"name" binding:"max=255,required"`
 	OS   string `json:"os" binding:"oneof=windows linux darwin,required" default:"linux" enums:"windows,linux,darwin"`
-	Arch string `json:"arch" binding:"oneof=386 amd64,required" default:"amd64" enums:"386,amd64"`
+	Arch string `json:"arch" binding:"alphanum,lowercase,min=2,max=10,required" default:"amd64" enums:"386,amd64,arm64"`
 }
 
 type agentCount struct {
@@ -71,52 +72,7 @@ type agentsActionResult struct {
 	Total uint64 `json:"total"`
 }
 
-var agentsSQLMappers = map[string]interface{}{
-	"id":             "`{{table}}`.id",
-	"hash":           "`{{table}}`.hash",
-	"group_id":       "`{{table}}`.group_id",
-	"group_name":     "JSON_UNQUOTE(JSON_EXTRACT(`groups`.info, '$.name.{{lang}}'))",
-	"policy_id":      "`gtp`.policy_id",
-	"module_name":    "`modules`.name",
-	"description":    "`{{table}}`.description",
-	"version":        "`{{table}}`.version",
-	"info":           "`{{table}}`.info",
-	"status":         "`{{table}}`.status",
-	"auth_status":    "`{{table}}`.auth_status",
-	"ip":             "`{{table}}`.ip",
-	"os":             "CONCAT(`{{table}}`.os_type,':',`{{table}}`.os_arch)",
-	"os_arch":        "`{{table}}`.os_arch",
-	"os_type":        "`{{table}}`.os_type",
-	"os_name":        "`{{table}}`.os_name",
-	"hostname":       "`{{table}}`.hostname",
-	"connected_date": "`{{table}}`.connected_date",
-	"created_date":   "`{{table}}`.created_date",
-	"net_ips":        "JSON_EXTRACT(`{{table}}`.info, '$.net.ips')",
-	"tags":           storage.TagsMapper,
-	"users":          "JSON_EXTRACT(`{{table}}`.info, '$.users')",
-	"data": "CONCAT(`{{table}}`.hash, ' | ', " +
-		"`{{table}}`.description, ' | ', " +
-		"`{{table}}`.status, ' | ', " +
-		"`{{table}}`.auth_status, ' | ', " +
-		"CASE" +
-		"  WHEN `{{table}}`.status = 'connected' THEN 'подключен'" +
-		"  WHEN `{{table}}`.status = 'disconnected' THEN 'отключен'" +
-		"  ELSE ''" +
-		"END, ' | ', " +
-		"CASE" +
-		"  WHEN `{{table}}`.auth_status = 'authorized' THEN 'авторизован'" +
-		"  WHEN `{{table}}`.auth_status = 'unauthorized' THEN 'неавторизован'" +
-		"  WHEN `{{table}}`.auth_status = 'blocked' THEN 'заблокирован'" +
-		"  ELSE ''" +
-		"END, ' | ', " +
-		"JSON_EXTRACT(`{{table}}`.info, '$.net.ips'), ' | ', " +
-		"JSON_EXTRACT(`{{table}}`.info, '$.tags'), ' | ', " +
-		"`{{table}}`.os_type, ' | ', " +
-		"`{{table}}`.os_arch, ' | ', " +
-		"`{{table}}`.os_name, ' | ', " +
-		"`{{table}}`.hostname, ' | ', " +
-		"`{{table}}`.version)",
-}
+var agentsSQLMappers = storage.AgentsSQLMappers
 
 const sqlAgentDetails = `
 	SELECT a.hash,
@@ -255,9 +211,11 @@ func NewAgentService(
 // @Tags Agents
 // @Produce json
 // @Param request query storage.TableQuery true "query table params"
+// @Param view query int false "saved view id to merge its query with query table params" minimum(1)
 // @Success 200 {object} response.successResp{data=agents} "agents list received successful"
 // @Failure 400 {object} response.errorResp "invalid query request data"
 // @Failure 403 {object} response.errorResp "getting agents not permitted"
+// @Failure 404 {object} response.errorResp "view not found"
 // @Failure 500 {object} response.errorResp "internal error on getting agents"
 // @Router /agents/ [get]
 func (s *AgentService) GetAgents(c *gin.Context) {
@@ -298,6 +256,12 @@ func (s *AgentService) GetAgents(c *gin.Context) {
 		return
 	}
 
+	if httpErr, err := applyView(c, s.db, "agents", &query); httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error applying view to query")
+		response.Error(c, httpErr, err)
+		return
+	}
+
 	if err = query.Init("agents", agentsSQLMappers); err != nil {
 		logger.FromContext(c).WithError(err).Errorf("error binding query")
 		response.Error(c, response.ErrGetAgentsInvalidRequest, err)
@@ -321,6 +285,9 @@ func (s *AgentService) GetAgents(c *gin.Context) {
 	for _, filter := range query.Filters {
 		setUsingTables(filter.Field)
 	}
+	for _, field := range query.ExpressionFields() {
+		setUsingTables(field)
+	}
 	query.SetFilters([]func(db *gorm.DB) *gorm.DB{
 		func(db *gorm.DB) *gorm.DB {
 			return db.Where("agents.deleted_at IS NULL")
@@ -612,6 +579,11 @@ func (s *AgentService) PatchAgents(c *gin.Context) {
 				response.Error(c, response.ErrPatchAgentsMoveFail, err)
 				return
 			}
+			if group.IsDynamic() {
+				logger.FromContext(c).Errorf("error moving agents to dynamic group '%s'", group.Hash)
+				response.Error(c, response.ErrPatchAgentsMoveToDynamicGroup, nil)
+				return
+			}
 		}
 		agentIds := make([]uint64, len(agents))
 		for i, v := range agents {
@@ -765,7 +737,9 @@ func (s *AgentService) GetAgent(c *gin.Context) {
 // @Router /agents/{hash} [put]
 func (s *AgentService) PatchAgent(c *gin.Context) {
 	uaf := useraction.NewFields(c, "agent", "agent", "undefined action", "", useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	hash := c.Param("hash")
 
@@ -812,6 +786,12 @@ func (s *AgentService) PatchAgent(c *gin.Context) {
 		return
 	}
 
+	if httpErr, err := checkAgentGroupMove(iDB, action.Agent); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error moving agent by hash '%s'", hash)
+		response.Error(c, httpErr, err)
+		return
+	}
+
 	if action.Agent.AuthStatus == "blocked" {
 		err = iDB.
 			Model(&models.AgentUpgradeTask{}).
@@ -844,6 +824,29 @@ func (s *AgentService) PatchAgent(c *gin.Context) {
 	response.Success(c, http.StatusOK, action.Agent)
 }
 
+// checkAgentGroupMove returns error if the agent is moved to the group with membership rule,
+// agents of such groups are managed by groups syncer only and manual moving would be reverted
+func checkAgentGroupMove(iDB *gorm.DB, agent models.Agent) (*response.HttpError, error) {
+	var current models.Agent
+	if err := iDB.Select("id, group_id").Take(&current, "id = ?", agent.ID).Error; err != nil {
+		return response.ErrInternal, err
+	}
+	if agent.GroupID == 0 || agent.GroupID == current.GroupID {
+		return nil, nil
+	}
+
+	var group models.Group
+	if err := iDB.Take(&group, "id = ?", agent.GroupID).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return response.ErrPatchAgentValidationError, fmt.Errorf("group '%d' not found", agent.GroupID)
+	} else if err != nil {
+		return response.ErrInternal, err
+	}
+	if group.IsDynamic() {
+		return response.ErrPatchAgentMoveToDynamicGroup, fmt.Errorf("group '%s' has membership rule", group.Hash)
+	}
+	return nil, nil
+}
+
 // CreateAgent is a function to create new agent
 // @Summary Create new agent in service
 // @Tags Agents
@@ -857,7 +860,9 @@ func (s *AgentService) PatchAgent(c *gin.Context) {
 // @Router /agents/ [post]
 func (s *AgentService) CreateAgent(c *gin.Context) {
 	uaf := useraction.NewFields(c, "agent", "agent", "creation", "", useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	var info agentInfo
 	if err := c.ShouldBindJSON(&info); err != nil {
@@ -867,6 +872,17 @@ func (s *AgentService) CreateAgent(c *gin.Context) {
 	}
 	uaf.ObjectDisplayName = info.Name
 
+	if ok, err := hasAgentPlatform(s.db, c.GetUint64("tid"), info.OS, info.Arch); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error checking agent platform '%s:%s'", info.OS, info.Arch)
+		response.Error(c, response.ErrInternal, err)
+		return
+	} else if !ok {
+		err = fmt.Errorf("agent binaries don't contain platform '%s:%s'", info.OS, info.Arch)
+		logger.FromContext(c).WithError(err).Errorf("error validating agent info")
+		response.Error(c, response.ErrCreateAgentValidationError, err)
+		return
+	}
+
 	serviceHash := c.GetString("svc")
 	if serviceHash == "" {
 		logger.FromContext(c).Errorf("could not get service hash")
@@ -937,7 +953,9 @@ func (s *AgentService) DeleteAgent(c *gin.Context) {
 	)
 
 	uaf := useraction.NewFields(c, "agent", "agent", "deletion", "", useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	serviceHash := c.GetString("svc")
 	if serviceHash == "" {
@@ -990,7 +1008,9 @@ func (s *AgentService) GetAgentsCount(c *gin.Context) {
 		ActionCode:        "counting",
 		ObjectDisplayName: useraction.UnknownObjectDisplayName,
 	}
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	serviceHash := c.GetString("svc")
 	if serviceHash == "" {
@@ -0,0 +1,176 @@
+package private
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"soldr/pkg/app/api/client"
+	"soldr/pkg/app/api/models"
+	"soldr/pkg/app/api/storage"
+	"soldr/pkg/app/api/useraction"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testStaticGroupID  = 1
+	testDynamicGroupID = 2
+	testOtherGroupID   = 3
+)
+
+// newTestAgentsDB returns in-memory instance DB with agent in the static group and groups with and without rules
+func newTestAgentsDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.DB().SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, query := range []string{
+		`CREATE TABLE agents (id INTEGER PRIMARY KEY, hash TEXT NOT NULL, group_id INTEGER NOT NULL,
+			ip TEXT NOT NULL, description TEXT NOT NULL, version TEXT NOT NULL, info TEXT NOT NULL,
+			status TEXT NOT NULL, auth_status TEXT NOT NULL, connected_date DATETIME, created_date DATETIME,
+			updated_at DATETIME, deleted_at DATETIME)`,
+		`CREATE TABLE groups (id INTEGER PRIMARY KEY, hash TEXT NOT NULL, info TEXT NOT NULL,
+			rule TEXT NOT NULL DEFAULT '', created_date DATETIME, updated_at DATETIME, deleted_at DATETIME)`,
+		`INSERT INTO groups (id, hash, info, rule) VALUES
+			(1, '11111111111111111111111111111111', '{}', ''),
+			(2, '22222222222222222222222222222222', '{}', 'os.type == "linux"'),
+			(3, '33333333333333333333333333333333', '{}', '')`,
+	} {
+		require.NoError(t, db.Exec(query).Error)
+	}
+	require.NoError(t, db.Create(newTestAgent(testStaticGroupID)).Error)
+	return db
+}
+
+func newTestAgent(groupID uint64) *models.Agent {
+	return &models.Agent{
+		ID:          1,
+		Hash:        "0123456789abcdef0123456789abcdef",
+		GroupID:     groupID,
+		IP:          "10.0.0.1",
+		Description: "agent",
+		Version:     "v1.0.0.0",
+		Info: models.AgentInfo{
+			OS:    models.AgentOS{Type: "linux", Arch: "amd64", Name: "Ubuntu"},
+			Net:   models.AgentNet{Hostname: "host", IPs: []string{"10.0.0.1"}},
+			Users: []models.AgentUser{},
+			Tags:  []string{},
+		},
+		Status:     "connected",
+		AuthStatus: "authorized",
+	}
+}
+
+func callAgentsHandler(t *testing.T, iDB *gorm.DB, handler func(*AgentService, *gin.Context), body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	dbConns := storage.NewDBConnectionStorage()
+	dbConns.Set("svc1", iDB)
+	service := NewAgentService(nil, client.NewAgentServerClient(nil, dbConns, storage.NewS3ConnectionStorage()),
+		useraction.NewLogger(), nil)
+
+	router := gin.New()
+	router.Use(sessions.Sessions("auth", cookie.NewStore([]byte("test cookie key"))))
+	router.PUT("/agents/:hash", func(c *gin.Context) {
+		c.Set("svc", "svc1")
+		handler(service, c)
+	})
+
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/agents/"+newTestAgent(0).Hash, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func getTestAgentGroupID(t *testing.T, iDB *gorm.DB) uint64 {
+	t.Helper()
+	var agent models.Agent
+	require.NoError(t, iDB.Take(&agent, "id = ?", 1).Error)
+	return agent.GroupID
+}
+
+func getTestErrorCode(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	var resp struct {
+		Code string `json:"code"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp.Code
+}
+
+func TestPatchAgentGroup(t *testing.T) {
+	patchAgent := (*AgentService).PatchAgent
+
+	t.Run("move to dynamic group is rejected", func(t *testing.T) {
+		iDB := newTestAgentsDB(t)
+		rec := callAgentsHandler(t, iDB, patchAgent, patchAgentAction{
+			Action: "move",
+			Agent:  *newTestAgent(testDynamicGroupID),
+		})
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "Agents.PatchAgent.MoveToDynamicGroup", getTestErrorCode(t, rec))
+		assert.Equal(t, uint64(testStaticGroupID), getTestAgentGroupID(t, iDB))
+	})
+
+	t.Run("move to unknown group is rejected", func(t *testing.T) {
+		iDB := newTestAgentsDB(t)
+		rec := callAgentsHandler(t, iDB, patchAgent, patchAgentAction{
+			Action: "move",
+			Agent:  *newTestAgent(100),
+		})
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "Agents.PatchAgent.ValidationError", getTestErrorCode(t, rec))
+		assert.Equal(t, uint64(testStaticGroupID), getTestAgentGroupID(t, iDB))
+	})
+
+	t.Run("move to static group", func(t *testing.T) {
+		iDB := newTestAgentsDB(t)
+		rec := callAgentsHandler(t, iDB, patchAgent, patchAgentAction{
+			Action: "move",
+			Agent:  *newTestAgent(testOtherGroupID),
+		})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, uint64(testOtherGroupID), getTestAgentGroupID(t, iDB))
+	})
+
+	t.Run("edit agent of dynamic group", func(t *testing.T) {
+		iDB := newTestAgentsDB(t)
+		require.NoError(t, iDB.Model(&models.Agent{}).Where("id = ?", 1).
+			UpdateColumn("group_id", testDynamicGroupID).Error)
+		agent := newTestAgent(testDynamicGroupID)
+		agent.Description = "new description"
+		rec := callAgentsHandler(t, iDB, patchAgent, patchAgentAction{
+			Action: "edit",
+			Agent:  *agent,
+		})
+		assert.Equal(t, http.StatusOK, rec.Code, "unchanged group must not be checked")
+		assert.Equal(t, uint64(testDynamicGroupID), getTestAgentGroupID(t, iDB))
+	})
+}
+
+func TestPatchAgentsMoveToDynamicGroup(t *testing.T) {
+	iDB := newTestAgentsDB(t)
+	rec := callAgentsHandler(t, iDB, (*AgentService).PatchAgents, AgentsAction{
+		Action: "move",
+		Filters: []storage.TableFilter{
+			{Field: "hash", Value: newTestAgent(0).Hash, Operator: "="},
+		},
+		To: testDynamicGroupID,
+	})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "Agents.PatchAgents.MoveToDynamicGroup", getTestErrorCode(t, rec))
+	assert.Equal(t, uint64(testStaticGroupID), getTestAgentGroupID(t, iDB))
+}
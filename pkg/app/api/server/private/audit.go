@@ -0,0 +1,246 @@
+package private
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+
+	"soldr/pkg/app/api/logger"
+	"soldr/pkg/app/api/models"
+	"soldr/pkg/app/api/server/response"
+	"soldr/pkg/app/api/storage"
+)
+
+type auditLog struct {
+	Records []models.AuditLog `json:"records"`
+	Total   uint64            `json:"total"`
+}
+
+var auditLogSQLMappers = map[string]interface{}{
+	"id":                  "`{{table}}`.id",
+	"tenant_id":           "`{{table}}`.tenant_id",
+	"user_id":             "`{{table}}`.user_id",
+	"user_uuid":           "`{{table}}`.user_uuid",
+	"user_name":           "`{{table}}`.user_name",
+	"domain":              "`{{table}}`.domain",
+	"object_type":         "`{{table}}`.object_type",
+	"object_id":           "`{{table}}`.object_id",
+	"object_display_name": "`{{table}}`.object_display_name",
+	"action_code":         "`{{table}}`.action_code",
+	"success":             "`{{table}}`.success",
+	"fail_reason":         "`{{table}}`.fail_reason",
+	"created_date":        "`{{table}}`.created_date",
+	"data": "CONCAT(`{{table}}`.user_name, ' | ', " +
+		"`{{table}}`.object_id, ' | ', " +
+		"`{{table}}`.object_display_name, ' | ', " +
+		"`{{table}}`.action_code)",
+}
+
+var auditLogExportHeader = []string{
+	"id",
+	"created_date",
+	"tenant_id",
+	"user_id",
+	"user_uuid",
+	"user_name",
+	"domain",
+	"object_type",
+	"object_id",
+	"object_display_name",
+	"action_code",
+	"success",
+	"fail_reason",
+}
+
+func auditLogExportRecord(record *models.AuditLog) []string {
+	return []string{
+		strconv.FormatUint(record.ID, 10),
+		record.CreatedDate.UTC().Format(time.RFC3339Nano),
+		strconv.FormatUint(record.TenantID, 10),
+		strconv.FormatUint(record.UserID, 10),
+		record.UserUUID,
+		record.UserName,
+		record.Domain,
+		record.ObjectType,
+		record.ObjectID,
+		record.ObjectDisplayName,
+		record.ActionCode,
+		strconv.FormatBool(record.Success),
+		record.FailReason,
+	}
+}
+
+type AuditService struct {
+	db *gorm.DB
+}
+
+func NewAuditService(db *gorm.DB) *AuditService {
+	return &AuditService{
+		db: db,
+	}
+}
+
+// getAuditLogScope returns filter of audit log records which are visible for the current user
+func getAuditLogScope(c *gin.Context) (func(db *gorm.DB) *gorm.DB, bool) {
+	rid := models.GetRoleScope(c.GetUint64("rid"))
+	tid := c.GetUint64("tid")
+	uid := c.GetUint64("uid")
+
+	switch rid {
+	case models.RoleSAdmin:
+		return func(db *gorm.DB) *gorm.DB {
+			return db
+		}, true
+	case models.RoleAdmin:
+		return func(db *gorm.DB) *gorm.DB {
+			return db.Where("tenant_id = ?", tid)
+		}, true
+	case models.RoleUser:
+		return func(db *gorm.DB) *gorm.DB {
+			return db.Where("tenant_id = ? AND user_id = ?", tid, uid)
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+// GetAuditLog is a function to return user actions from the audit log
+// @Summary Retrieve user actions list from the audit log by filters
+// @Tags Audit
+// @Produce json
+// @Param request query storage.TableQuery true "query table params"
+// @Success 200 {object} response.successResp{data=auditLog} "audit log received successful"
+// @Failure 400 {object} response.errorResp "invalid query request data"
+// @Failure 403 {object} response.errorResp "getting audit log not permitted"
+// @Failure 500 {object} response.errorResp "internal error on getting audit log"
+// @Router /audit/ [get]
+func (s *AuditService) GetAuditLog(c *gin.Context) {
+	var (
+		err   error
+		query storage.TableQuery
+		resp  auditLog
+	)
+
+	if err = c.ShouldBindQuery(&query); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding query")
+		response.Error(c, response.ErrAuditInvalidRequest, err)
+		return
+	}
+
+	if err = query.Init("audit_log", auditLogSQLMappers); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error initializing query")
+		response.Error(c, response.ErrAuditInvalidRequest, err)
+		return
+	}
+
+	scope, ok := getAuditLogScope(c)
+	if !ok {
+		logger.FromContext(c).Errorf("error filtering user role audit log: unexpected role")
+		response.Error(c, response.ErrInternal, nil)
+		return
+	}
+	query.SetFilters([]func(db *gorm.DB) *gorm.DB{scope})
+
+	if resp.Total, err = query.Query(s.db, &resp.Records); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding audit log records")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	for i := 0; i < len(resp.Records); i++ {
+		if err = resp.Records[i].Valid(); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error validating audit log record '%d'", resp.Records[i].ID)
+			response.Error(c, response.ErrAuditInvalidData, err)
+			return
+		}
+	}
+
+	response.Success(c, http.StatusOK, resp)
+}
+
+// ExportAuditLog is a function to export user actions from the audit log to the file
+// @Summary Export user actions from the audit log by filters as CSV or NDJSON file, use pageSize -1 to export all records
+// @Tags Audit
+// @Produce text/csv,application/x-ndjson
+// @Param request query storage.TableQuery true "query table params"
+// @Param format query ExportQuery true "export format"
+// @Success 200 {file} file "audit log exported successful"
+// @Failure 400 {object} response.errorResp "invalid query request data"
+// @Failure 403 {object} response.errorResp "exporting audit log not permitted"
+// @Failure 500 {object} response.errorResp "internal error on exporting audit log"
+// @Router /audit/export [get]
+func (s *AuditService) ExportAuditLog(c *gin.Context) {
+	var (
+		err    error
+		export ExportQuery
+		query  storage.TableQuery
+	)
+
+	if err = c.ShouldBindQuery(&query); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding query")
+		response.Error(c, response.ErrAuditInvalidRequest, err)
+		return
+	}
+	if err = c.ShouldBindQuery(&export); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding export query")
+		response.Error(c, response.ErrAuditInvalidRequest, err)
+		return
+	}
+
+	if err = query.Init("audit_log", auditLogSQLMappers); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error initializing query")
+		response.Error(c, response.ErrAuditInvalidRequest, err)
+		return
+	}
+
+	scope, ok := getAuditLogScope(c)
+	if !ok {
+		logger.FromContext(c).Errorf("error filtering user role audit log: unexpected role")
+		response.Error(c, response.ErrInternal, nil)
+		return
+	}
+	query.SetFilters([]func(db *gorm.DB) *gorm.DB{scope})
+
+	rows, err := storage.ApplyToChainDB(
+		s.db.Table(query.Table()).Scopes(query.DataFilter()),
+		query.Ordering(),
+		query.Paginate(),
+	).Rows()
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding audit log records")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+	defer rows.Close()
+
+	w, err := newExportWriter(c, export.Format, "audit_log", auditLogExportHeader)
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error initializing export writer")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+	c.Status(http.StatusOK)
+
+	// response status is already sent so errors are only logged and the stream is interrupted
+	for rows.Next() {
+		var record models.AuditLog
+		if err = s.db.ScanRows(rows, &record); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error scanning audit log record")
+			return
+		}
+		if err = w.Write(record, auditLogExportRecord(&record)); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error writing audit log record '%d'", record.ID)
+			return
+		}
+	}
+	if err = rows.Err(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error reading audit log records")
+		return
+	}
+	if err = w.Flush(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error flushing audit log records")
+	}
+}
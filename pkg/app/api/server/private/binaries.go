@@ -84,6 +84,18 @@ func patchOrderingByVersion(query *storage.TableQuery) {
 	})
 }
 
+// hasAgentPlatform returns true if the agent binaries catalogue of the tenant contains files for the OS type and arch
+func hasAgentPlatform(db *gorm.DB, tid uint64, osType, osArch string) (bool, error) {
+	var infos []models.BinaryInfo
+	err := db.Model(&models.Binary{}).
+		Where("tenant_id IN (?) AND type LIKE ?", []uint64{0, tid}, "vxagent").
+		Pluck("info", &infos).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to get agent binaries: %w", err)
+	}
+	return models.BinariesHavePlatform(infos, osType, osArch), nil
+}
+
 // GetAgentBinaries is a function to return agent binaries list
 // @Summary Retrieve agent binaries list by filters
 // @Tags Binaries
@@ -142,7 +154,7 @@ func (s *BinariesService) GetAgentBinaries(c *gin.Context) {
 // @Tags Binaries
 // @Produce octet-stream,json
 // @Param os path string true "agent info OS" default(linux) Enums(windows, linux, darwin)
-// @Param arch path string true "agent info arch" default(amd64) Enums(386, amd64)
+// @Param arch path string true "agent info arch, any arch from the binaries list" default(amd64) Enums(386, amd64, arm64)
 // @Param version path string true "agent version string according semantic version format" default(latest)
 // @Success 200 {file} file "agent binary as a file"
 // @Failure 400 {object} response.errorResp "invalid agent info"
@@ -159,7 +171,7 @@ func (s *BinariesService) GetAgentBinaryFile(c *gin.Context) {
 // @Tags Binaries
 // @Produce octet-stream,json
 // @Param os path string true "agent info OS" default(linux) Enums(windows, linux, darwin)
-// @Param arch path string true "agent info arch" default(amd64) Enums(386, amd64)
+// @Param arch path string true "agent info arch, any arch from the binaries list" default(amd64) Enums(386, amd64, arm64)
 // @Param version path string true "agent version string according semantic version format" default(latest)
 // @Param package path string true "agent package type" default(bin) Enums(bin, msi, deb, rpm, pkg)
 // @Success 200 {file} file "agent package or binary as a file"
@@ -186,7 +198,9 @@ func (s *BinariesService) getAgentBinaryFile(c *gin.Context, packageType string)
 		validate     = validator.New()
 	)
 	uaf := useraction.NewFields(c, "agent", "distribution", "downloading", "", useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	unsupportedOS := fmt.Errorf("unsupported OS '%s' for package type '%s'", agentOS, packageType)
 	switch packageType {
@@ -227,7 +241,17 @@ func (s *BinariesService) getAgentBinaryFile(c *gin.Context, packageType string)
 		response.Error(c, response.ErrAgentBinaryFileInvalidOS, err)
 		return
 	}
-	if err := validate.Var(agentArch, "oneof=386 amd64,required"); err != nil {
+	if err := models.GetValidator().Var(agentArch, "vxarch,required"); err != nil {
+		response.Error(c, response.ErrAgentBinaryFileInvalidArch, err)
+		return
+	}
+	tid := c.GetUint64("tid")
+	if ok, err := hasAgentPlatform(s.db, tid, agentOS, agentArch); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error checking agent platform '%s:%s'", agentOS, agentArch)
+		response.Error(c, response.ErrInternal, err)
+		return
+	} else if !ok {
+		err = fmt.Errorf("agent binaries don't contain platform '%s:%s'", agentOS, agentArch)
 		response.Error(c, response.ErrAgentBinaryFileInvalidArch, err)
 		return
 	}
@@ -236,7 +260,6 @@ func (s *BinariesService) getAgentBinaryFile(c *gin.Context, packageType string)
 		return
 	}
 
-	tid := c.GetUint64("tid")
 	scope := func(db *gorm.DB) *gorm.DB {
 		db = db.Where("tenant_id IN (?)", []uint64{0, tid}).Where("type LIKE ?", "vxagent")
 		if agentVersion == "latest" {
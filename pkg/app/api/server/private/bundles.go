@@ -0,0 +1,927 @@
+package private
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+
+	"soldr/pkg/app/api/client"
+	"soldr/pkg/app/api/logger"
+	"soldr/pkg/app/api/models"
+	"soldr/pkg/app/api/modules"
+	"soldr/pkg/app/api/server/response"
+	"soldr/pkg/app/api/storage"
+	"soldr/pkg/app/api/useraction"
+	"soldr/pkg/crypto"
+)
+
+// maxBundleSize is a maximum size of uploaded policies bundle file
+const maxBundleSize = 64 * 1024 * 1024
+
+const (
+	bundleModeSkip      = "skip"
+	bundleModeOverwrite = "overwrite"
+	bundleModeRename    = "rename"
+)
+
+const (
+	bundleActionCreate    = "create"
+	bundleActionDelete    = "delete"
+	bundleActionOverwrite = "overwrite"
+	bundleActionRename    = "rename"
+	bundleActionSkip      = "skip"
+)
+
+// policiesBundleExport is a request to export policies bundle
+type policiesBundleExport struct {
+	Policies []string `form:"policies" json:"policies" binding:"required,min=1,unique,dive,len=32,hexadecimal,lowercase"`
+	Groups   bool     `form:"groups" json:"groups"`
+	// SecureConfig includes secure config of modules into the bundle encrypted by the passphrase
+	SecureConfig bool   `form:"secure_config" json:"secure_config"`
+	Passphrase   string `form:"passphrase,omitempty" json:"passphrase,omitempty"`
+}
+
+// policiesBundleImport is query params to import policies bundle
+type policiesBundleImport struct {
+	Mode   string `form:"mode" json:"mode" binding:"omitempty,oneof=skip overwrite rename" default:"skip" enums:"skip,overwrite,rename"`
+	DryRun bool   `form:"dry_run" json:"dry_run"`
+}
+
+// bundleImportItem is a planned or done change of the target service by the policies bundle import
+type bundleImportItem struct {
+	Type       string `json:"type" enums:"policy,module,group,link"`
+	Name       string `json:"name"`
+	Hash       string `json:"hash"`
+	TargetHash string `json:"target_hash,omitempty"`
+	Action     string `json:"action" enums:"create,delete,overwrite,rename,skip"`
+}
+
+type bundleImportResult struct {
+	Mode   string             `json:"mode"`
+	DryRun bool               `json:"dry_run"`
+	Items  []bundleImportItem `json:"items"`
+}
+
+// bundleTarget is hash and name of the existing object which may conflict with the object from the bundle
+type bundleTarget struct {
+	hash string
+	name string
+}
+
+// findBundleConflict returns index of the existing object which has the same hash or the same name,
+// the hash match is preferred because it keeps identity of the object between services
+func findBundleConflict(targets []bundleTarget, hash, name string) int {
+	for idx, target := range targets {
+		if target.hash == hash {
+			return idx
+		}
+	}
+	for idx, target := range targets {
+		if target.name == name {
+			return idx
+		}
+	}
+	return -1
+}
+
+// getBundleAction returns action on the object from the bundle by conflict resolution mode
+func getBundleAction(mode string, conflict bool) string {
+	if !conflict {
+		return bundleActionCreate
+	}
+	switch mode {
+	case bundleModeOverwrite:
+		return bundleActionOverwrite
+	case bundleModeRename:
+		return bundleActionRename
+	default:
+		return bundleActionSkip
+	}
+}
+
+type bundlePolicyPlan struct {
+	source  models.BundlePolicy
+	target  models.Policy
+	action  string
+	modules []models.ModuleS
+	// deleted contains modules of the overwritten policy which are missing in the bundle
+	deleted []models.ModuleA
+}
+
+type bundleGroupPlan struct {
+	source models.BundleGroup
+	target models.Group
+	action string
+}
+
+// bundlePlan contains resolved objects of the target service to import policies bundle
+type bundlePlan struct {
+	policies []*bundlePolicyPlan
+	groups   []*bundleGroupPlan
+	byHash   map[string]*bundlePolicyPlan
+}
+
+// items returns list of changes which are made by the plan
+func (bp *bundlePlan) items() []bundleImportItem {
+	items := make([]bundleImportItem, 0)
+	for _, pp := range bp.policies {
+		items = append(items, bundleImportItem{
+			Type:       "policy",
+			Name:       pp.source.Info.Name.En,
+			Hash:       pp.source.Hash,
+			TargetHash: pp.target.Hash,
+			Action:     pp.action,
+		})
+		for _, module := range pp.source.Modules {
+			action := pp.action
+			if action == bundleActionRename {
+				action = bundleActionCreate
+			}
+			items = append(items, bundleImportItem{
+				Type:       "module",
+				Name:       module.Name + " " + module.Version,
+				Hash:       pp.source.Hash,
+				TargetHash: pp.target.Hash,
+				Action:     action,
+			})
+		}
+		for _, module := range pp.deleted {
+			items = append(items, bundleImportItem{
+				Type:       "module",
+				Name:       module.Info.Name + " " + module.Info.Version.String(),
+				Hash:       pp.source.Hash,
+				TargetHash: pp.target.Hash,
+				Action:     bundleActionDelete,
+			})
+		}
+	}
+	for _, gp := range bp.groups {
+		items = append(items, bundleImportItem{
+			Type:       "group",
+			Name:       gp.source.Info.Name.En,
+			Hash:       gp.source.Hash,
+			TargetHash: gp.target.Hash,
+			Action:     gp.action,
+		})
+		for _, hash := range gp.source.Policies {
+			action := bundleActionCreate
+			if gp.action == bundleActionSkip {
+				action = bundleActionSkip
+			}
+			items = append(items, bundleImportItem{
+				Type:       "link",
+				Name:       gp.source.Info.Name.En + " / " + bp.byHash[hash].source.Info.Name.En,
+				Hash:       gp.source.Hash,
+				TargetHash: bp.byHash[hash].target.Hash,
+				Action:     action,
+			})
+		}
+	}
+	return items
+}
+
+type BundleService struct {
+	db               *gorm.DB
+	serverConnector  *client.AgentServerClient
+	userActionWriter useraction.Writer
+}
+
+func NewBundleService(
+	db *gorm.DB,
+	serverConnector *client.AgentServerClient,
+	userActionWriter useraction.Writer,
+) *BundleService {
+	return &BundleService{
+		db:               db,
+		serverConnector:  serverConnector,
+		userActionWriter: userActionWriter,
+	}
+}
+
+// makeBundle is a function to collect policies bundle from instance DB, secure config is kept only
+// if it's requested by the form and it's re-encrypted by the passphrase of the form
+func makeBundle(
+	iDB *gorm.DB,
+	encryptor crypto.IDBConfigEncryptor,
+	form policiesBundleExport,
+) (*models.Bundle, *response.HttpError, error) {
+	var (
+		groups   []models.Group
+		links    []models.GroupToPolicy
+		policies []models.Policy
+	)
+
+	if err := iDB.Where("hash IN (?)", form.Policies).Find(&policies).Error; err != nil {
+		return nil, response.ErrInternal, err
+	} else if len(policies) != len(form.Policies) {
+		return nil, response.ErrBundlesPolicyNotFound, errors.New("some policies were not found by hash")
+	}
+
+	bundle := models.Bundle{
+		Version:  models.BundleVersion,
+		Created:  time.Now().UTC(),
+		Policies: make([]models.BundlePolicy, 0, len(policies)),
+	}
+	pids := make([]uint64, 0, len(policies))
+	hashes := make(map[uint64]string, len(policies))
+	for _, policy := range policies {
+		var modulesA []models.ModuleA
+		if err := policy.Valid(); err != nil {
+			return nil, response.ErrPoliciesInvalidData, err
+		}
+		if err := iDB.Where("policy_id = ?", policy.ID).Order("id ASC").Find(&modulesA).Error; err != nil {
+			return nil, response.ErrInternal, err
+		}
+
+		bp := models.BundlePolicy{
+			Hash:    policy.Hash,
+			Info:    policy.Info,
+			Modules: make([]models.BundleModule, 0, len(modulesA)),
+		}
+		for _, moduleA := range modulesA {
+			bm := models.BundleModule{
+				Name:                moduleA.Info.Name,
+				Version:             moduleA.Info.Version.String(),
+				Status:              moduleA.Status,
+				CurrentConfig:       moduleA.CurrentConfig,
+				CurrentActionConfig: moduleA.CurrentActionConfig,
+				CurrentEventConfig:  moduleA.CurrentEventConfig,
+				DynamicDependencies: moduleA.DynamicDependencies,
+			}
+			if form.SecureConfig && len(moduleA.SecureCurrentConfig) != 0 {
+				if err := moduleA.DecryptSecureParameters(encryptor); err != nil {
+					return nil, response.ErrModulesFailedToDecryptSecureConfig, err
+				}
+				bm.SecureCurrentConfig = moduleA.SecureCurrentConfig
+			}
+			bp.Modules = append(bp.Modules, bm)
+		}
+		bundle.Policies = append(bundle.Policies, bp)
+		pids = append(pids, policy.ID)
+		hashes[policy.ID] = policy.Hash
+	}
+	if err := bundle.EncryptSecureConfig(form.Passphrase); err != nil {
+		return nil, response.ErrModulesFailedToEncryptSecureConfig, err
+	}
+
+	if !form.Groups {
+		return &bundle, nil, nil
+	}
+
+	if err := iDB.Where("policy_id IN (?)", pids).Order("id ASC").Find(&links).Error; err != nil {
+		return nil, response.ErrInternal, err
+	}
+	gids := make([]uint64, 0, len(links))
+	linked := make(map[uint64][]string)
+	for _, link := range links {
+		if _, ok := linked[link.GroupID]; !ok {
+			gids = append(gids, link.GroupID)
+		}
+		linked[link.GroupID] = append(linked[link.GroupID], hashes[link.PolicyID])
+	}
+	if len(gids) == 0 {
+		return &bundle, nil, nil
+	}
+
+	if err := iDB.Where("id IN (?)", gids).Order("id ASC").Find(&groups).Error; err != nil {
+		return nil, response.ErrInternal, err
+	}
+	for _, group := range groups {
+		if err := group.Valid(); err != nil {
+			return nil, response.ErrGroupsInvalidData, err
+		}
+		bundle.Groups = append(bundle.Groups, models.BundleGroup{
+			Hash:     group.Hash,
+			Info:     group.Info,
+			Rule:     group.Rule,
+			Policies: linked[group.ID],
+		})
+	}
+
+	return &bundle, nil, nil
+}
+
+// readBundle is a function to parse and validate policies bundle from uploaded file
+func readBundle(c *gin.Context) (*models.Bundle, error) {
+	var bundle models.Bundle
+
+	fileHeader, err := c.FormFile("bundle")
+	if err != nil {
+		return nil, err
+	}
+	if fileHeader.Size > maxBundleSize {
+		return nil, fmt.Errorf("bundle file size exceeds %d bytes", maxBundleSize)
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxBundleSize))
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle: %w", err)
+	}
+	if err = bundle.Valid(); err != nil {
+		return nil, err
+	}
+	for _, group := range bundle.Groups {
+		if group.Rule == "" {
+			continue
+		}
+		if err = storage.ValidateGroupRule(group.Rule); err != nil {
+			return nil, fmt.Errorf("invalid rule of group '%s': %w", group.Hash, err)
+		}
+	}
+
+	return &bundle, nil
+}
+
+// planBundleImport is a function to resolve conflicts of policies and groups from the bundle
+// with existing ones and to find system modules which are used by policies of the bundle
+func (s *BundleService) planBundleImport(
+	c *gin.Context,
+	iDB *gorm.DB,
+	sv *models.Service,
+	bundle *models.Bundle,
+	mode string,
+) (*bundlePlan, *response.HttpError, error) {
+	var (
+		groups   []models.Group
+		policies []models.Policy
+		plan     = bundlePlan{byHash: make(map[string]*bundlePolicyPlan)}
+		systems  = make(map[string]models.ModuleS)
+		tid      = c.GetUint64("tid")
+	)
+
+	if err := iDB.Find(&policies).Error; err != nil {
+		return nil, response.ErrInternal, err
+	}
+	targets := make([]bundleTarget, 0, len(policies))
+	for _, policy := range policies {
+		targets = append(targets, bundleTarget{hash: policy.Hash, name: policy.Info.Name.En})
+	}
+
+	for _, source := range bundle.Policies {
+		idx := findBundleConflict(targets, source.Hash, source.Info.Name.En)
+		pp := &bundlePolicyPlan{
+			source: source,
+			action: getBundleAction(mode, idx != -1),
+		}
+		switch pp.action {
+		case bundleActionCreate:
+			pp.target = models.Policy{Hash: source.Hash, Info: source.Info}
+		case bundleActionRename:
+			pp.target = models.Policy{Hash: storage.MakePolicyHash(source.Hash), Info: source.Info}
+			pp.target.Info.Name.Ru += " (импорт)"
+			pp.target.Info.Name.En += " (import)"
+		default:
+			pp.target = policies[idx]
+		}
+		pp.target.Info.System = false
+
+		for _, module := range source.Modules {
+			key := module.Name + "@" + module.Version
+			moduleS, ok := systems[key]
+			if !ok {
+				scope := func(db *gorm.DB) *gorm.DB {
+					return db.Where("name = ? AND version = ? AND tenant_id IN (0, ?) AND service_type = ?",
+						module.Name, module.Version, tid, sv.Type)
+				}
+				err := s.db.Scopes(scope).Take(&moduleS).Error
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, response.ErrBundlesModuleNotFound,
+						fmt.Errorf("system module '%s' version '%s' not found", module.Name, module.Version)
+				} else if err != nil {
+					return nil, response.ErrInternal, err
+				} else if err = moduleS.Valid(); err != nil {
+					return nil, response.ErrModulesInvalidSystemModuleData, err
+				}
+				systems[key] = moduleS
+			}
+			pp.modules = append(pp.modules, moduleS)
+		}
+
+		if pp.action == bundleActionOverwrite {
+			if err := planDeletedBundleModules(iDB, pp); err != nil {
+				return nil, response.ErrInternal, err
+			}
+		}
+
+		plan.policies = append(plan.policies, pp)
+		plan.byHash[source.Hash] = pp
+	}
+
+	if len(bundle.Groups) == 0 {
+		return &plan, nil, nil
+	}
+
+	if err := iDB.Find(&groups).Error; err != nil {
+		return nil, response.ErrInternal, err
+	}
+	targets = make([]bundleTarget, 0, len(groups))
+	for _, group := range groups {
+		targets = append(targets, bundleTarget{hash: group.Hash, name: group.Info.Name.En})
+	}
+
+	for _, source := range bundle.Groups {
+		idx := findBundleConflict(targets, source.Hash, source.Info.Name.En)
+		gp := &bundleGroupPlan{
+			source: source,
+			action: getBundleAction(mode, idx != -1),
+		}
+		switch gp.action {
+		case bundleActionCreate:
+			gp.target = models.Group{Hash: source.Hash, Info: source.Info, Rule: source.Rule}
+		case bundleActionRename:
+			gp.target = models.Group{Hash: storage.MakeGroupHash(source.Hash), Info: source.Info, Rule: source.Rule}
+			gp.target.Info.Name.Ru += " (импорт)"
+			gp.target.Info.Name.En += " (import)"
+		default:
+			gp.target = groups[idx]
+		}
+		gp.target.Info.System = false
+		plan.groups = append(plan.groups, gp)
+	}
+
+	return &plan, nil, nil
+}
+
+// bundleModuleVersion is name and version of the module which files are stored into instance S3
+type bundleModuleVersion struct {
+	name    string
+	version string
+}
+
+// copyBundleModulesFiles is a function to copy files of system modules versions which are used by the bundle
+// to instance S3, it's done out of DB transaction because S3 can't be rolled back, so copied versions
+// are returned even on error to remove the ones which are left unused
+func copyBundleModulesFiles(
+	sv *models.Service,
+	plan *bundlePlan,
+) (map[string]models.FilesChecksumsMap, []bundleModuleVersion, error) {
+	var (
+		checksums = make(map[string]models.FilesChecksumsMap)
+		copied    []bundleModuleVersion
+	)
+
+	for _, pp := range plan.policies {
+		if pp.action == bundleActionSkip {
+			continue
+		}
+		for _, moduleS := range pp.modules {
+			version := moduleS.Info.Version.String()
+			key := moduleS.Info.Name + "@" + version
+			if _, ok := checksums[key]; ok {
+				continue
+			}
+			copied = append(copied, bundleModuleVersion{name: moduleS.Info.Name, version: version})
+			fcs, err := modules.CopyModuleAFilesToInstanceS3(&moduleS.Info, sv)
+			if err != nil {
+				return nil, copied, fmt.Errorf("error copying module '%s' files to S3: %w", key, err)
+			}
+			checksums[key] = fcs
+		}
+	}
+
+	return checksums, copied, nil
+}
+
+// removeUnusedBundleModulesFiles is a function to remove files of modules versions from instance S3
+// if they aren't used by policy modules, it must be called out of DB transaction, errors are only logged
+// because DB state is already committed or rolled back
+func removeUnusedBundleModulesFiles(c *gin.Context, iDB *gorm.DB, sv *models.Service, versions []bundleModuleVersion) {
+	for _, mv := range versions {
+		if err := modules.RemoveUnusedModuleVersion(iDB, mv.name, mv.version, sv); err != nil {
+			logger.FromContext(c).WithError(err).
+				Errorf("error removing unused module '%s' version '%s' data", mv.name, mv.version)
+		}
+	}
+}
+
+// applyBundleModule is a function to create or update policy module from the bundle,
+// config of the bundle module is merged into the system module version and secure config is encrypted
+// by the target service key, existing secure config is kept if the bundle doesn't contain it,
+// replaced module version is returned to remove its files after commit if it's left unused
+func applyBundleModule(
+	iDB *gorm.DB,
+	encryptor crypto.IDBConfigEncryptor,
+	policy *models.Policy,
+	source models.BundleModule,
+	moduleS models.ModuleS,
+	checksums models.FilesChecksumsMap,
+) (*bundleModuleVersion, *response.HttpError, error) {
+	var current models.ModuleA
+
+	err := iDB.Take(&current, "policy_id = ? AND name = ?", policy.ID, source.Name).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, response.ErrInternal, err
+	}
+	exists := err == nil
+
+	moduleA := models.ModuleA{
+		PolicyID:            policy.ID,
+		Status:              source.Status,
+		CurrentConfig:       source.CurrentConfig,
+		SecureCurrentConfig: source.SecureCurrentConfig,
+		CurrentActionConfig: source.CurrentActionConfig,
+		CurrentEventConfig:  source.CurrentEventConfig,
+		DynamicDependencies: source.DynamicDependencies,
+	}
+	if exists {
+		if err = current.DecryptSecureParameters(encryptor); err != nil {
+			return nil, response.ErrModulesFailedToDecryptSecureConfig, err
+		}
+		moduleA.ID = current.ID
+		moduleA.JoinDate = current.JoinDate
+		moduleA.LastUpdate = current.LastUpdate
+		moduleA.PrevVersion = current.PrevVersion
+		if len(moduleA.SecureCurrentConfig) == 0 {
+			moduleA.SecureCurrentConfig = current.SecureCurrentConfig
+		}
+	}
+	if moduleA.SecureCurrentConfig == nil {
+		moduleA.SecureCurrentConfig = make(models.ModuleSecureConfig)
+	}
+
+	merged, err := modules.MergeModuleAConfigFromModuleS(&moduleA, &moduleS, encryptor)
+	if err != nil {
+		return nil, response.ErrInternal, fmt.Errorf("invalid module state: %w", err)
+	}
+	version := current.Info.Version.String()
+	if exists && version != merged.Info.Version.String() {
+		merged.PrevVersion = version
+	}
+	if err = merged.Valid(); err != nil {
+		return nil, response.ErrBundlesInvalidBundle, fmt.Errorf("invalid module '%s' state: %w", source.Name, err)
+	}
+	if err = merged.EncryptSecureParameters(encryptor); err != nil {
+		return nil, response.ErrModulesFailedToEncryptSecureConfig, err
+	}
+
+	// the module is checked if it's joined by the bundle, existing inactive module is joined too
+	if merged.Status == "joined" && (!exists || current.Status != "joined") {
+		if err = modules.CheckModulesDuplicate(iDB, &merged); err != nil {
+			return nil, response.ErrPatchPolicyModuleDuplicatedModule, err
+		}
+	}
+
+	merged.FilesChecksums = checksums
+	if !exists {
+		err = iDB.Create(&merged).Error
+	} else {
+		err = iDB.Omit("policy_id", "join_date", "last_update").Save(&merged).Error
+	}
+	if err != nil {
+		return nil, response.ErrInternal, fmt.Errorf("error storing module '%s': %w", source.Name, err)
+	}
+
+	if exists && merged.PrevVersion == version {
+		return &bundleModuleVersion{name: source.Name, version: version}, nil, nil
+	}
+	return nil, nil, nil
+}
+
+// planDeletedBundleModules is a function to find modules of the overwritten policy which are missing in the bundle,
+// the policy from the bundle replaces existing one entirely so these modules are deleted
+func planDeletedBundleModules(iDB *gorm.DB, pp *bundlePolicyPlan) error {
+	var current []models.ModuleA
+
+	names := make(map[string]struct{}, len(pp.source.Modules))
+	for _, module := range pp.source.Modules {
+		names[module.Name] = struct{}{}
+	}
+	if err := iDB.Order("id ASC").Find(&current, "policy_id = ?", pp.target.ID).Error; err != nil {
+		return err
+	}
+	for _, module := range current {
+		if _, ok := names[module.Info.Name]; !ok {
+			pp.deleted = append(pp.deleted, module)
+		}
+	}
+
+	return nil
+}
+
+// applyBundlePlan is a function to store policies, modules, groups and links from the bundle into instance DB,
+// files of modules must be copied to instance S3 before and the returned unused versions must be removed
+// from S3 after commit
+func applyBundlePlan(
+	iDB *gorm.DB,
+	encryptor crypto.IDBConfigEncryptor,
+	plan *bundlePlan,
+	checksums map[string]models.FilesChecksumsMap,
+) ([]bundleModuleVersion, *response.HttpError, error) {
+	var unused []bundleModuleVersion
+
+	for _, pp := range plan.policies {
+		var err error
+		switch pp.action {
+		case bundleActionSkip:
+			continue
+		case bundleActionOverwrite:
+			pp.target.Info = pp.source.Info
+			pp.target.Info.System = false
+			err = iDB.Save(&pp.target).Error
+		default:
+			err = iDB.Create(&pp.target).Error
+		}
+		if err != nil {
+			return nil, response.ErrInternal, fmt.Errorf("error storing policy '%s': %w", pp.target.Hash, err)
+		}
+
+		for idx := range pp.deleted {
+			module := &pp.deleted[idx]
+			if err = iDB.Delete(module).Error; err != nil {
+				return nil, response.ErrInternal, fmt.Errorf("error deleting policy module '%s': %w", module.Info.Name, err)
+			}
+			unused = append(unused, bundleModuleVersion{name: module.Info.Name, version: module.Info.Version.String()})
+		}
+
+		for idx, module := range pp.source.Modules {
+			moduleS := pp.modules[idx]
+			fcs := checksums[moduleS.Info.Name+"@"+moduleS.Info.Version.String()]
+			replaced, httpErr, err := applyBundleModule(iDB, encryptor, &pp.target, module, moduleS, fcs)
+			if httpErr != nil {
+				return nil, httpErr, err
+			} else if replaced != nil {
+				unused = append(unused, *replaced)
+			}
+		}
+	}
+
+	for _, gp := range plan.groups {
+		var err error
+		switch gp.action {
+		case bundleActionSkip:
+			continue
+		case bundleActionOverwrite:
+			gp.target.Info = gp.source.Info
+			gp.target.Info.System = false
+			gp.target.Rule = gp.source.Rule
+			err = iDB.Save(&gp.target).Error
+		default:
+			err = iDB.Create(&gp.target).Error
+		}
+		if err != nil {
+			return nil, response.ErrInternal, fmt.Errorf("error storing group '%s': %w", gp.target.Hash, err)
+		}
+
+		for _, hash := range gp.source.Policies {
+			httpErr, err := makeGroupPolicyAction("activate", iDB, gp.target, plan.byHash[hash].target)
+			if httpErr == response.ErrGroupPolicyLinkExists {
+				continue
+			} else if httpErr != nil {
+				if err == nil {
+					err = fmt.Errorf("failed to link group '%s' to policy '%s'", gp.target.Hash, hash)
+				}
+				return nil, httpErr, err
+			}
+		}
+	}
+
+	return unused, nil, nil
+}
+
+// ExportPolicies is a function to export policies with their modules and optional groups as a bundle
+// @Summary Export of policies bundle which contains policies, linked module versions, modules config and optional groups
+// @Description If secure_config is requested then secure config of modules (passwords, tokens, keys) is encrypted
+// @Description by the passphrase which is required to import the bundle
+// @Tags Policies,Export
+// @Accept json
+// @Produce octet-stream,json
+// @Param json body policiesBundleExport true "policies hashes and bundle options"
+// @Success 200 {file} file "policies bundle file"
+// @Failure 400 {object} response.errorResp "invalid policies bundle request data"
+// @Failure 403 {object} response.errorResp "exporting policies, groups or secure config not permitted"
+// @Failure 404 {object} response.errorResp "policy not found"
+// @Failure 500 {object} response.errorResp "internal error on exporting policies"
+// @Router /export/policies [post]
+func (s *BundleService) ExportPolicies(c *gin.Context) {
+	var (
+		encryptor crypto.IDBConfigEncryptor
+		form      policiesBundleExport
+	)
+
+	uaf := useraction.NewFields(c, "policy", "policy", "export", "", useraction.UnknownObjectDisplayName)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
+
+	if err := c.ShouldBindJSON(&form); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding JSON")
+		response.Error(c, response.ErrBundlesInvalidRequest, err)
+		return
+	}
+
+	if form.SecureConfig {
+		if err := checkGrantedPrivileges(c, []string{"vxapi.modules.secure-config.view"}); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error checking secure config privileges")
+			response.Error(c, response.ErrBundlesSecureConfigNotPermitted, err)
+			return
+		}
+		if len(form.Passphrase) < models.BundleMinPassphraseLength {
+			err := fmt.Errorf("passphrase must be at least %d characters long", models.BundleMinPassphraseLength)
+			logger.FromContext(c).WithError(err).Errorf("error checking bundle passphrase")
+			response.Error(c, response.ErrBundlesPassphraseRequired, err)
+			return
+		}
+	}
+	if form.Groups {
+		if err := checkGrantedPrivileges(c, []string{"vxapi.groups.api.view"}); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error checking groups privileges")
+			response.Error(c, response.ErrBundlesGroupsNotPermitted, err)
+			return
+		}
+	}
+
+	iDB, err := s.serverConnector.GetDB(c, c.GetString("svc"))
+	if err != nil {
+		logger.FromContext(c).WithError(err).Error()
+		response.Error(c, response.ErrInternalDBNotFound, err)
+		return
+	}
+
+	if encryptor = getDBEncryptor(c); encryptor == nil {
+		response.Error(c, response.ErrInternalDBEncryptorNotFound, nil)
+		return
+	}
+
+	bundle, httpErr, err := makeBundle(iDB, encryptor, form)
+	if httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error making policies bundle")
+		response.Error(c, httpErr, err)
+		return
+	}
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error serializing policies bundle")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	uaf.Success = true
+	uaf.ObjectID = bundle.Policies[0].Hash
+	uaf.ObjectDisplayName = bundle.Policies[0].Info.Name.En
+	date := time.Now().Format("06.01.02")
+	contentDisposition := fmt.Sprintf("attachment; filename=policies.bundle.%s.json", date)
+	c.Writer.Header().Add("Content-Disposition", contentDisposition)
+	c.Data(http.StatusOK, "application/octet-stream", data)
+}
+
+// ImportPolicies is a function to import policies bundle with conflict resolution or to preview it
+// @Summary Import of policies bundle into the service, policies and groups with the same hash or name are resolved by the mode
+// @Tags Policies,Import
+// @Accept multipart/form-data
+// @Produce json
+// @Param mode query string false "conflict resolution mode" Enums(skip, overwrite, rename) default(skip)
+// @Param dry_run query boolean false "return planned changes without storing them" default(false)
+// @Param bundle formData file true "policies bundle file"
+// @Param passphrase formData string false "passphrase of the bundle secure config"
+// @Success 200 {object} response.successResp{data=bundleImportResult} "policies bundle imported or previewed successful"
+// @Failure 400 {object} response.errorResp "invalid policies bundle request data"
+// @Failure 403 {object} response.errorResp "importing policies, groups or secure config not permitted"
+// @Failure 404 {object} response.errorResp "system module version from the bundle not found"
+// @Failure 500 {object} response.errorResp "internal error on importing policies"
+// @Router /import/policies [post]
+func (s *BundleService) ImportPolicies(c *gin.Context) {
+	var (
+		encryptor crypto.IDBConfigEncryptor
+		params    policiesBundleImport
+		sv        *models.Service
+	)
+
+	uaf := useraction.NewFields(c, "policy", "policy", "import", "", useraction.UnknownObjectDisplayName)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
+
+	if err := c.ShouldBindQuery(&params); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding query")
+		response.Error(c, response.ErrBundlesInvalidRequest, err)
+		return
+	}
+	if params.Mode == "" {
+		params.Mode = bundleModeSkip
+	}
+
+	bundle, err := readBundle(c)
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error reading policies bundle")
+		response.Error(c, response.ErrBundlesInvalidBundle, err)
+		return
+	}
+	uaf.ObjectID = bundle.Policies[0].Hash
+	uaf.ObjectDisplayName = bundle.Policies[0].Info.Name.En
+
+	if bundle.HasSecureConfig() {
+		if err = checkGrantedPrivileges(c, []string{"vxapi.modules.secure-config.edit"}); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error checking secure config privileges")
+			response.Error(c, response.ErrBundlesSecureConfigNotPermitted, err)
+			return
+		}
+		passphrase := c.PostForm("passphrase")
+		if passphrase == "" {
+			logger.FromContext(c).Errorf("error checking bundle passphrase")
+			response.Error(c, response.ErrBundlesPassphraseRequired, nil)
+			return
+		}
+		// secure config is decrypted here to be re-encrypted by the target service key on import
+		if err = bundle.DecryptSecureConfig(passphrase); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error decrypting policies bundle secure config")
+			if errors.Is(err, models.ErrBundleInvalidPassphrase) {
+				response.Error(c, response.ErrBundlesInvalidPassphrase, err)
+			} else {
+				response.Error(c, response.ErrBundlesInvalidBundle, err)
+			}
+			return
+		}
+	}
+	if len(bundle.Groups) != 0 {
+		privs := []string{"vxapi.groups.api.create", "vxapi.groups.api.edit", "vxapi.policies.control.link"}
+		if err = checkGrantedPrivileges(c, privs); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error checking groups privileges")
+			response.Error(c, response.ErrBundlesGroupsNotPermitted, err)
+			return
+		}
+	}
+
+	iDB, err := s.serverConnector.GetDB(c, c.GetString("svc"))
+	if err != nil {
+		logger.FromContext(c).WithError(err).Error()
+		response.Error(c, response.ErrInternalDBNotFound, err)
+		return
+	}
+
+	if sv = getService(c); sv == nil {
+		response.Error(c, response.ErrInternalServiceNotFound, nil)
+		return
+	}
+
+	if encryptor = getDBEncryptor(c); encryptor == nil {
+		response.Error(c, response.ErrInternalDBEncryptorNotFound, nil)
+		return
+	}
+
+	plan, httpErr, err := s.planBundleImport(c, iDB, sv, bundle, params.Mode)
+	if httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error planning policies bundle import")
+		response.Error(c, httpErr, err)
+		return
+	}
+
+	result := bundleImportResult{
+		Mode:   params.Mode,
+		DryRun: params.DryRun,
+	}
+	if params.DryRun {
+		uaf.Success = true
+		result.Items = plan.items()
+		response.Success(c, http.StatusOK, result)
+		return
+	}
+
+	checksums, copied, err := copyBundleModulesFiles(sv, plan)
+	if err != nil {
+		removeUnusedBundleModulesFiles(c, iDB, sv, copied)
+		logger.FromContext(c).WithError(err).Errorf("error copying policies bundle modules files")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	var unused []bundleModuleVersion
+	err = iDB.Transaction(func(tx *gorm.DB) error {
+		unused, httpErr, err = applyBundlePlan(tx, encryptor, plan, checksums)
+		if httpErr != nil && err == nil {
+			err = errors.New(httpErr.Error())
+		}
+		return err
+	})
+	if err != nil {
+		// files of the versions which were copied only for the bundle are left unused after rollback
+		removeUnusedBundleModulesFiles(c, iDB, sv, copied)
+		if httpErr == nil {
+			httpErr = response.ErrInternal
+		}
+		logger.FromContext(c).WithError(err).Errorf("error importing policies bundle")
+		response.Error(c, httpErr, err)
+		return
+	}
+	removeUnusedBundleModulesFiles(c, iDB, sv, unused)
+
+	uaf.Success = true
+	result.Items = plan.items()
+	logger.FromContext(c).Infof("policies bundle was imported with %d policies and %d groups by mode '%s'",
+		len(plan.policies), len(plan.groups), params.Mode)
+	response.Success(c, http.StatusOK, result)
+}
@@ -0,0 +1,87 @@
+package private
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"soldr/pkg/app/api/models"
+)
+
+func TestFindBundleConflict(t *testing.T) {
+	targets := []bundleTarget{
+		{hash: "a", name: "First"},
+		{hash: "b", name: "Second"},
+	}
+	assert.Equal(t, 1, findBundleConflict(targets, "b", "First"), "hash match must be preferred")
+	assert.Equal(t, 0, findBundleConflict(targets, "c", "First"))
+	assert.Equal(t, -1, findBundleConflict(targets, "c", "Third"))
+	assert.Equal(t, -1, findBundleConflict(nil, "a", "First"))
+}
+
+func TestGetBundleAction(t *testing.T) {
+	for _, mode := range []string{bundleModeSkip, bundleModeOverwrite, bundleModeRename} {
+		assert.Equal(t, bundleActionCreate, getBundleAction(mode, false))
+	}
+	assert.Equal(t, bundleActionSkip, getBundleAction(bundleModeSkip, true))
+	assert.Equal(t, bundleActionOverwrite, getBundleAction(bundleModeOverwrite, true))
+	assert.Equal(t, bundleActionRename, getBundleAction(bundleModeRename, true))
+}
+
+func TestBundlePlanItems(t *testing.T) {
+	policy := &bundlePolicyPlan{
+		source: models.BundlePolicy{
+			Hash:    "p1",
+			Info:    models.PolicyInfo{Name: models.PolicyItemLocale{En: "Policy"}},
+			Modules: []models.BundleModule{{Name: "test_module", Version: "1.0.0"}},
+		},
+		target: models.Policy{Hash: "p2"},
+		action: bundleActionRename,
+	}
+	plan := bundlePlan{
+		policies: []*bundlePolicyPlan{policy},
+		groups: []*bundleGroupPlan{{
+			source: models.BundleGroup{
+				Hash:     "g1",
+				Info:     models.GroupInfo{Name: models.GroupItemLocale{En: "Group"}},
+				Policies: []string{"p1"},
+			},
+			target: models.Group{Hash: "g1"},
+			action: bundleActionSkip,
+		}},
+		byHash: map[string]*bundlePolicyPlan{"p1": policy},
+	}
+
+	assert.Equal(t, []bundleImportItem{
+		{Type: "policy", Name: "Policy", Hash: "p1", TargetHash: "p2", Action: bundleActionRename},
+		{Type: "module", Name: "test_module 1.0.0", Hash: "p1", TargetHash: "p2", Action: bundleActionCreate},
+		{Type: "group", Name: "Group", Hash: "g1", TargetHash: "g1", Action: bundleActionSkip},
+		{Type: "link", Name: "Group / Policy", Hash: "g1", TargetHash: "p2", Action: bundleActionSkip},
+	}, plan.items())
+}
+
+func TestBundlePlanItemsOverwrite(t *testing.T) {
+	deleted := models.ModuleA{}
+	deleted.Info.Name = "old_module"
+	deleted.Info.Version = models.SemVersion{Major: 1, Minor: 2, Patch: 3}
+	policy := &bundlePolicyPlan{
+		source: models.BundlePolicy{
+			Hash:    "p1",
+			Info:    models.PolicyInfo{Name: models.PolicyItemLocale{En: "Policy"}},
+			Modules: []models.BundleModule{{Name: "test_module", Version: "1.0.0"}},
+		},
+		target:  models.Policy{Hash: "p1"},
+		action:  bundleActionOverwrite,
+		deleted: []models.ModuleA{deleted},
+	}
+	plan := bundlePlan{
+		policies: []*bundlePolicyPlan{policy},
+		byHash:   map[string]*bundlePolicyPlan{"p1": policy},
+	}
+
+	assert.Equal(t, []bundleImportItem{
+		{Type: "policy", Name: "Policy", Hash: "p1", TargetHash: "p1", Action: bundleActionOverwrite},
+		{Type: "module", Name: "test_module 1.0.0", Hash: "p1", TargetHash: "p1", Action: bundleActionOverwrite},
+		{Type: "module", Name: "old_module 1.2.3", Hash: "p1", TargetHash: "p1", Action: bundleActionDelete},
+	}, plan.items())
+}
@@ -1,7 +1,12 @@
 package private
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jinzhu/gorm"
@@ -81,6 +86,8 @@ func getAgentIDs(iDB *gorm.DB, query *storage.TableQuery, epids []uint64) ([]uin
 		aidsSort = query.Sort
 	}
 
+	// filter expression is applied to the events query only
+	query.SetExpressionEnabled(false)
 	query.Filters = aidsFilters
 	query.Sort = aidsSort
 	err := iDB.Table("agents").
@@ -90,6 +97,7 @@ func getAgentIDs(iDB *gorm.DB, query *storage.TableQuery, epids []uint64) ([]uin
 		Where("gtp.policy_id IN (?) AND agents.deleted_at IS NULL", epids).
 		Pluck("agents.id", &eaids).Error
 
+	query.SetExpressionEnabled(true)
 	query.Filters = eventsFilters
 	query.Sort = eventsSort
 
@@ -127,6 +135,8 @@ func getModuleIDs(iDB *gorm.DB, query *storage.TableQuery) ([]uint64, []uint64,
 		midsSort = query.Sort
 	}
 
+	// filter expression is applied to the events query only
+	query.SetExpressionEnabled(false)
 	query.Filters = midsFilters
 	query.Sort = midsSort
 	err := iDB.Table("modules").
@@ -136,6 +146,7 @@ func getModuleIDs(iDB *gorm.DB, query *storage.TableQuery) ([]uint64, []uint64,
 		Pluck("modules.id", &emids).
 		Pluck("policies.id", &epids).Error
 
+	query.SetExpressionEnabled(true)
 	query.Filters = eventsFilters
 	query.Sort = eventsSort
 
@@ -198,6 +209,9 @@ func getFilters(query *storage.TableQuery) []func(db *gorm.DB) *gorm.DB {
 		}
 		setUsingTables(filter.Field)
 	}
+	for _, field := range query.ExpressionFields() {
+		setUsingTables(field)
+	}
 
 	funcs := []func(db *gorm.DB) *gorm.DB{
 		func(db *gorm.DB) *gorm.DB {
@@ -259,12 +273,52 @@ func doQuery(iDB *gorm.DB, query *storage.TableQuery, resp *eventResponse, funcs
 	return err
 }
 
+// initEventsQuery is a function to prepare events query by filters of linked agents, groups, modules and policies
+func initEventsQuery(iDB *gorm.DB, query *storage.TableQuery) (*response.HttpError, error) {
+	if err := query.Init("events", eventsSQLMappers); err != nil {
+		return response.ErrEventsInvalidRequest, err
+	}
+
+	emids, epids, err := getModuleIDs(iDB, query)
+	if err != nil {
+		return response.ErrEventsInvalidQuery, fmt.Errorf("error getting modules list by filter: %w", err)
+	}
+
+	eaids, err := getAgentIDs(iDB, query, epids)
+	if err != nil {
+		return response.ErrEventsInvalidQuery, fmt.Errorf("error getting agents list by filter: %w", err)
+	}
+
+	copyEventsSQLMappers := make(map[string]interface{}, len(eventsSQLMappers))
+	for key, value := range eventsSQLMappers {
+		copyEventsSQLMappers[key] = value
+	}
+
+	copyEventsSQLMappers["agent_id"] = "`events`.agent_id"
+	copyEventsSQLMappers["group_id"] = "`agents`.group_id"
+	copyEventsSQLMappers["module_id"] = "`events`.module_id"
+	copyEventsSQLMappers["policy_id"] = "`modules`.policy_id"
+	if err = query.Init("events", copyEventsSQLMappers); err != nil {
+		return response.ErrEventsInvalidRequest, err
+	}
+
+	query.SetFilters([]func(db *gorm.DB) *gorm.DB{
+		func(db *gorm.DB) *gorm.DB {
+			return db.Where("`events`.agent_id IN (?) AND `events`.module_id IN (?)", eaids, emids)
+		},
+	})
+
+	return nil, nil
+}
+
 type EventService struct {
+	db              *gorm.DB
 	serverConnector *client.AgentServerClient
 }
 
-func NewEventService(serverConnector *client.AgentServerClient) *EventService {
+func NewEventService(db *gorm.DB, serverConnector *client.AgentServerClient) *EventService {
 	return &EventService{
+		db:              db,
 		serverConnector: serverConnector,
 	}
 }
@@ -274,17 +328,16 @@ func NewEventService(serverConnector *client.AgentServerClient) *EventService {
 // @Tags Events
 // @Produce json
 // @Param request query storage.TableQuery true "query table params"
+// @Param view query int false "saved view id to merge its query with query table params" minimum(1)
 // @Success 200 {object} response.successResp{data=eventResponse} "events list received successful"
 // @Failure 400 {object} response.errorResp "invalid query request data"
 // @Failure 403 {object} response.errorResp "getting events not permitted"
+// @Failure 404 {object} response.errorResp "view not found"
 // @Failure 500 {object} response.errorResp "internal error on getting events"
 // @Router /events/ [get]
 func (s *EventService) GetEvents(c *gin.Context) {
 	var (
 		aids        []uint64
-		eaids       []uint64
-		emids       []uint64
-		epids       []uint64
 		gids        []uint64
 		mids        []uint64
 		pids        []uint64
@@ -312,47 +365,19 @@ func (s *EventService) GetEvents(c *gin.Context) {
 		return
 	}
 
-	if err = query.Init("events", eventsSQLMappers); err != nil {
-		logger.FromContext(c).WithError(err).Errorf("error binding query")
-		response.Error(c, response.ErrEventsInvalidRequest, err)
+	if httpErr, err := applyView(c, s.db, "events", &query); httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error applying view to query")
+		response.Error(c, httpErr, err)
 		return
 	}
 
-	emids, epids, err = getModuleIDs(iDB, &query)
-	if err != nil {
-		logger.FromContext(c).WithError(err).Errorf("error getting modules list by filter")
-		response.Error(c, response.ErrEventsInvalidQuery, err)
-		return
-	}
-
-	eaids, err = getAgentIDs(iDB, &query, epids)
-	if err != nil {
-		logger.FromContext(c).WithError(err).Errorf("error getting agents list by filter")
-		response.Error(c, response.ErrEventsInvalidQuery, err)
-		return
-	}
-
-	copyEventsSQLMappers := make(map[string]interface{}, len(eventsSQLMappers))
-	for key, value := range eventsSQLMappers {
-		copyEventsSQLMappers[key] = value
-	}
-
-	copyEventsSQLMappers["agent_id"] = "`events`.agent_id"
-	copyEventsSQLMappers["group_id"] = "`agents`.group_id"
-	copyEventsSQLMappers["module_id"] = "`events`.module_id"
-	copyEventsSQLMappers["policy_id"] = "`modules`.policy_id"
-	if err = query.Init("events", copyEventsSQLMappers); err != nil {
-		logger.FromContext(c).WithError(err).Errorf("error binding query")
-		response.Error(c, response.ErrEventsInvalidRequest, err)
+	if httpErr, err := initEventsQuery(iDB, &query); httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error preparing events query")
+		response.Error(c, httpErr, err)
 		return
 	}
 
 	funcs := getFilters(&query)
-	query.SetFilters([]func(db *gorm.DB) *gorm.DB{
-		func(db *gorm.DB) *gorm.DB {
-			return db.Where("`events`.agent_id IN (?) AND `events`.module_id IN (?)", eaids, emids)
-		},
-	})
 
 	if query.Group == "" {
 		if err = doQuery(iDB, &query, &resp, funcs); err != nil {
@@ -427,3 +452,175 @@ func UniqueUint64InSlice(slice []uint64) []uint64 {
 	}
 	return list
 }
+
+var eventsExportHeader = []string{
+	"id",
+	"date",
+	"name",
+	"uniq",
+	"agent_id",
+	"agent_hash",
+	"agent_name",
+	"group_hash",
+	"group_name",
+	"module_id",
+	"module_name",
+	"policy_hash",
+	"policy_name",
+	"actions",
+	"data",
+}
+
+func eventsExportRecord(event *models.EventExport) ([]string, error) {
+	data, err := json.Marshal(event.Info.Data)
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		strconv.FormatUint(event.ID, 10),
+		event.Date.UTC().Format(time.RFC3339),
+		event.Info.Name,
+		event.Info.Uniq,
+		strconv.FormatUint(event.AgentID, 10),
+		event.AgentHash,
+		event.AgentName,
+		event.GroupHash,
+		event.GroupName,
+		strconv.FormatUint(event.ModuleID, 10),
+		event.ModuleName,
+		event.PolicyHash,
+		event.PolicyName,
+		strings.Join(event.Info.Actions, ","),
+		string(data),
+	}, nil
+}
+
+// getExportFilters returns joins of all linked tables to resolve names of linked objects inline
+func getExportFilters(query *storage.TableQuery) []func(db *gorm.DB) *gorm.DB {
+	return []func(db *gorm.DB) *gorm.DB{
+		func(db *gorm.DB) *gorm.DB {
+			return db.
+				Select(query.DoConditionFormat("`events`.id, `events`.module_id, `events`.agent_id, " +
+					"`events`.info, `events`.date, " +
+					"IFNULL(`agents`.hash, '') AS agent_hash, " +
+					"IFNULL(`agents`.description, '') AS agent_name, " +
+					"IFNULL(`groups`.hash, '') AS group_hash, " +
+					"IFNULL(JSON_UNQUOTE(JSON_EXTRACT(`groups`.info, '$.name.{{lang}}')), '') AS group_name, " +
+					"IFNULL(`modules`.name, '') AS module_name, " +
+					"IFNULL(`policies`.hash, '') AS policy_hash, " +
+					"IFNULL(JSON_UNQUOTE(JSON_EXTRACT(`policies`.info, '$.name.{{lang}}')), '') AS policy_name")).
+				Joins("LEFT JOIN agents ON agents.id = `events`.agent_id").
+				Joins("LEFT JOIN groups ON groups.id = agents.group_id").
+				Joins("LEFT JOIN modules ON modules.id = `events`.module_id").
+				Joins("LEFT JOIN policies ON policies.id = modules.policy_id")
+		},
+	}
+}
+
+// ExportEvents is a function to export events to the file
+// @Summary Export events by filters as CSV or NDJSON file with names of linked objects, use pageSize -1 to export all
+// @Description Every row contains names of linked agent, group, module and policy
+// @Tags Events
+// @Produce text/csv,application/x-ndjson
+// @Param request query storage.TableQuery true "query table params"
+// @Param format query ExportQuery true "export format"
+// @Param view query int false "saved view id to merge its query with query table params" minimum(1)
+// @Success 200 {file} file "events exported successful"
+// @Failure 400 {object} response.errorResp "invalid query request data"
+// @Failure 403 {object} response.errorResp "exporting events not permitted"
+// @Failure 404 {object} response.errorResp "view not found"
+// @Failure 500 {object} response.errorResp "internal error on exporting events"
+// @Router /events/export [get]
+func (s *EventService) ExportEvents(c *gin.Context) {
+	var (
+		export ExportQuery
+		query  storage.TableQuery
+	)
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding query")
+		response.Error(c, response.ErrEventsInvalidRequest, err)
+		return
+	}
+	if err := c.ShouldBindQuery(&export); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding export query")
+		response.Error(c, response.ErrEventsInvalidRequest, err)
+		return
+	}
+	if query.Group != "" {
+		logger.FromContext(c).Errorf("error exporting grouped events")
+		response.Error(c, response.ErrEventsInvalidRequest, fmt.Errorf("grouping is not supported by export"))
+		return
+	}
+
+	serviceHash := c.GetString("svc")
+	if serviceHash == "" {
+		logger.FromContext(c).Errorf("could not get service hash")
+		response.Error(c, response.ErrInternal, nil)
+		return
+	}
+	iDB, err := s.serverConnector.GetDB(c, serviceHash)
+	if err != nil {
+		logger.FromContext(c).WithError(err).Error()
+		response.Error(c, response.ErrInternalDBNotFound, err)
+		return
+	}
+
+	if httpErr, err := applyView(c, s.db, "events", &query); httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error applying view to query")
+		response.Error(c, httpErr, err)
+		return
+	}
+
+	if httpErr, err := initEventsQuery(iDB, &query); httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error preparing events query")
+		response.Error(c, httpErr, err)
+		return
+	}
+
+	// rows are read by server-side cursor to avoid loading all events into memory
+	rows, err := storage.ApplyToChainDB(
+		storage.ApplyToChainDB(iDB.Table(query.Table()), getExportFilters(&query)...).Scopes(query.DataFilter()),
+		query.Ordering(),
+		query.Paginate(),
+	).Rows()
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding events")
+		response.Error(c, response.ErrEventsInvalidQuery, err)
+		return
+	}
+	defer rows.Close()
+
+	w, err := newExportWriter(c, export.Format, "events", eventsExportHeader)
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error initializing export writer")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+	c.Status(http.StatusOK)
+
+	// response status is already sent so errors are only logged and the stream is interrupted
+	for rows.Next() {
+		var event models.EventExport
+		if err = iDB.ScanRows(rows, &event); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error scanning event")
+			return
+		}
+		record, err := eventsExportRecord(&event)
+		if err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error making event record '%d'", event.ID)
+			return
+		}
+		if err = w.Write(event, record); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error writing event '%d'", event.ID)
+			return
+		}
+	}
+	if err = rows.Err(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error reading events")
+		return
+	}
+	if err = w.Flush(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error flushing events")
+	}
+}
@@ -0,0 +1,164 @@
+package private
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"soldr/pkg/app/api/client"
+	"soldr/pkg/app/api/storage"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExportEventsDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	db, err := gorm.Open("mysql", sqlDB)
+	require.NoError(t, err)
+	return db, mock
+}
+
+func callExportEvents(t *testing.T, iDB *gorm.DB, params url.Values) *httptest.ResponseRecorder {
+	t.Helper()
+	dbConns := storage.NewDBConnectionStorage()
+	dbConns.Set("svc1", iDB)
+	service := NewEventService(nil, client.NewAgentServerClient(nil, dbConns, storage.NewS3ConnectionStorage()))
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/events/export?"+params.Encode(), nil)
+	c.Set("svc", "svc1")
+	service.ExportEvents(c)
+	return rec
+}
+
+func exportEventsParams(format string, filters ...string) url.Values {
+	return url.Values{
+		"page":      {"1"},
+		"pageSize":  {"-1"},
+		"type":      {"filter"},
+		"lang":      {"en"},
+		"sort":      {`{"prop":"id","order":"descending"}`},
+		"filters[]": filters,
+		"format":    {format},
+	}
+}
+
+// expectExportEvents sets expectations of the events export queries which are filtered by the module name
+func expectExportEvents(mock sqlmock.Sqlmock) {
+	date := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	info := func(name string) string {
+		return `{"actions":["log_to_db"],"data":{"key":"value"},"name":"` + name + `","time":0,"uniq":"uniq"}`
+	}
+
+	mock.ExpectQuery("SELECT modules.id FROM `modules` JOIN policies .+" +
+		"WHERE \\(LOWER\\(`modules`.name\\) like \\?\\)").
+		WithArgs("%mod1%").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("SELECT policies.id FROM `modules`").
+		WithArgs("%mod1%").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("SELECT agents.id FROM `agents` .+WHERE \\(gtp.policy_id IN \\(\\?\\)").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("SELECT `events`.id, .+ AS group_name, .+ FROM `events` "+
+		"LEFT JOIN agents .+WHERE \\(`events`.agent_id IN \\(\\?\\) AND `events`.module_id IN \\(\\?\\)\\) "+
+		"ORDER BY `events`.id DESC").
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "module_id", "agent_id", "info", "date", "agent_hash", "agent_name",
+			"group_hash", "group_name", "module_name", "policy_hash", "policy_name",
+		}).
+			AddRow(3, 1, 1, info("event3"), date, "agent1", "Agent 1",
+				"group1", "Group 1", "mod1", "policy1", "Policy 1").
+			AddRow(1, 1, 1, info("event1"), date, "agent1", "Agent 1",
+				"group1", "Group 1", "mod1", "policy1", "Policy 1"))
+}
+
+func TestExportEvents(t *testing.T) {
+	filter := `{"field":"module_name","value":"mod1"}`
+
+	t.Run("csv", func(t *testing.T) {
+		iDB, mock := newTestExportEventsDB(t)
+		defer iDB.Close()
+		expectExportEvents(mock)
+
+		rec := callExportEvents(t, iDB, exportEventsParams("csv", filter))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Header().Get("Content-Disposition"), "events_")
+
+		rows, err := csv.NewReader(rec.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			eventsExportHeader,
+			{"3", "2022-10-01T12:00:00Z", "event3", "uniq", "1", "agent1", "Agent 1", "group1", "Group 1",
+				"1", "mod1", "policy1", "Policy 1", "log_to_db", `{"key":"value"}`},
+			{"1", "2022-10-01T12:00:00Z", "event1", "uniq", "1", "agent1", "Agent 1", "group1", "Group 1",
+				"1", "mod1", "policy1", "Policy 1", "log_to_db", `{"key":"value"}`},
+		}, rows)
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		iDB, mock := newTestExportEventsDB(t)
+		defer iDB.Close()
+		expectExportEvents(mock)
+
+		rec := callExportEvents(t, iDB, exportEventsParams("ndjson", filter))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+
+		lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+		require.Len(t, lines, 2)
+		for i, id := range []float64{3, 1} {
+			var event map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(lines[i]), &event))
+			assert.Equal(t, id, event["id"])
+			assert.Equal(t, "Group 1", event["group_name"])
+			assert.Equal(t, "Policy 1", event["policy_name"])
+		}
+	})
+
+	t.Run("grouped events are rejected", func(t *testing.T) {
+		iDB, mock := newTestExportEventsDB(t)
+		defer iDB.Close()
+
+		params := exportEventsParams("csv", filter)
+		params.Set("group", "module_name")
+		rec := callExportEvents(t, iDB, params)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown format is rejected", func(t *testing.T) {
+		iDB, mock := newTestExportEventsDB(t)
+		defer iDB.Close()
+
+		rec := callExportEvents(t, iDB, exportEventsParams("xml", filter))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid filter is rejected", func(t *testing.T) {
+		iDB, mock := newTestExportEventsDB(t)
+		defer iDB.Close()
+
+		rec := callExportEvents(t, iDB, exportEventsParams("csv", `{"field":"module_name"`))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
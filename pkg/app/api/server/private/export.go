@@ -0,0 +1,92 @@
+package private
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+
+	// exportFlushRows is an amount of rows which are buffered before sending to the client
+	exportFlushRows = 100
+)
+
+// ExportQuery is auxiliary struct to contain export format of table data
+type ExportQuery struct {
+	// Format of exported data
+	Format string `form:"format" json:"format" binding:"oneof=csv ndjson,required" default:"csv" enums:"csv,ndjson"`
+}
+
+// exportWriter is a writer to stream table rows to the client as CSV or NDJSON file
+type exportWriter struct {
+	c      *gin.Context
+	format string
+	csv    *csv.Writer
+	json   *json.Encoder
+	rows   int
+}
+
+// newExportWriter writes response headers and CSV header row, the header is used only for CSV format
+func newExportWriter(c *gin.Context, format, name string, header []string) (*exportWriter, error) {
+	w := &exportWriter{
+		c:      c,
+		format: format,
+	}
+	filename := fmt.Sprintf("%s_%s.%s", name, time.Now().UTC().Format("20060102T150405"), format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("X-Content-Type-Options", "nosniff")
+
+	switch format {
+	case exportFormatCSV:
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		w.csv = csv.NewWriter(c.Writer)
+		if err := w.csv.Write(header); err != nil {
+			return nil, err
+		}
+	case exportFormatNDJSON:
+		c.Header("Content-Type", "application/x-ndjson")
+		w.json = json.NewEncoder(c.Writer)
+	default:
+		return nil, fmt.Errorf("unsupported export format '%s'", format)
+	}
+
+	return w, nil
+}
+
+// Write writes one row, record is used for CSV format and item is used for NDJSON format
+func (w *exportWriter) Write(item interface{}, record []string) error {
+	var err error
+	switch w.format {
+	case exportFormatCSV:
+		err = w.csv.Write(record)
+	case exportFormatNDJSON:
+		err = w.json.Encode(item)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.rows++
+	if w.rows%exportFlushRows == 0 {
+		return w.Flush()
+	}
+	return nil
+}
+
+// Flush sends buffered rows to the client
+func (w *exportWriter) Flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	w.c.Writer.Flush()
+	return nil
+}
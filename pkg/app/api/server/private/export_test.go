@@ -0,0 +1,69 @@
+package private
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type exportTestItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestExportWriter(t *testing.T) {
+	items := []exportTestItem{{1, "first"}, {2, "second, \"quoted\""}}
+	record := func(item exportTestItem) []string {
+		return []string{strconv.Itoa(item.ID), item.Name}
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rec)
+		w, err := newExportWriter(c, exportFormatCSV, "items", []string{"id", "name"})
+		require.NoError(t, err)
+		for _, item := range items {
+			require.NoError(t, w.Write(item, record(item)))
+		}
+		require.NoError(t, w.Flush())
+
+		assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Header().Get("Content-Disposition"), "items_")
+		rows, err := csv.NewReader(rec.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"id", "name"}, {"1", "first"}, {"2", "second, \"quoted\""}}, rows)
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rec)
+		w, err := newExportWriter(c, exportFormatNDJSON, "items", nil)
+		require.NoError(t, err)
+		for _, item := range items {
+			require.NoError(t, w.Write(item, record(item)))
+		}
+		require.NoError(t, w.Flush())
+
+		assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+		lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+		require.Len(t, lines, len(items))
+		for i, line := range lines {
+			var item exportTestItem
+			require.NoError(t, json.Unmarshal([]byte(line), &item))
+			assert.Equal(t, items[i], item)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		_, err := newExportWriter(c, "xml", "items", nil)
+		assert.Error(t, err)
+	})
+}
@@ -41,9 +41,15 @@ type group struct {
 type groupInfo struct {
 	Name string   `json:"name" binding:"max=255,required_without=From"`
 	Tags []string `json:"tags" binding:"omitempty"`
+	Rule string   `json:"rule" binding:"max=8192,omitempty"`
 	From uint64   `json:"from" binding:"min=0,numeric,omitempty"`
 }
 
+type groupRuleAgents struct {
+	Agents []models.Agent `json:"agents"`
+	Total  uint64         `json:"total"`
+}
+
 type groupPolicyPatch struct {
 	// Action on group policy must be one of activate, deactivate
 	Action string        `form:"action" json:"action" binding:"oneof=activate deactivate,required" default:"activate" enums:"activate,deactivate"`
@@ -268,6 +274,9 @@ func (s *GroupService) GetGroups(c *gin.Context) {
 	for _, filter := range query.Filters {
 		setUsingTables(filter.Field)
 	}
+	for _, field := range query.ExpressionFields() {
+		setUsingTables(field)
+	}
 	query.SetFilters([]func(db *gorm.DB) *gorm.DB{
 		func(db *gorm.DB) *gorm.DB {
 			return db.Where("groups.deleted_at IS NULL")
@@ -486,7 +495,9 @@ func (s *GroupService) PatchGroup(c *gin.Context) {
 	)
 
 	uaf := useraction.NewFields(c, "group", "group", "editing", hash, useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	serviceHash := c.GetString("svc")
 	if serviceHash == "" {
@@ -516,6 +527,14 @@ func (s *GroupService) PatchGroup(c *gin.Context) {
 
 	uaf.ObjectDisplayName = group.Info.Name.En
 
+	if group.IsDynamic() {
+		if err = storage.ValidateGroupRule(group.Rule); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error validating group rule")
+			response.Error(c, response.ErrGroupsInvalidRule, err)
+			return
+		}
+	}
+
 	if hash != group.Hash {
 		logger.FromContext(c).Errorf("mismatch group hash to requested one")
 		response.Error(c, response.ErrGroupsValidationFail, nil)
@@ -528,7 +547,7 @@ func (s *GroupService) PatchGroup(c *gin.Context) {
 		return
 	}
 
-	public_info := []interface{}{"info", "updated_at"}
+	public_info := []interface{}{"info", "rule", "updated_at"}
 	err = iDB.Select("", public_info...).Save(&group).Error
 
 	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
@@ -566,7 +585,9 @@ func (s *GroupService) PatchGroupPolicy(c *gin.Context) {
 	)
 
 	uaf := useraction.NewFields(c, "policy", "policy", "undefined action", "", useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	if err := c.ShouldBindJSON(&form); err != nil {
 		logger.FromContext(c).WithError(err).Errorf("error binding JSON")
@@ -650,7 +671,9 @@ func (s *GroupService) CreateGroup(c *gin.Context) {
 	)
 
 	uaf := useraction.NewFields(c, "group", "group", "creation", "", useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	if err := c.ShouldBindJSON(&info); err != nil {
 		logger.FromContext(c).WithError(err).Errorf("error binding JSON")
@@ -682,6 +705,7 @@ func (s *GroupService) CreateGroup(c *gin.Context) {
 			Tags:   info.Tags,
 			System: false,
 		},
+		Rule: info.Rule,
 	}
 	uaf.ObjectID = group.Hash
 
@@ -713,6 +737,17 @@ func (s *GroupService) CreateGroup(c *gin.Context) {
 		if len(info.Tags) != 0 {
 			group.Info.Tags = info.Tags
 		}
+		if info.Rule != "" {
+			group.Rule = info.Rule
+		}
+	}
+
+	if group.IsDynamic() {
+		if err = storage.ValidateGroupRule(group.Rule); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error validating group rule")
+			response.Error(c, response.ErrGroupsInvalidRule, err)
+			return
+		}
 	}
 
 	if err = iDB.Create(&group).Error; err != nil {
@@ -760,7 +795,9 @@ func (s *GroupService) DeleteGroup(c *gin.Context) {
 	)
 
 	uaf := useraction.NewFields(c, "group", "group", "deletion", hash, useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	serviceHash := c.GetString("svc")
 	if serviceHash == "" {
@@ -799,3 +836,80 @@ func (s *GroupService) DeleteGroup(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, struct{}{})
 }
+
+// GetGroupRuleAgents is a function to preview agents which are matched by the rule of dynamic group
+// @Summary Retrieve agents list matched by the group rule without saving it (dry-run)
+// @Tags Groups,Agents
+// @Produce json
+// @Param rule query string true "group rule as filter expression over agents fields" maxlength(8192)
+// @Param request query storage.TableQuery true "query table params"
+// @Success 200 {object} response.successResp{data=groupRuleAgents} "matched agents list received successful"
+// @Failure 400 {object} response.errorResp "invalid group rule or query request data"
+// @Failure 403 {object} response.errorResp "getting agents not permitted"
+// @Failure 500 {object} response.errorResp "internal error on matching agents"
+// @Router /groups/dry-run [get]
+func (s *GroupService) GetGroupRuleAgents(c *gin.Context) {
+	var (
+		query storage.TableQuery
+		resp  groupRuleAgents
+		rule  = c.Query("rule")
+	)
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding query")
+		response.Error(c, response.ErrGroupsInvalidRequest, err)
+		return
+	}
+
+	if err := storage.ValidateGroupRule(rule); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error validating group rule")
+		response.Error(c, response.ErrGroupsInvalidRule, err)
+		return
+	}
+
+	if err := query.Merge(&storage.TableQuery{Expression: rule}); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error merging group rule to query")
+		response.Error(c, response.ErrGroupsInvalidRule, err)
+		return
+	}
+
+	serviceHash := c.GetString("svc")
+	if serviceHash == "" {
+		logger.FromContext(c).Errorf("could not get service hash")
+		response.Error(c, response.ErrInternal, nil)
+		return
+	}
+	iDB, err := s.serverConnector.GetDB(c, serviceHash)
+	if err != nil {
+		logger.FromContext(c).WithError(err).Error()
+		response.Error(c, response.ErrInternalDBNotFound, err)
+		return
+	}
+
+	if err = query.Init("agents", storage.GroupRuleSQLMappers()); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding query")
+		response.Error(c, response.ErrGroupsInvalidRequest, err)
+		return
+	}
+	query.SetFilters([]func(db *gorm.DB) *gorm.DB{
+		func(db *gorm.DB) *gorm.DB {
+			return db.Where("agents.deleted_at IS NULL")
+		},
+	})
+
+	if resp.Total, err = query.Query(iDB, &resp.Agents); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding agents by group rule")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	for i := 0; i < len(resp.Agents); i++ {
+		if err = resp.Agents[i].Valid(); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error validating agent data '%s'", resp.Agents[i].Hash)
+			response.Error(c, response.ErrAgentsInvalidData, err)
+			return
+		}
+	}
+
+	response.Success(c, http.StatusOK, resp)
+}
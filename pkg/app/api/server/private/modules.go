@@ -260,7 +260,11 @@ func (s *ModuleService) GetAgentModules(c *gin.Context) {
 		pids = append(pids, p.ID)
 	}
 
-	query.Init("modules", modulesSQLMappers)
+	if err = query.Init("modules", modulesSQLMappers); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding query")
+		response.Error(c, response.ErrModulesInvalidRequest, err)
+		return
+	}
 	query.SetFilters([]func(db *gorm.DB) *gorm.DB{
 		func(db *gorm.DB) *gorm.DB {
 			return db.Where("policy_id IN (?) AND status LIKE 'joined'", pids)
@@ -572,7 +576,11 @@ func (s *ModuleService) GetGroupModules(c *gin.Context) {
 		pids = append(pids, p.ID)
 	}
 
-	query.Init("modules", modulesSQLMappers)
+	if err = query.Init("modules", modulesSQLMappers); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding query")
+		response.Error(c, response.ErrModulesInvalidRequest, err)
+		return
+	}
 	query.SetFilters([]func(db *gorm.DB) *gorm.DB{
 		func(db *gorm.DB) *gorm.DB {
 			return db.Where("policy_id IN (?) AND status LIKE 'joined'", pids)
@@ -1146,7 +1154,9 @@ func (s *ModuleService) PatchPolicyModule(c *gin.Context) {
 	)
 
 	uaf := useraction.NewFields(c, "policy", "policy", "editing", hash, useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	serviceHash := c.GetString("svc")
 	if serviceHash == "" {
@@ -1322,6 +1332,7 @@ func (s *ModuleService) PatchPolicyModule(c *gin.Context) {
 			response.Error(c, response.ErrModulesFailedToEncryptSecureConfig, err)
 			return
 		}
+		form.Module.PrevVersion = moduleA.PrevVersion
 		if err = iDB.Omit(excl...).Save(&form.Module).Error; err != nil {
 			logger.FromContext(c).WithError(err).Errorf("error saving module")
 			response.Error(c, response.ErrInternal, err)
@@ -1329,60 +1340,201 @@ func (s *ModuleService) PatchPolicyModule(c *gin.Context) {
 		}
 
 	case "update":
-		moduleVersion := moduleA.Info.Version.String()
-		if moduleVersion == moduleS.Info.Version.String() {
-			logger.FromContext(c).Errorf("error updating module to the same version: %s", moduleVersion)
-			response.Error(c, response.ErrInternal, err)
+		if httpErr, err := changePolicyModuleVersion(iDB, sv, encryptor, moduleA, &moduleS); httpErr != nil {
+			logger.FromContext(c).WithError(err).Errorf("error updating policy module version: %s", httpErr.Error())
+			response.Error(c, httpErr, err)
 			return
 		}
 
-		moduleA, err = modules.MergeModuleAConfigFromModuleS(&moduleA, &moduleS, encryptor)
-		if err != nil {
-			logger.FromContext(c).WithError(err).Errorf("invalid module state")
-			response.Error(c, response.ErrInternal, err)
-			return
-		}
+	default:
+		logger.FromContext(c).Errorf("error making unknown action on module")
+		response.Error(c, response.ErrPatchPolicyModuleActionNotFound, nil)
+		return
+	}
 
-		if err = moduleA.Valid(); err != nil {
-			logger.FromContext(c).WithError(err).Errorf("invalid module state")
-			response.Error(c, response.ErrInternal, err)
-			return
-		}
+	response.Success(c, http.StatusOK, struct{}{})
+}
 
-		err = moduleA.EncryptSecureParameters(encryptor)
-		if err != nil {
-			logger.FromContext(c).WithError(err).Errorf("failed to encrypt module secure config")
-			response.Error(c, response.ErrModulesFailedToEncryptSecureConfig, err)
-			return
-		}
+// changePolicyModuleVersion is a function to move policy module to the version of system module,
+// the current config of the policy module is merged into the target version and
+// the replaced version is kept to make rollback of the policy module
+func changePolicyModuleVersion(
+	iDB *gorm.DB,
+	sv *models.Service,
+	encryptor crypto.IDBConfigEncryptor,
+	moduleA models.ModuleA,
+	moduleS *models.ModuleS,
+) (*response.HttpError, error) {
+	moduleName := moduleA.Info.Name
+	moduleVersion := moduleA.Info.Version.String()
+	if moduleVersion == moduleS.Info.Version.String() {
+		return response.ErrInternal, fmt.Errorf("policy module already has the version: %s", moduleVersion)
+	}
+
+	moduleA, err := modules.MergeModuleAConfigFromModuleS(&moduleA, moduleS, encryptor)
+	if err != nil {
+		return response.ErrInternal, fmt.Errorf("invalid module state: %w", err)
+	}
+	moduleA.PrevVersion = moduleVersion
 
-		checksums, err := modules.CopyModuleAFilesToInstanceS3(&moduleA.Info, sv)
-		if err != nil {
-			logger.FromContext(c).WithError(err).Errorf("error copying module files to S3")
+	if err = moduleA.Valid(); err != nil {
+		return response.ErrInternal, fmt.Errorf("invalid module state: %w", err)
+	}
+
+	if err = moduleA.EncryptSecureParameters(encryptor); err != nil {
+		return response.ErrModulesFailedToEncryptSecureConfig, err
+	}
+
+	checksums, err := modules.CopyModuleAFilesToInstanceS3(&moduleA.Info, sv)
+	if err != nil {
+		return response.ErrInternal, fmt.Errorf("error copying module files to S3: %w", err)
+	}
+	moduleA.FilesChecksums = checksums
+
+	excl := []string{"policy_id", "status", "join_date", "last_update"}
+	if err = iDB.Omit(excl...).Save(&moduleA).Error; err != nil {
+		return response.ErrInternal, fmt.Errorf("error updating module: %w", err)
+	}
+
+	if err = modules.RemoveUnusedModuleVersion(iDB, moduleName, moduleVersion, sv); err != nil {
+		return response.ErrInternal, fmt.Errorf("error removing unused module data: %w", err)
+	}
+
+	return nil, nil
+}
+
+// RollbackPolicyModule is a function to pin policy module back to the previously deployed version
+// @Summary Rollback policy module to the previously deployed version by policy hash and module name
+// @Tags Policies,Modules
+// @Produce json
+// @Param hash path string true "policy hash in hex format (md5)" minlength(32) maxlength(32)
+// @Param module_name path string true "module name without spaces"
+// @Success 200 {object} response.successResp{data=models.ModuleA} "policy module rolled back successful"
+// @Failure 400 {object} response.errorResp "policy module has no previously deployed version"
+// @Failure 403 {object} response.errorResp "updating policy module not permitted"
+// @Failure 404 {object} response.errorResp "policy, policy module or system module version not found"
+// @Failure 500 {object} response.errorResp "internal error on rollback of policy module"
+// @Router /policies/{hash}/modules/{module_name}/rollback [post]
+func (s *ModuleService) RollbackPolicyModule(c *gin.Context) {
+	var (
+		encryptor  crypto.IDBConfigEncryptor
+		hash       = c.Param("hash")
+		moduleA    models.ModuleA
+		moduleName = c.Param("module_name")
+		moduleS    models.ModuleS
+		policy     models.Policy
+		sv         *models.Service
+	)
+
+	uaf := useraction.NewFields(c, "policy", "policy", "module version rollback", hash, useraction.UnknownObjectDisplayName)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
+
+	serviceHash := c.GetString("svc")
+	if serviceHash == "" {
+		logger.FromContext(c).Errorf("could not get service hash")
+		response.Error(c, response.ErrInternal, nil)
+		return
+	}
+	iDB, err := s.serverConnector.GetDB(c, serviceHash)
+	if err != nil {
+		logger.FromContext(c).WithError(err).Error()
+		response.Error(c, response.ErrInternalDBNotFound, err)
+		return
+	}
+
+	if err = iDB.Take(&policy, "hash = ?", hash).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding policy by hash")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, response.ErrRollbackPolicyModulePolicyNotFound, err)
+		} else {
 			response.Error(c, response.ErrInternal, err)
-			return
 		}
-		moduleA.FilesChecksums = checksums
+		return
+	}
+	uaf.ObjectDisplayName = policy.Info.Name.En
 
-		if err = iDB.Omit(excl...).Save(&moduleA).Error; err != nil {
-			logger.FromContext(c).WithError(err).Errorf("error updating module")
+	if err = policy.Valid(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error validating policy data '%s'", policy.Hash)
+		response.Error(c, response.ErrPatchPolicyModuleInvalidPolicyData, err)
+		return
+	}
+
+	if sv = getService(c); sv == nil {
+		response.Error(c, response.ErrInternalServiceNotFound, nil)
+		return
+	}
+
+	if encryptor = getDBEncryptor(c); encryptor == nil {
+		response.Error(c, response.ErrInternalDBEncryptorNotFound, nil)
+		return
+	}
+
+	if err = iDB.Take(&moduleA, "policy_id = ? AND name = ?", policy.ID, moduleName).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding policy module by name")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, response.ErrRollbackPolicyModuleModuleNotFound, err)
+		} else {
 			response.Error(c, response.ErrInternal, err)
-			return
 		}
+		return
+	} else if err = moduleA.Valid(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error validating module data '%s'", moduleA.Info.Name)
+		response.Error(c, response.ErrModulesInvalidData, err)
+		return
+	}
+
+	if moduleA.PrevVersion == "" {
+		logger.FromContext(c).Errorf("policy module '%s' has no previously deployed version", moduleName)
+		response.Error(c, response.ErrRollbackPolicyModuleNoPreviousVersion, nil)
+		return
+	}
 
-		if err = modules.RemoveUnusedModuleVersion(iDB, moduleName, moduleVersion, sv); err != nil {
-			logger.FromContext(c).WithError(err).Errorf("error removing unused module data")
+	tid := c.GetUint64("tid")
+	scope := func(db *gorm.DB) *gorm.DB {
+		return db.Where("name = ? AND tenant_id IN (0, ?) AND service_type = ? AND version = ?",
+			moduleName, tid, sv.Type, moduleA.PrevVersion)
+	}
+	if err = s.db.Scopes(scope).Take(&moduleS).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding system module by version '%s'", moduleA.PrevVersion)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, response.ErrModulesSystemModuleNotFound, err)
+		} else {
 			response.Error(c, response.ErrInternal, err)
-			return
 		}
+		return
+	} else if err = moduleS.Valid(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error validating system module data '%s'", moduleS.Info.Name)
+		response.Error(c, response.ErrModulesInvalidSystemModuleData, err)
+		return
+	}
 
-	default:
-		logger.FromContext(c).Errorf("error making unknown action on module")
-		response.Error(c, response.ErrPatchPolicyModuleActionNotFound, nil)
+	if httpErr, err := changePolicyModuleVersion(iDB, sv, encryptor, moduleA, &moduleS); httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error rolling back policy module version: %s", httpErr.Error())
+		response.Error(c, httpErr, err)
 		return
 	}
 
-	response.Success(c, http.StatusOK, struct{}{})
+	// other policies which use the restored version must get the last changes of the system module
+	if err = modules.UpdatePolicyModulesByModuleS(&moduleS, sv, encryptor); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error updating modules in policies")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	if err = iDB.Take(&moduleA, "id = ?", moduleA.ID).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding policy module by id")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+	if err = moduleA.DecryptSecureParameters(encryptor); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error decrypting module data")
+		response.Error(c, response.ErrModulesFailedToDecryptSecureConfig, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, moduleA)
 }
 
 // DeletePolicyModule is a function to delete policy module instance
@@ -1407,7 +1559,9 @@ func (s *ModuleService) DeletePolicyModule(c *gin.Context) {
 	)
 
 	uaf := useraction.NewFields(c, "policy", "policy", "editing", hash, useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	serviceHash := c.GetString("svc")
 	if serviceHash == "" {
@@ -1500,7 +1654,9 @@ func (s *ModuleService) SetPolicyModuleSecureConfigValue(c *gin.Context) {
 	)
 
 	uaf := useraction.NewFields(c, "policy", "policy", "setting value to module secure config", hash, useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	err := c.ShouldBindJSON(&payload)
 	switch {
@@ -1651,7 +1807,9 @@ func (s *ModuleService) GetPolicyModuleSecureConfigValue(c *gin.Context) {
 
 	actionCode := fmt.Sprintf("retrieving value in module secure config, key: %s", paramName)
 	uaf := useraction.NewFields(c, "policy", "policy", actionCode, hash, useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	serviceHash := c.GetString("svc")
 	if serviceHash == "" {
@@ -1776,7 +1934,11 @@ func (s *ModuleService) GetModules(c *gin.Context) {
 
 	tid := c.GetUint64("tid")
 
-	query.Init("modules", modulesSQLMappers)
+	if err := query.Init("modules", modulesSQLMappers); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding query")
+		response.Error(c, response.ErrModulesInvalidRequest, err)
+		return
+	}
 
 	setUsingTables := func(sfield string) {
 		if sfield == "version" {
@@ -1787,6 +1949,9 @@ func (s *ModuleService) GetModules(c *gin.Context) {
 	for _, filter := range query.Filters {
 		setUsingTables(filter.Field)
 	}
+	for _, field := range query.ExpressionFields() {
+		setUsingTables(field)
+	}
 	query.SetFilters([]func(db *gorm.DB) *gorm.DB{
 		func(db *gorm.DB) *gorm.DB {
 			return db.Where("tenant_id IN (0, ?) AND service_type = ?", tid, sv.Type)
@@ -1854,7 +2019,9 @@ func (s *ModuleService) CreateModule(c *gin.Context) {
 	)
 
 	uaf := useraction.NewFields(c, "module", "module", "creation", "", useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	if sv = getService(c); sv == nil {
 		response.Error(c, response.ErrInternalServiceNotFound, nil)
@@ -1950,7 +2117,9 @@ func (s *ModuleService) DeleteModule(c *gin.Context) {
 	)
 
 	uaf := useraction.NewFields(c, "module", "module", "deletion", moduleName, useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	if sv = getService(c); sv == nil {
 		response.Error(c, response.ErrInternalServiceNotFound, nil)
@@ -2076,7 +2245,11 @@ func (s *ModuleService) GetModuleVersions(c *gin.Context) {
 
 	tid := c.GetUint64("tid")
 
-	query.Init("modules", modulesSQLMappers)
+	if err := query.Init("modules", modulesSQLMappers); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding query")
+		response.Error(c, response.ErrModulesInvalidRequest, err)
+		return
+	}
 	query.SetFilters([]func(db *gorm.DB) *gorm.DB{
 		func(db *gorm.DB) *gorm.DB {
 			return db.Where("name = ? AND tenant_id IN (0, ?) AND service_type = ?", moduleName, tid, sv.Type)
@@ -2166,6 +2339,71 @@ func (s *ModuleService) GetModuleVersion(c *gin.Context) {
 	response.Success(c, http.StatusOK, module)
 }
 
+// GetModuleVersionsDiff is a function to return difference between two versions of system module
+// @Summary Retrieve difference of config, schemas, locales and files between two versions of system module
+// @Tags Modules
+// @Produce json
+// @Param module_name path string true "module name without spaces"
+// @Param version path string true "source module version string according semantic version format"
+// @Param diff_version path string true "target module version string according semantic version format" default(latest)
+// @Success 200 {object} response.successResp{data=modules.VersionsDiff} "system module versions diff received successful"
+// @Failure 403 {object} response.errorResp "getting system module data not permitted"
+// @Failure 404 {object} response.errorResp "system module version not found"
+// @Failure 500 {object} response.errorResp "internal error on comparing system module versions"
+// @Router /modules/{module_name}/versions/{version}/diff/{diff_version} [get]
+func (s *ModuleService) GetModuleVersionsDiff(c *gin.Context) {
+	var (
+		moduleName = c.Param("module_name")
+		sv         *models.Service
+		versions   = []string{c.Param("version"), c.Param("diff_version")}
+		modulesS   = make([]models.ModuleS, len(versions))
+		checksums  = make([]models.FilesChecksumsMap, len(versions))
+	)
+
+	if sv = getService(c); sv == nil {
+		response.Error(c, response.ErrInternalServiceNotFound, nil)
+		return
+	}
+
+	tid := c.GetUint64("tid")
+	scope := func(db *gorm.DB) *gorm.DB {
+		return db.Where("name = ? AND tenant_id = ? AND service_type = ?", moduleName, tid, sv.Type)
+	}
+
+	for idx, version := range versions {
+		module := &modulesS[idx]
+		if err := s.db.Scopes(modules.FilterModulesByVersion(version), scope).Take(module).Error; err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error finding system module by name and version '%s'", version)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				response.Error(c, response.ErrModulesNotFound, err)
+			} else {
+				response.Error(c, response.ErrInternal, err)
+			}
+			return
+		} else if err = module.Valid(); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error validating system module data '%s'", module.Info.Name)
+			response.Error(c, response.ErrModulesInvalidSystemModuleData, err)
+			return
+		}
+
+		var err error
+		if checksums[idx], err = modules.GetModuleSFilesChecksums(&module.Info); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error reading system module files '%s'", version)
+			response.Error(c, response.ErrGetModuleVersionsDiffFilesNotFound, err)
+			return
+		}
+	}
+
+	diff, err := modules.DiffModulesVersions(&modulesS[0], &modulesS[1], checksums[0], checksums[1])
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error comparing system module versions")
+		response.Error(c, response.ErrModulesFailedToCompareChanges, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, diff)
+}
+
 // PatchModuleVersion is a function to update system module by name and version
 // @Summary Update the version of system module to global DB and global S3 storage
 // @Tags Modules
@@ -2193,7 +2431,9 @@ func (s *ModuleService) PatchModuleVersion(c *gin.Context) {
 	)
 
 	uaf := useraction.NewFields(c, "module", "module", "undefined action", moduleName, useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	tid := c.GetUint64("tid")
 	if sv = getService(c); sv == nil {
@@ -2345,7 +2585,9 @@ func (s *ModuleService) CreateModuleVersion(c *gin.Context) {
 	)
 
 	uaf := useraction.NewFields(c, "module", "module", "creation of the draft", moduleName, useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	tid := c.GetUint64("tid")
 	if sv = getService(c); sv == nil {
@@ -2491,7 +2733,9 @@ func (s *ModuleService) DeleteModuleVersion(c *gin.Context) {
 	)
 
 	uaf := useraction.NewFields(c, "module", "module", "deletion of the version", moduleName, useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	if sv = getService(c); sv == nil {
 		response.Error(c, response.ErrInternalServiceNotFound, nil)
@@ -2665,7 +2909,9 @@ func (s *ModuleService) CreateModuleVersionUpdates(c *gin.Context) {
 	)
 
 	uaf := useraction.NewFields(c, "module", "module", "version update in policies", moduleName, useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	if svc = getService(c); svc == nil {
 		response.Error(c, response.ErrInternalServiceNotFound, nil)
@@ -2865,7 +3111,9 @@ func (s *ModuleService) PatchModuleVersionFile(c *gin.Context) {
 	)
 
 	uaf := useraction.NewFields(c, "module", "module", "module editing", moduleName, useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	if sv = getService(c); sv == nil {
 		response.Error(c, response.ErrInternalServiceNotFound, nil)
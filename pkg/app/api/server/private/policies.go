@@ -270,6 +270,9 @@ func (s *PolicyService) GetPolicies(c *gin.Context) {
 	for _, filter := range query.Filters {
 		setUsingTables(filter.Field)
 	}
+	for _, field := range query.ExpressionFields() {
+		setUsingTables(field)
+	}
 	query.SetFilters([]func(db *gorm.DB) *gorm.DB{
 		func(db *gorm.DB) *gorm.DB {
 			return db.Where("policies.deleted_at IS NULL")
@@ -539,7 +542,9 @@ func (s *PolicyService) PatchPolicy(c *gin.Context) {
 		ObjectID:          hash,
 		ObjectDisplayName: useraction.UnknownObjectDisplayName,
 	}
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	serviceHash := c.GetString("svc")
 	if serviceHash == "" {
@@ -623,7 +628,9 @@ func (s *PolicyService) PatchPolicyGroup(c *gin.Context) {
 		ActionCode:        "undefined action",
 		ObjectDisplayName: useraction.UnknownObjectDisplayName,
 	}
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	serviceHash := c.GetString("svc")
 	if serviceHash == "" {
@@ -713,7 +720,9 @@ func (s *PolicyService) CreatePolicy(c *gin.Context) {
 		ActionCode:        "creation",
 		ObjectDisplayName: useraction.UnknownObjectDisplayName,
 	}
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	if err := c.ShouldBindJSON(&info); err != nil {
 		logger.FromContext(c).WithError(err).Errorf("error binding JSON")
@@ -830,7 +839,9 @@ func (s *PolicyService) DeletePolicy(c *gin.Context) {
 		ObjectID:          hash,
 		ObjectDisplayName: useraction.UnknownObjectDisplayName,
 	}
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	serviceHash := c.GetString("svc")
 	if serviceHash == "" {
@@ -924,7 +935,9 @@ func (s *PolicyService) GetPoliciesCount(c *gin.Context) {
 		ActionCode:        "counting",
 		ObjectDisplayName: useraction.UnknownObjectDisplayName,
 	}
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	serviceHash := c.GetString("svc")
 	if serviceHash == "" {
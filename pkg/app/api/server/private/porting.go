@@ -4,11 +4,13 @@ import (
 	"archive/zip"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -23,22 +25,8 @@ import (
 	"soldr/pkg/app/api/utils"
 )
 
-func getModuleTemplates(zipArchive *multipart.FileHeader, moduleName, version string) ([]modules.Template, error) {
-	templates := make(map[string]modules.Template)
-	putFile := func(ver, tpath, fpath string, fdata []byte) {
-		template, ok := templates[ver]
-		if !ok {
-			template = make(modules.Template)
-			templates[ver] = template
-		}
-		tcont, ok := template[tpath]
-		if !ok {
-			tcont = make(map[string][]byte)
-			template[tpath] = tcont
-		}
-		tcont[fpath] = fdata
-	}
-
+// readModuleArchive returns all files of the zip archive by full path except of directories and service files
+func readModuleArchive(zipArchive *multipart.FileHeader) (map[string][]byte, error) {
 	zipBuffer, err := zipArchive.Open()
 	if err != nil {
 		return nil, err
@@ -65,9 +53,41 @@ func getModuleTemplates(zipArchive *multipart.FileHeader, moduleName, version st
 		return ioutil.ReadAll(f)
 	}
 	exclNames := []string{"", ".DS_Store"}
+	files := make(map[string][]byte, len(zipReader.File))
 	for _, zipFile := range zipReader.File {
 		ppath := strings.Split(zipFile.Name, "/")
-		if len(ppath) < 4 || utils.StringInSlice(ppath[len(ppath)-1], exclNames) {
+		if utils.StringInSlice(ppath[len(ppath)-1], exclNames) {
+			continue
+		}
+		fileBytes, err := readZipFile(zipFile)
+		if err != nil {
+			return nil, err
+		}
+		files[zipFile.Name] = fileBytes
+	}
+
+	return files, nil
+}
+
+func getModuleTemplates(files map[string][]byte, moduleName, version string) []modules.Template {
+	templates := make(map[string]modules.Template)
+	putFile := func(ver, tpath, fpath string, fdata []byte) {
+		template, ok := templates[ver]
+		if !ok {
+			template = make(modules.Template)
+			templates[ver] = template
+		}
+		tcont, ok := template[tpath]
+		if !ok {
+			tcont = make(map[string][]byte)
+			template[tpath] = tcont
+		}
+		tcont[fpath] = fdata
+	}
+
+	for name, fileBytes := range files {
+		ppath := strings.Split(name, "/")
+		if len(ppath) < 4 {
 			continue
 		}
 		if ppath[0] != moduleName {
@@ -76,10 +96,6 @@ func getModuleTemplates(zipArchive *multipart.FileHeader, moduleName, version st
 		if ppath[1] != version && version != "all" {
 			continue
 		}
-		fileBytes, err := readZipFile(zipFile)
-		if err != nil {
-			return nil, err
-		}
 
 		putFile(ppath[1], ppath[2], strings.Join(ppath[3:], "/"), fileBytes)
 	}
@@ -88,24 +104,85 @@ func getModuleTemplates(zipArchive *multipart.FileHeader, moduleName, version st
 	for _, template := range templates {
 		result = append(result, template)
 	}
-	return result, nil
+	return result
+}
+
+// importResult is a result of signature check of imported system module archive
+type importResult struct {
+	Signature string                   `json:"signature" enums:"valid,unsigned,invalid,skipped"`
+	Publisher *models.TrustedPublisher `json:"publisher,omitempty"`
+	Warning   string                   `json:"warning,omitempty"`
 }
 
 type PortingService struct {
 	db               *gorm.DB
 	userActionWriter useraction.Writer
+	signer           *modules.PackageSigner
+	importPolicy     string
 }
 
+// NewPortingService returns porting service which signs exported archives by the signer if it's set
+// and checks signatures of imported archives according to the import policy (require, warn or allow)
 func NewPortingService(
 	db *gorm.DB,
 	userActionWriter useraction.Writer,
+	signer *modules.PackageSigner,
+	importPolicy string,
 ) *PortingService {
 	return &PortingService{
 		db:               db,
 		userActionWriter: userActionWriter,
+		signer:           signer,
+		importPolicy:     importPolicy,
 	}
 }
 
+// verifyModuleArchive is a function to check signature of system module archive by trusted publishers
+// of the tenant, unsigned and invalid archives are rejected by the require policy only
+func (s *PortingService) verifyModuleArchive(
+	c *gin.Context,
+	files map[string][]byte,
+	moduleName string,
+) (*importResult, *response.HttpError, error) {
+	var (
+		publisher models.TrustedPublisher
+		result    importResult
+	)
+
+	if s.importPolicy == modules.ImportPolicyAllow {
+		result.Signature = modules.SignatureStatusSkipped
+		return &result, nil, nil
+	}
+
+	getKey := getTrustedPublisherKey(s.db, c.GetUint64("tid"), &publisher)
+	_, err := modules.VerifyPackage(moduleName, files, getKey)
+	switch {
+	case err == nil:
+		result.Signature = modules.SignatureStatusValid
+		result.Publisher = &publisher
+		return &result, nil, nil
+	case errors.Is(err, modules.ErrPackageUnsigned):
+		result.Signature = modules.SignatureStatusUnsigned
+		if s.importPolicy == modules.ImportPolicyRequire {
+			return nil, response.ErrImportSignatureRequired, err
+		}
+	case errors.Is(err, modules.ErrPackageUntrustedKey):
+		result.Signature = modules.SignatureStatusInvalid
+		if s.importPolicy == modules.ImportPolicyRequire {
+			return nil, response.ErrImportUntrustedPublisher, err
+		}
+	default:
+		result.Signature = modules.SignatureStatusInvalid
+		if s.importPolicy == modules.ImportPolicyRequire {
+			return nil, response.ErrImportInvalidSignature, err
+		}
+	}
+
+	result.Warning = err.Error()
+	logger.FromContext(c).WithError(err).Warnf("system module archive '%s' is imported without valid signature", moduleName)
+	return &result, nil, nil
+}
+
 // ExportModule is a function to export system module as a zip archive
 // @Summary Export of zip archive which contains selected system module and versions
 // @Tags Modules,Export
@@ -132,7 +209,9 @@ func (s *PortingService) ExportModule(c *gin.Context) {
 		ObjectID:          moduleName,
 		ObjectDisplayName: useraction.UnknownObjectDisplayName,
 	}
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	if sv = getService(c); sv == nil {
 		response.Error(c, response.ErrInternalServiceNotFound, nil)
@@ -164,10 +243,7 @@ func (s *PortingService) ExportModule(c *gin.Context) {
 	}
 	uaf.ObjectDisplayName = moduleList[len(moduleList)-1].Locale.Module["en"].Title
 
-	zipBuffer := new(bytes.Buffer)
-	zipWriter := zip.NewWriter(zipBuffer)
-	defer zipWriter.Close()
-
+	files := make(map[string][]byte)
 	for _, module := range moduleList {
 		prefix := moduleName + "/" + module.Info.Version.String()
 		template, err := modules.LoadModuleSFromGlobalS3(&module.Info)
@@ -186,21 +262,46 @@ func (s *PortingService) ExportModule(c *gin.Context) {
 
 		for folderName, folderContent := range template {
 			for fileName, fileContent := range folderContent {
-				zipFile, err := zipWriter.Create(prefix + "/" + folderName + "/" + fileName)
-				if err != nil {
-					logger.FromContext(c).WithError(err).Errorf("error adding new system module file to zip")
-					response.Error(c, response.ErrExportAddFileFail, err)
-					return
-				}
-
-				if _, err = zipFile.Write(fileContent); err != nil {
-					logger.FromContext(c).WithError(err).Errorf("error writing system module file to zip")
-					response.Error(c, response.ErrExportWriteFileFail, err)
-					return
-				}
+				files[prefix+"/"+folderName+"/"+fileName] = fileContent
 			}
 		}
 	}
+
+	if s.signer != nil {
+		manifest, signature, err := s.signer.Sign(moduleName, files)
+		if err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error signing system module archive")
+			response.Error(c, response.ErrExportSignArchiveFail, err)
+			return
+		}
+		files[modules.ManifestPath(moduleName)] = manifest
+		files[modules.SignaturePath(moduleName)] = signature
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	zipBuffer := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(zipBuffer)
+	defer zipWriter.Close()
+
+	for _, path := range paths {
+		zipFile, err := zipWriter.Create(path)
+		if err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error adding new system module file to zip")
+			response.Error(c, response.ErrExportAddFileFail, err)
+			return
+		}
+
+		if _, err = zipFile.Write(files[path]); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error writing system module file to zip")
+			response.Error(c, response.ErrExportWriteFileFail, err)
+			return
+		}
+	}
 	if err = zipWriter.Close(); err != nil {
 		logger.FromContext(c).WithError(err).Errorf("error closing system module archive")
 		response.Error(c, response.ErrExportCloseArchiveFail, err)
@@ -224,9 +325,9 @@ func (s *PortingService) ExportModule(c *gin.Context) {
 // @Param version path string true "module version string according semantic version format or 'all'" default(all)
 // @Param rewrite query boolean true "override system module files and records in global DB" default(true)
 // @Param archive formData file true "system module archive file"
-// @Success 200 {object} response.successResp "system module archive uploaded successful"
-// @Failure 400 {object} response.errorResp "bad format input system module archive"
-// @Failure 403 {object} response.errorResp "importing system module content not permitted"
+// @Success 200 {object} response.successResp{data=importResult} "system module archive uploaded successful"
+// @Failure 400 {object} response.errorResp "bad format input system module archive or invalid signature"
+// @Failure 403 {object} response.errorResp "importing system module content not permitted or archive publisher is untrusted"
 // @Failure 404 {object} response.errorResp "system module or version in archive not found"
 // @Failure 500 {object} response.errorResp "internal error on importing system module"
 // @Router /import/modules/{module_name}/versions/{version} [post]
@@ -248,7 +349,9 @@ func (s *PortingService) ImportModule(c *gin.Context) {
 		ObjectID:          moduleName,
 		ObjectDisplayName: useraction.UnknownObjectDisplayName,
 	}
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	if sv = getService(c); sv == nil {
 		response.Error(c, response.ErrInternalServiceNotFound, nil)
@@ -281,12 +384,21 @@ func (s *PortingService) ImportModule(c *gin.Context) {
 		return
 	}
 
-	templates, err := getModuleTemplates(zipArchive, moduleName, version)
+	files, err := readModuleArchive(zipArchive)
 	if err != nil {
 		logger.FromContext(c).WithError(err).Errorf("error parsing system module zip file")
 		response.Error(c, response.ErrImportParseArchiveFail, err)
 		return
 	}
+
+	result, httpErr, err := s.verifyModuleArchive(c, files, moduleName)
+	if httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error verifying system module archive signature")
+		response.Error(c, httpErr, err)
+		return
+	}
+
+	templates := getModuleTemplates(files, moduleName, version)
 	if len(templates) == 0 {
 		logger.FromContext(c).Errorf("system module by name and version not found: %s : %s", moduleName, version)
 		response.Error(c, response.ErrPortingModuleNotFound, nil)
@@ -345,5 +457,5 @@ func (s *PortingService) ImportModule(c *gin.Context) {
 		}
 	}
 
-	response.Success(c, http.StatusOK, struct{}{})
+	response.Success(c, http.StatusOK, result)
 }
@@ -11,7 +11,6 @@ import (
 	"soldr/pkg/app/api/logger"
 	"soldr/pkg/app/api/models"
 	"soldr/pkg/app/api/server/response"
-	"soldr/pkg/app/api/storage"
 )
 
 func makeTokenClaims(c *gin.Context, cpt string) (*models.ProtoAuthTokenClaims, error) {
@@ -44,7 +43,7 @@ func makeTokenClaims(c *gin.Context, cpt string) (*models.ProtoAuthTokenClaims,
 	}, nil
 }
 
-func MakeToken(c *gin.Context, req *models.ProtoAuthTokenRequest) (string, error) {
+func MakeToken(c *gin.Context, req *models.ProtoAuthTokenRequest, key []byte) (string, error) {
 	claims, err := makeTokenClaims(c, req.Type)
 	if err != nil {
 		return "", fmt.Errorf("failed to get token claims: %w", err)
@@ -58,17 +57,20 @@ func MakeToken(c *gin.Context, req *models.ProtoAuthTokenRequest) (string, error
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(storage.MakeCookieStoreKey())
+	tokenString, err := token.SignedString(key)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
 	return tokenString, nil
 }
 
-func ValidateToken(tokenString string) (*models.ProtoAuthTokenClaims, error) {
+func ValidateToken(tokenString string, key []byte) (*models.ProtoAuthTokenClaims, error) {
 	var claims models.ProtoAuthTokenClaims
 	token, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
-		return storage.MakeCookieStoreKey(), nil
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method '%v'", token.Header["alg"])
+		}
+		return key, nil
 	})
 
 	if token != nil && token.Valid {
@@ -85,6 +87,16 @@ func ValidateToken(tokenString string) (*models.ProtoAuthTokenClaims, error) {
 	return nil, fmt.Errorf("received data is not a token: %w", err)
 }
 
+type TokenService struct {
+	protoTokenKey []byte
+}
+
+func NewTokenService(protoTokenKey []byte) *TokenService {
+	return &TokenService{
+		protoTokenKey: protoTokenKey,
+	}
+}
+
 // CreateAuthToken is a function to create new JWT token to authorize proto requests
 // @Summary Create new JWT token to use it into vxproto connections
 // @Tags Proto
@@ -96,7 +108,7 @@ func ValidateToken(tokenString string) (*models.ProtoAuthTokenClaims, error) {
 // @Failure 403 {object} response.errorResp "creating token not permitted"
 // @Failure 500 {object} response.errorResp "internal error on creating token"
 // @Router /token/vxproto [post]
-func CreateAuthToken(c *gin.Context) {
+func (s *TokenService) CreateAuthToken(c *gin.Context) {
 	var req models.ProtoAuthTokenRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.FromContext(c).WithError(err).Errorf("error binding JSON")
@@ -108,13 +120,13 @@ func CreateAuthToken(c *gin.Context) {
 		return
 	}
 
-	token, err := MakeToken(c, &req)
+	token, err := MakeToken(c, &req, s.protoTokenKey)
 	if err != nil {
 		logger.FromContext(c).WithError(err).Errorf("error on making token")
 		response.Error(c, response.ErrProtoCreateTokenFail, err)
 		return
 	}
-	if _, err := ValidateToken(token); err != nil {
+	if _, err := ValidateToken(token, s.protoTokenKey); err != nil {
 		logger.FromContext(c).WithError(err).Errorf("error on validating token")
 		response.Error(c, response.ErrProtoInvalidToken, err)
 		return
@@ -0,0 +1,297 @@
+package private
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+
+	"soldr/pkg/app/api/logger"
+	"soldr/pkg/app/api/models"
+	"soldr/pkg/app/api/modules"
+	"soldr/pkg/app/api/server/response"
+	"soldr/pkg/app/api/storage"
+)
+
+type publishers struct {
+	Publishers []models.TrustedPublisher `json:"publishers"`
+	Total      uint64                    `json:"total"`
+}
+
+var publishersSQLMappers = map[string]interface{}{
+	"id":           "`{{table}}`.id",
+	"name":         "`{{table}}`.name",
+	"key_id":       "`{{table}}`.key_id",
+	"status":       "`{{table}}`.status",
+	"created_date": "`{{table}}`.created_date",
+	"data": "CONCAT(`{{table}}`.name, ' | ', " +
+		"`{{table}}`.key_id)",
+}
+
+func getPublisherID(c *gin.Context) (uint64, error) {
+	return strconv.ParseUint(c.Param("id"), 10, 64)
+}
+
+// getTrustedPublisherKey returns callback to find active publisher key of the tenant to verify module archive
+func getTrustedPublisherKey(db *gorm.DB, tid uint64, publisher *models.TrustedPublisher) func(string) (ed25519.PublicKey, error) {
+	return func(keyID string) (ed25519.PublicKey, error) {
+		err := db.Take(publisher, "tenant_id = ? AND key_id = ? AND status = 'active'", tid, keyID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("active trusted publisher not found")
+		} else if err != nil {
+			return nil, err
+		}
+		return modules.ParsePublicKey(publisher.PublicKey)
+	}
+}
+
+type PublisherService struct {
+	db *gorm.DB
+}
+
+func NewPublisherService(db *gorm.DB) *PublisherService {
+	return &PublisherService{
+		db: db,
+	}
+}
+
+// getPublisher returns the trusted publisher by ID from the path into the tenant of current user
+func (s *PublisherService) getPublisher(c *gin.Context) (*models.TrustedPublisher, *response.HttpError, error) {
+	var publisher models.TrustedPublisher
+
+	id, err := getPublisherID(c)
+	if err != nil {
+		return nil, response.ErrPublishersInvalidRequest, err
+	}
+
+	err = s.db.Take(&publisher, "id = ? AND tenant_id = ?", id, c.GetUint64("tid")).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, response.ErrPublishersNotFound, err
+	} else if err != nil {
+		return nil, response.ErrInternal, err
+	} else if err = publisher.Valid(); err != nil {
+		return nil, response.ErrPublishersInvalidData, err
+	}
+
+	return &publisher, nil, nil
+}
+
+// GetPublishers is a function to return trusted publishers list
+// @Summary Retrieve trusted publishers list whose signed module archives are accepted on import
+// @Tags Publishers
+// @Produce json
+// @Param request query storage.TableQuery true "query table params"
+// @Success 200 {object} response.successResp{data=publishers} "trusted publishers list received successful"
+// @Failure 400 {object} response.errorResp "invalid query request data"
+// @Failure 403 {object} response.errorResp "getting trusted publishers not permitted"
+// @Failure 500 {object} response.errorResp "internal error on getting trusted publishers"
+// @Router /publishers/ [get]
+func (s *PublisherService) GetPublishers(c *gin.Context) {
+	var (
+		err   error
+		query storage.TableQuery
+		resp  publishers
+	)
+
+	if err = c.ShouldBindQuery(&query); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding query")
+		response.Error(c, response.ErrPublishersInvalidRequest, err)
+		return
+	}
+
+	if err = query.Init("trusted_publishers", publishersSQLMappers); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error initializing query")
+		response.Error(c, response.ErrPublishersInvalidRequest, err)
+		return
+	}
+
+	tid := c.GetUint64("tid")
+	query.SetFilters([]func(db *gorm.DB) *gorm.DB{
+		func(db *gorm.DB) *gorm.DB {
+			return db.Where("tenant_id = ?", tid)
+		},
+	})
+
+	if resp.Total, err = query.Query(s.db, &resp.Publishers); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding trusted publishers")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	for i := 0; i < len(resp.Publishers); i++ {
+		if err = resp.Publishers[i].Valid(); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error validating trusted publisher data '%d'", resp.Publishers[i].ID)
+			response.Error(c, response.ErrPublishersInvalidData, err)
+			return
+		}
+	}
+
+	response.Success(c, http.StatusOK, resp)
+}
+
+// GetPublisher is a function to return trusted publisher by id
+// @Summary Retrieve trusted publisher by id
+// @Tags Publishers
+// @Produce json
+// @Param id path int true "trusted publisher id" minimum(0)
+// @Success 200 {object} response.successResp{data=models.TrustedPublisher} "trusted publisher received successful"
+// @Failure 400 {object} response.errorResp "invalid trusted publisher request data"
+// @Failure 403 {object} response.errorResp "getting trusted publisher not permitted"
+// @Failure 404 {object} response.errorResp "trusted publisher not found"
+// @Failure 500 {object} response.errorResp "internal error on getting trusted publisher"
+// @Router /publishers/{id} [get]
+func (s *PublisherService) GetPublisher(c *gin.Context) {
+	publisher, httpErr, err := s.getPublisher(c)
+	if httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding trusted publisher by id")
+		response.Error(c, httpErr, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, publisher)
+}
+
+// CreatePublisher is a function to create new trusted publisher
+// @Summary Create new trusted publisher by Ed25519 public key which is encoded by base64
+// @Tags Publishers
+// @Accept json
+// @Produce json
+// @Param json body models.TrustedPublisherCreate true "trusted publisher model to create from"
+// @Success 201 {object} response.successResp{data=models.TrustedPublisher} "trusted publisher created successful"
+// @Failure 400 {object} response.errorResp "invalid trusted publisher request data"
+// @Failure 403 {object} response.errorResp "creating trusted publisher not permitted"
+// @Failure 500 {object} response.errorResp "internal error on creating trusted publisher"
+// @Router /publishers/ [post]
+func (s *PublisherService) CreatePublisher(c *gin.Context) {
+	var (
+		count int
+		err   error
+		form  models.TrustedPublisherCreate
+		key   ed25519.PublicKey
+		tid   = c.GetUint64("tid")
+	)
+
+	if err = c.ShouldBindJSON(&form); err != nil || form.Valid() != nil {
+		if err == nil {
+			err = form.Valid()
+		}
+		logger.FromContext(c).WithError(err).Errorf("error binding JSON")
+		response.Error(c, response.ErrPublishersInvalidRequest, err)
+		return
+	}
+
+	if key, err = modules.ParsePublicKey(form.PublicKey); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error parsing trusted publisher key")
+		response.Error(c, response.ErrPublishersInvalidRequest, err)
+		return
+	}
+
+	publisher := models.TrustedPublisher{
+		TenantID:    tid,
+		Name:        form.Name,
+		KeyID:       modules.GetPublicKeyID(key),
+		PublicKey:   form.PublicKey,
+		Status:      "active",
+		CreatedDate: time.Now(),
+	}
+
+	err = s.db.Model(&publisher).Where("tenant_id = ? AND key_id = ?", tid, publisher.KeyID).Count(&count).Error
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error checking trusted publisher key")
+		response.Error(c, response.ErrInternal, err)
+		return
+	} else if count != 0 {
+		err = fmt.Errorf("trusted publisher key '%s' already exists", publisher.KeyID)
+		logger.FromContext(c).WithError(err).Errorf("error creating trusted publisher")
+		response.Error(c, response.ErrPublishersKeyExists, err)
+		return
+	}
+
+	if err = s.db.Create(&publisher).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error creating trusted publisher")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	logger.FromContext(c).Infof("trusted publisher '%s' was added with key '%s'", publisher.Name, publisher.KeyID)
+
+	response.Success(c, http.StatusCreated, publisher)
+}
+
+// PatchPublisher is a function to update trusted publisher by id
+// @Summary Update name of trusted publisher or revoke its key
+// @Tags Publishers
+// @Accept json
+// @Produce json
+// @Param json body models.TrustedPublisherPatch true "trusted publisher model to update"
+// @Param id path int true "trusted publisher id" minimum(0)
+// @Success 200 {object} response.successResp{data=models.TrustedPublisher} "trusted publisher updated successful"
+// @Failure 400 {object} response.errorResp "invalid trusted publisher request data"
+// @Failure 403 {object} response.errorResp "updating trusted publisher not permitted"
+// @Failure 404 {object} response.errorResp "trusted publisher not found"
+// @Failure 500 {object} response.errorResp "internal error on updating trusted publisher"
+// @Router /publishers/{id} [put]
+func (s *PublisherService) PatchPublisher(c *gin.Context) {
+	var (
+		err  error
+		form models.TrustedPublisherPatch
+	)
+
+	if err = c.ShouldBindJSON(&form); err != nil || form.Valid() != nil {
+		if err == nil {
+			err = form.Valid()
+		}
+		logger.FromContext(c).WithError(err).Errorf("error binding JSON")
+		response.Error(c, response.ErrPublishersInvalidRequest, err)
+		return
+	}
+
+	publisher, httpErr, err := s.getPublisher(c)
+	if httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding trusted publisher by id")
+		response.Error(c, httpErr, err)
+		return
+	}
+
+	publisher.Name = form.Name
+	publisher.Status = form.Status
+	if err = s.db.Save(publisher).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error updating trusted publisher")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, publisher)
+}
+
+// DeletePublisher is a function to delete trusted publisher by id
+// @Summary Delete trusted publisher, module archives signed by its key won't be trusted anymore
+// @Tags Publishers
+// @Produce json
+// @Param id path int true "trusted publisher id" minimum(0)
+// @Success 200 {object} response.successResp "trusted publisher deleted successful"
+// @Failure 403 {object} response.errorResp "deleting trusted publisher not permitted"
+// @Failure 404 {object} response.errorResp "trusted publisher not found"
+// @Failure 500 {object} response.errorResp "internal error on deleting trusted publisher"
+// @Router /publishers/{id} [delete]
+func (s *PublisherService) DeletePublisher(c *gin.Context) {
+	publisher, httpErr, err := s.getPublisher(c)
+	if httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding trusted publisher by id")
+		response.Error(c, httpErr, err)
+		return
+	}
+
+	if err = s.db.Delete(publisher).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error deleting trusted publisher")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, struct{}{})
+}
@@ -1,7 +1,10 @@
 package private
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jinzhu/gorm"
@@ -23,6 +26,28 @@ var rolesSQLMappers = map[string]interface{}{
 	"data": "`{{table}}`.name",
 }
 
+// checkGrantedPrivileges returns an error if some of the privileges are not granted to the current session
+func checkGrantedPrivileges(c *gin.Context, privs []string) error {
+	prms := c.GetStringSlice("prm")
+	for _, priv := range privs {
+		granted := false
+		for _, prm := range prms {
+			if priv == prm {
+				granted = true
+				break
+			}
+		}
+		if !granted {
+			return fmt.Errorf("privilege '%s' is not granted to the current user", priv)
+		}
+	}
+	return nil
+}
+
+func getRoleID(c *gin.Context) (uint64, error) {
+	return strconv.ParseUint(c.Param("id"), 10, 64)
+}
+
 type RoleService struct {
 	db *gorm.DB
 }
@@ -74,3 +99,322 @@ func (s *RoleService) GetRoles(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, resp)
 }
+
+// GetRole is a function to return role by id with granted privileges
+// @Summary Retrieve role by id with granted privileges
+// @Tags Roles
+// @Produce json
+// @Param id path integer true "role id" minimum(0)
+// @Success 200 {object} response.successResp{data=models.RolePrivileges} "role received successful"
+// @Failure 400 {object} response.errorResp "invalid role id"
+// @Failure 403 {object} response.errorResp "getting role not permitted"
+// @Failure 404 {object} response.errorResp "role not found"
+// @Failure 500 {object} response.errorResp "internal error on getting role"
+// @Router /roles/{id} [get]
+func (s *RoleService) GetRole(c *gin.Context) {
+	var (
+		err  error
+		id   uint64
+		resp models.RolePrivileges
+	)
+
+	if id, err = getRoleID(c); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error parsing role id")
+		response.Error(c, response.ErrRolesInvalidRequest, err)
+		return
+	}
+
+	if err = s.db.Take(&resp.Role, "id = ?", id).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding role by id '%d'", id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, response.ErrRolesNotFound, err)
+		} else {
+			response.Error(c, response.ErrInternal, err)
+		}
+		return
+	}
+
+	if resp.Privileges, err = s.getPrivileges(id); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error getting role privileges list '%d'", id)
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	if err = resp.Valid(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error validating role data '%d'", id)
+		response.Error(c, response.ErrRolesInvalidData, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp)
+}
+
+// CreateRole is a function to create new custom role with privileges
+// @Summary Create new custom role with privileges
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param json body models.RolePrivileges true "role model to create from"
+// @Success 201 {object} response.successResp{data=models.RolePrivileges} "role created successful"
+// @Failure 400 {object} response.errorResp "invalid role request data"
+// @Failure 403 {object} response.errorResp "creating role not permitted"
+// @Failure 500 {object} response.errorResp "internal error on creating role"
+// @Router /roles/ [post]
+func (s *RoleService) CreateRole(c *gin.Context) {
+	var (
+		err  error
+		role models.RolePrivileges
+	)
+
+	if err = c.ShouldBindJSON(&role); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding JSON")
+		response.Error(c, response.ErrRolesInvalidRequest, err)
+		return
+	}
+
+	role.ID = 0
+	if role.Privileges == nil {
+		role.Privileges = models.PrivilegesList{}
+	}
+	if err = role.Valid(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error validating role")
+		response.Error(c, response.ErrRolesInvalidRequest, err)
+		return
+	}
+
+	if err = checkGrantedPrivileges(c, role.Privileges); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error checking role privileges")
+		response.Error(c, response.ErrRolesInvalidPrivileges, err)
+		return
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&role.Role).Error; err != nil {
+			return err
+		}
+		return setPrivileges(tx, role.ID, role.Privileges)
+	})
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error creating role")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, role)
+}
+
+// PatchRole is a function to update custom role name by id
+// @Summary Update custom role name
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param json body models.Role true "role model to update"
+// @Param id path integer true "role id" minimum(0)
+// @Success 200 {object} response.successResp{data=models.Role} "role updated successful"
+// @Failure 400 {object} response.errorResp "invalid role request data"
+// @Failure 403 {object} response.errorResp "updating role not permitted"
+// @Failure 404 {object} response.errorResp "role not found"
+// @Failure 500 {object} response.errorResp "internal error on updating role"
+// @Router /roles/{id} [put]
+func (s *RoleService) PatchRole(c *gin.Context) {
+	var (
+		err  error
+		id   uint64
+		role models.Role
+	)
+
+	if id, err = getRoleID(c); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error parsing role id")
+		response.Error(c, response.ErrRolesInvalidRequest, err)
+		return
+	}
+
+	if err = c.ShouldBindJSON(&role); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding JSON")
+		response.Error(c, response.ErrRolesInvalidRequest, err)
+		return
+	}
+
+	role.ID = id
+	if err = role.Valid(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error validating role")
+		response.Error(c, response.ErrRolesInvalidRequest, err)
+		return
+	}
+
+	if httpErr, err := s.checkCustomRole(id); httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error checking role '%d'", id)
+		response.Error(c, httpErr, err)
+		return
+	}
+
+	if err = s.db.Model(&role).Update("name", role.Name).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error updating role by id '%d'", id)
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, role)
+}
+
+// PatchRolePrivileges is a function to replace privileges list of custom role
+// @Summary Assign privileges to custom role, current privileges of the role are replaced
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param json body models.RolePrivilegesPatch true "privileges list to assign"
+// @Param id path integer true "role id" minimum(0)
+// @Success 200 {object} response.successResp{data=models.RolePrivileges} "role privileges updated successful"
+// @Failure 400 {object} response.errorResp "invalid role privileges request data"
+// @Failure 403 {object} response.errorResp "updating role privileges not permitted"
+// @Failure 404 {object} response.errorResp "role not found"
+// @Failure 500 {object} response.errorResp "internal error on updating role privileges"
+// @Router /roles/{id}/privileges [put]
+func (s *RoleService) PatchRolePrivileges(c *gin.Context) {
+	var (
+		err  error
+		form models.RolePrivilegesPatch
+		id   uint64
+		resp models.RolePrivileges
+	)
+
+	if id, err = getRoleID(c); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error parsing role id")
+		response.Error(c, response.ErrRolesInvalidRequest, err)
+		return
+	}
+
+	if err = c.ShouldBindJSON(&form); err != nil || form.Valid() != nil {
+		if err == nil {
+			err = form.Valid()
+		}
+		logger.FromContext(c).WithError(err).Errorf("error binding JSON")
+		response.Error(c, response.ErrRolesInvalidRequest, err)
+		return
+	}
+
+	if err = checkGrantedPrivileges(c, form.Privileges); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error checking role privileges")
+		response.Error(c, response.ErrRolesInvalidPrivileges, err)
+		return
+	}
+
+	if httpErr, err := s.checkCustomRole(id); httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error checking role '%d'", id)
+		response.Error(c, httpErr, err)
+		return
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", id).Delete(&models.Privilege{}).Error; err != nil {
+			return err
+		}
+		return setPrivileges(tx, id, form.Privileges)
+	})
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error updating role privileges '%d'", id)
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	if err = s.db.Take(&resp.Role, "id = ?", id).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding role by id '%d'", id)
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+	if resp.Privileges, err = s.getPrivileges(id); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error getting role privileges list '%d'", id)
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp)
+}
+
+// DeleteRole is a function to delete custom role by id
+// @Summary Delete custom role by id, the role must not be assigned to users
+// @Tags Roles
+// @Produce json
+// @Param id path integer true "role id" minimum(0)
+// @Success 200 {object} response.successResp "role deleted successful"
+// @Failure 400 {object} response.errorResp "invalid role id"
+// @Failure 403 {object} response.errorResp "deleting role not permitted"
+// @Failure 404 {object} response.errorResp "role not found"
+// @Failure 500 {object} response.errorResp "internal error on deleting role"
+// @Router /roles/{id} [delete]
+func (s *RoleService) DeleteRole(c *gin.Context) {
+	var (
+		err   error
+		id    uint64
+		users uint64
+	)
+
+	if id, err = getRoleID(c); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error parsing role id")
+		response.Error(c, response.ErrRolesInvalidRequest, err)
+		return
+	}
+
+	if httpErr, err := s.checkCustomRole(id); httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error checking role '%d'", id)
+		response.Error(c, httpErr, err)
+		return
+	}
+
+	if err = s.db.Model(&models.User{}).Where("role_id = ?", id).Count(&users).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error counting role users '%d'", id)
+		response.Error(c, response.ErrInternal, err)
+		return
+	} else if users != 0 {
+		err = fmt.Errorf("role is assigned to %d users", users)
+		logger.FromContext(c).WithError(err).Errorf("error deleting role '%d'", id)
+		response.Error(c, response.ErrRolesRoleInUse, err)
+		return
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", id).Delete(&models.Privilege{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&models.Role{}).Error
+	})
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error deleting role by id '%d'", id)
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, struct{}{})
+}
+
+// checkCustomRole returns API error if the role doesn't exist or it's a built-in role
+func (s *RoleService) checkCustomRole(id uint64) (*response.HttpError, error) {
+	if models.IsBuiltinRole(id) {
+		return response.ErrRolesBuiltinRole, fmt.Errorf("role '%d' is a built-in role", id)
+	}
+	var role models.Role
+	if err := s.db.Take(&role, "id = ?", id).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return response.ErrRolesNotFound, err
+	} else if err != nil {
+		return response.ErrInternal, err
+	}
+	return nil, nil
+}
+
+func (s *RoleService) getPrivileges(id uint64) (models.PrivilegesList, error) {
+	privs := models.PrivilegesList{}
+	err := s.db.Model(&models.Privilege{}).
+		Where("role_id = ?", id).
+		Order("name ASC").
+		Pluck("name", &privs).Error
+	return privs, err
+}
+
+func setPrivileges(tx *gorm.DB, id uint64, privs models.PrivilegesList) error {
+	for _, name := range privs {
+		if err := tx.Create(&models.Privilege{RoleID: id, Name: name}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
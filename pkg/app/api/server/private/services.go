@@ -77,7 +77,7 @@ func (s *ServicesService) GetServices(c *gin.Context) {
 
 	query.Init("services", servicesSQLMappers)
 
-	rid := c.GetUint64("rid")
+	rid := models.GetRoleScope(c.GetUint64("rid"))
 	tid := c.GetUint64("tid")
 
 	switch rid {
@@ -128,7 +128,7 @@ func (s *ServicesService) GetService(c *gin.Context) {
 		resp models.Service
 	)
 
-	rid := c.GetUint64("rid")
+	rid := models.GetRoleScope(c.GetUint64("rid"))
 	tid := c.GetUint64("tid")
 	scope := func(db *gorm.DB) *gorm.DB {
 		switch rid {
@@ -182,7 +182,7 @@ func (s *ServicesService) CreateService(c *gin.Context) {
 		return
 	}
 
-	rid := c.GetUint64("rid")
+	rid := models.GetRoleScope(c.GetUint64("rid"))
 	tid := c.GetUint64("tid")
 
 	switch rid {
@@ -239,7 +239,7 @@ func (s *ServicesService) PatchService(c *gin.Context) {
 		return
 	}
 
-	rid := c.GetUint64("rid")
+	rid := models.GetRoleScope(c.GetUint64("rid"))
 	tid := c.GetUint64("tid")
 	if rid == models.RoleExternal {
 		logger.FromContext(c).Errorf("error: no rights to patch service")
@@ -290,7 +290,7 @@ func (s *ServicesService) DeleteService(c *gin.Context) {
 		service models.Service
 	)
 
-	rid := c.GetUint64("rid")
+	rid := models.GetRoleScope(c.GetUint64("rid"))
 	tid := c.GetUint64("tid")
 	if rid == models.RoleExternal {
 		logger.FromContext(c).Errorf("error: no rights to delete service")
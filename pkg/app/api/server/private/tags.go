@@ -218,6 +218,9 @@ func (s *TagService) GetTags(c *gin.Context) {
 	for _, filter := range query.Filters {
 		setUsingTables(filter.Field)
 	}
+	for _, field := range query.ExpressionFields() {
+		setUsingTables(field)
+	}
 	query.SetFilters([]func(db *gorm.DB) *gorm.DB{
 		func(db *gorm.DB) *gorm.DB {
 			return db.Where(table + ".deleted_at IS NULL")
@@ -61,7 +61,7 @@ func (s *TenantService) GetTenants(c *gin.Context) {
 
 	query.Init("tenants", tenantsSQLMappers)
 
-	rid := c.GetUint64("rid")
+	rid := models.GetRoleScope(c.GetUint64("rid"))
 	tid := c.GetUint64("tid")
 
 	switch rid {
@@ -114,7 +114,7 @@ func (s *TenantService) GetTenant(c *gin.Context) {
 		resp models.Tenant
 	)
 
-	rid := c.GetUint64("rid")
+	rid := models.GetRoleScope(c.GetUint64("rid"))
 	tid := c.GetUint64("tid")
 	scope := func(db *gorm.DB) *gorm.DB {
 		switch rid {
@@ -215,7 +215,7 @@ func (s *TenantService) PatchTenant(c *gin.Context) {
 		return
 	}
 
-	rid := c.GetUint64("rid")
+	rid := models.GetRoleScope(c.GetUint64("rid"))
 	tid := c.GetUint64("tid")
 	scope := func(db *gorm.DB) *gorm.DB {
 		switch rid {
@@ -263,7 +263,7 @@ func (s *TenantService) DeleteTenant(c *gin.Context) {
 		tenant models.Tenant
 	)
 
-	rid := c.GetUint64("rid")
+	rid := models.GetRoleScope(c.GetUint64("rid"))
 	tid := c.GetUint64("tid")
 	scope := func(db *gorm.DB) *gorm.DB {
 		switch rid {
@@ -217,7 +217,12 @@ func (s *UpgradeService) CreateAgentsUpgrades(c *gin.Context) {
 
 	query.Init("agents", agentsSQLMappers)
 	query.Filters = upgradeReq.Filters
-	agentsScope := query.DataFilter()
+	dataScope := query.DataFilter()
+	// agents are upgraded only if the binary contains files for their OS type and arch
+	platforms := binary.Info.GetPlatforms()
+	agentsScope := func(db *gorm.DB) *gorm.DB {
+		return dataScope(db).Where("CONCAT(os_type, ':', os_arch) IN (?)", platforms)
+	}
 	var agents []models.Agent
 	err = iDB.Scopes(agentsScope).Model(&models.Agent{}).
 		Where("version NOT LIKE ? AND id NOT IN (?)", upgradeReq.Version, iDB.
@@ -365,7 +370,9 @@ func (s *UpgradeService) PatchLastAgentUpgrade(c *gin.Context) {
 	)
 
 	uaf := useraction.NewFields(c, "agent", "agent", "undefined action", hash, useraction.UnknownObjectDisplayName)
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	serviceHash := c.GetString("svc")
 	if serviceHash == "" {
@@ -439,6 +446,12 @@ func (s *UpgradeService) PatchLastAgentUpgrade(c *gin.Context) {
 			response.Error(c, response.ErrInternal, err)
 			return
 		}
+		if osInfo := agent.Info.OS; !binary.Info.HasPlatform(osInfo.Type, osInfo.Arch) {
+			err = fmt.Errorf("binary '%s' doesn't contain files for '%s:%s'", binary.Version, osInfo.Type, osInfo.Arch)
+			logger.FromContext(c).WithError(err).Errorf("error checking agent binary compatibility")
+			response.Error(c, response.ErrPatchLastAgentUpgradeAgentBinaryNotFound, err)
+			return
+		}
 
 		s3Client, err := s3.New(sv.Info.S3.ToS3ConnParams())
 		if err != nil {
@@ -0,0 +1,351 @@
+package private
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+
+	"soldr/pkg/app/api/logger"
+	"soldr/pkg/app/api/models"
+	"soldr/pkg/app/api/server/response"
+	"soldr/pkg/app/api/storage"
+	"soldr/pkg/app/api/utils/mfa"
+)
+
+// mfaIssuer is an issuer name which is shown into authenticator application
+const mfaIssuer = "SOLDR"
+
+var errMFAAlreadyEnabled = errors.New("multi-factor authentication is already enabled")
+
+// GetCurrentUserMFA is a function to return multi-factor authentication state of the current user
+// @Summary Retrieve multi-factor authentication state of the current user
+// @Tags Users
+// @Produce json
+// @Success 200 {object} response.successResp{data=models.UserMFAStatus} "user MFA state received successful"
+// @Failure 403 {object} response.errorResp "getting user MFA state not permitted"
+// @Failure 500 {object} response.errorResp "internal error on getting user MFA state"
+// @Router /user/mfa [get]
+func (s *UserService) GetCurrentUserMFA(c *gin.Context) {
+	var (
+		err     error
+		resp    = models.UserMFAStatus{Status: "disabled"}
+		userMFA models.UserMFA
+	)
+
+	uid := c.GetUint64("uid")
+	if err = s.db.Take(&userMFA, "user_id = ?", uid).Error; err == nil {
+		resp.Status = userMFA.Status
+		resp.RecoveryCodesLeft = len(userMFA.RecoveryCodes)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.FromContext(c).WithError(err).Errorf("error finding MFA settings of current user")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp)
+}
+
+// EnrollCurrentUserMFA is a function to start multi-factor authentication enrollment for the current user
+// @Summary Generate new TOTP secret for the current user, the secret is returned only once
+// @Tags Users
+// @Produce json
+// @Success 201 {object} response.successResp{data=models.UserMFASecret} "user MFA secret generated successful"
+// @Failure 403 {object} response.errorResp "enrolling user MFA not permitted"
+// @Failure 404 {object} response.errorResp "current user not found"
+// @Failure 409 {object} response.errorResp "user MFA is already enabled"
+// @Failure 500 {object} response.errorResp "internal error on enrolling user MFA"
+// @Router /user/mfa [post]
+func (s *UserService) EnrollCurrentUserMFA(c *gin.Context) {
+	var (
+		err     error
+		user    models.User
+		userMFA models.UserMFA
+	)
+
+	uid := c.GetUint64("uid")
+	if err = s.db.Take(&user, "id = ?", uid).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding current user")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, response.ErrUsersNotFound, err)
+		} else {
+			response.Error(c, response.ErrInternal, err)
+		}
+		return
+	} else if user.Type != "local" {
+		logger.FromContext(c).Errorf("error enrolling MFA for non local user '%s'", user.Hash)
+		response.Error(c, response.ErrUserMFANotPermitted, nil)
+		return
+	}
+
+	encryptor := getDBEncryptor(c)
+	if encryptor == nil {
+		response.Error(c, response.ErrInternalDBEncryptorNotFound, nil)
+		return
+	}
+
+	secret, err := mfa.GenerateSecret()
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error generating MFA secret")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+	encSecret, err := encryptor.EncryptValue([]byte(secret))
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error encrypting MFA secret")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Take(&userMFA, "user_id = ?", uid).Error; err == nil && userMFA.Status == "active" {
+			return errMFAAlreadyEnabled
+		} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err := tx.Where("user_id = ?", uid).Delete(&models.UserMFA{}).Error; err != nil {
+			return err
+		}
+		userMFA = models.UserMFA{
+			UserID:        uid,
+			Secret:        encSecret,
+			Status:        "pending",
+			RecoveryCodes: models.UserMFARecoveryCodes{},
+		}
+		return tx.Create(&userMFA).Error
+	})
+	if errors.Is(err, errMFAAlreadyEnabled) {
+		logger.FromContext(c).WithError(err).Errorf("error enrolling MFA for current user")
+		response.Error(c, response.ErrUserMFAAlreadyEnabled, err)
+		return
+	} else if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error creating MFA settings for current user")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	resp := models.UserMFASecret{
+		Secret: secret,
+		URI:    mfa.GetProvisioningURI(mfaIssuer, user.Mail, secret),
+	}
+	response.Success(c, http.StatusCreated, resp)
+}
+
+// ActivateCurrentUserMFA is a function to confirm multi-factor authentication enrollment by TOTP code
+// @Summary Activate multi-factor authentication for the current user, recovery codes are returned only once
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param json body models.MFACode true "TOTP code from authenticator application"
+// @Success 200 {object} response.successResp{data=models.UserMFARecovery} "user MFA activated successful"
+// @Failure 400 {object} response.errorResp "invalid MFA code"
+// @Failure 403 {object} response.errorResp "activating user MFA not permitted"
+// @Failure 404 {object} response.errorResp "user MFA is not enrolled"
+// @Failure 409 {object} response.errorResp "user MFA is already enabled"
+// @Failure 500 {object} response.errorResp "internal error on activating user MFA"
+// @Router /user/mfa [put]
+func (s *UserService) ActivateCurrentUserMFA(c *gin.Context) {
+	var (
+		err     error
+		form    models.MFACode
+		userMFA models.UserMFA
+	)
+
+	if err = c.ShouldBindJSON(&form); err != nil || form.Valid() != nil {
+		if err == nil {
+			err = form.Valid()
+		}
+		logger.FromContext(c).WithError(err).Errorf("error binding JSON")
+		response.Error(c, response.ErrUserMFAInvalidRequest, err)
+		return
+	}
+
+	uid := c.GetUint64("uid")
+	if err = s.db.Take(&userMFA, "user_id = ?", uid).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding MFA settings of current user")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, response.ErrUserMFANotFound, err)
+		} else {
+			response.Error(c, response.ErrInternal, err)
+		}
+		return
+	} else if err = userMFA.Valid(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error validating MFA settings of current user")
+		response.Error(c, response.ErrUserMFAInvalidData, err)
+		return
+	} else if userMFA.Status == "active" {
+		logger.FromContext(c).Errorf("error activating MFA for current user, it's already enabled")
+		response.Error(c, response.ErrUserMFAAlreadyEnabled, nil)
+		return
+	}
+
+	encryptor := getDBEncryptor(c)
+	if encryptor == nil {
+		response.Error(c, response.ErrInternalDBEncryptorNotFound, nil)
+		return
+	}
+
+	if ok, err := userMFA.VerifyTOTP(encryptor, form.Code, time.Now()); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error checking MFA code for current user")
+		response.Error(c, response.ErrInternal, err)
+		return
+	} else if !ok {
+		logger.FromContext(c).Errorf("error matching MFA code for current user")
+		response.Error(c, response.ErrUserMFAInvalidCode, nil)
+		return
+	}
+
+	codes, hashes, err := mfa.GenerateRecoveryCodes(mfa.RecoveryCodesCount)
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error generating MFA recovery codes")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+	userMFA.Status = "active"
+	userMFA.RecoveryCodes = hashes
+
+	if err = s.db.Save(&userMFA).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error activating MFA for current user")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, models.UserMFARecovery{RecoveryCodes: codes})
+}
+
+// DisableCurrentUserMFA is a function to disable multi-factor authentication for the current user
+// @Summary Disable multi-factor authentication for the current user by TOTP code or recovery code
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param json body models.MFACode true "TOTP code or one of recovery codes"
+// @Success 200 {object} response.successResp "user MFA disabled successful"
+// @Failure 400 {object} response.errorResp "invalid MFA code"
+// @Failure 403 {object} response.errorResp "disabling user MFA not permitted"
+// @Failure 404 {object} response.errorResp "user MFA is not enrolled"
+// @Failure 429 {object} response.errorResp "disabling user MFA is locked after too many failed attempts"
+// @Failure 500 {object} response.errorResp "internal error on disabling user MFA"
+// @Router /user/mfa [delete]
+func (s *UserService) DisableCurrentUserMFA(c *gin.Context) {
+	var (
+		err     error
+		form    models.MFACode
+		user    models.User
+		userMFA models.UserMFA
+	)
+
+	if err = c.ShouldBindJSON(&form); err != nil || form.Valid() != nil {
+		if err == nil {
+			err = form.Valid()
+		}
+		logger.FromContext(c).WithError(err).Errorf("error binding JSON")
+		response.Error(c, response.ErrUserMFAInvalidRequest, err)
+		return
+	}
+
+	uid := c.GetUint64("uid")
+	if err = s.db.Take(&userMFA, "user_id = ?", uid).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding MFA settings of current user")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, response.ErrUserMFANotFound, err)
+		} else {
+			response.Error(c, response.ErrInternal, err)
+		}
+		return
+	} else if err = userMFA.Valid(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error validating MFA settings of current user")
+		response.Error(c, response.ErrUserMFAInvalidData, err)
+		return
+	}
+
+	encryptor := getDBEncryptor(c)
+	if encryptor == nil {
+		response.Error(c, response.ErrInternalDBEncryptorNotFound, nil)
+		return
+	}
+
+	// failed attempts are shared with the login lockout to prevent guessing codes by the stolen session
+	if err = s.db.Take(&user, "id = ?", uid).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding current user")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+	userKey, ipKey := storage.LoginLockoutKeys(user.Mail, c.ClientIP())
+	if until, locked, err := s.loginLimiter.LockedUntil(userKey, ipKey); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error checking MFA lockout for current user")
+		response.Error(c, response.ErrInternal, err)
+		return
+	} else if locked {
+		retryAfter := int64(time.Until(until)/time.Second) + 1
+		c.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+		logger.FromContext(c).Errorf("disabling MFA is locked until %s", until.Format(time.RFC3339))
+		response.Error(c, response.ErrUserMFALocked, nil)
+		return
+	}
+
+	if ok, err := userMFA.VerifyCode(encryptor, form.Code, time.Now()); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error checking MFA code for current user")
+		response.Error(c, response.ErrInternal, err)
+		return
+	} else if !ok {
+		logger.FromContext(c).Errorf("error matching MFA code for current user")
+		if _, _, err = s.loginLimiter.Fail(userKey, ipKey); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error registering failed MFA attempt")
+		}
+		response.Error(c, response.ErrUserMFAInvalidCode, nil)
+		return
+	}
+
+	if err = s.db.Delete(&userMFA).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error disabling MFA for current user")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+	if err = s.loginLimiter.Reset(userKey); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error resetting failed MFA attempts")
+	}
+
+	response.Success(c, http.StatusOK, struct{}{})
+}
+
+// ResetUserMFA is a function to disable multi-factor authentication for the user by hash,
+// it's used by administrator when the user lost access to authenticator application and recovery codes
+// @Summary Reset multi-factor authentication for the user
+// @Tags Users
+// @Produce json
+// @Param hash path string true "user hash in hex format (md5)" minlength(32) maxlength(32)
+// @Success 200 {object} response.successResp "user MFA reset successful"
+// @Failure 403 {object} response.errorResp "resetting user MFA not permitted"
+// @Failure 404 {object} response.errorResp "user not found"
+// @Failure 500 {object} response.errorResp "internal error on resetting user MFA"
+// @Router /users/{hash}/mfa [delete]
+func (s *UserService) ResetUserMFA(c *gin.Context) {
+	var (
+		err  error
+		hash = c.Param("hash")
+		user models.User
+	)
+
+	if user, err = s.getScopedUser(c, hash); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding user by hash")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, response.ErrUsersNotFound, err)
+		} else {
+			response.Error(c, response.ErrInternal, err)
+		}
+		return
+	}
+
+	if err = s.db.Where("user_id = ?", user.ID).Delete(&models.UserMFA{}).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error resetting MFA for user by hash '%s'", hash)
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	logger.FromContext(c).Infof("MFA settings were reset for user '%s'", user.Hash)
+
+	response.Success(c, http.StatusOK, struct{}{})
+}
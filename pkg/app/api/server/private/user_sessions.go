@@ -0,0 +1,267 @@
+package private
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+
+	"soldr/pkg/app/api/logger"
+	"soldr/pkg/app/api/models"
+	"soldr/pkg/app/api/server/response"
+	"soldr/pkg/app/api/storage"
+)
+
+type userSessions struct {
+	Sessions []models.UserSessionInfo `json:"sessions"`
+	Total    uint64                   `json:"total"`
+}
+
+var userSessionsSQLMappers = map[string]interface{}{
+	"id":             "`{{table}}`.id",
+	"ip":             "`{{table}}`.ip",
+	"user_agent":     "`{{table}}`.user_agent",
+	"last_active_at": "`{{table}}`.last_active_at",
+	"expires_at":     "`{{table}}`.expires_at",
+	"created_date":   "`{{table}}`.created_date",
+	"data": "CONCAT(`{{table}}`.ip, ' | ', " +
+		"`{{table}}`.user_agent)",
+}
+
+// getUserSessions is a function to query active sessions of the user and to mark the session of current request
+func (s *UserService) getUserSessions(c *gin.Context, query *storage.TableQuery, uid uint64) (userSessions, error) {
+	var (
+		err  error
+		list []models.UserSession
+		resp userSessions
+	)
+
+	if err = query.Init("user_sessions", userSessionsSQLMappers); err != nil {
+		return resp, err
+	}
+	query.SetFilters([]func(db *gorm.DB) *gorm.DB{
+		func(db *gorm.DB) *gorm.DB {
+			return db.Where("user_id = ?", uid)
+		},
+		s.sessionStore.ActiveScope(),
+	})
+	if resp.Total, err = query.Query(s.db, &list); err != nil {
+		return resp, err
+	}
+
+	currentHash := storage.GetSessionTokenHash(sessions.Default(c))
+	resp.Sessions = make([]models.UserSessionInfo, 0, len(list))
+	for _, session := range list {
+		resp.Sessions = append(resp.Sessions, models.UserSessionInfo{
+			Current:     currentHash != "" && session.TokenHash == currentHash,
+			UserSession: session,
+		})
+	}
+
+	return resp, nil
+}
+
+// GetCurrentUserSessions is a function to return active sessions list of the current user
+// @Summary Retrieve active sessions list of the current user by filters
+// @Tags Users
+// @Produce json
+// @Param request query storage.TableQuery true "query table params"
+// @Success 200 {object} response.successResp{data=userSessions} "user sessions list received successful"
+// @Failure 400 {object} response.errorResp "invalid query request data"
+// @Failure 403 {object} response.errorResp "getting user sessions not permitted"
+// @Failure 500 {object} response.errorResp "internal error on getting user sessions"
+// @Router /user/sessions/ [get]
+func (s *UserService) GetCurrentUserSessions(c *gin.Context) {
+	var (
+		err   error
+		query storage.TableQuery
+		resp  userSessions
+	)
+
+	if err = c.ShouldBindQuery(&query); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding query")
+		response.Error(c, response.ErrUserSessionsInvalidRequest, err)
+		return
+	}
+
+	if resp, err = s.getUserSessions(c, &query, c.GetUint64("uid")); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding user sessions")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	for i := 0; i < len(resp.Sessions); i++ {
+		if err = resp.Sessions[i].Valid(); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error validating user session data '%d'", resp.Sessions[i].ID)
+			response.Error(c, response.ErrUserSessionsInvalidData, err)
+			return
+		}
+	}
+
+	response.Success(c, http.StatusOK, resp)
+}
+
+// DeleteCurrentUserSession is a function to revoke the session of the current user by id
+// @Summary Revoke the session of the current user by id
+// @Tags Users
+// @Produce json
+// @Param id path int true "session id" minimum(1)
+// @Success 200 {object} response.successResp "user session revoked successful"
+// @Failure 400 {object} response.errorResp "invalid user session request data"
+// @Failure 403 {object} response.errorResp "revoking user session not permitted"
+// @Failure 404 {object} response.errorResp "user session not found"
+// @Failure 500 {object} response.errorResp "internal error on revoking user session"
+// @Router /user/sessions/{id} [delete]
+func (s *UserService) DeleteCurrentUserSession(c *gin.Context) {
+	var (
+		err     error
+		id      uint64
+		session models.UserSession
+	)
+
+	if id, err = strconv.ParseUint(c.Param("id"), 10, 64); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error parsing session id")
+		response.Error(c, response.ErrUserSessionsInvalidRequest, err)
+		return
+	}
+
+	uid := c.GetUint64("uid")
+	if err = s.db.Take(&session, "id = ? AND user_id = ?", id, uid).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding user session by id")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, response.ErrUserSessionsNotFound, err)
+		} else {
+			response.Error(c, response.ErrInternal, err)
+		}
+		return
+	}
+
+	if err = s.db.Delete(&session).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error deleting user session by id '%d'", id)
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, struct{}{})
+}
+
+// getScopedUser is a function to find the user by hash into the scope of current user role
+func (s *UserService) getScopedUser(c *gin.Context, hash string) (models.User, error) {
+	var user models.User
+
+	rid := models.GetRoleScope(c.GetUint64("rid"))
+	tid := c.GetUint64("tid")
+	uid := c.GetUint64("uid")
+	scope := func(db *gorm.DB) *gorm.DB {
+		switch rid {
+		case models.RoleSAdmin:
+			return db.Where("hash = ?", hash)
+		case models.RoleAdmin:
+			return db.Where("tenant_id = ? AND hash = ?", tid, hash)
+		case models.RoleUser:
+			return db.Where("tenant_id = ? AND hash = ? AND id = ?", tid, hash, uid)
+		default:
+			db.AddError(errors.New("unexpected user role"))
+			return db
+		}
+	}
+
+	err := s.db.Scopes(scope).Take(&user).Error
+	return user, err
+}
+
+// GetUserSessions is a function to return active sessions list of the user by hash
+// @Summary Retrieve active sessions list of the user by filters
+// @Tags Users
+// @Produce json
+// @Param hash path string true "user hash in hex format (md5)" minlength(32) maxlength(32)
+// @Param request query storage.TableQuery true "query table params"
+// @Success 200 {object} response.successResp{data=userSessions} "user sessions list received successful"
+// @Failure 400 {object} response.errorResp "invalid query request data"
+// @Failure 403 {object} response.errorResp "getting user sessions not permitted"
+// @Failure 404 {object} response.errorResp "user not found"
+// @Failure 500 {object} response.errorResp "internal error on getting user sessions"
+// @Router /users/{hash}/sessions [get]
+func (s *UserService) GetUserSessions(c *gin.Context) {
+	var (
+		err   error
+		hash  = c.Param("hash")
+		query storage.TableQuery
+		resp  userSessions
+		user  models.User
+	)
+
+	if err = c.ShouldBindQuery(&query); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding query")
+		response.Error(c, response.ErrUserSessionsInvalidRequest, err)
+		return
+	}
+
+	if user, err = s.getScopedUser(c, hash); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding user by hash")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, response.ErrUsersNotFound, err)
+		} else {
+			response.Error(c, response.ErrInternal, err)
+		}
+		return
+	}
+
+	if resp, err = s.getUserSessions(c, &query, user.ID); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding user sessions by hash '%s'", hash)
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	for i := 0; i < len(resp.Sessions); i++ {
+		if err = resp.Sessions[i].Valid(); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error validating user session data '%d'", resp.Sessions[i].ID)
+			response.Error(c, response.ErrUserSessionsInvalidData, err)
+			return
+		}
+	}
+
+	response.Success(c, http.StatusOK, resp)
+}
+
+// DeleteUserSessions is a function to revoke all sessions of the user by hash
+// @Summary Revoke all sessions of the user
+// @Tags Users
+// @Produce json
+// @Param hash path string true "user hash in hex format (md5)" minlength(32) maxlength(32)
+// @Success 200 {object} response.successResp "user sessions revoked successful"
+// @Failure 403 {object} response.errorResp "revoking user sessions not permitted"
+// @Failure 404 {object} response.errorResp "user not found"
+// @Failure 500 {object} response.errorResp "internal error on revoking user sessions"
+// @Router /users/{hash}/sessions [delete]
+func (s *UserService) DeleteUserSessions(c *gin.Context) {
+	var (
+		err  error
+		hash = c.Param("hash")
+		user models.User
+	)
+
+	if user, err = s.getScopedUser(c, hash); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding user by hash")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, response.ErrUsersNotFound, err)
+		} else {
+			response.Error(c, response.ErrInternal, err)
+		}
+		return
+	}
+
+	result := s.db.Where("user_id = ?", user.ID).Delete(&models.UserSession{})
+	if err = result.Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error revoking sessions of user by hash '%s'", hash)
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	logger.FromContext(c).Infof("%d sessions were revoked for user '%s'", result.RowsAffected, user.Hash)
+
+	response.Success(c, http.StatusOK, struct{}{})
+}
@@ -0,0 +1,301 @@
+package private
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+
+	"soldr/pkg/app/api/logger"
+	"soldr/pkg/app/api/models"
+	"soldr/pkg/app/api/server/response"
+	"soldr/pkg/app/api/storage"
+)
+
+type userTokens struct {
+	Tokens []models.UserToken `json:"tokens"`
+	Total  uint64             `json:"total"`
+}
+
+var userTokensSQLMappers = map[string]interface{}{
+	"id":           "`{{table}}`.id",
+	"hash":         "`{{table}}`.hash",
+	"name":         "`{{table}}`.name",
+	"status":       "`{{table}}`.status",
+	"expires_at":   "`{{table}}`.expires_at",
+	"last_used_at": "`{{table}}`.last_used_at",
+	"created_date": "`{{table}}`.created_date",
+	"data": "CONCAT(`{{table}}`.hash, ' | ', " +
+		"`{{table}}`.name, ' | ', " +
+		"`{{table}}`.status)",
+}
+
+// GetCurrentUserTokens is a function to return personal API tokens list of the current user
+// @Summary Retrieve personal API tokens list of the current user by filters
+// @Tags Users
+// @Produce json
+// @Param request query storage.TableQuery true "query table params"
+// @Success 200 {object} response.successResp{data=userTokens} "user tokens list received successful"
+// @Failure 400 {object} response.errorResp "invalid query request data"
+// @Failure 403 {object} response.errorResp "getting user tokens not permitted"
+// @Failure 500 {object} response.errorResp "internal error on getting user tokens"
+// @Router /user/tokens/ [get]
+func (s *UserService) GetCurrentUserTokens(c *gin.Context) {
+	var (
+		err   error
+		query storage.TableQuery
+		resp  userTokens
+	)
+
+	if err = c.ShouldBindQuery(&query); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding query")
+		response.Error(c, response.ErrUserTokensInvalidRequest, err)
+		return
+	}
+
+	uid := c.GetUint64("uid")
+	if err = query.Init("user_tokens", userTokensSQLMappers); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding query")
+		response.Error(c, response.ErrUserTokensInvalidRequest, err)
+		return
+	}
+	query.SetFilters([]func(db *gorm.DB) *gorm.DB{
+		func(db *gorm.DB) *gorm.DB {
+			return db.Where("user_id = ?", uid)
+		},
+	})
+
+	if resp.Total, err = query.Query(s.db, &resp.Tokens); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding user tokens")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	for i := 0; i < len(resp.Tokens); i++ {
+		if err = resp.Tokens[i].Valid(); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error validating user token data '%s'", resp.Tokens[i].Hash)
+			response.Error(c, response.ErrUserTokensInvalidData, err)
+			return
+		}
+	}
+
+	response.Success(c, http.StatusOK, resp)
+}
+
+// GetCurrentUserToken is a function to return personal API token of the current user by hash
+// @Summary Retrieve personal API token of the current user by hash
+// @Tags Users
+// @Produce json
+// @Param hash path string true "token hash in hex format (md5)" minlength(32) maxlength(32)
+// @Success 200 {object} response.successResp{data=models.UserToken} "user token received successful"
+// @Failure 403 {object} response.errorResp "getting user token not permitted"
+// @Failure 404 {object} response.errorResp "user token not found"
+// @Failure 500 {object} response.errorResp "internal error on getting user token"
+// @Router /user/tokens/{hash} [get]
+func (s *UserService) GetCurrentUserToken(c *gin.Context) {
+	var (
+		err  error
+		hash = c.Param("hash")
+		resp models.UserToken
+	)
+
+	uid := c.GetUint64("uid")
+	if err = s.db.Take(&resp, "user_id = ? AND hash = ?", uid, hash).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding user token by hash")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, response.ErrUserTokensNotFound, err)
+		} else {
+			response.Error(c, response.ErrInternal, err)
+		}
+		return
+	} else if err = resp.Valid(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error validating user token data '%s'", resp.Hash)
+		response.Error(c, response.ErrUserTokensInvalidData, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp)
+}
+
+// CreateCurrentUserToken is a function to create new personal API token for the current user
+// @Summary Create new personal API token for the current user, the token value is returned only once
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param json body models.UserTokenCreate true "user token model to create from"
+// @Success 201 {object} response.successResp{data=models.UserTokenSecret} "user token created successful"
+// @Failure 400 {object} response.errorResp "invalid user token request data"
+// @Failure 403 {object} response.errorResp "creating user token not permitted"
+// @Failure 500 {object} response.errorResp "internal error on creating user token"
+// @Router /user/tokens/ [post]
+func (s *UserService) CreateCurrentUserToken(c *gin.Context) {
+	var (
+		err  error
+		form models.UserTokenCreate
+		resp models.UserTokenSecret
+	)
+
+	if err = c.ShouldBindJSON(&form); err != nil || form.Valid() != nil {
+		if err == nil {
+			err = form.Valid()
+		}
+		logger.FromContext(c).WithError(err).Errorf("error binding JSON")
+		response.Error(c, response.ErrUserTokensInvalidRequest, err)
+		return
+	} else if form.ExpiresAt != nil && !form.ExpiresAt.After(time.Now()) {
+		err = fmt.Errorf("expiration time is in the past")
+		logger.FromContext(c).WithError(err).Errorf("error validating user token expiration")
+		response.Error(c, response.ErrUserTokensInvalidRequest, err)
+		return
+	}
+
+	if err = checkGrantedPrivileges(c, form.Scopes); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error checking user token scopes")
+		response.Error(c, response.ErrUserTokensInvalidScopes, err)
+		return
+	}
+
+	if resp.Token, resp.TokenHash, err = storage.MakeUserToken(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error making user token")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+	resp.Hash = storage.MakeUserTokenHash(form.Name)
+	resp.UserID = c.GetUint64("uid")
+	resp.Name = form.Name
+	resp.Scopes = form.Scopes
+	resp.Status = "active"
+	resp.ExpiresAt = form.ExpiresAt
+	resp.CreatedDate = time.Now()
+	if err = resp.UserToken.Valid(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error validating user token")
+		response.Error(c, response.ErrUserTokensInvalidRequest, err)
+		return
+	}
+
+	if err = s.db.Create(&resp.UserToken).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error creating user token")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, resp)
+}
+
+// PatchCurrentUserToken is a function to update personal API token of the current user by hash
+// @Summary Update personal API token of the current user, it's used to revoke token too
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param json body models.UserTokenPatch true "user token model to update"
+// @Param hash path string true "token hash in hex format (md5)" minlength(32) maxlength(32)
+// @Success 200 {object} response.successResp{data=models.UserToken} "user token updated successful"
+// @Failure 400 {object} response.errorResp "invalid user token request data"
+// @Failure 403 {object} response.errorResp "updating user token not permitted"
+// @Failure 404 {object} response.errorResp "user token not found"
+// @Failure 409 {object} response.errorResp "revoked user token can't be reactivated"
+// @Failure 500 {object} response.errorResp "internal error on updating user token"
+// @Router /user/tokens/{hash} [put]
+func (s *UserService) PatchCurrentUserToken(c *gin.Context) {
+	var (
+		err  error
+		form models.UserTokenPatch
+		hash = c.Param("hash")
+		resp models.UserToken
+	)
+
+	if err = c.ShouldBindJSON(&form); err != nil || form.Valid() != nil {
+		if err == nil {
+			err = form.Valid()
+		}
+		logger.FromContext(c).WithError(err).Errorf("error binding JSON")
+		response.Error(c, response.ErrUserTokensInvalidRequest, err)
+		return
+	}
+
+	if err = checkGrantedPrivileges(c, form.Scopes); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error checking user token scopes")
+		response.Error(c, response.ErrUserTokensInvalidScopes, err)
+		return
+	}
+
+	uid := c.GetUint64("uid")
+	scope := func(db *gorm.DB) *gorm.DB {
+		return db.Where("user_id = ? AND hash = ?", uid, hash)
+	}
+
+	if err = s.db.Scopes(scope).Take(&resp).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding user token by hash")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, response.ErrUserTokensNotFound, err)
+		} else {
+			response.Error(c, response.ErrInternal, err)
+		}
+		return
+	}
+
+	// revocation is terminal because the token may be leaked
+	if resp.Status == "revoked" && form.Status != "revoked" {
+		err = fmt.Errorf("user token '%s' is revoked", resp.Hash)
+		logger.FromContext(c).WithError(err).Errorf("error changing status of revoked user token")
+		response.Error(c, response.ErrUserTokensRevoked, err)
+		return
+	}
+
+	resp.Name = form.Name
+	resp.Scopes = form.Scopes
+	resp.Status = form.Status
+	if err = resp.Valid(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error validating user token data '%s'", resp.Hash)
+		response.Error(c, response.ErrUserTokensInvalidRequest, err)
+		return
+	}
+
+	if err = s.db.Scopes(scope).Select("", "name", "scopes", "status").Save(&resp).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error updating user token by hash '%s'", hash)
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp)
+}
+
+// DeleteCurrentUserToken is a function to delete personal API token of the current user by hash
+// @Summary Delete personal API token of the current user by hash
+// @Tags Users
+// @Produce json
+// @Param hash path string true "token hash in hex format (md5)" minlength(32) maxlength(32)
+// @Success 200 {object} response.successResp "user token deleted successful"
+// @Failure 403 {object} response.errorResp "deleting user token not permitted"
+// @Failure 404 {object} response.errorResp "user token not found"
+// @Failure 500 {object} response.errorResp "internal error on deleting user token"
+// @Router /user/tokens/{hash} [delete]
+func (s *UserService) DeleteCurrentUserToken(c *gin.Context) {
+	var (
+		err   error
+		hash  = c.Param("hash")
+		token models.UserToken
+	)
+
+	uid := c.GetUint64("uid")
+	if err = s.db.Take(&token, "user_id = ? AND hash = ?", uid, hash).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding user token by hash")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, response.ErrUserTokensNotFound, err)
+		} else {
+			response.Error(c, response.ErrInternal, err)
+		}
+		return
+	}
+
+	if err = s.db.Delete(&token).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error deleting user token by hash '%s'", hash)
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, struct{}{})
+}
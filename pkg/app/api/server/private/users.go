@@ -34,12 +34,16 @@ var usersSQLMappers = map[string]interface{}{
 }
 
 type UserService struct {
-	db *gorm.DB
+	db           *gorm.DB
+	sessionStore *storage.DBSessionStore
+	loginLimiter *storage.LoginLimiter
 }
 
-func NewUserService(db *gorm.DB) *UserService {
+func NewUserService(db *gorm.DB, sessionStore *storage.DBSessionStore, loginLimiter *storage.LoginLimiter) *UserService {
 	return &UserService{
-		db: db,
+		db:           db,
+		sessionStore: sessionStore,
+		loginLimiter: loginLimiter,
 	}
 }
 
@@ -180,7 +184,7 @@ func (s *UserService) GetUsers(c *gin.Context) {
 
 	query.Init("users", usersSQLMappers)
 
-	rid := c.GetUint64("rid")
+	rid := models.GetRoleScope(c.GetUint64("rid"))
 	tid := c.GetUint64("tid")
 	uid := c.GetUint64("uid")
 
@@ -273,7 +277,7 @@ func (s *UserService) GetUser(c *gin.Context) {
 		resp models.UserRoleTenant
 	)
 
-	rid := c.GetUint64("rid")
+	rid := models.GetRoleScope(c.GetUint64("rid"))
 	tid := c.GetUint64("tid")
 	uid := c.GetUint64("uid")
 	scope := func(db *gorm.DB) *gorm.DB {
@@ -342,7 +346,7 @@ func (s *UserService) CreateUser(c *gin.Context) {
 		return
 	}
 
-	rid := c.GetUint64("rid")
+	rid := models.GetRoleScope(c.GetUint64("rid"))
 	tid := c.GetUint64("tid")
 
 	switch rid {
@@ -360,6 +364,21 @@ func (s *UserService) CreateUser(c *gin.Context) {
 		return
 	}
 
+	// user can't grant privileges which are not granted to himself
+	if rid != models.RoleSAdmin {
+		var privs []string
+		if err = s.db.Model(&models.Privilege{}).Where("role_id = ?", user.RoleID).Pluck("name", &privs).Error; err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error getting role privileges list '%d'", user.RoleID)
+			response.Error(c, response.ErrInternal, err)
+			return
+		}
+		if err = checkGrantedPrivileges(c, privs); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error checking user role privileges")
+			response.Error(c, response.ErrRolesInvalidPrivileges, err)
+			return
+		}
+	}
+
 	user.ID = 0
 	user.Hash = storage.MakeUserHash(user.Name)
 	if err = user.Valid(); err != nil {
@@ -436,7 +455,7 @@ func (s *UserService) PatchUser(c *gin.Context) {
 		return
 	}
 
-	rid := c.GetUint64("rid")
+	rid := models.GetRoleScope(c.GetUint64("rid"))
 	tid := c.GetUint64("tid")
 	uid := c.GetUint64("uid")
 	scope := func(db *gorm.DB) *gorm.DB {
@@ -504,6 +523,15 @@ func (s *UserService) PatchUser(c *gin.Context) {
 		return
 	}
 
+	// blocked user must lose access immediately
+	if resp.Status != "active" {
+		if err = s.db.Where("user_id = ?", resp.ID).Delete(&models.UserSession{}).Error; err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error revoking sessions of user '%s'", resp.Hash)
+			response.Error(c, response.ErrInternal, err)
+			return
+		}
+	}
+
 	response.Success(c, http.StatusOK, resp)
 }
 
@@ -524,7 +552,7 @@ func (s *UserService) DeleteUser(c *gin.Context) {
 		user models.UserRoleTenant
 	)
 
-	rid := c.GetUint64("rid")
+	rid := models.GetRoleScope(c.GetUint64("rid"))
 	tid := c.GetUint64("tid")
 	uid := c.GetUint64("uid")
 	scope := func(db *gorm.DB) *gorm.DB {
@@ -0,0 +1,418 @@
+package private
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+
+	"soldr/pkg/app/api/logger"
+	"soldr/pkg/app/api/models"
+	"soldr/pkg/app/api/server/response"
+	"soldr/pkg/app/api/storage"
+)
+
+type views struct {
+	Views []models.View `json:"views"`
+	Total uint64        `json:"total"`
+}
+
+var viewsSQLMappers = map[string]interface{}{
+	"id":           "`{{table}}`.id",
+	"user_id":      "`{{table}}`.user_id",
+	"name":         "`{{table}}`.name",
+	"type":         "`{{table}}`.type",
+	"shared":       "`{{table}}`.shared",
+	"created_date": "`{{table}}`.created_date",
+	"data": "CONCAT(`{{table}}`.name, ' | ', " +
+		"`{{table}}`.type)",
+}
+
+// viewType contains table of the view type and privilege which is required to use it
+type viewType struct {
+	table     string
+	mappers   map[string]interface{}
+	privilege string
+}
+
+var viewTypes = map[string]viewType{
+	"agents": {table: "agents", mappers: agentsSQLMappers, privilege: "vxapi.agents.api.view"},
+	"events": {table: "events", mappers: eventsSQLMappers, privilege: "vxapi.modules.events"},
+}
+
+// viewParams is query params to apply saved view to the table query
+type viewParams struct {
+	View uint64 `form:"view" json:"view" binding:"omitempty,min=1"`
+}
+
+func getViewID(c *gin.Context) (uint64, error) {
+	return strconv.ParseUint(c.Param("id"), 10, 64)
+}
+
+// getViewsScope returns filter of views which are visible for the current user: own ones and shared into the tenant
+func getViewsScope(c *gin.Context) func(db *gorm.DB) *gorm.DB {
+	tid := c.GetUint64("tid")
+	uid := c.GetUint64("uid")
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("tenant_id = ? AND (user_id = ? OR shared = true)", tid, uid)
+	}
+}
+
+// getGrantedViewTypes returns list of view types which are permitted to the current user
+func getGrantedViewTypes(c *gin.Context) []string {
+	var types []string
+	for name, vt := range viewTypes {
+		if checkGrantedPrivileges(c, []string{vt.privilege}) == nil {
+			types = append(types, name)
+		}
+	}
+	return types
+}
+
+// parseViewQuery returns table query which is stored into the view, only filters, sorting,
+// grouping and filter expression are kept and checked by fields of the view type
+func parseViewQuery(vtype string, value models.ViewQuery) (*storage.TableQuery, error) {
+	vt, ok := viewTypes[vtype]
+	if !ok {
+		return nil, fmt.Errorf("unknown view type '%s'", vtype)
+	}
+
+	var stored storage.TableQuery
+	if err := json.Unmarshal(value, &stored); err != nil {
+		return nil, fmt.Errorf("failed to parse view query: %w", err)
+	}
+
+	query := storage.TableQuery{
+		Lang:       "en",
+		Sort:       stored.Sort,
+		Filters:    stored.Filters,
+		Group:      stored.Group,
+		Expression: stored.Expression,
+	}
+	if err := query.Init(vt.table, vt.mappers); err != nil {
+		return nil, err
+	}
+	mappers := query.Mappers()
+	if _, ok := mappers[query.Sort.Prop]; query.Sort.Prop != "" && !ok {
+		return nil, fmt.Errorf("wrong field '%s' for sorting", query.Sort.Prop)
+	}
+	for _, filter := range query.Filters {
+		if _, ok := mappers[filter.Field]; !ok {
+			return nil, fmt.Errorf("wrong field '%s' for filtering", filter.Field)
+		}
+	}
+
+	return &storage.TableQuery{
+		Sort:       query.Sort,
+		Filters:    query.Filters,
+		Group:      query.Group,
+		Expression: query.Expression,
+	}, nil
+}
+
+// applyView is a function to merge table query with the saved view which is set by view query param
+func applyView(c *gin.Context, db *gorm.DB, vtype string, query *storage.TableQuery) (*response.HttpError, error) {
+	var params viewParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		return response.ErrViewsInvalidRequest, err
+	}
+	if params.View == 0 {
+		return nil, nil
+	}
+
+	var view models.View
+	err := db.Scopes(getViewsScope(c)).Take(&view, "id = ? AND type = ?", params.View, vtype).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return response.ErrViewsNotFound, err
+	} else if err != nil {
+		return response.ErrInternal, err
+	} else if err = view.Valid(); err != nil {
+		return response.ErrViewsInvalidData, err
+	}
+
+	stored, err := parseViewQuery(view.Type, view.Query)
+	if err != nil {
+		return response.ErrViewsInvalidData, err
+	}
+	if err = query.Merge(stored); err != nil {
+		return response.ErrViewsInvalidData, err
+	}
+
+	return nil, nil
+}
+
+type ViewService struct {
+	db *gorm.DB
+}
+
+func NewViewService(db *gorm.DB) *ViewService {
+	return &ViewService{
+		db: db,
+	}
+}
+
+// getView returns the view by ID from the path if it's visible for the current user
+func (s *ViewService) getView(c *gin.Context) (*models.View, *response.HttpError, error) {
+	var view models.View
+
+	id, err := getViewID(c)
+	if err != nil {
+		return nil, response.ErrViewsInvalidRequest, err
+	}
+
+	types := getGrantedViewTypes(c)
+	err = s.db.Scopes(getViewsScope(c)).Take(&view, "id = ? AND type IN (?)", id, types).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, response.ErrViewsNotFound, err
+	} else if err != nil {
+		return nil, response.ErrInternal, err
+	} else if err = view.Valid(); err != nil {
+		return nil, response.ErrViewsInvalidData, err
+	}
+
+	return &view, nil, nil
+}
+
+// GetViews is a function to return saved views list
+// @Summary Retrieve saved views list of agents and events which are owned by the current user or shared into the tenant
+// @Tags Views
+// @Produce json
+// @Param request query storage.TableQuery true "query table params"
+// @Success 200 {object} response.successResp{data=views} "views list received successful"
+// @Failure 400 {object} response.errorResp "invalid query request data"
+// @Failure 403 {object} response.errorResp "getting views not permitted"
+// @Failure 500 {object} response.errorResp "internal error on getting views"
+// @Router /views/ [get]
+func (s *ViewService) GetViews(c *gin.Context) {
+	var (
+		err   error
+		query storage.TableQuery
+		resp  views
+	)
+
+	if err = c.ShouldBindQuery(&query); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error binding query")
+		response.Error(c, response.ErrViewsInvalidRequest, err)
+		return
+	}
+
+	if err = query.Init("views", viewsSQLMappers); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error initializing query")
+		response.Error(c, response.ErrViewsInvalidRequest, err)
+		return
+	}
+
+	types := getGrantedViewTypes(c)
+	query.SetFilters([]func(db *gorm.DB) *gorm.DB{
+		getViewsScope(c),
+		func(db *gorm.DB) *gorm.DB {
+			return db.Where("type IN (?)", types)
+		},
+	})
+
+	if resp.Total, err = query.Query(s.db, &resp.Views); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding views")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	for i := 0; i < len(resp.Views); i++ {
+		if err = resp.Views[i].Valid(); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error validating view data '%d'", resp.Views[i].ID)
+			response.Error(c, response.ErrViewsInvalidData, err)
+			return
+		}
+	}
+
+	response.Success(c, http.StatusOK, resp)
+}
+
+// GetView is a function to return saved view by id
+// @Summary Retrieve saved view by id
+// @Tags Views
+// @Produce json
+// @Param id path int true "view id" minimum(0)
+// @Success 200 {object} response.successResp{data=models.View} "view received successful"
+// @Failure 400 {object} response.errorResp "invalid view request data"
+// @Failure 403 {object} response.errorResp "getting view not permitted"
+// @Failure 404 {object} response.errorResp "view not found"
+// @Failure 500 {object} response.errorResp "internal error on getting view"
+// @Router /views/{id} [get]
+func (s *ViewService) GetView(c *gin.Context) {
+	view, httpErr, err := s.getView(c)
+	if httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding view by id")
+		response.Error(c, httpErr, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, view)
+}
+
+// CreateView is a function to create new saved view
+// @Summary Create new saved view of agents or events from the table query
+// @Tags Views
+// @Accept json
+// @Produce json
+// @Param json body models.ViewCreate true "view model to create from"
+// @Success 201 {object} response.successResp{data=models.View} "view created successful"
+// @Failure 400 {object} response.errorResp "invalid view request data"
+// @Failure 403 {object} response.errorResp "creating view not permitted"
+// @Failure 500 {object} response.errorResp "internal error on creating view"
+// @Router /views/ [post]
+func (s *ViewService) CreateView(c *gin.Context) {
+	var (
+		err  error
+		form models.ViewCreate
+		view models.View
+	)
+
+	if err = c.ShouldBindJSON(&form); err != nil || form.Valid() != nil {
+		if err == nil {
+			err = form.Valid()
+		}
+		logger.FromContext(c).WithError(err).Errorf("error binding JSON")
+		response.Error(c, response.ErrViewsInvalidRequest, err)
+		return
+	}
+
+	if err = checkGrantedPrivileges(c, []string{viewTypes[form.Type].privilege}); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error checking view type")
+		response.Error(c, response.ErrNotPermitted, err)
+		return
+	}
+
+	stored, err := parseViewQuery(form.Type, form.Query)
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error parsing view query")
+		response.Error(c, response.ErrViewsInvalidRequest, err)
+		return
+	}
+
+	view = models.View{
+		TenantID:    c.GetUint64("tid"),
+		UserID:      c.GetUint64("uid"),
+		Name:        form.Name,
+		Type:        form.Type,
+		Shared:      form.Shared,
+		CreatedDate: time.Now(),
+	}
+	if view.Query, err = json.Marshal(stored); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error making view query")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	if err = s.db.Create(&view).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error creating view")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, view)
+}
+
+// PatchView is a function to update saved view by id
+// @Summary Update saved view which is owned by the current user
+// @Tags Views
+// @Accept json
+// @Produce json
+// @Param json body models.ViewPatch true "view model to update"
+// @Param id path int true "view id" minimum(0)
+// @Success 200 {object} response.successResp{data=models.View} "view updated successful"
+// @Failure 400 {object} response.errorResp "invalid view request data"
+// @Failure 403 {object} response.errorResp "updating view not permitted"
+// @Failure 404 {object} response.errorResp "view not found"
+// @Failure 500 {object} response.errorResp "internal error on updating view"
+// @Router /views/{id} [put]
+func (s *ViewService) PatchView(c *gin.Context) {
+	var (
+		err  error
+		form models.ViewPatch
+	)
+
+	if err = c.ShouldBindJSON(&form); err != nil || form.Valid() != nil {
+		if err == nil {
+			err = form.Valid()
+		}
+		logger.FromContext(c).WithError(err).Errorf("error binding JSON")
+		response.Error(c, response.ErrViewsInvalidRequest, err)
+		return
+	}
+
+	view, httpErr, err := s.getView(c)
+	if httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding view by id")
+		response.Error(c, httpErr, err)
+		return
+	}
+	if view.UserID != c.GetUint64("uid") {
+		err = fmt.Errorf("view '%d' is owned by another user", view.ID)
+		logger.FromContext(c).WithError(err).Errorf("error updating view")
+		response.Error(c, response.ErrViewsNotPermitted, err)
+		return
+	}
+
+	stored, err := parseViewQuery(view.Type, form.Query)
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error parsing view query")
+		response.Error(c, response.ErrViewsInvalidRequest, err)
+		return
+	}
+
+	view.Name = form.Name
+	view.Shared = form.Shared
+	if view.Query, err = json.Marshal(stored); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error making view query")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	if err = s.db.Save(view).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error updating view")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, view)
+}
+
+// DeleteView is a function to delete saved view by id
+// @Summary Delete saved view which is owned by the current user, shared views can be deleted by tenant admin too
+// @Tags Views
+// @Produce json
+// @Param id path int true "view id" minimum(0)
+// @Success 200 {object} response.successResp "view deleted successful"
+// @Failure 403 {object} response.errorResp "deleting view not permitted"
+// @Failure 404 {object} response.errorResp "view not found"
+// @Failure 500 {object} response.errorResp "internal error on deleting view"
+// @Router /views/{id} [delete]
+func (s *ViewService) DeleteView(c *gin.Context) {
+	view, httpErr, err := s.getView(c)
+	if httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error finding view by id")
+		response.Error(c, httpErr, err)
+		return
+	}
+
+	rid := models.GetRoleScope(c.GetUint64("rid"))
+	if view.UserID != c.GetUint64("uid") && rid != models.RoleSAdmin && rid != models.RoleAdmin {
+		err = fmt.Errorf("view '%d' is owned by another user", view.ID)
+		logger.FromContext(c).WithError(err).Errorf("error deleting view")
+		response.Error(c, response.ErrViewsNotPermitted, err)
+		return
+	}
+
+	if err = s.db.Delete(view).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error deleting view")
+		response.Error(c, response.ErrInternal, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, struct{}{})
+}
@@ -0,0 +1,60 @@
+package private
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soldr/pkg/app/api/models"
+	"soldr/pkg/app/api/storage"
+)
+
+func TestParseViewQuery(t *testing.T) {
+	stored, err := parseViewQuery("agents", models.ViewQuery(`{
+		"page": 3, "pageSize": 100, "lang": "ru",
+		"sort": {"prop": "description", "order": "ascending"},
+		"filters[]": [{"field": "status", "value": "connected", "operator": "="}],
+		"expr": "os like \"linux\" OR NOT group_name = \"default\""
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, &storage.TableQuery{
+		Sort:       storage.TableSort{Prop: "description", Order: "ascending"},
+		Filters:    []storage.TableFilter{{Field: "status", Value: "connected", Operator: "="}},
+		Expression: `os like "linux" OR NOT group_name = "default"`,
+	}, stored)
+
+	value, err := json.Marshal(stored)
+	require.NoError(t, err)
+	restored, err := parseViewQuery("agents", value)
+	require.NoError(t, err)
+	assert.Equal(t, stored, restored, "stored query must be parsed back")
+
+	for vtype, value := range map[string]string{
+		"agents":  `{"filters[]": [{"field": "unknown", "value": "a"}]}`,
+		"events":  `{"sort": {"prop": "unknown", "order": "ascending"}}`,
+		"agents ": `{}`,
+		"groups":  `{}`,
+	} {
+		_, err = parseViewQuery(vtype, models.ViewQuery(value))
+		assert.Error(t, err, "view query must be rejected: %s %s", vtype, value)
+	}
+	_, err = parseViewQuery("events", models.ViewQuery(`{"expr": "agent_name = \"a\" AND unknown = 1"}`))
+	assert.Error(t, err)
+	_, err = parseViewQuery("events", models.ViewQuery(`{"group": "data"}`))
+	assert.Error(t, err)
+}
+
+func TestViewQueryValid(t *testing.T) {
+	assert.NoError(t, models.ViewQuery(`{"expr":"id = 1"}`).Valid())
+	assert.Error(t, models.ViewQuery(`[]`).Valid())
+	assert.Error(t, models.ViewQuery(`{"expr":`).Valid())
+
+	var view models.ViewCreate
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"v","type":"events","query":{"group":"name"}}`), &view))
+	assert.NoError(t, view.Valid())
+	value, err := json.Marshal(view)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"v","type":"events","shared":false,"query":{"group":"name"}}`, string(value))
+}
@@ -68,7 +68,7 @@ func prepareVM(
 	certsProvider certs.CertProvider,
 	ltacGetter vxcommonVM.LTACGetter,
 ) (*vm.VM, tunnel.PackEncryptor, error) {
-	packEncrypter, err := tunnel.NewPackEncrypter(&tunnel.Config{
+	packEncrypter, err := tunnel.NewNegotiablePackEncrypter(&tunnel.Config{
 		Simple: &tunnelSimple.Config{},
 	})
 	if err != nil {
@@ -392,7 +392,9 @@ func (s *ProtoService) AggregateWSConnect(c *gin.Context) {
 		ActionCode:        "interactive interaction",
 		ObjectDisplayName: useraction.UnknownObjectDisplayName,
 	}
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	serviceHash, err := getServiceHash(c)
 	if err != nil {
@@ -440,7 +442,9 @@ func (s *ProtoService) BrowserWSConnect(c *gin.Context) {
 		ActionCode:        "interactive interaction",
 		ObjectDisplayName: useraction.UnknownObjectDisplayName,
 	}
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	serviceHash, err := getServiceHash(c)
 	if err != nil {
@@ -488,7 +492,9 @@ func (s *ProtoService) ExternalWSConnect(c *gin.Context) {
 		ActionCode:        "interactive interaction",
 		ObjectDisplayName: useraction.UnknownObjectDisplayName,
 	}
-	defer s.userActionWriter.WriteUserAction(c, uaf)
+	defer func() {
+		s.userActionWriter.WriteUserAction(c, uaf)
+	}()
 
 	serviceHash, err := getServiceHash(c)
 	if err != nil {
@@ -46,7 +46,11 @@ func NewVM(
 	return vm, nil
 }
 
-func (v *VM) ProcessConnectionChallengeRequest(ctx context.Context, req []byte) ([]byte, error) {
+func (v *VM) ProcessConnectionChallengeRequest(
+	ctx context.Context,
+	req []byte,
+	packEncrypter tunnel.PackEncryptor,
+) ([]byte, error) {
 	var connChallengeReq protoagent.ConnectionChallengeRequest
 	if err := protoagent.UnpackProtoMessage(&connChallengeReq, req, protoagent.Message_CONNECTION_CHALLENGE_REQUEST); err != nil {
 		return nil, fmt.Errorf("failed to unpack the connection challenge request: %w", err)
@@ -59,8 +63,12 @@ func (v *VM) ProcessConnectionChallengeRequest(ctx context.Context, req []byte)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare the connection challenge response: %w", err)
 	}
+	offer := tunnel.GetOffer(packEncrypter)
 	connChallengeResp := &protoagent.ConnectionChallengeResponse{
-		Ct: ct,
+		Ct:                 ct,
+		TunnelCiphers:      offer.Ciphers,
+		TunnelCompressors:  offer.Compressors,
+		TunnelDictionaries: offer.Dictionaries,
 	}
 	msg, err := protoagent.PackProtoMessage(connChallengeResp, protoagent.Message_CONNECTION_CHALLENGE_REQUEST)
 	if err != nil {
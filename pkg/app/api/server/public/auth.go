@@ -16,38 +16,52 @@ import (
 
 	"soldr/pkg/app/api/logger"
 	"soldr/pkg/app/api/models"
+	"soldr/pkg/app/api/oidc"
 	"soldr/pkg/app/api/server/response"
 	"soldr/pkg/app/api/storage"
+	"soldr/pkg/app/api/useraction"
+	"soldr/pkg/app/api/utils/dbencryptor"
+	"soldr/pkg/crypto"
 )
 
 type AuthServiceConfig struct {
 	APIBaseURL     string
 	SessionTimeout int
+	SessionStore   *storage.DBSessionStore
 	SecureCookie   bool
+	OIDC           *oidc.Client
+	LoginLimiter   *storage.LoginLimiter
 }
 
 type AuthService struct {
-	cfg AuthServiceConfig
-	db  *gorm.DB
+	cfg              AuthServiceConfig
+	db               *gorm.DB
+	encryptor        crypto.IDBConfigEncryptor
+	limiter          *storage.LoginLimiter
+	userActionWriter useraction.Writer
 }
 
-func NewAuthService(cfg AuthServiceConfig, db *gorm.DB) *AuthService {
+func NewAuthService(cfg AuthServiceConfig, db *gorm.DB, userActionWriter useraction.Writer) *AuthService {
 	return &AuthService{
-		cfg: cfg,
-		db:  db,
+		cfg:              cfg,
+		db:               db,
+		encryptor:        dbencryptor.NewSecureConfigEncryptor(dbencryptor.GetKey),
+		limiter:          cfg.LoginLimiter,
+		userActionWriter: userActionWriter,
 	}
 }
 
 // AuthLogin is function to login user in the system
-// @Summary Login user into system
+// @Summary Login user into system, the second step is required if user has enabled multi-factor authentication
 // @Tags Public
 // @Accept json
 // @Produce json
 // @Param json body models.Login true "Login form JSON data"
-// @Success 200 {object} response.successResp "login successful"
+// @Success 200 {object} response.successResp{data=models.LoginStatus} "login successful or MFA code is required"
 // @Failure 400 {object} response.errorResp "invalid login data"
 // @Failure 401 {object} response.errorResp "invalid login or password"
 // @Failure 403 {object} response.errorResp "login not permitted"
+// @Failure 429 {object} response.errorResp "login is locked after too many failed attempts"
 // @Failure 500 {object} response.errorResp "internal error on login"
 // @Router /auth/login [post]
 func (s *AuthService) AuthLogin(c *gin.Context) {
@@ -61,9 +75,15 @@ func (s *AuthService) AuthLogin(c *gin.Context) {
 		return
 	}
 
+	userKey, ipKey := getLoginLockoutKeys(c, data.Mail)
+	if s.isLoginLocked(c, userKey, ipKey) {
+		return
+	}
+
 	var user models.UserPassword
 	if err := s.db.Take(&user, "(mail = ? OR name = ?) AND password IS NOT NULL", data.Mail, data.Mail).Error; err != nil {
 		logrus.WithError(err).Errorf("error getting user by mail '%s'", data.Mail)
+		s.registerLoginFailure(c, nil, userKey, ipKey)
 		response.Error(c, response.ErrAuthInvalidCredentials, err)
 		return
 	} else if err = user.Valid(); err != nil {
@@ -76,8 +96,14 @@ func (s *AuthService) AuthLogin(c *gin.Context) {
 		return
 	}
 
+	// failed attempts are counted by user mail regardless of the login which was used
+	if userKey, _ = getLoginLockoutKeys(c, user.Mail); s.isLoginLocked(c, userKey) {
+		return
+	}
+
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(data.Password)); err != nil {
 		logger.FromContext(c).Errorf("error matching user input password")
+		s.registerLoginFailure(c, &user.User, userKey, ipKey)
 		response.Error(c, response.ErrAuthInvalidCredentials, err)
 		return
 	}
@@ -99,57 +125,27 @@ func (s *AuthService) AuthLogin(c *gin.Context) {
 		return
 	}
 
-	var privs []string
-	err = s.db.Table("privileges").
-		Where("role_id = ?", user.RoleID).
-		Pluck("name", &privs).Error
-	if err != nil {
-		logger.FromContext(c).WithError(err).Errorf("error getting user privileges list '%s'", user.Hash)
-		response.Error(c, response.ErrAuthInvalidServiceData, err)
+	var userMFA models.UserMFA
+	err = s.db.Take(&userMFA, "user_id = ? AND status = 'active'", user.ID).Error
+	if err == nil {
+		s.saveMFASession(c, &user.User, service)
+		logger.FromContext(c).Infof("user passed the first login step and MFA code is required for '%s'", data.Mail)
+		response.Success(c, http.StatusOK, models.LoginStatus{MFARequired: true})
+		return
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.FromContext(c).WithError(err).Errorf("error loading MFA settings for user '%s'", user.Hash)
+		response.Error(c, response.ErrInternal, err)
 		return
 	}
 
-	uuid, err := storage.MakeUuidStrFromHash(user.Hash)
-	if err != nil {
-		logger.FromContext(c).WithError(err).Errorf("error validating user data '%s'", user.Hash)
-		response.Error(c, response.ErrAuthInvalidUserData, err)
+	if err = s.saveUserSession(c, &user.User, service); err != nil {
 		return
 	}
+	s.resetLoginFailures(c, userKey)
 
-	expires := s.cfg.SessionTimeout
-	session := sessions.Default(c)
-	session.Set("uid", user.ID)
-	session.Set("rid", user.RoleID)
-	session.Set("tid", user.TenantID)
-	session.Set("sid", service.ID)
-	session.Set("svc", service.Hash)
-	session.Set("prm", privs)
-	session.Set("gtm", time.Now().Unix())
-	session.Set("exp", time.Now().Add(time.Duration(expires)*time.Second).Unix())
-	session.Set("uuid", uuid)
-	session.Set("uname", user.Name)
-	session.Options(sessions.Options{
-		HttpOnly: true,
-		Secure:   s.cfg.SecureCookie,
-		Path:     s.cfg.APIBaseURL,
-		MaxAge:   expires,
-	})
-	session.Save()
-
-	logger.FromContext(c).
-		WithFields(logrus.Fields{
-			"age": expires,
-			"uid": user.ID,
-			"rid": user.RoleID,
-			"tid": user.TenantID,
-			"sid": session.Get("sid"),
-			"gtm": session.Get("gtm"),
-			"exp": session.Get("exp"),
-			"prm": session.Get("prm"),
-		}).
-		Infof("user made successful local login for '%s'", data.Mail)
+	logger.FromContext(c).Infof("user made successful local login for '%s'", data.Mail)
 
-	response.Success(c, http.StatusOK, struct{}{})
+	response.Success(c, http.StatusOK, models.LoginStatus{})
 }
 
 // AuthLogout is function to logout current user
@@ -160,12 +156,7 @@ func (s *AuthService) AuthLogin(c *gin.Context) {
 // @Success 307 "redirect to input return_uri path"
 // @Router /auth/logout [get]
 func (s *AuthService) AuthLogout(c *gin.Context) {
-	returnURI := "/"
-	if returnURL, err := url.Parse(c.Query("return_uri")); err == nil {
-		if uri := returnURL.RequestURI(); uri != "" {
-			returnURI = path.Clean(path.Join("/", uri))
-		}
-	}
+	returnURI := getReturnURI(c.Query("return_uri"))
 
 	session := sessions.Default(c)
 	logger.FromContext(c).
@@ -271,6 +262,17 @@ func (s *AuthService) AuthSwitchService(c *gin.Context) {
 	response.Success(c, http.StatusOK, service)
 }
 
+// getReturnURI is a function to make local URI to redirect user there, it prevents open redirect
+func getReturnURI(value string) string {
+	returnURI := "/"
+	if returnURL, err := url.Parse(value); err == nil {
+		if uri := returnURL.RequestURI(); uri != "" {
+			returnURI = path.Clean(path.Join("/", uri))
+		}
+	}
+	return returnURI
+}
+
 func getService(c *gin.Context, gDB *gorm.DB, hash string, user *models.User) (*models.Service, error) {
 	var service models.Service
 
@@ -289,13 +291,82 @@ func getService(c *gin.Context, gDB *gorm.DB, hash string, user *models.User) (*
 	}
 }
 
-func (s *AuthService) refreshCookie(c *gin.Context, resp *info, privs []string) error {
-	session := sessions.Default(c)
+// saveUserSession is a function to make authorized session for the user and the service,
+// API error is written to the response on failure
+func (s *AuthService) saveUserSession(c *gin.Context, user *models.User, service *models.Service) error {
+	var privs []string
+	err := s.db.Table("privileges").
+		Where("role_id = ?", user.RoleID).
+		Pluck("name", &privs).Error
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error getting user privileges list '%s'", user.Hash)
+		response.Error(c, response.ErrAuthInvalidServiceData, err)
+		return err
+	}
+
+	uuid, err := storage.MakeUuidStrFromHash(user.Hash)
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error validating user data '%s'", user.Hash)
+		response.Error(c, response.ErrAuthInvalidUserData, err)
+		return err
+	}
+
+	// new session token is issued on login to prevent session fixation
+	if s.cfg.SessionStore != nil {
+		if err = s.cfg.SessionStore.Renew(c.Request, storage.SessionCookieName); err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error renewing session for user '%s'", user.Hash)
+			response.Error(c, response.ErrInternal, err)
+			return err
+		}
+	}
 
 	expires := s.cfg.SessionTimeout
+	session := sessions.Default(c)
+	session.Set("uid", user.ID)
+	session.Set("rid", user.RoleID)
+	session.Set("tid", user.TenantID)
+	session.Set("sid", service.ID)
+	session.Set("svc", service.Hash)
 	session.Set("prm", privs)
 	session.Set("gtm", time.Now().Unix())
 	session.Set("exp", time.Now().Add(time.Duration(expires)*time.Second).Unix())
+	session.Set("uuid", uuid)
+	session.Set("uname", user.Name)
+	session.Options(sessions.Options{
+		HttpOnly: true,
+		Secure:   s.cfg.SecureCookie,
+		Path:     s.cfg.APIBaseURL,
+		MaxAge:   expires,
+	})
+	session.Save()
+
+	logger.FromContext(c).
+		WithFields(logrus.Fields{
+			"age": expires,
+			"uid": user.ID,
+			"rid": user.RoleID,
+			"tid": user.TenantID,
+			"sid": session.Get("sid"),
+			"gtm": session.Get("gtm"),
+			"exp": session.Get("exp"),
+			"prm": session.Get("prm"),
+		}).
+		Infof("session was created for '%s' '%s'", user.Mail, user.Name)
+
+	return nil
+}
+
+func (s *AuthService) refreshCookie(c *gin.Context, resp *info, privs []string) error {
+	session := sessions.Default(c)
+
+	// session lifetime isn't prolonged on refreshing because session timeout is absolute
+	exp, _ := session.Get("exp").(int64)
+	expires := int(time.Until(time.Unix(exp, 0)) / time.Second)
+	if expires <= 0 {
+		return fmt.Errorf("session is expired")
+	}
+	session.Set("prm", privs)
+	session.Set("gtm", time.Now().Unix())
 	resp.Privs = privs
 
 	session.Set("uid", resp.User.ID)
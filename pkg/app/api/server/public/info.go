@@ -16,6 +16,7 @@ import (
 
 type info struct {
 	Type     string            `json:"type"`
+	SSO      bool              `json:"sso"`
 	Service  *models.Service   `json:"service"`
 	Develop  bool              `json:"develop"`
 	User     models.User       `json:"user"`
@@ -52,6 +53,7 @@ func (s *AuthService) Info(c *gin.Context) {
 	gtm := session.Get("gtm")
 	svc := session.Get("svc")
 	resp.Develop = version.IsDevelop == "true"
+	resp.SSO = s.cfg.OIDC != nil
 
 	if privs := session.Get("prm"); privs != nil {
 		if resp.Privs, ok = privs.([]string); !ok || resp.Privs == nil {
@@ -103,13 +105,15 @@ func (s *AuthService) Info(c *gin.Context) {
 			}
 		}
 
-		// check 5 minutes timeout to refresh current token
+		// check 5 minutes timeout to refresh current token,
+		// it's refreshed immediately if user role or role privileges were changed
 		var fiveMins int64 = 5 * 60
-		if nowt >= gtmt+fiveMins && c.Query("refresh_cookie") != "false" {
+		isExpired := nowt >= gtmt+fiveMins || !isPrivilegesEqual(resp.Privs, privs)
+		if isExpired && c.Query("refresh_cookie") != "false" {
 			if err = s.refreshCookie(c, &resp, privs); err != nil {
 				logger.FromContext(c).WithError(err).Errorf("failed to refresh token")
 				// raise error when there is elapsing last five minutes
-				if nowt >= gtmt+int64(s.cfg.SessionTimeout)-fiveMins {
+				if nowt >= expt-fiveMins {
 					response.Error(c, response.ErrInternal, err)
 					return
 				}
@@ -133,3 +137,19 @@ func (s *AuthService) Info(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, resp)
 }
+
+func isPrivilegesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]struct{}, len(a))
+	for _, priv := range a {
+		set[priv] = struct{}{}
+	}
+	for _, priv := range b {
+		if _, ok := set[priv]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,248 @@
+package public
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+
+	"soldr/pkg/app/api/logger"
+	"soldr/pkg/app/api/models"
+	"soldr/pkg/app/api/server/response"
+	"soldr/pkg/app/api/storage"
+	"soldr/pkg/app/api/useraction"
+)
+
+// mfaLoginTimeout is a time to enter MFA code after the first login step
+const mfaLoginTimeout = 5 * 60
+
+var mfaSessionKeys = []string{
+	"mfa_uid",
+	"mfa_service",
+	"mfa_exp",
+}
+
+// AuthLoginMFA is function to complete user login by TOTP code or recovery code
+// @Summary Complete login into system by multi-factor authentication code
+// @Tags Public
+// @Accept json
+// @Produce json
+// @Param json body models.MFACode true "TOTP code or one of recovery codes"
+// @Success 200 {object} response.successResp{data=models.LoginStatus} "login successful"
+// @Failure 400 {object} response.errorResp "invalid MFA login data or MFA state is expired"
+// @Failure 401 {object} response.errorResp "invalid MFA code"
+// @Failure 403 {object} response.errorResp "login not permitted"
+// @Failure 429 {object} response.errorResp "login is locked after too many failed attempts"
+// @Failure 500 {object} response.errorResp "internal error on login"
+// @Router /auth/login-mfa [post]
+func (s *AuthService) AuthLoginMFA(c *gin.Context) {
+	var data models.MFACode
+	if err := c.ShouldBindJSON(&data); err != nil || data.Valid() != nil {
+		if err == nil {
+			err = data.Valid()
+		}
+		logger.FromContext(c).WithError(err).Errorf("error validating MFA request data")
+		response.Error(c, response.ErrAuthInvalidMFARequest, err)
+		return
+	}
+
+	session := sessions.Default(c)
+	uid, _ := session.Get("mfa_uid").(uint64)
+	serviceHash, _ := session.Get("mfa_service").(string)
+	exp, _ := session.Get("mfa_exp").(int64)
+	if uid == 0 || time.Now().Unix() > exp {
+		s.clearMFASession(c)
+		err := fmt.Errorf("MFA login state is unknown or expired")
+		logger.FromContext(c).WithError(err).Errorf("error validating MFA login state")
+		response.Error(c, response.ErrAuthInvalidMFAState, err)
+		return
+	}
+
+	var user models.User
+	if err := s.db.Take(&user, "id = ?", uid).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error getting user by id '%d'", uid)
+		s.clearMFASession(c)
+		response.Error(c, response.ErrAuthInvalidMFAState, err)
+		return
+	} else if err = user.Valid(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error validating user data '%s'", user.Hash)
+		response.Error(c, response.ErrAuthInvalidUserData, err)
+		return
+	} else if user.Status != "active" {
+		logger.FromContext(c).Errorf("error checking active state for user '%s'", user.Status)
+		s.clearMFASession(c)
+		response.Error(c, response.ErrAuthInactiveUser, fmt.Errorf("user is inactive"))
+		return
+	}
+
+	userKey, ipKey := getLoginLockoutKeys(c, user.Mail)
+	if s.isLoginLocked(c, userKey, ipKey) {
+		return
+	}
+
+	var userMFA models.UserMFA
+	if err := s.db.Take(&userMFA, "user_id = ? AND status = 'active'", user.ID).Error; err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error loading MFA settings for user '%s'", user.Hash)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.clearMFASession(c)
+			response.Error(c, response.ErrAuthInvalidMFAState, err)
+		} else {
+			response.Error(c, response.ErrInternal, err)
+		}
+		return
+	} else if err = userMFA.Valid(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error validating MFA settings for user '%s'", user.Hash)
+		response.Error(c, response.ErrAuthInvalidUserData, err)
+		return
+	}
+
+	prevStep, prevCodes := userMFA.LastStep, len(userMFA.RecoveryCodes)
+	if ok, err := userMFA.VerifyCode(s.encryptor, data.Code, time.Now()); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error checking MFA code for user '%s'", user.Hash)
+		response.Error(c, response.ErrInternal, err)
+		return
+	} else if !ok {
+		logger.FromContext(c).Errorf("error matching MFA code for user '%s'", user.Hash)
+		s.registerLoginFailure(c, &user, userKey, ipKey)
+		response.Error(c, response.ErrAuthInvalidMFACode, nil)
+		return
+	}
+
+	// used TOTP step or recovery code must be stored before the session is created,
+	// the condition prevents using the same code by concurrent requests
+	result := s.db.Model(&userMFA).
+		Where("last_step = ? AND JSON_LENGTH(recovery_codes) = ?", prevStep, prevCodes).
+		UpdateColumns(map[string]interface{}{
+			"last_step":      userMFA.LastStep,
+			"recovery_codes": userMFA.RecoveryCodes,
+		})
+	if result.Error != nil {
+		logger.FromContext(c).WithError(result.Error).Errorf("error updating MFA settings for user '%s'", user.Hash)
+		response.Error(c, response.ErrInternal, result.Error)
+		return
+	} else if result.RowsAffected == 0 {
+		logger.FromContext(c).Errorf("MFA code was already used for user '%s'", user.Hash)
+		s.registerLoginFailure(c, &user, userKey, ipKey)
+		response.Error(c, response.ErrAuthInvalidMFACode, nil)
+		return
+	}
+
+	service, err := getService(c, s.db, serviceHash, &user)
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error loading service data by hash '%s'", serviceHash)
+		response.Error(c, response.ErrAuthInvalidServiceData, err)
+		return
+	} else if err = service.Valid(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error validating service data '%s'", service.Hash)
+		response.Error(c, response.ErrAuthInvalidServiceData, err)
+		return
+	}
+
+	s.clearMFASession(c)
+	if err = s.saveUserSession(c, &user, service); err != nil {
+		return
+	}
+	s.resetLoginFailures(c, userKey)
+
+	logger.FromContext(c).Infof("user made successful local login with MFA for '%s'", user.Mail)
+
+	response.Success(c, http.StatusOK, models.LoginStatus{})
+}
+
+// saveMFASession is a function to keep the user which passed the first login step until MFA code is entered
+func (s *AuthService) saveMFASession(c *gin.Context, user *models.User, service *models.Service) {
+	session := sessions.Default(c)
+	session.Set("mfa_uid", user.ID)
+	session.Set("mfa_service", service.Hash)
+	session.Set("mfa_exp", time.Now().Add(mfaLoginTimeout*time.Second).Unix())
+	session.Options(sessions.Options{
+		HttpOnly: true,
+		Secure:   s.cfg.SecureCookie,
+		Path:     s.cfg.APIBaseURL,
+		MaxAge:   mfaLoginTimeout,
+	})
+	session.Save()
+}
+
+func (s *AuthService) clearMFASession(c *gin.Context) {
+	session := sessions.Default(c)
+	for _, key := range mfaSessionKeys {
+		session.Delete(key)
+	}
+	session.Save()
+}
+
+// getLoginLockoutKeys returns keys to count failed login attempts per user and per source IP
+func getLoginLockoutKeys(c *gin.Context, login string) (string, string) {
+	return storage.LoginLockoutKeys(login, c.ClientIP())
+}
+
+// isLoginLocked is a function to check lockout by the keys, API error is written to the response if it's locked
+func (s *AuthService) isLoginLocked(c *gin.Context, keys ...string) bool {
+	until, locked, err := s.limiter.LockedUntil(keys...)
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error checking login lockout")
+		response.Error(c, response.ErrInternal, err)
+		return true
+	} else if !locked {
+		return false
+	}
+
+	retryAfter := int64(time.Until(until)/time.Second) + 1
+	c.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+	logger.FromContext(c).Errorf("login is locked until %s", until.Format(time.RFC3339))
+	response.Error(c, response.ErrAuthLoginLocked, nil)
+	return true
+}
+
+// registerLoginFailure is a function to count failed login attempt per user and per source IP,
+// the lockout events are written to the user actions log
+func (s *AuthService) registerLoginFailure(c *gin.Context, user *models.User, userKey, ipKey string) {
+	cfg := s.limiter.Config()
+	userLocked, ipLocked, err := s.limiter.Fail(userKey, ipKey)
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error registering failed login attempt")
+	}
+	if userLocked {
+		objectID := strings.TrimPrefix(userKey, "user:")
+		displayName := objectID
+		if user != nil {
+			objectID, displayName = user.Hash, user.Mail
+		}
+		s.writeLockoutAction(c, objectID, displayName,
+			fmt.Sprintf("user login is locked for %s after %d failed attempts", cfg.Duration, cfg.MaxUserAttempts))
+	}
+	if ipLocked {
+		ip := strings.TrimPrefix(ipKey, "ip:")
+		s.writeLockoutAction(c, ip, ip,
+			fmt.Sprintf("source IP login is locked for %s after %d failed attempts", cfg.Duration, cfg.MaxIPAttempts))
+	}
+}
+
+// resetLoginFailures is a function to forget failed login attempts of the user after successful login,
+// failures of the source IP are expired by the window only otherwise a login to own account would reset
+// the counter of password spraying from the same IP
+func (s *AuthService) resetLoginFailures(c *gin.Context, userKey string) {
+	if err := s.limiter.Reset(userKey); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error resetting failed login attempts")
+	}
+}
+
+func (s *AuthService) writeLockoutAction(c *gin.Context, objectID, displayName, reason string) {
+	logger.FromContext(c).Warnf("%s: %s", reason, displayName)
+	if s.userActionWriter == nil {
+		return
+	}
+
+	uaf := useraction.NewFields(c, "user", "user", "login lockout", objectID, displayName)
+	uaf.FailReason = reason
+	if err := s.userActionWriter.WriteUserAction(c, uaf); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error writing login lockout action")
+	}
+}
@@ -0,0 +1,284 @@
+package public
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+
+	"soldr/pkg/app/api/logger"
+	"soldr/pkg/app/api/models"
+	"soldr/pkg/app/api/oidc"
+	"soldr/pkg/app/api/server/response"
+	"soldr/pkg/app/api/storage"
+)
+
+// authorizeTimeout is a time to complete login on the provider side
+const authorizeTimeout = 10 * 60
+
+var oauthSessionKeys = []string{
+	"oauth_state",
+	"oauth_nonce",
+	"oauth_verifier",
+	"oauth_return_uri",
+	"oauth_service",
+	"oauth_exp",
+}
+
+// AuthAuthorize is function to start user login via OpenID Connect provider
+// @Summary Login user into system via OpenID Connect provider by authorization code flow with PKCE
+// @Tags Public
+// @Produce json
+// @Param request query models.Authorize true "authorize query params"
+// @Success 307 "redirect to the provider authorization endpoint"
+// @Failure 400 {object} response.errorResp "invalid authorize query"
+// @Failure 403 {object} response.errorResp "error on discovering provider"
+// @Failure 404 {object} response.errorResp "single sign-on is not configured"
+// @Failure 500 {object} response.errorResp "internal error on authorize"
+// @Router /auth/authorize [get]
+func (s *AuthService) AuthAuthorize(c *gin.Context) {
+	if s.cfg.OIDC == nil {
+		response.Error(c, response.ErrAuthSSONotConfigured, nil)
+		return
+	}
+
+	var query models.Authorize
+	if err := c.ShouldBindQuery(&query); err != nil || query.Valid() != nil {
+		if err == nil {
+			err = query.Valid()
+		}
+		logger.FromContext(c).WithError(err).Errorf("error validating authorize query")
+		response.Error(c, response.ErrAuthInvalidAuthorizeQuery, err)
+		return
+	}
+
+	values := make([]string, 0, 3)
+	for i := 0; i < 3; i++ {
+		value, err := oidc.MakeRandomString()
+		if err != nil {
+			logger.FromContext(c).WithError(err).Errorf("error generating authorization params")
+			response.Error(c, response.ErrInternal, err)
+			return
+		}
+		values = append(values, value)
+	}
+	state, nonce, verifier := values[0], values[1], values[2]
+
+	authURL, err := s.cfg.OIDC.AuthCodeURL(c.Request.Context(), state, nonce, verifier)
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error making authorization URL")
+		response.Error(c, response.ErrAuthExchangeTokenFail, err)
+		return
+	}
+
+	session := sessions.Default(c)
+	session.Set("oauth_state", state)
+	session.Set("oauth_nonce", nonce)
+	session.Set("oauth_verifier", verifier)
+	session.Set("oauth_return_uri", getReturnURI(query.ReturnURI))
+	session.Set("oauth_service", query.Service)
+	session.Set("oauth_exp", time.Now().Add(authorizeTimeout*time.Second).Unix())
+	session.Options(sessions.Options{
+		HttpOnly: true,
+		Secure:   s.cfg.SecureCookie,
+		Path:     s.cfg.APIBaseURL,
+		MaxAge:   authorizeTimeout,
+	})
+	session.Save()
+
+	http.Redirect(c.Writer, c.Request, authURL, http.StatusTemporaryRedirect)
+}
+
+// AuthLoginCallback is function to complete user login via OpenID Connect provider
+// @Summary Login user into system by the authorization code from OpenID Connect provider
+// @Tags Public
+// @Produce json
+// @Param request query models.LoginCallback true "login callback query params"
+// @Success 307 "redirect to return_uri path from the authorize request"
+// @Failure 400 {object} response.errorResp "invalid login callback data"
+// @Failure 403 {object} response.errorResp "login not permitted"
+// @Failure 404 {object} response.errorResp "single sign-on is not configured"
+// @Failure 500 {object} response.errorResp "internal error on login"
+// @Router /auth/login-callback [get]
+func (s *AuthService) AuthLoginCallback(c *gin.Context) {
+	if s.cfg.OIDC == nil {
+		response.Error(c, response.ErrAuthSSONotConfigured, nil)
+		return
+	}
+
+	var query models.LoginCallback
+	if err := c.ShouldBindQuery(&query); err != nil || query.Valid() != nil {
+		if err == nil {
+			err = query.Valid()
+		}
+		logger.FromContext(c).WithError(err).Errorf("error validating login callback query")
+		response.Error(c, response.ErrAuthInvalidLoginCallbackRequest, err)
+		return
+	}
+
+	session := sessions.Default(c)
+	state, _ := session.Get("oauth_state").(string)
+	nonce, _ := session.Get("oauth_nonce").(string)
+	verifier, _ := session.Get("oauth_verifier").(string)
+	returnURI, _ := session.Get("oauth_return_uri").(string)
+	serviceHash, _ := session.Get("oauth_service").(string)
+	exp, _ := session.Get("oauth_exp").(int64)
+	// authorization params must be used only once
+	for _, key := range oauthSessionKeys {
+		session.Delete(key)
+	}
+	session.Save()
+
+	if state == "" || state != query.State || time.Now().Unix() > exp {
+		err := fmt.Errorf("authorization state is unknown or expired")
+		logger.FromContext(c).WithError(err).Errorf("error validating authorization state")
+		response.Error(c, response.ErrAuthInvalidAuthorizationState, err)
+		return
+	}
+	if query.Error != "" {
+		err := fmt.Errorf("provider returned error '%s': %s", query.Error, query.ErrorDescription)
+		logger.FromContext(c).WithError(err).Errorf("error on provider side")
+		response.Error(c, response.ErrAuthInvalidLoginCallbackRequest, err)
+		return
+	}
+
+	token, err := s.cfg.OIDC.Exchange(c.Request.Context(), query.Code, verifier)
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error exchanging authorization code")
+		response.Error(c, response.ErrAuthExchangeTokenFail, err)
+		return
+	}
+
+	claims, err := s.cfg.OIDC.VerifyIDToken(c.Request.Context(), token.IDToken, nonce)
+	if errors.Is(err, oidc.ErrInvalidNonce) {
+		logger.FromContext(c).WithError(err).Errorf("error validating authorization nonce")
+		response.Error(c, response.ErrAuthInvalidAuthorizationNonce, err)
+		return
+	} else if errors.Is(err, oidc.ErrTokenExpired) {
+		logger.FromContext(c).WithError(err).Errorf("error validating id token expiration")
+		response.Error(c, response.ErrAuthTokenExpired, err)
+		return
+	} else if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error verifying id token")
+		response.Error(c, response.ErrAuthVerificationTokenFail, err)
+		return
+	}
+
+	user, httpErr, err := s.syncOAuthUser(c, claims)
+	if httpErr != nil {
+		logger.FromContext(c).WithError(err).Errorf("error getting user by id token claims")
+		response.Error(c, httpErr, err)
+		return
+	}
+
+	service, err := getService(c, s.db, serviceHash, user)
+	if err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error loading service data by hash '%s'", serviceHash)
+		response.Error(c, response.ErrAuthInvalidServiceData, err)
+		return
+	} else if err = service.Valid(); err != nil {
+		logger.FromContext(c).WithError(err).Errorf("error validating service data '%s'", service.Hash)
+		response.Error(c, response.ErrAuthInvalidServiceData, err)
+		return
+	}
+
+	if err = s.saveUserSession(c, user, service); err != nil {
+		return
+	}
+
+	logger.FromContext(c).
+		WithFields(logrus.Fields{"sub": claims.String("sub")}).
+		Infof("user made successful sso login for '%s'", user.Mail)
+
+	if returnURI == "" {
+		returnURI = "/"
+	}
+	http.Redirect(c.Writer, c.Request, returnURI, http.StatusTemporaryRedirect)
+}
+
+// syncOAuthUser is a function to find user by claims and to update its role and tenant by the mapping,
+// the user is created if provisioning is enabled
+func (s *AuthService) syncOAuthUser(c *gin.Context, claims oidc.Claims) (*models.User, *response.HttpError, error) {
+	mail := claims.Email()
+	if mail == "" {
+		return nil, response.ErrAuthInvalidUserData, fmt.Errorf("verified email claim is required")
+	}
+
+	roleID, err := s.cfg.OIDC.MapRole(claims)
+	if err != nil {
+		return nil, response.ErrAuthInvalidUserData, err
+	}
+	tenantHash, err := s.cfg.OIDC.MapTenant(claims)
+	if err != nil {
+		return nil, response.ErrAuthInvalidTenantData, err
+	}
+
+	var tenant models.Tenant
+	if err = s.db.Take(&tenant, "hash = ?", tenantHash).Error; err != nil {
+		return nil, response.ErrAuthInvalidTenantData, fmt.Errorf("failed to get tenant '%s': %w", tenantHash, err)
+	} else if err = tenant.Valid(); err != nil {
+		return nil, response.ErrAuthInvalidTenantData, err
+	}
+
+	name := claims.Name()
+	for utf8.RuneCountInString(name) > 70 {
+		name = string([]rune(name)[:70])
+	}
+
+	var user models.User
+	err = s.db.Take(&user, "mail = ?", mail).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		if !s.cfg.OIDC.IsProvisioningEnabled() {
+			return nil, response.ErrAuthInvalidCredentials, fmt.Errorf("user '%s' is not registered", mail)
+		}
+		user = models.User{
+			Mail:     mail,
+			Name:     name,
+			Status:   "active",
+			Type:     "oauth",
+			RoleID:   roleID,
+			TenantID: tenant.ID,
+			Hash:     storage.MakeUserHash(name),
+		}
+		if err = user.Valid(); err != nil {
+			return nil, response.ErrAuthInvalidUserData, err
+		}
+		if err = s.db.Create(&user).Error; err != nil {
+			return nil, response.ErrInternal, fmt.Errorf("failed to create user '%s': %w", mail, err)
+		}
+		logger.FromContext(c).Infof("user '%s' was provisioned by sso login", mail)
+		return &user, nil, nil
+	case err != nil:
+		return nil, response.ErrInternal, fmt.Errorf("failed to get user '%s': %w", mail, err)
+	}
+
+	if user.Type != "oauth" {
+		return nil, response.ErrAuthInvalidUserData, fmt.Errorf("user '%s' is a local user", mail)
+	}
+	if user.Status != "active" {
+		return nil, response.ErrAuthInactiveUser, fmt.Errorf("user is inactive")
+	}
+
+	user.Name = name
+	user.RoleID = roleID
+	user.TenantID = tenant.ID
+	if err = user.Valid(); err != nil {
+		return nil, response.ErrAuthInvalidUserData, err
+	}
+	if err = s.db.Model(&user).Updates(map[string]interface{}{
+		"name":      user.Name,
+		"role_id":   user.RoleID,
+		"tenant_id": user.TenantID,
+	}).Error; err != nil {
+		return nil, response.ErrInternal, fmt.Errorf("failed to update user '%s': %w", mail, err)
+	}
+
+	return &user, nil, nil
+}
@@ -54,6 +54,8 @@ func Error(c *gin.Context, err *HttpError, original error) {
 	logger.FromContext(c).
 		Errorf("api error with status code: '%d'; message: '%s'; error: '%s'",
 			err.HttpCode(), err.Msg(), original)
+	// keep API error in the context to make it available for deferred handlers such as user actions writer
+	_ = c.Error(err)
 	c.AbortWithStatusJSON(err.HttpCode(), body)
 }
 
@@ -14,6 +14,7 @@ var ErrPatchAgentsUpdateTasksFail = NewHttpError(500, "Agents.PatchAgents.Update
 var ErrPatchAgentsUpdateAgentsFail = NewHttpError(500, "Agents.PatchAgents.UpdateAgentsFail", "failed to update agents by filter")
 var ErrPatchAgentsDeleteAgentsFail = NewHttpError(500, "Agents.PatchAgents.DeleteAgentsFail", "failed to delete agents by filter")
 var ErrPatchAgentsMoveFail = NewHttpError(500, "Agents.PatchAgents.MoveFail", "move fail")
+var ErrPatchAgentsMoveToDynamicGroup = NewHttpError(400, "Agents.PatchAgents.MoveToDynamicGroup", "agents can't be moved to the group with membership rule")
 var ErrGetAgentDetailsNotFound = NewHttpError(404, "Agents.GetAgent.DetailsNotFound", "internal error on retrieving agent details")
 var ErrGetAgentGroupNotFound = NewHttpError(404, "Agents.GetAgent.GroupNotFound", "group not found")
 var ErrGetAgentInvalidGroupData = NewHttpError(500, "Agents.GetAgent.InvalidGroupData", "invalid group data")
@@ -24,9 +25,15 @@ var ErrGetAgentsGetSystemModulesFail = NewHttpError(500, "Agents.GetAgents.GetSy
 var ErrGetAgentPoliciesNotFound = NewHttpError(404, "Agents.GetAgent.PoliciesNotFound", "group policies not found")
 var ErrPatchAgentValidationError = NewHttpError(400, "Agents.PatchAgent.ValidationError", "failed to validate agent")
 var ErrPatchAgentTaskUpdateFail = NewHttpError(500, "Agents.PatchAgent.TaskUpdateFail", "failed to update tasks by agent")
+var ErrPatchAgentMoveToDynamicGroup = NewHttpError(400, "Agents.PatchAgent.MoveToDynamicGroup", "agent can't be moved to the group with membership rule")
 var ErrCreateAgentValidationError = NewHttpError(400, "Agents.CreateAgent.ValidationError", "failed to valid agent info")
 var ErrCreateAgentCreateError = NewHttpError(500, "Agents.CreateAgent.CreateError", "failed to create agent to db")
 
+// audit
+
+var ErrAuditInvalidRequest = NewHttpError(400, "Audit.InvalidRequest", "invalid audit log request data")
+var ErrAuditInvalidData = NewHttpError(500, "Audit.InvalidData", "invalid audit log data")
+
 // auth
 
 var ErrAuthInvalidLoginRequest = NewHttpError(400, "Auth.InvalidLoginRequest", "invalid login data")
@@ -43,6 +50,11 @@ var ErrAuthTokenExpired = NewHttpError(403, "Auth.TokenExpired", "token is expir
 var ErrAuthVerificationTokenFail = NewHttpError(403, "Auth.VerificationTokenFail", "error on verifying token")
 var ErrAuthInvalidServiceData = NewHttpError(500, "Auth.InvalidServiceData", "invalid service data")
 var ErrAuthInvalidTenantData = NewHttpError(500, "Auth.InvalidTenantData", "invalid tenant data")
+var ErrAuthSSONotConfigured = NewHttpError(404, "Auth.SSONotConfigured", "single sign-on is not configured")
+var ErrAuthInvalidMFARequest = NewHttpError(400, "Auth.InvalidMFARequest", "invalid multi-factor authentication data")
+var ErrAuthInvalidMFAState = NewHttpError(400, "Auth.InvalidMFAState", "multi-factor authentication state is unknown or expired")
+var ErrAuthInvalidMFACode = NewHttpError(401, "Auth.InvalidMFACode", "invalid multi-factor authentication code")
+var ErrAuthLoginLocked = NewHttpError(429, "Auth.LoginLocked", "too many failed login attempts")
 
 // binaries
 
@@ -55,6 +67,17 @@ var ErrAgentBinaryFileInvalidPackageType = NewHttpError(400, "Binaries.AgentBina
 var ErrAgentBinaryFileNotFound = NewHttpError(404, "Binaries.AgentBinaryFile.NotFound", "agent binary record not found")
 var ErrAgentBinaryFileCorrupted = NewHttpError(404, "Binaries.AgentBinaryFile.Corrupted", "agent binary file was corrupted")
 
+// bundles
+
+var ErrBundlesInvalidRequest = NewHttpError(400, "Bundles.InvalidRequest", "invalid policies bundle request data")
+var ErrBundlesInvalidBundle = NewHttpError(400, "Bundles.InvalidBundle", "invalid policies bundle data")
+var ErrBundlesPolicyNotFound = NewHttpError(404, "Bundles.PolicyNotFound", "policy to export not found")
+var ErrBundlesModuleNotFound = NewHttpError(404, "Bundles.ModuleNotFound", "system module version from policies bundle not found")
+var ErrBundlesSecureConfigNotPermitted = NewHttpError(403, "Bundles.SecureConfigNotPermitted", "export or import of modules secure config not permitted")
+var ErrBundlesGroupsNotPermitted = NewHttpError(403, "Bundles.GroupsNotPermitted", "export or import of groups not permitted")
+var ErrBundlesPassphraseRequired = NewHttpError(400, "Bundles.PassphraseRequired", "passphrase is required to export or import modules secure config")
+var ErrBundlesInvalidPassphrase = NewHttpError(400, "Bundles.InvalidPassphrase", "invalid passphrase of policies bundle secure config")
+
 // events
 
 var ErrEventsInvalidRequest = NewHttpError(400, "Events.InvalidRequest", "invalid event request data")
@@ -93,6 +116,7 @@ var ErrGroupsInvalidQuery = NewHttpError(500, "Groups.InvalidQuery", "invalid gr
 var ErrGroupsInvalidData = NewHttpError(500, "Groups.InvalidData", "invalid group data")
 var ErrGroupsNotFound = NewHttpError(404, "Groups.NotFound", "group not found")
 var ErrGroupsValidationFail = NewHttpError(400, "Groups.ValidationFail", "failed to validate group")
+var ErrGroupsInvalidRule = NewHttpError(400, "Groups.InvalidRule", "invalid group rule")
 var ErrGetGroupDetailsNotFound = NewHttpError(404, "Groups.GetGroup.DetailsNotFound", "internal error on retrieving group details")
 var ErrGetGroupModulesNotFound = NewHttpError(404, "Groups.GetGroup.ModulesNotFound", "modules not found while getting group")
 var ErrGetGroupsDetailsNotFound = NewHttpError(404, "Groups.GetGroups.DetailsNotFound", "internal error on retrieving groups details")
@@ -151,6 +175,9 @@ var ErrPatchPolicyModuleDuplicatedModule = NewHttpError(403, "Modules.PatchPolic
 var ErrPatchPolicyModuleNewModuleInvalid = NewHttpError(400, "Modules.PatchPolicyModule.NewModuleInvalid", "failed to valid new module data")
 var ErrPatchPolicyModuleAcceptFail = NewHttpError(500, "Modules.PatchPolicyModule.AcceptFail", "failed accept system changes")
 var ErrPatchPolicyModuleActionNotFound = NewHttpError(404, "Modules.PatchPolicyModule.ActionNotFound", "action not found or unknown")
+var ErrRollbackPolicyModulePolicyNotFound = NewHttpError(404, "Modules.RollbackPolicyModule.PolicyNotFound", "policy not found")
+var ErrRollbackPolicyModuleModuleNotFound = NewHttpError(404, "Modules.RollbackPolicyModule.ModuleNotFound", "policy module not found")
+var ErrRollbackPolicyModuleNoPreviousVersion = NewHttpError(400, "Modules.RollbackPolicyModule.NoPreviousVersion", "policy module has no previously deployed version")
 var ErrDeletePolicyModulePolicyNotFound = NewHttpError(404, "Modules.DeletePolicyModule.PolicyNotFound", "policy not found")
 var ErrDeletePolicyModuleInvalidPolicyData = NewHttpError(500, "Modules.DeletePolicyModule.InvalidPolicyData", "invalid policy data")
 var ErrGetModulesInvalidModulesQuery = NewHttpError(500, "Modules.GetModules.InvalidModulesQuery", "invalid system modules query")
@@ -165,6 +192,7 @@ var ErrDeleteModuleServiceNotFound = NewHttpError(404, "Modules.DeleteModule.Ser
 var ErrDeleteModuleDeleteFail = NewHttpError(500, "Modules.DeleteModule.DeleteFail", "failed to delete policy modules")
 var ErrDeleteModuleDeleteFilesFail = NewHttpError(500, "Modules.DeleteModule.DeleteFilesFail", "failed to delete system module files")
 var ErrGetModuleVersionsInvalidModulesQuery = NewHttpError(500, "Modules.GetModuleVersions.InvalidModulesQuery", "invalid system modules query")
+var ErrGetModuleVersionsDiffFilesNotFound = NewHttpError(500, "Modules.GetModuleVersionsDiff.FilesNotFound", "failed to read system module version files")
 var ErrPatchModuleVersionAcceptReleaseChangesFail = NewHttpError(500, "Modules.PatchModuleVersion.AcceptReleaseChangesFail", "failed to accept changes for released module")
 var ErrPatchModuleVersionAcceptSystemChangesFail = NewHttpError(500, "Modules.PatchModuleVersion.AcceptSystemChangesFail", "failed accept system changes")
 var ErrPatchModuleVersionUpdateFail = NewHttpError(500, "Modules.PatchModuleVersion.UpdateFail", "failed to update module into db")
@@ -256,6 +284,7 @@ var ErrExportBuildConfigFail = NewHttpError(500, "Porting.Export.BuildConfigFail
 var ErrExportAddFileFail = NewHttpError(500, "Porting.Export.AddFileFail", "failed to add system module file")
 var ErrExportWriteFileFail = NewHttpError(500, "Porting.Export.WriteFileFail", "failed to write system module file")
 var ErrExportCloseArchiveFail = NewHttpError(500, "Porting.Export.CloseArchiveFail", "failed to close system module archive")
+var ErrExportSignArchiveFail = NewHttpError(500, "Porting.Export.SignArchiveFail", "failed to sign system module archive")
 var ErrImportReadArchiveFail = NewHttpError(400, "Porting.Import.ReadArchiveFail", "failed to read system module archive")
 var ErrImportParseArchiveFail = NewHttpError(400, "Porting.Import.ParseArchiveFail", "failed to parse system module archive")
 var ErrImportParseConfigFail = NewHttpError(400, "Porting.Import.ParseConfigFail", "failed to parse system module config")
@@ -264,6 +293,9 @@ var ErrImportValidateConfigFail = NewHttpError(400, "Porting.Import.ValidateConf
 var ErrImportOverrideNotPermitted = NewHttpError(403, "Porting.Import.OverrideNotPermitted", "override system module version not permitted")
 var ErrImportStoreS3Fail = NewHttpError(500, "Porting.Import.StoreS3Fail", "failed to store system module to S3")
 var ErrImportStoreDBFail = NewHttpError(500, "Porting.Import.StoreDBFail", "failed to store system module to DB")
+var ErrImportSignatureRequired = NewHttpError(400, "Porting.Import.SignatureRequired", "system module archive must be signed by trusted publisher")
+var ErrImportInvalidSignature = NewHttpError(400, "Porting.Import.InvalidSignature", "invalid signature of system module archive")
+var ErrImportUntrustedPublisher = NewHttpError(403, "Porting.Import.UntrustedPublisher", "system module archive is signed by unknown or revoked publisher")
 
 // proto
 
@@ -278,10 +310,21 @@ var ErrProtoInvalidAgentID = NewHttpError(400, "Proto.InvalidAgentID", "error va
 var ErrProtoNoServiceInfo = NewHttpError(400, "Proto.NoServiceInfo", "error getting service information")
 var ErrProtoUpgradeFail = NewHttpError(400, "Proto.UpgradeFail", "error upgrading to websockets")
 
+// publishers
+
+var ErrPublishersInvalidRequest = NewHttpError(400, "Publishers.InvalidRequest", "invalid trusted publisher request data")
+var ErrPublishersInvalidData = NewHttpError(500, "Publishers.InvalidData", "invalid trusted publisher data")
+var ErrPublishersNotFound = NewHttpError(404, "Publishers.NotFound", "trusted publisher not found")
+var ErrPublishersKeyExists = NewHttpError(400, "Publishers.KeyExists", "trusted publisher with the same public key already exists")
+
 // roles
 
 var ErrRolesInvalidRequest = NewHttpError(400, "Roles.InvalidRequest", "invalid role request data")
 var ErrRolesInvalidData = NewHttpError(500, "Roles.InvalidData", "invalid role data")
+var ErrRolesNotFound = NewHttpError(404, "Roles.NotFound", "role not found")
+var ErrRolesBuiltinRole = NewHttpError(403, "Roles.BuiltinRole", "built-in role can't be changed")
+var ErrRolesRoleInUse = NewHttpError(403, "Roles.RoleInUse", "role is assigned to users")
+var ErrRolesInvalidPrivileges = NewHttpError(403, "Roles.InvalidPrivileges", "role privileges exceed current privileges")
 
 // services
 
@@ -334,9 +377,31 @@ var ErrGetUserModelsNotFound = NewHttpError(404, "Users.GetUser.ModelsNotFound",
 var ErrCreateUserInvalidUser = NewHttpError(400, "Users.CreateUser.InvalidUser", "failed to validate user")
 var ErrPatchUserModelsNotFound = NewHttpError(404, "Users.PatchUser.ModelsNotFound", "user linked models not found")
 var ErrDeleteUserModelsNotFound = NewHttpError(404, "Users.DeleteUser.ModelsNotFound", "user linked models not found")
+var ErrUserTokensNotFound = NewHttpError(404, "UserTokens.NotFound", "user token not found")
+var ErrUserTokensInvalidData = NewHttpError(500, "UserTokens.InvalidData", "invalid user token data")
+var ErrUserTokensInvalidRequest = NewHttpError(400, "UserTokens.InvalidRequest", "invalid user token request data")
+var ErrUserTokensInvalidScopes = NewHttpError(403, "UserTokens.InvalidScopes", "user token scopes exceed current privileges")
+var ErrUserTokensRevoked = NewHttpError(409, "UserTokens.Revoked", "revoked user token can't be reactivated")
+var ErrUserMFANotFound = NewHttpError(404, "UserMFA.NotFound", "multi-factor authentication is not enrolled")
+var ErrUserMFAInvalidData = NewHttpError(500, "UserMFA.InvalidData", "invalid multi-factor authentication data")
+var ErrUserMFAInvalidRequest = NewHttpError(400, "UserMFA.InvalidRequest", "invalid multi-factor authentication request data")
+var ErrUserMFAInvalidCode = NewHttpError(400, "UserMFA.InvalidCode", "invalid multi-factor authentication code")
+var ErrUserMFAAlreadyEnabled = NewHttpError(409, "UserMFA.AlreadyEnabled", "multi-factor authentication is already enabled")
+var ErrUserMFANotPermitted = NewHttpError(403, "UserMFA.NotPermitted", "multi-factor authentication is available only for local users")
+var ErrUserMFALocked = NewHttpError(429, "UserMFA.Locked", "too many failed multi-factor authentication attempts")
+var ErrUserSessionsNotFound = NewHttpError(404, "UserSessions.NotFound", "user session not found")
+var ErrUserSessionsInvalidData = NewHttpError(500, "UserSessions.InvalidData", "invalid user session data")
+var ErrUserSessionsInvalidRequest = NewHttpError(400, "UserSessions.InvalidRequest", "invalid user session request data")
 
 // versions
 
 var ErrVersionsInvalidRequest = NewHttpError(400, "Versions.InvalidRequest", "invalid versions request data")
 var ErrVersionsMapperNotFound = NewHttpError(400, "Versions.MapperNotFound", "failed to get version mappers by query")
 var ErrVersionsInvalidQuery = NewHttpError(500, "Versions.InvalidQuery", "invalid versions query")
+
+// views
+
+var ErrViewsInvalidRequest = NewHttpError(400, "Views.InvalidRequest", "invalid view request data")
+var ErrViewsInvalidData = NewHttpError(500, "Views.InvalidData", "invalid view data")
+var ErrViewsNotFound = NewHttpError(404, "Views.NotFound", "view not found")
+var ErrViewsNotPermitted = NewHttpError(403, "Views.NotPermitted", "view is owned by another user")
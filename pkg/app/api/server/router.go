@@ -3,15 +3,16 @@ package server
 import (
 	"crypto/tls"
 	"encoding/gob"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/gin-contrib/sessions"
-	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
 	"github.com/jinzhu/gorm"
 	swaggerFiles "github.com/swaggo/files"
@@ -19,6 +20,8 @@ import (
 
 	"soldr/pkg/app/api/client"
 	"soldr/pkg/app/api/logger"
+	"soldr/pkg/app/api/modules"
+	"soldr/pkg/app/api/oidc"
 	"soldr/pkg/app/api/server/private"
 	"soldr/pkg/app/api/server/proto"
 	"soldr/pkg/app/api/server/public"
@@ -35,6 +38,16 @@ type RouterConfig struct {
 	StaticURL    *url.URL
 	TemplatesDir string
 	CertsPath    string
+	OIDC         *oidc.Client
+	LoginLimiter *storage.LoginLimiter
+	SessionStore *storage.DBSessionStore
+	// TrustedProxies is a list of IPs and CIDRs of reverse proxies which set client IP to the request headers
+	TrustedProxies []string
+	// ProtoTokenKey signs vxproto tokens, it must be the same on all API replicas
+	ProtoTokenKey []byte
+	// ModuleSigner signs exported module archives, they are exported unsigned if it's not set
+	ModuleSigner       *modules.PackageSigner
+	ModuleImportPolicy string
 }
 
 // @title SOLDR Swagger API
@@ -60,7 +73,7 @@ func NewRouter(
 	dbConns *storage.DBConnectionStorage,
 	s3Conns *storage.S3ConnectionStorage,
 	modulesStorage *storage.ModuleStorage,
-) *gin.Engine {
+) (*gin.Engine, error) {
 	gin.SetMode(gin.ReleaseMode)
 	if cfg.Debug {
 		gin.SetMode(gin.DebugMode)
@@ -70,8 +83,6 @@ func NewRouter(
 	gob.Register([]string{})
 	gob.Register(map[string]interface{}{})
 
-	cookieStore := cookie.NewStore(storage.MakeCookieStoreKey())
-
 	index := func(c *gin.Context) {
 		data, err := ioutil.ReadFile(path.Join(cfg.StaticPath, "/index.html"))
 		if err != nil {
@@ -82,9 +93,13 @@ func NewRouter(
 	}
 
 	router := gin.New()
+	if err := setTrustedProxies(router, cfg.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("failed to set trusted proxies: %w", err)
+	}
 	router.Use(WithLogger("vxapi"))
 	router.Use(gin.Recovery())
-	router.Use(sessions.Sessions("auth", cookieStore))
+	router.Use(setRequestIP())
+	router.Use(sessions.Sessions(storage.SessionCookieName, cfg.SessionStore))
 
 	router.Static("/js", path.Join(cfg.StaticPath, "js"))
 	router.Static("/css", path.Join(cfg.StaticPath, "css"))
@@ -134,28 +149,36 @@ func NewRouter(
 
 	// services
 	authService := public.NewAuthService(public.AuthServiceConfig{
-		SessionTimeout: 3 * 3600, // 3 hours
+		SessionTimeout: int(cfg.SessionStore.Config().AbsoluteTimeout / time.Second),
+		SessionStore:   cfg.SessionStore,
 		APIBaseURL:     cfg.BaseURL,
 		SecureCookie:   cfg.UseSSL,
-	}, db)
+		OIDC:           cfg.OIDC,
+		LoginLimiter:   cfg.LoginLimiter,
+	}, db, userActionWriter)
 	protoService := proto.NewProtoService(db, serverConnector, userActionWriter, cfg.CertsPath)
 	agentService := private.NewAgentService(db, serverConnector, userActionWriter, modulesStorage)
+	auditService := private.NewAuditService(db)
 	binariesService := private.NewBinariesService(db, userActionWriter)
-	eventService := private.NewEventService(serverConnector)
+	bundleService := private.NewBundleService(db, serverConnector, userActionWriter)
+	eventService := private.NewEventService(db, serverConnector)
 	groupService := private.NewGroupService(serverConnector, userActionWriter, modulesStorage)
 	moduleService := private.NewModuleService(cfg.TemplatesDir, db, serverConnector, userActionWriter, modulesStorage)
 	optionService := private.NewOptionService(db)
 	policyService := private.NewPolicyService(db, serverConnector, userActionWriter)
-	portingService := private.NewPortingService(db, userActionWriter)
+	portingService := private.NewPortingService(db, userActionWriter, cfg.ModuleSigner, cfg.ModuleImportPolicy)
+	publisherService := private.NewPublisherService(db)
 	roleService := private.NewRoleService(db)
 	upgradeService := private.NewUpgradeService(db, serverConnector, userActionWriter)
 	tagService := private.NewTagService(db, serverConnector)
 	versionService := private.NewVersionService(db, serverConnector)
+	viewService := private.NewViewService(db)
 	servicesService := private.NewServicesService(db)
 	tenantService := private.NewTenantService(db)
-	userService := private.NewUserService(db)
+	tokenService := private.NewTokenService(cfg.ProtoTokenKey)
+	userService := private.NewUserService(db, cfg.SessionStore, cfg.LoginLimiter)
 
-	authMiddleware := NewAuthMiddleware(cfg.BaseURL)
+	authMiddleware := NewAuthMiddleware(cfg.BaseURL, db, cfg.ProtoTokenKey)
 
 	// set api handlers
 	api := router.Group(cfg.BaseURL)
@@ -172,7 +195,7 @@ func NewRouter(
 	privateGroup.Use(authMiddleware.AuthRequired)
 	privateGroup.Use(setServiceInfo(db))
 	{
-		setTokenGroup(privateGroup)
+		setTokenGroup(privateGroup, tokenService)
 
 		setBinariesGroup(privateGroup, binariesService)
 		setUpgradesGroup(privateGroup, upgradeService)
@@ -185,10 +208,14 @@ func NewRouter(
 		// collected events by policy modules
 		setEventsGroup(privateGroup, eventService)
 
+		// saved views of agents and events, privileges are checked by view type
+		setViewsGroup(privateGroup, viewService)
+
 		// system modules groups
 		setSystemModulesGroup(privateGroup, moduleService)
-		setExportGroup(privateGroup, portingService)
-		setImportGroup(privateGroup, portingService)
+		setExportGroup(privateGroup, portingService, bundleService)
+		setImportGroup(privateGroup, portingService, bundleService)
+		setPublishersGroup(privateGroup, publisherService)
 		setOptionsGroup(privateGroup, optionService)
 
 		setNotificationsGroup(privateGroup, exchanger)
@@ -197,13 +224,24 @@ func NewRouter(
 		setVersionsGroup(privateGroup, versionService)
 
 		// system objects
+		setAuditGroup(privateGroup, auditService)
 		setRolesGroup(privateGroup, roleService)
 		setServicesGroup(privateGroup, servicesService)
 		setTenanesGroup(privateGroup, tenantService)
 		setUsersGroup(privateGroup, userService)
 	}
 
-	return router
+	return router, nil
+}
+
+// setTrustedProxies makes gin to take client IP from X-Forwarded-For and X-Real-IP headers only for
+// the requests from the listed proxies, gin trusts all proxies by default so client could spoof own IP
+func setTrustedProxies(router *gin.Engine, proxies []string) error {
+	router.ForwardedByClientIP = len(proxies) != 0
+	if !router.ForwardedByClientIP {
+		proxies = nil
+	}
+	return router.SetTrustedProxies(proxies)
 }
 
 func setPublicGroup(parent *gin.RouterGroup, svc *public.AuthService, authMiddleware *AuthMiddleware) {
@@ -213,6 +251,9 @@ func setPublicGroup(parent *gin.RouterGroup, svc *public.AuthService, authMiddle
 		authGroup := publicGroup.Group("/auth")
 		{
 			authGroup.POST("/login", svc.AuthLogin)
+			authGroup.POST("/login-mfa", svc.AuthLoginMFA)
+			authGroup.GET("/authorize", svc.AuthAuthorize)
+			authGroup.GET("/login-callback", svc.AuthLoginCallback)
 			authGroup.GET("/logout", svc.AuthLogout)
 		}
 
@@ -243,11 +284,11 @@ func setVXProtoGroup(parent *gin.RouterGroup, db *gorm.DB, svc *proto.ProtoServi
 	}
 }
 
-func setTokenGroup(parent *gin.RouterGroup) {
+func setTokenGroup(parent *gin.RouterGroup, svc *private.TokenService) {
 	tokenGroup := parent.Group("/token")
 	tokenGroup.Use(privilegesRequired("vxapi.modules.interactive"))
 	{
-		tokenGroup.POST("/vxproto", private.CreateAuthToken)
+		tokenGroup.POST("/vxproto", svc.CreateAuthToken)
 	}
 }
 
@@ -346,6 +387,7 @@ func setGroupsGroup(
 	{
 		groupsViewGroup.GET("/", groupService.GetGroups)
 		groupsViewGroup.GET("/:hash", groupService.GetGroup)
+		groupsViewGroup.GET("/dry-run", groupService.GetGroupRuleAgents)
 	}
 
 	groupsModulesViewGroup := parent.Group("/groups")
@@ -394,6 +436,7 @@ func setPoliciesGroup(
 	policiesInconcurrentEditGroup.Use(inconcurrentRequest())
 	{
 		policiesInconcurrentEditGroup.PUT("/:hash/modules/:module_name", moduleService.PatchPolicyModule)
+		policiesInconcurrentEditGroup.POST("/:hash/modules/:module_name/rollback", moduleService.RollbackPolicyModule)
 	}
 
 	policiesViewGroup := parent.Group("/policies")
@@ -436,6 +479,18 @@ func setEventsGroup(parent *gin.RouterGroup, svc *private.EventService) {
 	eventsGroup.Use(privilegesRequired("vxapi.modules.events"))
 	{
 		eventsGroup.GET("/", svc.GetEvents)
+		eventsGroup.GET("/export", svc.ExportEvents)
+	}
+}
+
+func setViewsGroup(parent *gin.RouterGroup, svc *private.ViewService) {
+	viewsGroup := parent.Group("/views")
+	{
+		viewsGroup.GET("/", svc.GetViews)
+		viewsGroup.POST("/", svc.CreateView)
+		viewsGroup.GET("/:id", svc.GetView)
+		viewsGroup.PUT("/:id", svc.PatchView)
+		viewsGroup.DELETE("/:id", svc.DeleteView)
 	}
 }
 
@@ -476,24 +531,70 @@ func setSystemModulesGroup(parent *gin.RouterGroup, svc *private.ModuleService)
 		systemModulesViewGroup.GET("/", svc.GetModules)
 		systemModulesViewGroup.GET("/:module_name/versions/", svc.GetModuleVersions)
 		systemModulesViewGroup.GET("/:module_name/versions/:version", svc.GetModuleVersion)
+		systemModulesViewGroup.GET("/:module_name/versions/:version/diff/:diff_version", svc.GetModuleVersionsDiff)
 		systemModulesViewGroup.GET("/:module_name/versions/:version/options/:option_name", svc.GetModuleVersionOption)
 	}
 }
 
-func setExportGroup(parent *gin.RouterGroup, svc *private.PortingService) {
+func setExportGroup(parent *gin.RouterGroup, svc *private.PortingService, bundleSvc *private.BundleService) {
+	parent = parent.Group("/")
+	parent.Use(setSecureConfigEncryptor())
+
 	exportGroup := parent.Group("/export")
 	exportGroup.Use(privilegesRequired("vxapi.modules.control.export"))
 	{
 		exportGroup.POST("/modules/:module_name/versions/:version", svc.ExportModule)
 	}
+
+	exportPoliciesGroup := parent.Group("/export")
+	exportPoliciesGroup.Use(privilegesRequired("vxapi.modules.control.export", "vxapi.policies.api.view"))
+	{
+		exportPoliciesGroup.POST("/policies", bundleSvc.ExportPolicies)
+	}
 }
 
-func setImportGroup(parent *gin.RouterGroup, svc *private.PortingService) {
+func setImportGroup(parent *gin.RouterGroup, svc *private.PortingService, bundleSvc *private.BundleService) {
+	parent = parent.Group("/")
+	parent.Use(setSecureConfigEncryptor())
+
 	importGroup := parent.Group("/import")
 	importGroup.Use(privilegesRequired("vxapi.modules.control.import"))
 	{
 		importGroup.POST("/modules/:module_name/versions/:version", svc.ImportModule)
 	}
+
+	importPoliciesGroup := parent.Group("/import")
+	importPoliciesGroup.Use(privilegesRequired("vxapi.modules.control.import", "vxapi.policies.api.create", "vxapi.policies.api.edit"))
+	{
+		importPoliciesGroup.POST("/policies", bundleSvc.ImportPolicies)
+	}
+}
+
+func setPublishersGroup(parent *gin.RouterGroup, svc *private.PublisherService) {
+	publishersCreateGroup := parent.Group("/publishers")
+	publishersCreateGroup.Use(privilegesRequired("vxapi.publishers.api.create"))
+	{
+		publishersCreateGroup.POST("/", svc.CreatePublisher)
+	}
+
+	publishersDeleteGroup := parent.Group("/publishers")
+	publishersDeleteGroup.Use(privilegesRequired("vxapi.publishers.api.delete"))
+	{
+		publishersDeleteGroup.DELETE("/:id", svc.DeletePublisher)
+	}
+
+	publishersEditGroup := parent.Group("/publishers")
+	publishersEditGroup.Use(privilegesRequired("vxapi.publishers.api.edit"))
+	{
+		publishersEditGroup.PUT("/:id", svc.PatchPublisher)
+	}
+
+	publishersViewGroup := parent.Group("/publishers")
+	publishersViewGroup.Use(privilegesRequired("vxapi.publishers.api.view"))
+	{
+		publishersViewGroup.GET("/", svc.GetPublishers)
+		publishersViewGroup.GET("/:id", svc.GetPublisher)
+	}
 }
 
 func setOptionsGroup(parent *gin.RouterGroup, svc *private.OptionService) {
@@ -575,11 +676,40 @@ func setVersionsGroup(parent *gin.RouterGroup, svc *private.VersionService) {
 	}
 }
 
+func setAuditGroup(parent *gin.RouterGroup, svc *private.AuditService) {
+	auditGroup := parent.Group("/audit")
+	auditGroup.Use(privilegesRequired("vxapi.audit.api.view"))
+	{
+		auditGroup.GET("/", svc.GetAuditLog)
+		auditGroup.GET("/export", svc.ExportAuditLog)
+	}
+}
+
 func setRolesGroup(parent *gin.RouterGroup, svc *private.RoleService) {
-	rolesGroup := parent.Group("/roles")
-	rolesGroup.Use(privilegesRequired("vxapi.roles.api.view"))
+	rolesCreateGroup := parent.Group("/roles")
+	rolesCreateGroup.Use(privilegesRequired("vxapi.roles.api.create"))
+	{
+		rolesCreateGroup.POST("/", svc.CreateRole)
+	}
+
+	rolesDeleteGroup := parent.Group("/roles")
+	rolesDeleteGroup.Use(privilegesRequired("vxapi.roles.api.delete"))
+	{
+		rolesDeleteGroup.DELETE("/:id", svc.DeleteRole)
+	}
+
+	rolesEditGroup := parent.Group("/roles")
+	rolesEditGroup.Use(privilegesRequired("vxapi.roles.api.edit"))
 	{
-		rolesGroup.GET("/", svc.GetRoles)
+		rolesEditGroup.PUT("/:id", svc.PatchRole)
+		rolesEditGroup.PUT("/:id/privileges", svc.PatchRolePrivileges)
+	}
+
+	rolesViewGroup := parent.Group("/roles")
+	rolesViewGroup.Use(privilegesRequired("vxapi.roles.api.view"))
+	{
+		rolesViewGroup.GET("/", svc.GetRoles)
+		rolesViewGroup.GET("/:id", svc.GetRole)
 	}
 }
 
@@ -654,6 +784,8 @@ func setUsersGroup(parent *gin.RouterGroup, svc *private.UserService) {
 	usersEditGroup.Use(privilegesRequired("vxapi.users.api.edit"))
 	{
 		usersEditGroup.PUT("/:hash", svc.PatchUser)
+		usersEditGroup.DELETE("/:hash/mfa", svc.ResetUserMFA)
+		usersEditGroup.DELETE("/:hash/sessions", svc.DeleteUserSessions)
 	}
 
 	usersViewGroup := parent.Group("/users")
@@ -661,6 +793,7 @@ func setUsersGroup(parent *gin.RouterGroup, svc *private.UserService) {
 	{
 		usersViewGroup.GET("/", svc.GetUsers)
 		usersViewGroup.GET("/:hash", svc.GetUser)
+		usersViewGroup.GET("/:hash/sessions", svc.GetUserSessions)
 	}
 
 	userViewGroup := parent.Group("/user")
@@ -668,9 +801,34 @@ func setUsersGroup(parent *gin.RouterGroup, svc *private.UserService) {
 		userViewGroup.GET("/", svc.GetCurrentUser)
 	}
 
+	userTokensGroup := parent.Group("/user/tokens")
+	{
+		userTokensGroup.GET("/", svc.GetCurrentUserTokens)
+		userTokensGroup.POST("/", svc.CreateCurrentUserToken)
+		userTokensGroup.GET("/:hash", svc.GetCurrentUserToken)
+		userTokensGroup.PUT("/:hash", svc.PatchCurrentUserToken)
+		userTokensGroup.DELETE("/:hash", svc.DeleteCurrentUserToken)
+	}
+
+	userSessionsGroup := parent.Group("/user/sessions")
+	{
+		userSessionsGroup.GET("/", svc.GetCurrentUserSessions)
+		userSessionsGroup.DELETE("/:id", svc.DeleteCurrentUserSession)
+	}
+
 	userEditGroup := parent.Group("/user")
 	userEditGroup.Use(localUserRequired())
 	{
 		userEditGroup.PUT("/password", svc.ChangePasswordCurrentUser)
 	}
+
+	userMFAGroup := parent.Group("/user/mfa")
+	userMFAGroup.Use(localUserRequired())
+	userMFAGroup.Use(setSecureConfigEncryptor())
+	{
+		userMFAGroup.GET("/", svc.GetCurrentUserMFA)
+		userMFAGroup.POST("/", svc.EnrollCurrentUserMFA)
+		userMFAGroup.PUT("/", svc.ActivateCurrentUserMFA)
+		userMFAGroup.DELETE("/", svc.DisableCurrentUserMFA)
+	}
 }
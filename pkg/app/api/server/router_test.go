@@ -0,0 +1,56 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"soldr/pkg/app/api/storage"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetTrustedProxies(t *testing.T) {
+	getIPKey := func(t *testing.T, proxies []string, remoteAddr, forwardedFor string) string {
+		t.Helper()
+		router := gin.New()
+		require.NoError(t, setTrustedProxies(router, proxies))
+		var ipKey string
+		router.GET("/login", func(c *gin.Context) {
+			_, ipKey = storage.LoginLockoutKeys("admin", c.ClientIP())
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/login", nil)
+		req.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+			req.Header.Set("X-Real-IP", forwardedFor)
+		}
+		router.ServeHTTP(httptest.NewRecorder(), req)
+		return ipKey
+	}
+
+	t.Run("spoofed header without trusted proxies", func(t *testing.T) {
+		assert.Equal(t, "ip:10.0.0.1", getIPKey(t, nil, "10.0.0.1:12345", ""))
+		assert.Equal(t, "ip:10.0.0.1", getIPKey(t, nil, "10.0.0.1:12345", "192.168.1.1"))
+		assert.Equal(t, "ip:10.0.0.1", getIPKey(t, nil, "10.0.0.1:12345", "192.168.1.2, 10.0.0.1"))
+	})
+
+	t.Run("spoofed header from untrusted address", func(t *testing.T) {
+		proxies := []string{"172.16.0.0/12"}
+		assert.Equal(t, "ip:10.0.0.1", getIPKey(t, proxies, "10.0.0.1:12345", "192.168.1.1"))
+	})
+
+	t.Run("header from trusted proxy", func(t *testing.T) {
+		proxies := []string{"172.16.0.0/12"}
+		assert.Equal(t, "ip:10.0.0.1", getIPKey(t, proxies, "172.16.0.2:12345", "10.0.0.1"))
+		assert.Equal(t, "ip:10.0.0.1", getIPKey(t, proxies, "172.16.0.2:12345", "192.168.1.1, 10.0.0.1"),
+			"only addresses which are added by trusted proxies must be used")
+	})
+
+	t.Run("invalid proxy", func(t *testing.T) {
+		assert.Error(t, setTrustedProxies(gin.New(), []string{"proxy.local"}))
+	})
+}
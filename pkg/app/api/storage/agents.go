@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// AgentsSQLMappers is mapping of agents table query fields to the table columns
+var AgentsSQLMappers = map[string]interface{}{
+	"id":             "`{{table}}`.id",
+	"hash":           "`{{table}}`.hash",
+	"group_id":       "`{{table}}`.group_id",
+	"group_name":     "JSON_UNQUOTE(JSON_EXTRACT(`groups`.info, '$.name.{{lang}}'))",
+	"policy_id":      "`gtp`.policy_id",
+	"module_name":    "`modules`.name",
+	"description":    "`{{table}}`.description",
+	"version":        "`{{table}}`.version",
+	"info":           "`{{table}}`.info",
+	"status":         "`{{table}}`.status",
+	"auth_status":    "`{{table}}`.auth_status",
+	"ip":             "`{{table}}`.ip",
+	"os":             "CONCAT(`{{table}}`.os_type,':',`{{table}}`.os_arch)",
+	"os_arch":        "`{{table}}`.os_arch",
+	"os_type":        "`{{table}}`.os_type",
+	"os_name":        "`{{table}}`.os_name",
+	"hostname":       "`{{table}}`.hostname",
+	"connected_date": "`{{table}}`.connected_date",
+	"created_date":   "`{{table}}`.created_date",
+	"net_ips":        "JSON_EXTRACT(`{{table}}`.info, '$.net.ips')",
+	"tags":           TagsMapper,
+	"users":          "JSON_EXTRACT(`{{table}}`.info, '$.users')",
+	"cpu_model":      "JSON_UNQUOTE(JSON_EXTRACT(`{{table}}`.info, '$.hardware.cpu.model'))",
+	"macs":           AgentMACsMapper,
+	"packages":       AgentPackagesMapper,
+	"services":       AgentServicesMapper,
+	"data": "CONCAT(`{{table}}`.hash, ' | ', " +
+		"`{{table}}`.description, ' | ', " +
+		"`{{table}}`.status, ' | ', " +
+		"`{{table}}`.auth_status, ' | ', " +
+		"CASE" +
+		"  WHEN `{{table}}`.status = 'connected' THEN 'подключен'" +
+		"  WHEN `{{table}}`.status = 'disconnected' THEN 'отключен'" +
+		"  ELSE ''" +
+		"END, ' | ', " +
+		"CASE" +
+		"  WHEN `{{table}}`.auth_status = 'authorized' THEN 'авторизован'" +
+		"  WHEN `{{table}}`.auth_status = 'unauthorized' THEN 'неавторизован'" +
+		"  WHEN `{{table}}`.auth_status = 'blocked' THEN 'заблокирован'" +
+		"  ELSE ''" +
+		"END, ' | ', " +
+		"JSON_EXTRACT(`{{table}}`.info, '$.net.ips'), ' | ', " +
+		"JSON_EXTRACT(`{{table}}`.info, '$.tags'), ' | ', " +
+		"`{{table}}`.os_type, ' | ', " +
+		"`{{table}}`.os_arch, ' | ', " +
+		"`{{table}}`.os_name, ' | ', " +
+		"`{{table}}`.hostname, ' | ', " +
+		"`{{table}}`.version)",
+}
+
+// AgentMACsMapper is function to make network interfaces MAC addresses field mapper for agents table
+func AgentMACsMapper(q *TableQuery, db *gorm.DB, value interface{}) *gorm.DB {
+	return JsonArrayMapper(q, db, value, "`{{table}}`.info", "$.hardware.interfaces[*].mac")
+}
+
+// AgentServicesMapper is function to make running services field mapper for agents table
+func AgentServicesMapper(q *TableQuery, db *gorm.DB, value interface{}) *gorm.DB {
+	return JsonArrayMapper(q, db, value, "`{{table}}`.info", "$.services[*].name")
+}
+
+// maxPackageNameMatches is a number of packages with the same name which are checked by version filter,
+// the same package is installed several times for different architectures or kernel versions
+const maxPackageNameMatches = 16
+
+// AgentPackagesMapper is function to make installed packages field mapper for agents table,
+// the value is a package name with optional version after colon (e.g. "openssl:1.1%")
+func AgentPackagesMapper(q *TableQuery, db *gorm.DB, value interface{}) *gorm.DB {
+	const namePath = "$.packages[*].name"
+	nameCond := q.DoConditionFormat("JSON_SEARCH(LOWER(`{{table}}`.info), 'one', LOWER(?), NULL, '" +
+		namePath + "') IS NOT NULL")
+	// MySQL 5.7 has no JSON_TABLE so version is taken by the path of every found package name,
+	// single found path is returned as a string so it's merged into an array to take paths by index
+	searchAll := "JSON_MERGE_PRESERVE(JSON_ARRAY(), " +
+		"JSON_SEARCH(LOWER(`{{table}}`.info), 'all', LOWER(?), NULL, '" + namePath + "'))"
+	versionConds := make([]string, 0, maxPackageNameMatches)
+	for idx := 0; idx < maxPackageNameMatches; idx++ {
+		versionConds = append(versionConds, fmt.Sprintf("LOWER(JSON_UNQUOTE(JSON_EXTRACT(`{{table}}`.info, "+
+			"REPLACE(JSON_UNQUOTE(JSON_EXTRACT(%s, '$[%d]')), '.name', '.version')))) LIKE LOWER(?)", searchAll, idx))
+	}
+	versionCond := q.DoConditionFormat("(" + strings.Join(versionConds, " OR ") + ")")
+
+	var (
+		conds []string
+		vs    []interface{}
+	)
+	addPackage := func(v string) {
+		// version may contain colon as an epoch separator (e.g. "1:1.1.1f-1ubuntu2")
+		if name, version, ok := strings.Cut(v, ":"); ok && name != "" && version != "" {
+			conds = append(conds, versionCond)
+			for idx := 0; idx < maxPackageNameMatches; idx++ {
+				vs = append(vs, name, version)
+			}
+		} else {
+			conds = append(conds, nameCond)
+			vs = append(vs, v)
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		addPackage(v)
+	case []interface{}:
+		for _, t := range v {
+			if ts, ok := t.(string); ok {
+				addPackage(ts)
+			}
+		}
+	}
+	if len(conds) == 0 {
+		return db
+	}
+	return db.Where(strings.Join(conds, " OR "), vs...)
+}
@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentPackagesMapper(t *testing.T) {
+	db := newTestDB(t)
+	query := TableQuery{
+		Lang:       "en",
+		Expression: `packages in ("nginx", "openssl:1:1.1.1f%")`,
+	}
+	require.NoError(t, query.Init("agents", AgentsSQLMappers))
+
+	sql, args, err := query.expr.compile(&query, db)
+	require.NoError(t, err)
+	nameCond := "JSON_SEARCH(LOWER(`agents`.info), 'one', LOWER(?), NULL, '$.packages[*].name') IS NOT NULL"
+	require.True(t, strings.HasPrefix(sql, "("+nameCond+" OR ("), sql)
+
+	// version is checked by every path of the found package name
+	searchAll := "JSON_MERGE_PRESERVE(JSON_ARRAY(), " +
+		"JSON_SEARCH(LOWER(`agents`.info), 'all', LOWER(?), NULL, '$.packages[*].name'))"
+	versionConds := strings.Split(strings.TrimSuffix(strings.TrimPrefix(sql, "("+nameCond+" OR ("), "))"), " OR ")
+	require.Len(t, versionConds, maxPackageNameMatches)
+	for idx, cond := range versionConds {
+		assert.Equal(t, fmt.Sprintf("LOWER(JSON_UNQUOTE(JSON_EXTRACT(`agents`.info, "+
+			"REPLACE(JSON_UNQUOTE(JSON_EXTRACT(%s, '$[%d]')), '.name', '.version')))) LIKE LOWER(?)", searchAll, idx), cond)
+	}
+
+	expArgs := []interface{}{"nginx"}
+	for idx := 0; idx < maxPackageNameMatches; idx++ {
+		expArgs = append(expArgs, "openssl", "1:1.1.1f%")
+	}
+	assert.Equal(t, expArgs, args)
+}
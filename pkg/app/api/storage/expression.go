@@ -0,0 +1,527 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jinzhu/gorm"
+
+	"soldr/pkg/app/api/utils"
+)
+
+const (
+	// maxExpressionDepth is a maximum nesting level of filter expression
+	maxExpressionDepth = 16
+	// maxExpressionFilters is a maximum amount of filters into filter expression
+	maxExpressionFilters = 64
+)
+
+// FilterExpression is a node of boolean filter expression tree, the node is either
+// logical operation over nested nodes (and, or, not) or a single filter by the field
+//
+//	JSON syntax:  {"or":[{"field":"status","value":"disconnected","operator":"="},
+//	                     {"not":{"field":"tags","value":["a","b"],"operator":"in"}}]}
+//	query syntax: status = "disconnected" OR NOT tags in ("a", "b")
+type FilterExpression struct {
+	And         []FilterExpression `json:"and,omitempty"`
+	Or          []FilterExpression `json:"or,omitempty"`
+	Not         *FilterExpression  `json:"not,omitempty"`
+	TableFilter `json:""`
+}
+
+// ParseFilterExpression is function to parse filter expression from JSON tree or from query syntax,
+// JSON tree is detected by the leading brace
+func ParseFilterExpression(value string) (*FilterExpression, error) {
+	var (
+		expr *FilterExpression
+		err  error
+	)
+
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "{") {
+		expr = &FilterExpression{}
+		decoder := json.NewDecoder(strings.NewReader(value))
+		decoder.DisallowUnknownFields()
+		if err = decoder.Decode(expr); err != nil {
+			return nil, fmt.Errorf("failed to parse filter expression JSON: %w", err)
+		}
+	} else if expr, err = parseFilterExpressionSyntax(value); err != nil {
+		return nil, fmt.Errorf("failed to parse filter expression: %w", err)
+	}
+
+	filters := 0
+	if err = expr.check(0, &filters); err != nil {
+		return nil, fmt.Errorf("invalid filter expression: %w", err)
+	}
+
+	return expr, nil
+}
+
+func (e *FilterExpression) isFilter() bool {
+	return e.Field != ""
+}
+
+func (e *FilterExpression) check(depth int, filters *int) error {
+	if depth > maxExpressionDepth {
+		return fmt.Errorf("nesting level exceeds %d", maxExpressionDepth)
+	}
+
+	nodes := 0
+	if e.isFilter() {
+		nodes++
+	}
+	if e.And != nil {
+		nodes++
+	}
+	if e.Or != nil {
+		nodes++
+	}
+	if e.Not != nil {
+		nodes++
+	}
+	if nodes != 1 {
+		return errors.New("node must be one of and, or, not or filter")
+	}
+
+	switch {
+	case e.isFilter():
+		*filters++
+		if *filters > maxExpressionFilters {
+			return fmt.Errorf("amount of filters exceeds %d", maxExpressionFilters)
+		}
+		switch e.Operator {
+		case "", "<", "<=", ">=", ">", "=", "!=", "like", "not like", "in":
+		default:
+			return fmt.Errorf("unknown operator '%s' for field '%s'", e.Operator, e.Field)
+		}
+		if e.Value == nil {
+			return fmt.Errorf("value of field '%s' is required", e.Field)
+		}
+	case e.Not != nil:
+		return e.Not.check(depth+1, filters)
+	default:
+		nodes := e.And
+		if e.Or != nil {
+			nodes = e.Or
+		}
+		if len(nodes) == 0 {
+			return errors.New("logical operation must contain nested nodes")
+		}
+		for idx := range nodes {
+			if err := nodes[idx].check(depth+1, filters); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Fields returns list of all fields which are used into the expression
+func (e *FilterExpression) Fields() []string {
+	var fields []string
+	if e.isFilter() {
+		fields = append(fields, e.Field)
+	}
+	if e.Not != nil {
+		fields = append(fields, e.Not.Fields()...)
+	}
+	for idx := range e.And {
+		fields = append(fields, e.And[idx].Fields()...)
+	}
+	for idx := range e.Or {
+		fields = append(fields, e.Or[idx].Fields()...)
+	}
+	return fields
+}
+
+// conditionSQL returns where conditions SQL of the chain and its arguments
+func conditionSQL(db *gorm.DB) (string, []interface{}) {
+	scope := db.NewScope(nil)
+	scope.InstanceSet("skip_bindvar", true)
+	sql := strings.TrimPrefix(strings.TrimSpace(scope.CombinedConditionSql()), "WHERE ")
+	return sql, scope.SQLVars
+}
+
+// compile is function to build SQL condition by the expression through query sql mappers,
+// the filter is built by the same rules as filters list in DataFilter
+func (e *FilterExpression) compile(q *TableQuery, db *gorm.DB) (string, []interface{}, error) {
+	switch {
+	case e.isFilter():
+		mapper, ok := q.sqlMappers[e.Field]
+		if !ok {
+			return "", nil, fmt.Errorf("unknown field '%s'", e.Field)
+		}
+		operator, value, ok := filterValue(e.TableFilter)
+		if !ok {
+			return "", nil, fmt.Errorf("invalid value of field '%s'", e.Field)
+		}
+		cdb := db.New()
+		switch t := mapper.(type) {
+		case string:
+			if _, ok := value.([]interface{}); ok {
+				cdb = cdb.Where(t+" "+operator+" (?)", value)
+			} else {
+				cdb = cdb.Where(t+" "+operator+" ?", value)
+			}
+		case func(q *TableQuery, db *gorm.DB, value interface{}) *gorm.DB:
+			cdb = t(q, cdb, value)
+		}
+		sql, args := conditionSQL(cdb)
+		if sql == "" {
+			return "", nil, fmt.Errorf("unsupported value of field '%s'", e.Field)
+		}
+		return sql, args, nil
+	case e.Not != nil:
+		sql, args, err := e.Not.compile(q, db)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT (" + sql + ")", args, nil
+	default:
+		nodes, join := e.And, " AND "
+		if e.Or != nil {
+			nodes, join = e.Or, " OR "
+		}
+		var (
+			conds []string
+			args  []interface{}
+		)
+		for idx := range nodes {
+			sql, nargs, err := nodes[idx].compile(q, db)
+			if err != nil {
+				return "", nil, err
+			}
+			conds = append(conds, "("+sql+")")
+			args = append(args, nargs...)
+		}
+		return strings.Join(conds, join), args, nil
+	}
+}
+
+// filterValue returns operator and value of the filter prepared to use into SQL condition
+func filterValue(f TableFilter) (string, interface{}, bool) {
+	switch f.Operator {
+	case "<", "<=", ">=", ">", "=", "!=", "like", "not like", "in":
+	default:
+		f.Operator = "like"
+	}
+
+	switch v := f.Value.(type) {
+	case string:
+		if v == "" {
+			return "", nil, false
+		}
+		if utils.StringInSlice(f.Operator, []string{"like", "not like"}) {
+			v = "%" + strings.ToLower(v) + "%"
+		}
+		return f.Operator, v, true
+	case float64, bool:
+		return f.Operator, v, true
+	case []interface{}:
+		var vi []interface{}
+		for _, ti := range v {
+			switch ts := ti.(type) {
+			case string:
+				vi = append(vi, strings.ToLower(ts))
+			case float64, bool:
+				vi = append(vi, ts)
+			}
+		}
+		if len(vi) == 0 {
+			return "", nil, false
+		}
+		return "in", vi, true
+	default:
+		return "", nil, false
+	}
+}
+
+type exprTokenType int
+
+const (
+	exprTokenEOF exprTokenType = iota
+	exprTokenIdent
+	exprTokenString
+	exprTokenNumber
+	exprTokenOperator
+	exprTokenLParen
+	exprTokenRParen
+	exprTokenComma
+)
+
+type exprToken struct {
+	kind  exprTokenType
+	value string
+	pos   int
+}
+
+func tokenizeFilterExpression(input string) ([]exprToken, error) {
+	var tokens []exprToken
+	runes := []rune(input)
+	for pos := 0; pos < len(runes); {
+		r := runes[pos]
+		switch {
+		case unicode.IsSpace(r):
+			pos++
+		case r == '(':
+			tokens = append(tokens, exprToken{exprTokenLParen, "(", pos})
+			pos++
+		case r == ')':
+			tokens = append(tokens, exprToken{exprTokenRParen, ")", pos})
+			pos++
+		case r == ',':
+			tokens = append(tokens, exprToken{exprTokenComma, ",", pos})
+			pos++
+		case r == '"' || r == '\'':
+			start := pos
+			var value strings.Builder
+			for pos++; ; pos++ {
+				if pos >= len(runes) {
+					return nil, fmt.Errorf("unterminated string at %d", start)
+				}
+				if runes[pos] == '\\' && pos+1 < len(runes) {
+					pos++
+					value.WriteRune(runes[pos])
+					continue
+				}
+				if runes[pos] == r {
+					pos++
+					break
+				}
+				value.WriteRune(runes[pos])
+			}
+			tokens = append(tokens, exprToken{exprTokenString, value.String(), start})
+		case r == '<' || r == '>' || r == '=' || r == '!':
+			start := pos
+			op := string(r)
+			pos++
+			if pos < len(runes) && runes[pos] == '=' {
+				op += "="
+				pos++
+			}
+			if op == "!" {
+				return nil, fmt.Errorf("unexpected symbol '!' at %d", start)
+			}
+			tokens = append(tokens, exprToken{exprTokenOperator, op, start})
+		case r == '-' || r == '.' || unicode.IsDigit(r):
+			start := pos
+			for pos++; pos < len(runes) && (runes[pos] == '.' || unicode.IsDigit(runes[pos])); pos++ {
+			}
+			tokens = append(tokens, exprToken{exprTokenNumber, string(runes[start:pos]), start})
+		case r == '_' || unicode.IsLetter(r):
+			start := pos
+			for pos++; pos < len(runes) && (runes[pos] == '_' || runes[pos] == '.' ||
+				unicode.IsLetter(runes[pos]) || unicode.IsDigit(runes[pos])); pos++ {
+			}
+			tokens = append(tokens, exprToken{exprTokenIdent, string(runes[start:pos]), start})
+		default:
+			return nil, fmt.Errorf("unexpected symbol '%c' at %d", r, pos)
+		}
+	}
+	return append(tokens, exprToken{exprTokenEOF, "", len(runes)}), nil
+}
+
+// exprParser is a recursive descent parser of filter expression query syntax:
+//
+//	expr   = term { OR term }
+//	term   = factor { AND factor }
+//	factor = NOT factor | "(" expr ")" | field operator value
+//	value  = string | number | true | false | "(" value { "," value } ")"
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+	depth  int
+}
+
+func parseFilterExpressionSyntax(input string) (*FilterExpression, error) {
+	tokens, err := tokenizeFilterExpression(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{tokens: tokens}
+	expr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != exprTokenEOF {
+		return nil, fmt.Errorf("unexpected token '%s' at %d", tok.value, tok.pos)
+	}
+	return expr, nil
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != exprTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *exprParser) isKeyword(tok exprToken, keyword string) bool {
+	return tok.kind == exprTokenIdent && strings.EqualFold(tok.value, keyword)
+}
+
+func (p *exprParser) parseExpr() (*FilterExpression, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxExpressionDepth {
+		return nil, fmt.Errorf("nesting level exceeds %d", maxExpressionDepth)
+	}
+
+	term, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	nodes := []FilterExpression{*term}
+	for p.isKeyword(p.peek(), "or") {
+		p.next()
+		if term, err = p.parseTerm(); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, *term)
+	}
+	if len(nodes) == 1 {
+		return &nodes[0], nil
+	}
+	return &FilterExpression{Or: nodes}, nil
+}
+
+func (p *exprParser) parseTerm() (*FilterExpression, error) {
+	factor, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	nodes := []FilterExpression{*factor}
+	for p.isKeyword(p.peek(), "and") {
+		p.next()
+		if factor, err = p.parseFactor(); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, *factor)
+	}
+	if len(nodes) == 1 {
+		return &nodes[0], nil
+	}
+	return &FilterExpression{And: nodes}, nil
+}
+
+func (p *exprParser) parseFactor() (*FilterExpression, error) {
+	tok := p.peek()
+	switch {
+	case p.isKeyword(tok, "not"):
+		p.next()
+		p.depth++
+		defer func() { p.depth-- }()
+		if p.depth > maxExpressionDepth {
+			return nil, fmt.Errorf("nesting level exceeds %d", maxExpressionDepth)
+		}
+		factor, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return &FilterExpression{Not: factor}, nil
+	case tok.kind == exprTokenLParen:
+		p.next()
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if tok = p.next(); tok.kind != exprTokenRParen {
+			return nil, fmt.Errorf("expected ')' at %d", tok.pos)
+		}
+		return expr, nil
+	case tok.kind == exprTokenIdent:
+		return p.parseFilter()
+	default:
+		return nil, fmt.Errorf("unexpected token '%s' at %d", tok.value, tok.pos)
+	}
+}
+
+func (p *exprParser) parseFilter() (*FilterExpression, error) {
+	field := p.next()
+	if field.kind != exprTokenIdent {
+		return nil, fmt.Errorf("expected field name at %d", field.pos)
+	}
+
+	var operator string
+	tok := p.next()
+	switch {
+	case tok.kind == exprTokenOperator:
+		operator = tok.value
+	case p.isKeyword(tok, "like"):
+		operator = "like"
+	case p.isKeyword(tok, "in"):
+		operator = "in"
+	case p.isKeyword(tok, "not") && p.isKeyword(p.peek(), "like"):
+		p.next()
+		operator = "not like"
+	default:
+		return nil, fmt.Errorf("expected operator after field '%s' at %d", field.value, tok.pos)
+	}
+
+	value, err := p.parseValue(operator == "in")
+	if err != nil {
+		return nil, err
+	}
+
+	return &FilterExpression{
+		TableFilter: TableFilter{
+			Field:    field.value,
+			Operator: operator,
+			Value:    value,
+		},
+	}, nil
+}
+
+func (p *exprParser) parseValue(isList bool) (interface{}, error) {
+	if !isList {
+		return p.parseScalar()
+	}
+
+	if tok := p.next(); tok.kind != exprTokenLParen {
+		return nil, fmt.Errorf("expected '(' at %d", tok.pos)
+	}
+	var values []interface{}
+	for {
+		value, err := p.parseScalar()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		tok := p.next()
+		if tok.kind == exprTokenRParen {
+			return values, nil
+		} else if tok.kind != exprTokenComma {
+			return nil, fmt.Errorf("expected ',' or ')' at %d", tok.pos)
+		}
+	}
+}
+
+func (p *exprParser) parseScalar() (interface{}, error) {
+	tok := p.next()
+	switch {
+	case tok.kind == exprTokenString:
+		return tok.value, nil
+	case tok.kind == exprTokenNumber:
+		value, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s' at %d", tok.value, tok.pos)
+		}
+		return value, nil
+	case p.isKeyword(tok, "true"):
+		return true, nil
+	case p.isKeyword(tok, "false"):
+		return false, nil
+	default:
+		return nil, fmt.Errorf("expected value at %d", tok.pos)
+	}
+}
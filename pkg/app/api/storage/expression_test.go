@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSQLMappers = map[string]interface{}{
+	"id":     "`{{table}}`.id",
+	"status": "`{{table}}`.status",
+	"name":   "`{{table}}`.name",
+	"data": func(q *TableQuery, db *gorm.DB, value interface{}) *gorm.DB {
+		return db.Where("`agents`.a LIKE ? OR `agents`.b LIKE ?", value, value)
+	},
+}
+
+// newTestDB returns gorm instance which is used to build SQL only, connection is never established
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	sqlDB, err := sql.Open("mysql", "user@tcp(127.0.0.1:1)/db")
+	require.NoError(t, err)
+	db, _ := gorm.Open("mysql", sqlDB)
+	require.NotNil(t, db)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	return db
+}
+
+func TestParseFilterExpressionSyntax(t *testing.T) {
+	expr, err := ParseFilterExpression(
+		`status = "connected" OR (name like 'agent \'1\'' AND NOT id in (1, 2.5)) and data not like "x"`)
+	require.NoError(t, err)
+
+	expected := &FilterExpression{Or: []FilterExpression{
+		{TableFilter: TableFilter{Field: "status", Operator: "=", Value: "connected"}},
+		{And: []FilterExpression{
+			{And: []FilterExpression{
+				{TableFilter: TableFilter{Field: "name", Operator: "like", Value: "agent '1'"}},
+				{Not: &FilterExpression{
+					TableFilter: TableFilter{Field: "id", Operator: "in", Value: []interface{}{1.0, 2.5}},
+				}},
+			}},
+			{TableFilter: TableFilter{Field: "data", Operator: "not like", Value: "x"}},
+		}},
+	}}
+	assert.Equal(t, expected, expr)
+	assert.Equal(t, []string{"status", "name", "id", "data"}, expr.Fields())
+}
+
+func TestParseFilterExpressionJSON(t *testing.T) {
+	expr, err := ParseFilterExpression(`{"or":[{"field":"status","value":"connected","operator":"="},` +
+		`{"not":{"field":"id","value":[1,2],"operator":"in"}}]}`)
+	require.NoError(t, err)
+
+	expected := &FilterExpression{Or: []FilterExpression{
+		{TableFilter: TableFilter{Field: "status", Operator: "=", Value: "connected"}},
+		{Not: &FilterExpression{
+			TableFilter: TableFilter{Field: "id", Operator: "in", Value: []interface{}{1.0, 2.0}},
+		}},
+	}}
+	assert.Equal(t, expected, expr)
+}
+
+func TestParseFilterExpressionErrors(t *testing.T) {
+	for _, value := range []string{
+		``,
+		`status`,
+		`status =`,
+		`status == "a"`,
+		`status = "a`,
+		`status = "a" OR`,
+		`(status = "a"`,
+		`status in "a"`,
+		`status ~ "a"`,
+		`{"and":[]}`,
+		`{"field":"status","value":"a","operator":"regexp"}`,
+		`{"field":"status","operator":"="}`,
+		`{"field":"status","value":"a","and":[{"field":"id","value":1}]}`,
+		`{"field":"status","value":"a","unknown":true}`,
+		strings.Repeat("(", maxExpressionDepth+1) + `id = 1` + strings.Repeat(")", maxExpressionDepth+1),
+		strings.Repeat("NOT ", maxExpressionDepth+1) + `id = 1`,
+		strings.TrimSuffix(strings.Repeat(`id = 1 OR `, maxExpressionFilters+1), " OR "),
+	} {
+		_, err := ParseFilterExpression(value)
+		assert.Error(t, err, "expression must be rejected: %s", value)
+	}
+}
+
+func TestFilterExpressionCompile(t *testing.T) {
+	db := newTestDB(t)
+	query := TableQuery{
+		Lang:       "en",
+		Expression: `(status = "Connected" OR data like "X") AND NOT name in ("A", "b")`,
+	}
+	require.NoError(t, query.Init("agents", testSQLMappers))
+
+	sql, args, err := query.expr.compile(&query, db)
+	require.NoError(t, err)
+	assert.Equal(t, "(((LOWER(`agents`.status) = ?)) OR ((`agents`.a LIKE ? OR `agents`.b LIKE ?))) AND "+
+		"(NOT ((LOWER(`agents`.name) in (?,?))))", sql)
+	assert.Equal(t, []interface{}{"Connected", "%x%", "%x%", "a", "b"}, args)
+}
+
+func TestTableQueryInitExpression(t *testing.T) {
+	query := TableQuery{Lang: "en", Expression: `unknown = 1`}
+	assert.Error(t, query.Init("agents", testSQLMappers), "unknown field must be rejected")
+
+	query = TableQuery{Lang: "en", Expression: `id >`}
+	assert.Error(t, query.Init("agents", testSQLMappers), "invalid syntax must be rejected")
+
+	query = TableQuery{Lang: "en"}
+	require.NoError(t, query.Init("agents", testSQLMappers))
+	assert.Empty(t, query.ExpressionFields())
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// groupRuleExcludedFields are agents fields which depend on the group of agent,
+// they can't be used into the rule to avoid cyclic membership of dynamic groups
+var groupRuleExcludedFields = map[string]struct{}{
+	"group_id":    {},
+	"group_name":  {},
+	"policy_id":   {},
+	"module_name": {},
+}
+
+// GroupRuleSQLMappers returns agents fields mapping which is allowed into the rule of dynamic group
+func GroupRuleSQLMappers() map[string]interface{} {
+	mappers := make(map[string]interface{}, len(AgentsSQLMappers))
+	for field, mapper := range AgentsSQLMappers {
+		if _, ok := groupRuleExcludedFields[field]; !ok {
+			mappers[field] = mapper
+		}
+	}
+	return mappers
+}
+
+// ValidateGroupRule is function to check the rule of dynamic group,
+// the rule is filter expression over agents fields e.g.
+//
+//	os_type = "windows" AND tags in ("dmz") AND hostname like "web-%"
+func ValidateGroupRule(rule string) error {
+	if strings.TrimSpace(rule) == "" {
+		return errors.New("group rule is empty")
+	}
+	expr, err := ParseFilterExpression(rule)
+	if err != nil {
+		return err
+	}
+	mappers := GroupRuleSQLMappers()
+	for _, field := range expr.Fields() {
+		if _, ok := mappers[field]; !ok {
+			return fmt.Errorf("wrong field '%s' for group rule", field)
+		}
+	}
+	return nil
+}
+
+// MatchGroupRule is function to get IDs of all agents which are matched by the rule of dynamic group
+func MatchGroupRule(db *gorm.DB, rule string) ([]uint64, error) {
+	if err := ValidateGroupRule(rule); err != nil {
+		return nil, err
+	}
+
+	query := TableQuery{
+		Size:       -1,
+		Type:       "filter",
+		Lang:       "en",
+		Expression: rule,
+	}
+	if err := query.Init("agents", GroupRuleSQLMappers()); err != nil {
+		return nil, err
+	}
+	query.SetFilters([]func(db *gorm.DB) *gorm.DB{
+		func(db *gorm.DB) *gorm.DB {
+			return db.Where("agents.deleted_at IS NULL")
+		},
+	})
+
+	var ids []uint64
+	err := db.Table(query.Table()).Scopes(query.DataFilter()).Pluck("agents.id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to match agents by group rule: %w", err)
+	}
+	return ids, nil
+}
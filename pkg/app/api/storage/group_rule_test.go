@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateGroupRule(t *testing.T) {
+	tests := []struct {
+		rule  string
+		valid bool
+	}{
+		{rule: `os_type = "windows" AND tags in ("dmz") AND hostname like "web-%"`, valid: true},
+		{rule: `{"not":{"field":"auth_status","value":"blocked","operator":"="}}`, valid: true},
+		{rule: ""},
+		{rule: `hostname like "web-%" AND group_name = "servers"`},
+		{rule: `policy_id = 1`},
+		{rule: `unknown = "value"`},
+		{rule: `os_type = `},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			err := ValidateGroupRule(tt.rule)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
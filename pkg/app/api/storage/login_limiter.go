@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"soldr/pkg/app/api/models"
+)
+
+// loginAttemptsRetries is a number of tries to update the lockout state which is changed concurrently by other requests
+const loginAttemptsRetries = 5
+
+// LoginLockoutConfig is a configuration of brute-force protection for local logins,
+// zero number of attempts disables the lockout by the key type
+type LoginLockoutConfig struct {
+	MaxUserAttempts int
+	MaxIPAttempts   int
+	Window          time.Duration
+	Duration        time.Duration
+}
+
+// LoginLimiter is storage of failed login attempts per user and per source IP,
+// it's kept into DB to share the lockout state between all API replicas
+type LoginLimiter struct {
+	db  *gorm.DB
+	cfg LoginLockoutConfig
+	now func() time.Time
+}
+
+// NewLoginLimiter is function to make failed login attempts storage which is shared between all API replicas via DB
+func NewLoginLimiter(db *gorm.DB, cfg LoginLockoutConfig) *LoginLimiter {
+	return &LoginLimiter{
+		db:  db,
+		cfg: cfg,
+		now: time.Now,
+	}
+}
+
+// LoginLockoutKeys returns keys to count failed login attempts per user and per source IP
+func LoginLockoutKeys(login, ip string) (string, string) {
+	return "user:" + strings.ToLower(login), "ip:" + ip
+}
+
+// Config returns brute-force protection configuration of the limiter
+func (l *LoginLimiter) Config() LoginLockoutConfig {
+	return l.cfg
+}
+
+// LockedUntil returns the latest time of active lockout by the keys
+func (l *LoginLimiter) LockedUntil(keys ...string) (time.Time, bool, error) {
+	var (
+		now      = l.now()
+		until    time.Time
+		attempts []models.LoginAttempts
+	)
+	if err := l.db.Find(&attempts, "lock_key IN (?) AND locked_until > ?", keys, now).Error; err != nil {
+		return until, false, fmt.Errorf("failed to get login lockout state: %w", err)
+	}
+	for _, la := range attempts {
+		if la.IsLocked(now) && la.LockedUntil.After(until) {
+			until = *la.LockedUntil
+		}
+	}
+	return until, !until.IsZero(), nil
+}
+
+// Fail registers failed login attempt by the user key and by the source IP key
+// and returns which of the keys have been locked by this attempt
+func (l *LoginLimiter) Fail(userKey, ipKey string) (bool, bool, error) {
+	userLocked, err := l.fail(userKey, l.cfg.MaxUserAttempts)
+	if err != nil {
+		return false, false, err
+	}
+	ipLocked, err := l.fail(ipKey, l.cfg.MaxIPAttempts)
+	if err != nil {
+		return userLocked, false, err
+	}
+	return userLocked, ipLocked, nil
+}
+
+// Reset removes failed login attempts by the keys after successful login
+func (l *LoginLimiter) Reset(keys ...string) error {
+	if err := l.db.Where("lock_key IN (?)", keys).Delete(&models.LoginAttempts{}).Error; err != nil {
+		return fmt.Errorf("failed to reset login attempts: %w", err)
+	}
+	return nil
+}
+
+// fail registers failed attempt by the key, the record is updated only if it wasn't changed
+// by concurrent request after reading so failures are counted precisely without DB locks
+func (l *LoginLimiter) fail(key string, maxAttempts int) (bool, error) {
+	if maxAttempts <= 0 {
+		return false, nil
+	}
+
+	for i := 0; i < loginAttemptsRetries; i++ {
+		now := l.now()
+		var la models.LoginAttempts
+		err := l.db.Take(&la, "lock_key = ?", key).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			l.deleteStale(now)
+			next, locked := l.nextAttempts(models.LoginAttempts{LockKey: key, FirstFail: now}, now, maxAttempts)
+			// the record could be created by concurrent request, so it's read again on error
+			if err = l.db.Create(&next).Error; err == nil {
+				return locked, nil
+			}
+			continue
+		} else if err != nil {
+			return false, fmt.Errorf("failed to get login attempts: %w", err)
+		}
+
+		if la.IsLocked(now) {
+			return false, nil
+		}
+		prev := la
+		if now.Sub(la.FirstFail) > l.cfg.Window {
+			la.Failures, la.FirstFail = 0, now
+		}
+		next, locked := l.nextAttempts(la, now, maxAttempts)
+		result := l.db.Model(&models.LoginAttempts{}).
+			Where("lock_key = ? AND failures = ? AND first_fail = ?", key, prev.Failures, prev.FirstFail).
+			UpdateColumns(map[string]interface{}{
+				"failures":     next.Failures,
+				"first_fail":   next.FirstFail,
+				"locked_until": next.LockedUntil,
+			})
+		if result.Error != nil {
+			return false, fmt.Errorf("failed to update login attempts: %w", result.Error)
+		} else if result.RowsAffected != 0 {
+			return locked, nil
+		}
+	}
+
+	return false, fmt.Errorf("failed to update login attempts which are changed concurrently")
+}
+
+func (l *LoginLimiter) nextAttempts(la models.LoginAttempts, now time.Time, maxAttempts int) (models.LoginAttempts, bool) {
+	la.Failures++
+	if la.Failures < maxAttempts {
+		return la, false
+	}
+	lockedUntil := now.Add(l.cfg.Duration)
+	la.Failures, la.FirstFail, la.LockedUntil = 0, now, &lockedUntil
+	return la, true
+}
+
+// deleteStale removes records which are out of the window and aren't locked, errors are ignored
+// because stale records don't affect the lockout
+func (l *LoginLimiter) deleteStale(now time.Time) {
+	l.db.Where("first_fail < ? AND (locked_until IS NULL OR locked_until <= ?)", now.Add(-l.cfg.Window), now).
+		Delete(&models.LoginAttempts{})
+}
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSQLiteDB returns in-memory DB with the tables which are created by the queries
+func newTestSQLiteDB(t *testing.T, queries ...string) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.DB().SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	for _, query := range queries {
+		require.NoError(t, db.Exec(query).Error)
+	}
+	return db
+}
+
+func newTestLoginLimiter(db *gorm.DB, now *time.Time) *LoginLimiter {
+	limiter := NewLoginLimiter(db, LoginLockoutConfig{
+		MaxUserAttempts: 3,
+		MaxIPAttempts:   5,
+		Window:          10 * time.Minute,
+		Duration:        5 * time.Minute,
+	})
+	limiter.now = func() time.Time { return *now }
+	return limiter
+}
+
+func TestLoginLimiter(t *testing.T) {
+	db := newTestSQLiteDB(t, `CREATE TABLE login_attempts (lock_key TEXT PRIMARY KEY, failures INTEGER NOT NULL,
+		first_fail DATETIME NOT NULL, locked_until DATETIME)`)
+	now := time.Unix(1600000000, 0).UTC()
+	// replicas of the API share the lockout state via DB
+	limiter, replica := newTestLoginLimiter(db, &now), newTestLoginLimiter(db, &now)
+	userKey, ipKey := LoginLockoutKeys("Admin", "127.0.0.1")
+	assert.Equal(t, "user:admin", userKey)
+	assert.Equal(t, "ip:127.0.0.1", ipKey)
+
+	isLocked := func(l *LoginLimiter, keys ...string) bool {
+		t.Helper()
+		_, locked, err := l.LockedUntil(keys...)
+		require.NoError(t, err)
+		return locked
+	}
+	fail := func(l *LoginLimiter, userKey, ipKey string) (bool, bool) {
+		t.Helper()
+		userLocked, ipLocked, err := l.Fail(userKey, ipKey)
+		require.NoError(t, err)
+		return userLocked, ipLocked
+	}
+
+	fail(limiter, userKey, ipKey)
+	fail(replica, userKey, ipKey)
+	assert.False(t, isLocked(limiter, userKey, ipKey), "login must not be locked before max attempts")
+
+	userLocked, ipLocked := fail(replica, userKey, ipKey)
+	assert.True(t, userLocked, "lockout must be started")
+	assert.False(t, ipLocked)
+	until, locked, err := limiter.LockedUntil(ipKey, userKey)
+	require.NoError(t, err)
+	assert.True(t, locked)
+	assert.True(t, now.Add(5*time.Minute).Equal(until))
+	userLocked, _ = fail(limiter, userKey, ipKey)
+	assert.False(t, userLocked, "locked key must not be locked again")
+
+	// the source IP is locked by the failures of other users
+	userLocked, ipLocked = fail(limiter, "user:other", ipKey)
+	assert.False(t, userLocked)
+	assert.True(t, ipLocked)
+	assert.True(t, isLocked(replica, "user:other", ipKey))
+
+	now = now.Add(5*time.Minute + time.Second)
+	assert.False(t, isLocked(limiter, userKey, ipKey), "lockout must be expired")
+
+	fail(limiter, userKey, ipKey)
+	fail(limiter, userKey, ipKey)
+	require.NoError(t, replica.Reset(userKey, ipKey))
+	fail(limiter, userKey, ipKey)
+	userLocked, _ = fail(limiter, userKey, ipKey)
+	assert.False(t, userLocked, "failures must be forgotten after reset")
+
+	now = now.Add(10*time.Minute + time.Second)
+	userLocked, _ = fail(limiter, userKey, ipKey)
+	assert.False(t, userLocked, "failures out of window must be forgotten")
+
+	disabled := NewLoginLimiter(db, LoginLockoutConfig{})
+	userLocked, ipLocked, err = disabled.Fail("user:new", "ip:10.0.0.1")
+	require.NoError(t, err)
+	assert.False(t, userLocked || ipLocked, "zero attempts must disable lockout")
+	var count int
+	require.NoError(t, db.Table("login_attempts").Where("lock_key IN (?)", []string{"user:new", "ip:10.0.0.1"}).
+		Count(&count).Error)
+	assert.Zero(t, count)
+}
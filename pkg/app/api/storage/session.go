@@ -2,10 +2,13 @@ package storage
 
 import (
 	"crypto/md5"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"strings"
 
+	"soldr/pkg/app/api/utils/dbencryptor"
 	"soldr/pkg/system"
 	"soldr/pkg/version"
 )
@@ -23,3 +26,52 @@ func MakeCookieStoreKey() []byte {
 	hash := sha256.Sum256([]byte(key))
 	return hash[:]
 }
+
+// MakeProtoTokenKey is function to get the key to sign vxproto tokens, it must be the same on all API replicas
+// so it's made from the configured secret or from the DB encryption key which is shared by the replicas already
+func MakeProtoTokenKey(secret string) ([]byte, error) {
+	if secret == "" {
+		key, err := dbencryptor.GetKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get DB encryption key: %w", err)
+		}
+		secret = "vxproto|" + hex.EncodeToString(key)
+	}
+	hash := sha256.Sum256([]byte(secret))
+	return hash[:], nil
+}
+
+// SessionCookieName is a name of the cookie which contains session token of the user
+const SessionCookieName = "auth"
+
+// SessionTokenPrefix is a prefix of the session token value into the cookie
+const SessionTokenPrefix = "vxst_"
+
+// MakeSessionToken is function to generate new session token value and its hash to store into DB
+func MakeSessionToken() (string, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("failed to generate session token: %w", err)
+	}
+	token := SessionTokenPrefix + hex.EncodeToString(secret)
+	return token, MakeUserTokenSecretHash(token), nil
+}
+
+// UserTokenPrefix is a prefix of the personal API token value to distinguish it from other bearer tokens
+const UserTokenPrefix = "vxut_"
+
+// MakeUserToken is function to generate new personal API token value and its hash to store into DB
+func MakeUserToken() (string, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("failed to generate user token: %w", err)
+	}
+	token := UserTokenPrefix + hex.EncodeToString(secret)
+	return token, MakeUserTokenSecretHash(token), nil
+}
+
+// MakeUserTokenSecretHash is function to get hash of the personal API token value to find it into DB
+func MakeUserTokenSecretHash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
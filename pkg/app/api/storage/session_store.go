@@ -0,0 +1,249 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-contrib/sessions"
+	gsessions "github.com/gorilla/sessions"
+	"github.com/jinzhu/gorm"
+
+	"soldr/pkg/app/api/models"
+)
+
+// sessionTouchInterval is a minimal interval to update last activity time of the session into DB
+const sessionTouchInterval = time.Minute
+
+// SessionStoreConfig is a configuration of server-side sessions lifetime
+type SessionStoreConfig struct {
+	// IdleTimeout is a time after the last request when the session is expired
+	IdleTimeout time.Duration
+	// AbsoluteTimeout is a time after the login when the session is expired regardless of activity
+	AbsoluteTimeout time.Duration
+}
+
+// DBSessionStore is sessions store for gin-contrib/sessions which keeps session values into DB,
+// the cookie contains only random session token and DB contains only its hash
+type DBSessionStore struct {
+	db      *gorm.DB
+	cfg     SessionStoreConfig
+	options *gsessions.Options
+	now     func() time.Time
+}
+
+// NewDBSessionStore is function to make sessions store which is shared between all API replicas via DB
+func NewDBSessionStore(db *gorm.DB, cfg SessionStoreConfig) *DBSessionStore {
+	return &DBSessionStore{
+		db:  db,
+		cfg: cfg,
+		options: &gsessions.Options{
+			Path:   "/",
+			MaxAge: int(cfg.AbsoluteTimeout / time.Second),
+		},
+		now: time.Now,
+	}
+}
+
+// Options is interface function to set default options of new sessions
+func (st *DBSessionStore) Options(options sessions.Options) {
+	st.options = options.ToGorillaOptions()
+}
+
+// Config returns sessions lifetime configuration of the store
+func (st *DBSessionStore) Config() SessionStoreConfig {
+	return st.cfg
+}
+
+// ActiveScope returns DB scope to select only sessions which are not expired yet
+func (st *DBSessionStore) ActiveScope() func(db *gorm.DB) *gorm.DB {
+	now := st.now()
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("expires_at > ?", now)
+		if st.cfg.IdleTimeout > 0 {
+			db = db.Where("last_active_at >= ?", now.Add(-st.cfg.IdleTimeout))
+		}
+		return db
+	}
+}
+
+// Get is interface function to return cached session from the request registry
+func (st *DBSessionStore) Get(r *http.Request, name string) (*gsessions.Session, error) {
+	return gsessions.GetRegistry(r).Get(st, name)
+}
+
+// New is interface function to load session by the token from the cookie,
+// it returns new empty session if the cookie is absent or the session is expired or revoked
+func (st *DBSessionStore) New(r *http.Request, name string) (*gsessions.Session, error) {
+	session := gsessions.NewSession(st, name)
+	options := *st.options
+	session.Options = &options
+	session.IsNew = true
+
+	cookie, err := r.Cookie(name)
+	if err != nil || !strings.HasPrefix(cookie.Value, SessionTokenPrefix) {
+		return session, nil
+	}
+
+	var record models.UserSession
+	err = st.db.Take(&record, "token_hash = ?", MakeUserTokenSecretHash(cookie.Value)).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return session, nil
+	} else if err != nil {
+		return session, fmt.Errorf("failed to load session: %w", err)
+	}
+
+	now := st.now()
+	if record.IsExpired(now, st.cfg.IdleTimeout) {
+		if err = st.db.Delete(&record).Error; err != nil {
+			return session, fmt.Errorf("failed to delete expired session: %w", err)
+		}
+		return session, nil
+	}
+	if err = gob.NewDecoder(bytes.NewReader(record.Data)).Decode(&session.Values); err != nil {
+		return session, fmt.Errorf("failed to decode session values: %w", err)
+	}
+	if now.Sub(record.LastActiveAt) >= sessionTouchInterval {
+		if err = st.db.Model(&record).UpdateColumn("last_active_at", now).Error; err != nil {
+			return session, fmt.Errorf("failed to update session activity: %w", err)
+		}
+	}
+
+	session.ID = cookie.Value
+	session.IsNew = false
+	return session, nil
+}
+
+// Save is interface function to store session values into DB and to set the cookie,
+// negative max age of the session means logout and the session is deleted
+func (st *DBSessionStore) Save(r *http.Request, w http.ResponseWriter, session *gsessions.Session) error {
+	if session.Options.MaxAge < 0 {
+		if session.ID != "" {
+			tokenHash := MakeUserTokenSecretHash(session.ID)
+			if err := st.db.Delete(&models.UserSession{}, "token_hash = ?", tokenHash).Error; err != nil {
+				return fmt.Errorf("failed to delete session: %w", err)
+			}
+		}
+		http.SetCookie(w, gsessions.NewCookie(session.Name(), "", session.Options))
+		return nil
+	}
+
+	var data bytes.Buffer
+	if err := gob.NewEncoder(&data).Encode(session.Values); err != nil {
+		return fmt.Errorf("failed to encode session values: %w", err)
+	}
+
+	now := st.now()
+	var userID *uint64
+	if uid, ok := session.Values["uid"].(uint64); ok {
+		userID = &uid
+	}
+
+	if session.ID == "" {
+		token, tokenHash, err := MakeSessionToken()
+		if err != nil {
+			return err
+		}
+		record := models.UserSession{
+			UserID:       userID,
+			TokenHash:    tokenHash,
+			Data:         data.Bytes(),
+			IP:           truncateString(getRequestIP(r), 50),
+			UserAgent:    truncateString(r.UserAgent(), 255),
+			LastActiveAt: now,
+			ExpiresAt:    now.Add(st.cfg.AbsoluteTimeout),
+			CreatedDate:  now,
+		}
+		if err = st.db.Create(&record).Error; err != nil {
+			return fmt.Errorf("failed to create session: %w", err)
+		}
+		session.ID = token
+	} else {
+		err := st.db.Model(&models.UserSession{}).
+			Where("token_hash = ?", MakeUserTokenSecretHash(session.ID)).
+			UpdateColumns(map[string]interface{}{
+				"user_id":        userID,
+				"data":           data.Bytes(),
+				"last_active_at": now,
+			}).Error
+		if err != nil {
+			return fmt.Errorf("failed to update session: %w", err)
+		}
+	}
+
+	http.SetCookie(w, gsessions.NewCookie(session.Name(), session.ID, session.Options))
+	return nil
+}
+
+// Renew is function to drop current session of the request and to issue new session token on the next saving,
+// it must be used on login to prevent session fixation
+func (st *DBSessionStore) Renew(r *http.Request, name string) error {
+	session, err := st.Get(r, name)
+	if err != nil {
+		return err
+	}
+	if session.ID != "" {
+		tokenHash := MakeUserTokenSecretHash(session.ID)
+		if err = st.db.Delete(&models.UserSession{}, "token_hash = ?", tokenHash).Error; err != nil {
+			return fmt.Errorf("failed to delete session: %w", err)
+		}
+	}
+	session.ID = ""
+	session.IsNew = true
+	session.Values = make(map[interface{}]interface{})
+	return nil
+}
+
+// DeleteExpired is function to remove sessions which reached absolute or idle timeout from DB
+func (st *DBSessionStore) DeleteExpired() (int64, error) {
+	now := st.now()
+	query := st.db.Where("expires_at <= ?", now)
+	if st.cfg.IdleTimeout > 0 {
+		query = query.Or("last_active_at < ?", now.Add(-st.cfg.IdleTimeout))
+	}
+	result := query.Delete(&models.UserSession{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired sessions: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// GetSessionTokenHash returns hash of the session token to match the session into DB
+func GetSessionTokenHash(session sessions.Session) string {
+	if id := session.ID(); id != "" {
+		return MakeUserTokenSecretHash(id)
+	}
+	return ""
+}
+
+type requestIPKey struct{}
+
+// WithRequestIP returns the request with client IP which is resolved by the router with trusted proxies
+func WithRequestIP(r *http.Request, ip string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), requestIPKey{}, ip))
+}
+
+// getRequestIP returns client IP which was set by WithRequestIP or the remote address of the request
+func getRequestIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(requestIPKey{}).(string); ok && ip != "" {
+		return ip
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+func truncateString(value string, length int) string {
+	if utf8.RuneCountInString(value) <= length {
+		return value
+	}
+	return string([]rune(value)[:length])
+}
@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gsessions "github.com/gorilla/sessions"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soldr/pkg/app/api/models"
+)
+
+func newTestSessionStore(t *testing.T, now *time.Time) (*DBSessionStore, *gorm.DB) {
+	t.Helper()
+	db := newTestSQLiteDB(t, `CREATE TABLE user_sessions (id INTEGER PRIMARY KEY, user_id INTEGER,
+		token_hash TEXT NOT NULL UNIQUE, data BLOB NOT NULL, ip TEXT NOT NULL, user_agent TEXT NOT NULL,
+		last_active_at DATETIME NOT NULL, expires_at DATETIME NOT NULL, created_date DATETIME NOT NULL)`)
+	store := NewDBSessionStore(db, SessionStoreConfig{
+		IdleTimeout:     time.Hour,
+		AbsoluteTimeout: 3 * time.Hour,
+	})
+	store.now = func() time.Time { return *now }
+	return store, db
+}
+
+func newTestSessionRequest(token string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.1:12345"
+	r.Header.Set("User-Agent", "test agent")
+	// client IP headers are trusted only by the router for the configured proxies
+	r.Header.Set("X-Forwarded-For", "192.168.1.1")
+	if token != "" {
+		r.AddCookie(&http.Cookie{Name: SessionCookieName, Value: token})
+	}
+	return r
+}
+
+// saveTestSession stores the session and returns the token from the cookie of the response
+func saveTestSession(t *testing.T, store *DBSessionStore, r *http.Request, session *gsessions.Session) string {
+	t.Helper()
+	w := httptest.NewRecorder()
+	require.NoError(t, store.Save(r, w, session))
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, SessionCookieName, cookies[0].Name)
+	return cookies[0].Value
+}
+
+func getTestSessionHashes(t *testing.T, db *gorm.DB) []string {
+	t.Helper()
+	var hashes []string
+	require.NoError(t, db.Model(&models.UserSession{}).Order("id").Pluck("token_hash", &hashes).Error)
+	return hashes
+}
+
+func TestDBSessionStoreLifecycle(t *testing.T) {
+	now := time.Unix(1600000000, 0).UTC()
+	store, db := newTestSessionStore(t, &now)
+
+	session, err := store.New(newTestSessionRequest(""), SessionCookieName)
+	require.NoError(t, err)
+	assert.True(t, session.IsNew)
+	assert.Empty(t, session.Values)
+
+	session, err = store.New(newTestSessionRequest(SessionTokenPrefix+"unknown"), SessionCookieName)
+	require.NoError(t, err)
+	assert.True(t, session.IsNew, "unknown token must produce new session")
+
+	session.Values["uid"] = uint64(1)
+	token := saveTestSession(t, store, newTestSessionRequest(""), session)
+	assert.True(t, strings.HasPrefix(token, SessionTokenPrefix))
+	assert.Equal(t, []string{MakeUserTokenSecretHash(token)}, getTestSessionHashes(t, db),
+		"only hash of the token must be stored")
+
+	var record models.UserSession
+	require.NoError(t, db.Take(&record).Error)
+	require.NotNil(t, record.UserID)
+	assert.Equal(t, uint64(1), *record.UserID)
+	assert.Equal(t, "10.0.0.1", record.IP)
+	assert.Equal(t, "test agent", record.UserAgent)
+	assert.True(t, now.Add(3*time.Hour).Equal(record.ExpiresAt))
+
+	session, err = store.New(newTestSessionRequest(""), SessionCookieName)
+	require.NoError(t, err)
+	saveTestSession(t, store, WithRequestIP(newTestSessionRequest(""), "172.16.0.1"), session)
+	var proxied models.UserSession
+	require.NoError(t, db.Order("id DESC").Take(&proxied).Error)
+	assert.Equal(t, "172.16.0.1", proxied.IP, "client IP resolved by the router must be stored")
+	require.NoError(t, db.Delete(&proxied).Error)
+
+	now = now.Add(2 * time.Minute)
+	session, err = store.New(newTestSessionRequest(token), SessionCookieName)
+	require.NoError(t, err)
+	assert.False(t, session.IsNew)
+	assert.Equal(t, token, session.ID)
+	assert.Equal(t, uint64(1), session.Values["uid"])
+	require.NoError(t, db.Take(&record).Error)
+	assert.True(t, now.Equal(record.LastActiveAt), "activity time must be updated")
+
+	session.Values["prm"] = []string{"vxapi.agents.api.view"}
+	assert.Equal(t, token, saveTestSession(t, store, newTestSessionRequest(token), session))
+	session, err = store.New(newTestSessionRequest(token), SessionCookieName)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vxapi.agents.api.view"}, session.Values["prm"])
+	assert.Len(t, getTestSessionHashes(t, db), 1, "existing session must be updated")
+
+	session.Options.MaxAge = -1
+	w := httptest.NewRecorder()
+	require.NoError(t, store.Save(newTestSessionRequest(token), w, session))
+	assert.Empty(t, getTestSessionHashes(t, db), "session must be deleted on logout")
+	assert.Empty(t, w.Result().Cookies()[0].Value)
+}
+
+func TestDBSessionStoreRenew(t *testing.T) {
+	now := time.Unix(1600000000, 0).UTC()
+	store, db := newTestSessionStore(t, &now)
+
+	session, err := store.New(newTestSessionRequest(""), SessionCookieName)
+	require.NoError(t, err)
+	session.Values["uid"] = uint64(1)
+	oldToken := saveTestSession(t, store, newTestSessionRequest(""), session)
+
+	r := newTestSessionRequest(oldToken)
+	require.NoError(t, store.Renew(r, SessionCookieName))
+	assert.Empty(t, getTestSessionHashes(t, db), "old session must be deleted on renew")
+
+	// renewed session is taken from the request registry and it gets new token on saving
+	session, err = store.Get(r, SessionCookieName)
+	require.NoError(t, err)
+	assert.True(t, session.IsNew)
+	assert.Empty(t, session.ID)
+	assert.Empty(t, session.Values)
+	session.Values["uid"] = uint64(2)
+	newToken := saveTestSession(t, store, r, session)
+
+	assert.NotEqual(t, oldToken, newToken)
+	assert.Equal(t, []string{MakeUserTokenSecretHash(newToken)}, getTestSessionHashes(t, db))
+	session, err = store.New(newTestSessionRequest(oldToken), SessionCookieName)
+	require.NoError(t, err)
+	assert.True(t, session.IsNew, "old token must not be accepted after renew")
+}
+
+func TestDBSessionStoreDeleteExpired(t *testing.T) {
+	start := time.Unix(1600000000, 0).UTC()
+	now := start
+	store, db := newTestSessionStore(t, &now)
+
+	newSession := func() string {
+		t.Helper()
+		session, err := store.New(newTestSessionRequest(""), SessionCookieName)
+		require.NoError(t, err)
+		return saveTestSession(t, store, newTestSessionRequest(""), session)
+	}
+	touchSession := func(token string) {
+		t.Helper()
+		_, err := store.New(newTestSessionRequest(token), SessionCookieName)
+		require.NoError(t, err)
+	}
+
+	absolute := newSession()
+	idle := newSession()
+	now = start.Add(50 * time.Minute)
+	active := newSession()
+	touchSession(absolute)
+
+	now = start.Add(100 * time.Minute)
+	touchSession(absolute)
+	touchSession(active)
+	count, err := store.DeleteExpired()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count, "idle session must be deleted")
+	assert.NotContains(t, getTestSessionHashes(t, db), MakeUserTokenSecretHash(idle))
+
+	now = start.Add(150 * time.Minute)
+	touchSession(absolute)
+	touchSession(active)
+	now = start.Add(3*time.Hour + time.Second)
+	count, err = store.DeleteExpired()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count, "session must be deleted by absolute timeout regardless of activity")
+
+	assert.Equal(t, []string{MakeUserTokenSecretHash(active)}, getTestSessionHashes(t, db))
+}
@@ -3,6 +3,7 @@ package storage
 import (
 	"crypto/md5"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -17,7 +18,6 @@ import (
 	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
 
-	"soldr/pkg/app/api/utils"
 	"soldr/pkg/mysql"
 )
 
@@ -52,13 +52,19 @@ type TableQuery struct {
 	Filters []TableFilter `form:"filters[]" json:"filters[]" binding:"omitempty" swaggertype:"array,string"`
 	// Field to group results by
 	Group string `form:"group" json:"group" binding:"omitempty" swaggertype:"string"`
+	// Boolean filter expression which is combined with filters by AND e.g. JSON tree
+	//   {"or":[{"field":"...","value":...,"operator":"..."},{"not":{...}}]} or query syntax
+	//   field = "value" OR (field like "value" AND NOT field in ("value1", "value2"))
+	Expression string `form:"expr" json:"expr" binding:"omitempty,max=8192" swaggertype:"string"`
 	// non input arguments
-	table      string                                        `form:"-" json:"-"`
-	groupField string                                        `form:"-" json:"-"`
-	sqlMappers map[string]interface{}                        `form:"-" json:"-"`
-	sqlFind    func(out interface{}) func(*gorm.DB) *gorm.DB `form:"-" json:"-"`
-	sqlFilters []func(*gorm.DB) *gorm.DB                     `form:"-" json:"-"`
-	sqlOrders  []func(*gorm.DB) *gorm.DB                     `form:"-" json:"-"`
+	table        string                                        `form:"-" json:"-"`
+	expr         *FilterExpression                             `form:"-" json:"-"`
+	exprDisabled bool                                          `form:"-" json:"-"`
+	groupField   string                                        `form:"-" json:"-"`
+	sqlMappers   map[string]interface{}                        `form:"-" json:"-"`
+	sqlFind      func(out interface{}) func(*gorm.DB) *gorm.DB `form:"-" json:"-"`
+	sqlFilters   []func(*gorm.DB) *gorm.DB                     `form:"-" json:"-"`
+	sqlOrders    []func(*gorm.DB) *gorm.DB                     `form:"-" json:"-"`
 }
 
 // Init is function to set table name and sql mapping to data columns
@@ -102,9 +108,70 @@ func (q *TableQuery) Init(table string, sqlMappers map[string]interface{}) error
 			return errors.New("wrong field for grouping")
 		}
 	}
+	q.expr = nil
+	if q.Expression != "" {
+		expr, err := ParseFilterExpression(q.Expression)
+		if err != nil {
+			return err
+		}
+		for _, field := range expr.Fields() {
+			if _, ok := q.sqlMappers[field]; !ok {
+				return fmt.Errorf("wrong field '%s' for filter expression", field)
+			}
+		}
+		q.expr = expr
+	}
 	return nil
 }
 
+// ExpressionFields returns list of fields which are used into the filter expression
+func (q *TableQuery) ExpressionFields() []string {
+	if q.expr == nil {
+		return nil
+	}
+	return q.expr.Fields()
+}
+
+// Merge is function to combine the query with stored one (e.g. saved view) before Init,
+// filters and filter expressions are joined by AND, sorting and grouping of the query take precedence
+func (q *TableQuery) Merge(base *TableQuery) error {
+	q.Filters = append(append([]TableFilter{}, base.Filters...), q.Filters...)
+	if q.Sort.Prop == "" || q.Sort.Order == "" {
+		q.Sort = base.Sort
+	}
+	if q.Group == "" {
+		q.Group = base.Group
+	}
+
+	switch {
+	case base.Expression == "":
+	case q.Expression == "":
+		q.Expression = base.Expression
+	default:
+		baseExpr, err := ParseFilterExpression(base.Expression)
+		if err != nil {
+			return err
+		}
+		expr, err := ParseFilterExpression(q.Expression)
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(FilterExpression{And: []FilterExpression{*baseExpr, *expr}})
+		if err != nil {
+			return err
+		}
+		q.Expression = string(value)
+	}
+
+	return nil
+}
+
+// SetExpressionEnabled is function to exclude filter expression from data filter,
+// it's used to apply filters only to the part of query
+func (q *TableQuery) SetExpressionEnabled(enabled bool) {
+	q.exprDisabled = !enabled
+}
+
 // DoConditionFormat is auxiliary function to prepare condition to the table
 func (q *TableQuery) DoConditionFormat(cond string) string {
 	cond = strings.ReplaceAll(cond, "{{lang}}", q.Lang)
@@ -213,45 +280,10 @@ func (q *TableQuery) DataFilter() func(db *gorm.DB) *gorm.DB {
 			fl[field] = append(fvalue, item{operator, tvalue})
 		}
 	}
-	patchOperator := func(f *TableFilter) {
-		switch f.Operator {
-		case "<", "<=", ">=", ">", "=", "!=", "like", "not like", "in":
-		default:
-			f.Operator = "like"
-		}
-	}
 	for _, f := range q.Filters {
-		patchOperator(&f)
 		if _, ok := q.sqlMappers[f.Field]; ok {
-			if v, ok := f.Value.(string); ok && v != "" {
-				vs := v
-				if utils.StringInSlice(f.Operator, []string{"like", "not like"}) {
-					vs = "%" + strings.ToLower(vs) + "%"
-				}
-				setFilter(f.Field, f.Operator, vs)
-			}
-			if v, ok := f.Value.(float64); ok {
-				setFilter(f.Field, f.Operator, v)
-			}
-			if v, ok := f.Value.(bool); ok {
-				setFilter(f.Field, f.Operator, v)
-			}
-			if v, ok := f.Value.([]interface{}); ok && len(v) != 0 {
-				var vi []interface{}
-				for _, ti := range v {
-					if ts, ok := ti.(string); ok {
-						vi = append(vi, strings.ToLower(ts))
-					}
-					if ts, ok := ti.(float64); ok {
-						vi = append(vi, ts)
-					}
-					if ts, ok := ti.(bool); ok {
-						vi = append(vi, ts)
-					}
-				}
-				if len(vi) != 0 {
-					setFilter(f.Field, "in", vi)
-				}
+			if operator, value, ok := filterValue(f); ok {
+				setFilter(f.Field, operator, value)
 			}
 		}
 	}
@@ -276,6 +308,18 @@ func (q *TableQuery) DataFilter() func(db *gorm.DB) *gorm.DB {
 				}
 			}
 		}
+		if q.Expression != "" && q.expr == nil {
+			db.AddError(errors.New("filter expression was not initialized"))
+			return db
+		}
+		if q.expr != nil && !q.exprDisabled {
+			sql, args, err := q.expr.compile(q, db)
+			if err != nil {
+				db.AddError(err)
+				return db
+			}
+			db = db.Where(sql, args...)
+		}
 		for _, filter := range q.sqlFilters {
 			db = filter(db)
 		}
@@ -537,6 +581,11 @@ func MakeUserHash(name string) string {
 	return MakeMD5Hash(name, "335e5be8ff97eedb86414062a94898619599b1da")
 }
 
+// MakeUserTokenHash is function to generate user token hash from name
+func MakeUserTokenHash(name string) string {
+	return MakeMD5Hash(name, "b3a2cfa1b7b1e6d0b3f1a9c0a4f3d8a2e6c7d9f1")
+}
+
 // MakeUuidStrFromHash is function to convert format view from hash to UUID
 func MakeUuidStrFromHash(hash string) (string, error) {
 	hashBytes, err := hex.DecodeString(hash)
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableQueryMerge(t *testing.T) {
+	base := TableQuery{
+		Sort:       TableSort{Prop: "name", Order: "ascending"},
+		Filters:    []TableFilter{{Field: "status", Value: "connected", Operator: "="}},
+		Group:      "status",
+		Expression: `name like "a"`,
+	}
+
+	query := TableQuery{
+		Lang:    "en",
+		Filters: []TableFilter{{Field: "id", Value: 1.0, Operator: "="}},
+	}
+	require.NoError(t, query.Merge(&base))
+	assert.Equal(t, base.Sort, query.Sort)
+	assert.Equal(t, "status", query.Group)
+	assert.Equal(t, base.Expression, query.Expression)
+	assert.Equal(t, []TableFilter{base.Filters[0], {Field: "id", Value: 1.0, Operator: "="}}, query.Filters)
+	assert.Len(t, base.Filters, 1, "stored query must not be changed")
+
+	query = TableQuery{
+		Lang:       "en",
+		Sort:       TableSort{Prop: "id", Order: "descending"},
+		Group:      "name",
+		Expression: `id > 10 OR id < 5`,
+	}
+	require.NoError(t, query.Merge(&base))
+	assert.Equal(t, TableSort{Prop: "id", Order: "descending"}, query.Sort)
+	assert.Equal(t, "name", query.Group)
+	require.NoError(t, query.Init("agents", testSQLMappers))
+	assert.Equal(t, []string{"name", "id", "id"}, query.ExpressionFields())
+
+	query = TableQuery{Lang: "en", Expression: `id >`}
+	assert.Error(t, query.Merge(&base))
+}
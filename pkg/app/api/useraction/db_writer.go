@@ -0,0 +1,62 @@
+package useraction
+
+import (
+	"fmt"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+
+	"soldr/pkg/app/api/models"
+)
+
+type DBWriter struct {
+	db *gorm.DB
+}
+
+func NewDBWriter(db *gorm.DB) *DBWriter {
+	return &DBWriter{
+		db: db,
+	}
+}
+
+func (w *DBWriter) WriteUserAction(c *gin.Context, uaf Fields) error {
+	success, failReason := uaf.Success, uaf.FailReason
+	// most of handlers don't set result explicitly so it's taken from the response
+	if !success && failReason == "" {
+		if status := c.Writer.Status(); status < http.StatusBadRequest {
+			success = true
+		} else if lastErr := c.Errors.Last(); lastErr != nil {
+			failReason = lastErr.Error()
+		} else {
+			failReason = http.StatusText(status)
+		}
+	}
+
+	record := models.AuditLog{
+		TenantID:          uaf.TenantID,
+		UserID:            uaf.UserID,
+		UserUUID:          truncate(uaf.UserUUID, 36),
+		UserName:          truncate(uaf.UserName, 70),
+		Domain:            truncate(uaf.Domain, 50),
+		ObjectType:        truncate(uaf.ObjectType, 50),
+		ObjectID:          truncate(uaf.ObjectID, 255),
+		ObjectDisplayName: truncate(uaf.ObjectDisplayName, 255),
+		ActionCode:        truncate(uaf.ActionCode, 100),
+		Success:           success,
+		FailReason:        truncate(failReason, 255),
+		CreatedDate:       uaf.StartTime,
+	}
+	if err := w.db.Create(&record).Error; err != nil {
+		return fmt.Errorf("failed to store user action to the audit log: %w", err)
+	}
+	return nil
+}
+
+func truncate(value string, length int) string {
+	if utf8.RuneCountInString(value) <= length {
+		return value
+	}
+	return string([]rune(value)[:length])
+}
@@ -18,6 +18,8 @@ type Writer interface {
 
 type Fields struct {
 	StartTime         time.Time
+	UserID            uint64
+	TenantID          uint64
 	UserName          string
 	UserUUID          string
 	Domain            string
@@ -31,10 +33,19 @@ type Fields struct {
 
 func NewFields(c *gin.Context, domain, objectType, actionCode, objectID, objectDisplayName string) Fields {
 	session := sessions.Default(c)
-	uuid := (session.Get("uuid")).(string)
-	userName := (session.Get("uname")).(string)
+	uuid, _ := session.Get("uuid").(string)
+	userName, _ := session.Get("uname").(string)
+	// session is empty when request is authorized by token
+	if uuid == "" {
+		uuid = c.GetString("uuid")
+	}
+	if userName == "" {
+		userName = c.GetString("uname")
+	}
 	return Fields{
 		StartTime:         time.Now(),
+		UserID:            c.GetUint64("uid"),
+		TenantID:          c.GetUint64("tid"),
 		UserName:          userName,
 		UserUUID:          uuid,
 		Domain:            domain,
@@ -54,6 +65,8 @@ func NewLogger() *Logger {
 func (w *Logger) WriteUserAction(c *gin.Context, uaf Fields) error {
 	fields := logrus.Fields{
 		"start_time":          uaf.StartTime,
+		"user_id":             uaf.UserID,
+		"tenant_id":           uaf.TenantID,
 		"user_name":           uaf.UserName,
 		"user_uuid":           uaf.UserUUID,
 		"domain":              uaf.Domain,
@@ -68,3 +81,23 @@ func (w *Logger) WriteUserAction(c *gin.Context, uaf Fields) error {
 	logger.FromContext(c).WithFields(fields).Info()
 	return nil
 }
+
+type MultiWriter struct {
+	writers []Writer
+}
+
+func NewMultiWriter(writers ...Writer) *MultiWriter {
+	return &MultiWriter{
+		writers: writers,
+	}
+}
+
+func (w *MultiWriter) WriteUserAction(c *gin.Context, uaf Fields) error {
+	var result error
+	for _, writer := range w.writers {
+		if err := writer.WriteUserAction(c, uaf); err != nil && result == nil {
+			result = err
+		}
+	}
+	return result
+}
@@ -0,0 +1,138 @@
+package mfa
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Period is a time step of TOTP code in seconds (RFC 6238)
+	Period = 30
+	// Digits is a length of TOTP code
+	Digits = 6
+	// Skew is a number of time steps before and after current one which are accepted to verify TOTP code
+	Skew = 1
+	// RecoveryCodesCount is a number of recovery codes which are issued on MFA enrollment
+	RecoveryCodesCount = 10
+
+	secretSize       = 20
+	recoveryCodeSize = 5
+	codeModulo       = 1000000
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret is function to make new random TOTP secret in base32 encoding without padding
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate TOTP secret: %w", err)
+	}
+	return secretEncoding.EncodeToString(secret), nil
+}
+
+// GetStep returns TOTP time step (counter) for the time
+func GetStep(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+// GenerateCode is function to calculate TOTP code for the time step (RFC 4226 and RFC 6238)
+func GenerateCode(secret string, step int64) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	code := strconv.FormatUint(uint64(value%codeModulo), 10)
+	return strings.Repeat("0", Digits-len(code)) + code, nil
+}
+
+// ValidateCode is function to check TOTP code for the time with allowed skew,
+// it returns matched time step to prevent reusing the same code
+func ValidateCode(secret, code string, t time.Time) (int64, bool, error) {
+	if len(code) != Digits {
+		return 0, false, nil
+	}
+	current := GetStep(t)
+	for step := current - Skew; step <= current+Skew; step++ {
+		expected, err := GenerateCode(secret, step)
+		if err != nil {
+			return 0, false, err
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// GetProvisioningURI returns otpauth URI to enroll the secret into authenticator application
+func GetProvisioningURI(issuer, account, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", strconv.Itoa(Digits))
+	params.Set("period", strconv.Itoa(Period))
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: params.Encode(),
+	}
+	return uri.String()
+}
+
+// GenerateRecoveryCodes is function to make list of one-time recovery codes and their hashes to store into DB
+func GenerateRecoveryCodes(count int) ([]string, []string, error) {
+	codes, hashes := make([]string, 0, count), make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		value := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(value); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		code := hex.EncodeToString(value)
+		code = code[:recoveryCodeSize] + "-" + code[recoveryCodeSize:]
+		codes = append(codes, code)
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode returns hash of the recovery code to lookup it into DB, it ignores case and dashes
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	hash := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(hash[:])
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.TrimRight(strings.TrimSpace(secret), "="))
+	key, err := secretEncoding.DecodeString(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode TOTP secret: %w", err)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("TOTP secret is empty")
+	}
+	return key, nil
+}
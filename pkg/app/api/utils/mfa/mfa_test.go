@@ -0,0 +1,81 @@
+package mfa
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSecret is a base32 encoded seed "12345678901234567890" from RFC 6238 test vectors
+const testSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateCode(t *testing.T) {
+	for ts, expected := range map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	} {
+		code, err := GenerateCode(testSecret, GetStep(time.Unix(ts, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, expected, code, "time %d", ts)
+	}
+
+	_, err := GenerateCode("not base32!", 1)
+	assert.Error(t, err)
+}
+
+func TestValidateCode(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := GetStep(now)
+
+	for _, delta := range []int64{-Skew, 0, Skew} {
+		code, err := GenerateCode(testSecret, step+delta)
+		require.NoError(t, err)
+		matched, ok, err := ValidateCode(testSecret, code, now)
+		require.NoError(t, err)
+		assert.True(t, ok, "code of step delta %d must be accepted", delta)
+		assert.Equal(t, step+delta, matched)
+	}
+
+	code, err := GenerateCode(testSecret, step+Skew+1)
+	require.NoError(t, err)
+	_, ok, err := ValidateCode(testSecret, code, now)
+	require.NoError(t, err)
+	assert.False(t, ok, "code out of skew must be rejected")
+
+	_, ok, err = ValidateCode(testSecret, "12345", now)
+	require.NoError(t, err)
+	assert.False(t, ok, "code with wrong length must be rejected")
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	key, err := decodeSecret(secret)
+	require.NoError(t, err)
+	assert.Len(t, key, secretSize)
+
+	uri, err := url.Parse(GetProvisioningURI("SOLDR", "admin@vxcontrol.com", secret))
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/SOLDR:admin@vxcontrol.com", uri.Path)
+	assert.Equal(t, secret, uri.Query().Get("secret"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := GenerateRecoveryCodes(RecoveryCodesCount)
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodesCount)
+	require.Len(t, hashes, RecoveryCodesCount)
+
+	for i, code := range codes {
+		assert.Regexp(t, "^[0-9a-f]{5}-[0-9a-f]{5}$", code)
+		assert.Equal(t, hashes[i], HashRecoveryCode(code))
+	}
+	assert.Equal(t, HashRecoveryCode("abcde-01234"), HashRecoveryCode(" ABCDE01234 "))
+}
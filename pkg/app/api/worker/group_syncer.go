@@ -0,0 +1,181 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+
+	"soldr/pkg/app/api/models"
+	"soldr/pkg/app/api/storage"
+	obs "soldr/pkg/observability"
+)
+
+type agentGroupLink struct {
+	ID      uint64 `gorm:"column:id"`
+	GroupID uint64 `gorm:"column:group_id"`
+}
+
+type groupsState struct {
+	Agents       uint64     `gorm:"column:agents"`
+	AgentsUpdate *time.Time `gorm:"column:agents_update"`
+	Groups       uint64     `gorm:"column:groups"`
+	GroupsUpdate *time.Time `gorm:"column:groups_update"`
+}
+
+// getGroupsState returns fingerprint of agents and dynamic groups to skip rules evaluation if nothing was changed,
+// agents info, tags and OS changes, groups rules changes and deletion of groups update these values
+func getGroupsState(iDB *gorm.DB) (string, error) {
+	var state groupsState
+	err := iDB.Raw(`SELECT
+		(SELECT COUNT(id) FROM agents WHERE deleted_at IS NULL) AS agents,
+		(SELECT MAX(updated_at) FROM agents) AS agents_update,
+		(SELECT COUNT(id) FROM groups WHERE deleted_at IS NULL AND rule <> '') AS ` + "`groups`" + `,
+		(SELECT MAX(updated_at) FROM groups) AS groups_update`).Scan(&state).Error
+	if err != nil {
+		return "", err
+	}
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	return fmt.Sprintf("%d|%s|%d|%s",
+		state.Agents, formatTime(state.AgentsUpdate), state.Groups, formatTime(state.GroupsUpdate)), nil
+}
+
+// getDynamicGroupsMoves returns agents IDs which must be moved to the target group ID (zero means without group),
+// groups rules are applied in the order of dynamic groups and the first matched group wins,
+// agents of static groups are never moved and members of groups with failed rules keep their group
+func getDynamicGroupsMoves(
+	groupIDs []uint64,
+	matches map[uint64][]uint64,
+	current map[uint64]uint64,
+) map[uint64][]uint64 {
+	isDynamic := make(map[uint64]bool, len(groupIDs))
+	for _, gid := range groupIDs {
+		isDynamic[gid] = true
+	}
+
+	desired := make(map[uint64]uint64)
+	for _, gid := range groupIDs {
+		for _, aid := range matches[gid] {
+			cgid, ok := current[aid]
+			if !ok || (cgid != 0 && !isDynamic[cgid]) {
+				continue
+			}
+			if _, ok := desired[aid]; !ok {
+				desired[aid] = gid
+			}
+		}
+	}
+
+	moves := make(map[uint64][]uint64)
+	for aid, cgid := range current {
+		if cgid != 0 && !isDynamic[cgid] {
+			continue
+		}
+		if _, ok := matches[cgid]; cgid != 0 && !ok {
+			continue
+		}
+		if gid := desired[aid]; gid != cgid {
+			moves[gid] = append(moves[gid], aid)
+		}
+	}
+
+	return moves
+}
+
+func syncDynamicGroups(ctx context.Context, srv *service) error {
+	var (
+		agents  []agentGroupLink
+		groups  []models.Group
+		gids    []uint64
+		matches = make(map[uint64][]uint64)
+		current = make(map[uint64]uint64)
+	)
+
+	if err := srv.iDB.Where("rule <> ''").Order("id ASC").Find(&groups).Error; err != nil {
+		return fmt.Errorf("failed to load dynamic groups: %w", err)
+	}
+	for _, group := range groups {
+		gids = append(gids, group.ID)
+		ids, err := storage.MatchGroupRule(srv.iDB, group.Rule)
+		if err != nil {
+			logrus.WithContext(ctx).WithError(err).
+				Errorf("failed to evaluate rule of group '%s' for service '%s'", group.Hash, srv.sv.Hash)
+			continue
+		}
+		matches[group.ID] = ids
+	}
+
+	err := srv.iDB.Model(&models.Agent{}).Select("id, group_id").Scan(&agents).Error
+	if err != nil {
+		return fmt.Errorf("failed to load agents groups: %w", err)
+	}
+	for _, agent := range agents {
+		current[agent.ID] = agent.GroupID
+	}
+
+	for gid, aids := range getDynamicGroupsMoves(gids, matches, current) {
+		err := srv.iDB.Model(&models.Agent{}).Where("id IN (?)", aids).
+			UpdateColumns(map[string]interface{}{
+				"group_id":   gid,
+				"updated_at": time.Now(),
+			}).Error
+		if err != nil {
+			return fmt.Errorf("failed to move agents to group '%d': %w", gid, err)
+		}
+		logrus.WithContext(ctx).
+			Infof("moved %d agents to dynamic group '%d' for service '%s'", len(aids), gid, srv.sv.Hash)
+	}
+
+	return nil
+}
+
+// SyncDynamicGroups is a worker to re-evaluate membership of agents in rule-based groups
+// on changes of agents or groups into instance DB
+func SyncDynamicGroups(ctx context.Context, gDB *gorm.DB) {
+	mSV := make(map[uint64]*service)
+	states := make(map[uint64]string)
+
+	syncGroupsInstance := func(ctx context.Context, srv *service) {
+		state, err := getGroupsState(srv.iDB)
+		if err != nil {
+			logrus.WithContext(ctx).WithError(err).
+				Errorf("failed to get groups state for service '%s'", srv.sv.Hash)
+			return
+		} else if state == states[srv.sv.ID] {
+			return
+		}
+
+		if err = syncDynamicGroups(ctx, srv); err != nil {
+			logrus.WithContext(ctx).WithError(err).
+				Errorf("failed to sync dynamic groups for service '%s'", srv.sv.Hash)
+			return
+		}
+
+		// moved agents change the state so it must be taken after the sync
+		if states[srv.sv.ID], err = getGroupsState(srv.iDB); err != nil {
+			delete(states, srv.sv.ID)
+		}
+	}
+
+	for {
+		mSV = loadServices(gDB, mSV)
+		ctx, span := obs.Observer.NewSpan(ctx, obs.SpanKindClient, "groups_syncer")
+		for _, s := range mSV {
+			syncGroupsInstance(ctx, s)
+		}
+		span.End()
+		select {
+		case <-time.NewTimer(syncGroupsDelay).C:
+			continue
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package worker
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDynamicGroupsMoves(t *testing.T) {
+	// groups 10 and 20 are dynamic, group 5 is static and group 30 has failed rule
+	groupIDs := []uint64{10, 20, 30}
+	matches := map[uint64][]uint64{
+		10: {1, 2},
+		20: {2, 3, 4, 7},
+	}
+	current := map[uint64]uint64{
+		1: 0,  // matched by 10
+		2: 20, // matched by 10 and 20, the first group wins
+		3: 20, // stays in 20
+		4: 5,  // static group is kept
+		5: 10, // not matched anymore
+		6: 30, // group rule is failed, membership is kept
+		7: 30, // group rule is failed, membership is kept
+		8: 0,  // not matched
+	}
+
+	moves := getDynamicGroupsMoves(groupIDs, matches, current)
+	for _, aids := range moves {
+		sort.Slice(aids, func(i, j int) bool { return aids[i] < aids[j] })
+	}
+	assert.Equal(t, map[uint64][]uint64{
+		10: {1, 2},
+		0:  {5},
+	}, moves)
+}
@@ -31,6 +31,9 @@ const (
 	syncBinariesDelay  = 3 * time.Minute
 	syncModulesDelay   = 30 * time.Minute
 	syncRetEventsDelay = 3 * time.Hour
+	syncRetAuditDelay  = 3 * time.Hour
+	syncSessionsDelay  = 10 * time.Minute
+	syncGroupsDelay    = 30 * time.Second
 )
 
 type service struct {
@@ -376,3 +379,45 @@ func SyncRetentionEvents(ctx context.Context, gDB *gorm.DB, keepAmountDays int)
 		}
 	}
 }
+
+func SyncRetentionAuditLog(ctx context.Context, gDB *gorm.DB, keepAmountDays int) {
+	rotateAuditLog := func(ctx context.Context) {
+		var record models.AuditLog
+		sqlRes := gDB.Delete(&record, "`created_date` < NOW() - INTERVAL ? DAY", keepAmountDays)
+		if err := sqlRes.Error; err != nil {
+			logrus.WithContext(ctx).WithError(err).Errorf("failed to rotate audit log")
+		} else if sqlRes.RowsAffected != 0 {
+			logrus.WithContext(ctx).Infof("deleted %d records from audit log", sqlRes.RowsAffected)
+		}
+	}
+
+	for {
+		ctx, span := obs.Observer.NewSpan(ctx, obs.SpanKindClient, "audit_log_syncer")
+		rotateAuditLog(ctx)
+		span.End()
+		select {
+		case <-time.NewTimer(syncRetAuditDelay).C:
+			continue
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func SyncExpiredSessions(ctx context.Context, sessionStore *storage.DBSessionStore) {
+	for {
+		ctx, span := obs.Observer.NewSpan(ctx, obs.SpanKindClient, "sessions_syncer")
+		if count, err := sessionStore.DeleteExpired(); err != nil {
+			logrus.WithContext(ctx).WithError(err).Errorf("failed to delete expired sessions")
+		} else if count != 0 {
+			logrus.WithContext(ctx).Infof("deleted %d expired sessions", count)
+		}
+		span.End()
+		select {
+		case <-time.NewTimer(syncSessionsDelay).C:
+			continue
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/imdario/mergo"
 	"github.com/sirupsen/logrus"
 
+	forwarderConfig "soldr/pkg/app/server/mmodule/forwarder/config"
 	hardeningConfig "soldr/pkg/app/server/mmodule/hardening/config"
 	"soldr/pkg/vxproto"
 )
@@ -26,12 +27,15 @@ type Config struct {
 	S3                   S3                              `json:"s3"`
 	Certs                CertsConfig                     `json:"certs"`
 	Validator            hardeningConfig.Validator       `json:"validator"`
+	Forwarder            forwarderConfig.Forwarder       `json:"forwarder"`
 	APIVersionsConfig    vxproto.ServerAPIVersionsConfig `json:"-"`
 	Base                 string                          `json:"base"`
 	LogDir               string                          `json:"log_dir"`
 	Listen               string                          `json:"listen"`
 	OtelAddr             string                          `json:"otel_addr"`
+	MetricsListen        string                          `json:"metrics_listen"`
 	MaxConcSyncingAgents int                             `json:"max_conc_syncing_agents"`
+	AgentRateLimits      string                          `json:"agent_rate_limits"`
 
 	// The following fields should not be present in the config file
 	IsPrintVersionOnly bool   `json:"version"`
@@ -109,6 +113,9 @@ func (c *Config) Validate() error {
 	if c.MaxConcSyncingAgents < 1 {
 		return fmt.Errorf("incorrect value of maximum agents to synchronise concurrently")
 	}
+	if _, err := vxproto.ParseRateLimits(c.AgentRateLimits); err != nil {
+		return fmt.Errorf("incorrect value of the agent rate limits: %w", err)
+	}
 	return nil
 }
 
@@ -117,6 +124,15 @@ func (c *Config) PrettyPrint() (string, error) {
 	const secretPlaceholder = "xxxxx"
 	confCopy.S3.SecretKey = secretPlaceholder
 	confCopy.DB.Pass = secretPlaceholder
+	confCopy.Forwarder.Webhook = make([]forwarderConfig.Webhook, len(c.Forwarder.Webhook))
+	for i, wh := range c.Forwarder.Webhook {
+		headers := make(map[string]string, len(wh.Headers))
+		for k := range wh.Headers {
+			headers[k] = secretPlaceholder
+		}
+		wh.Headers = headers
+		confCopy.Forwarder.Webhook[i] = wh
+	}
 	prettyConf, err := json.MarshalIndent(&confCopy, "", "\t")
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal config with indent: %w", err)
@@ -177,7 +193,9 @@ func parseFlags() *Config {
   status - status of the service`)
 	flag.StringVar(&c.LogDir, "logdir", "", "System option to define log directory to vxserver")
 	flag.StringVar(&c.OtelAddr, "oteladdr", "", "System option to define log opentelemetry address")
+	flag.StringVar(&c.MetricsListen, "metricslisten", "", "Listen IP:Port to serve Prometheus metrics endpoint (disabled if empty)")
 	flag.IntVar(&c.MaxConcSyncingAgents, "mcsa", defaultConfig.MaxConcSyncingAgents, "Maximum agents to synchronise concurrently")
+	flag.StringVar(&c.AgentRateLimits, "arl", "", "Rate limits of the packets from each agent, e.g. 'data=100:200,action=50' (unlimited if empty)")
 	flag.BoolVar(&c.Debug, "debug", false, "System option to run vxserver in debug mode")
 	flag.BoolVar(&c.IsProfiling, "profiling", false, "System option to run vxserver in profiling mode")
 	flag.BoolVar(&c.Service, "service", false, "System option to run vxserver as a service")
@@ -199,6 +217,8 @@ func parseEnvVars() (*Config, error) {
 	c.Loader.Files = os.Getenv("FILES_LOADER")
 	c.LogDir = os.Getenv("LOG_DIR")
 	c.OtelAddr = os.Getenv("OTEL_ADDR")
+	c.MetricsListen = os.Getenv("METRICS_LISTEN")
+	c.AgentRateLimits = os.Getenv("AGENT_RATE_LIMITS")
 	// bool parameters can only be passed as flags
 	c.IsProfiling = false
 	// bool parameters can only be passed as flags
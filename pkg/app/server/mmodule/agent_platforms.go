@@ -0,0 +1,56 @@
+package mmodule
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"soldr/pkg/app/api/models"
+)
+
+// agentPlatformsTTL is a time to keep agent platforms of the binaries catalogue, it prevents reading
+// the whole binaries table on every agent handshake when many agents reconnect after server restart
+const agentPlatformsTTL = time.Minute
+
+// agentPlatforms is a cache of agent platforms in format "os:arch" which are listed in the binaries catalogue
+type agentPlatforms struct {
+	db        *gorm.DB
+	platforms map[string]struct{}
+	expiresAt time.Time
+	mutex     *sync.Mutex
+	now       func() time.Time
+}
+
+func newAgentPlatforms(db *gorm.DB) *agentPlatforms {
+	return &agentPlatforms{
+		db:    db,
+		mutex: &sync.Mutex{},
+		now:   time.Now,
+	}
+}
+
+// Has returns true if the binaries catalogue contains agent files for the OS type and arch,
+// the platforms are loaded under the lock so concurrent handshakes read the catalogue once
+func (ap *agentPlatforms) Has(osType, osArch string) (bool, error) {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	if now := ap.now(); ap.platforms == nil || !now.Before(ap.expiresAt) {
+		var infos []models.BinaryInfo
+		if err := ap.db.Table("binaries").Pluck("info", &infos).Error; err != nil {
+			return false, fmt.Errorf("failed to get agent binaries: %w", err)
+		}
+		ap.platforms = make(map[string]struct{})
+		for _, info := range infos {
+			for _, platform := range info.GetPlatforms() {
+				ap.platforms[platform] = struct{}{}
+			}
+		}
+		ap.expiresAt = now.Add(agentPlatformsTTL)
+	}
+
+	_, ok := ap.platforms[osType+":"+osArch]
+	return ok, nil
+}
@@ -0,0 +1,51 @@
+package mmodule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentPlatforms(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.DB().SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, db.Exec(`CREATE TABLE binaries (id INTEGER PRIMARY KEY, info TEXT NOT NULL)`).Error)
+	addBinary := func(files string) {
+		t.Helper()
+		require.NoError(t, db.Exec(`INSERT INTO binaries (info) VALUES (?)`, `{"files":[`+files+`]}`).Error)
+	}
+	addBinary(`"vxagent/1.0.0.0/linux/amd64/vxagent","vxagent/1.0.0.0/windows/386/vxagent.exe"`)
+
+	now := time.Unix(1600000000, 0)
+	platforms := newAgentPlatforms(db)
+	platforms.now = func() time.Time { return now }
+	hasPlatform := func(osType, osArch string) bool {
+		t.Helper()
+		ok, err := platforms.Has(osType, osArch)
+		require.NoError(t, err)
+		return ok
+	}
+
+	assert.True(t, hasPlatform("linux", "amd64"))
+	assert.True(t, hasPlatform("windows", "386"))
+	assert.False(t, hasPlatform("linux", "arm64"))
+
+	addBinary(`"vxagent/1.1.0.0/linux/arm64/vxagent"`)
+	now = now.Add(agentPlatformsTTL - time.Second)
+	assert.False(t, hasPlatform("linux", "arm64"), "binaries catalogue must not be read before TTL is expired")
+
+	now = now.Add(time.Second)
+	assert.True(t, hasPlatform("linux", "arm64"), "binaries catalogue must be read again after TTL")
+	assert.True(t, hasPlatform("linux", "amd64"))
+
+	require.NoError(t, db.Exec(`DROP TABLE binaries`).Error)
+	now = now.Add(agentPlatformsTTL)
+	_, err = platforms.Has("linux", "amd64")
+	assert.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package config
+
+// Forwarder is a configuration of sinks to forward agents events to external systems
+type Forwarder struct {
+	// QueueSize is a maximum amount of events batches waiting to be sent per sink
+	QueueSize int       `json:"queue_size"`
+	Syslog    []Syslog  `json:"syslog"`
+	Webhook   []Webhook `json:"webhook"`
+	File      []File    `json:"file"`
+}
+
+// Syslog is a configuration of RFC5424 syslog sink
+type Syslog struct {
+	Name string `json:"name"`
+	// Network is a transport to deliver messages: [udp, tcp, tls]
+	Network string `json:"network"`
+	Address string `json:"address"`
+	// Format is a message payload format: [cef, leef, json]
+	Format string `json:"format"`
+	// Facility is a syslog facility code [0-23], local0 (16) is used if it's unset
+	Facility *int   `json:"facility"`
+	AppName  string `json:"app_name"`
+	// TLSCA is a path to PEM file with CA certificates to verify the syslog server
+	TLSCA         string `json:"tls_ca"`
+	TLSSkipVerify bool   `json:"tls_skip_verify"`
+}
+
+// Webhook is a configuration of sink which posts events as NDJSON over HTTP
+type Webhook struct {
+	Name       string            `json:"name"`
+	URL        string            `json:"url"`
+	Headers    map[string]string `json:"headers"`
+	TimeoutSec int               `json:"timeout_sec"`
+	MaxRetries int               `json:"max_retries"`
+}
+
+// File is a configuration of sink which writes events as NDJSON into a rotating local file
+type File struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	MaxSizeMB  int    `json:"max_size_mb"`
+	MaxBackups int    `json:"max_backups"`
+	MaxAgeDays int    `json:"max_age_days"`
+	Compress   bool   `json:"compress"`
+}
+
+// IsEmpty returns true if there is no sink configured
+func (c *Forwarder) IsEmpty() bool {
+	return c == nil || len(c.Syslog)+len(c.Webhook)+len(c.File) == 0
+}
@@ -0,0 +1,76 @@
+package forwarder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"soldr/pkg/app/server/mmodule/forwarder/config"
+)
+
+const (
+	defaultFileMaxSizeMB  = 100
+	defaultFileMaxBackups = 7
+)
+
+// FileSink writes events as NDJSON lines into a local file with size based rotation
+type FileSink struct {
+	name   string
+	mx     sync.Mutex
+	writer *lumberjack.Logger
+}
+
+// NewFileSink is a function to create file sink
+func NewFileSink(c *config.File) (*FileSink, error) {
+	if c.Path == "" {
+		return nil, fmt.Errorf("file path is empty")
+	}
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory for file %s: %w", c.Path, err)
+	}
+	maxSize := c.MaxSizeMB
+	if maxSize <= 0 {
+		maxSize = defaultFileMaxSizeMB
+	}
+	maxBackups := c.MaxBackups
+	if maxBackups <= 0 {
+		maxBackups = defaultFileMaxBackups
+	}
+	return &FileSink{
+		name: c.Name,
+		writer: &lumberjack.Logger{
+			Filename:   c.Path,
+			MaxSize:    maxSize,
+			MaxBackups: maxBackups,
+			MaxAge:     c.MaxAgeDays,
+			Compress:   c.Compress,
+		},
+	}, nil
+}
+
+func (s *FileSink) Name() string {
+	return s.name
+}
+
+func (s *FileSink) Send(_ context.Context, events []*Event) error {
+	data, err := marshalNDJSON(events)
+	if err != nil {
+		return err
+	}
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if _, err = s.writer.Write(data); err != nil {
+		return fmt.Errorf("failed to write events to file: %w", err)
+	}
+	return nil
+}
+
+func (s *FileSink) Close() error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.writer.Close()
+}
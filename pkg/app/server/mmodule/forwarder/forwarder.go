@@ -0,0 +1,218 @@
+package forwarder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"soldr/pkg/app/server/mmodule/forwarder/config"
+)
+
+const (
+	defaultQueueSize = 100
+	sendTimeout      = 30 * time.Second
+)
+
+// Event is an agent event enriched by its origin to be forwarded to external systems
+type Event struct {
+	AgentID    string                 `json:"agent_id"`
+	GroupID    string                 `json:"group_id"`
+	PolicyID   string                 `json:"policy_id"`
+	ModuleName string                 `json:"module_name"`
+	Name       string                 `json:"name"`
+	Uniq       string                 `json:"uniq"`
+	Time       uint64                 `json:"time,omitempty"`
+	Actions    []string               `json:"actions,omitempty"`
+	Data       map[string]interface{} `json:"data"`
+	Date       time.Time              `json:"date"`
+}
+
+// Sink is an interface to deliver events batch to an external system
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, events []*Event) error
+	Close() error
+}
+
+type sinkStats struct {
+	sent    atomic.Int64
+	failed  atomic.Int64
+	dropped atomic.Int64
+}
+
+type sinkWorker struct {
+	sink  Sink
+	queue chan []*Event
+	stats sinkStats
+}
+
+// Forwarder is a fan-out of the events batches to all configured sinks,
+// each sink has own queue to avoid blocking by slow external systems
+type Forwarder struct {
+	workers  []*sinkWorker
+	wg       sync.WaitGroup
+	logger   *logrus.Entry
+	mx       sync.RWMutex
+	isClosed bool
+}
+
+// New is a function to create sinks from the configuration and to start its workers
+func New(c *config.Forwarder, version string, logger *logrus.Entry) (*Forwarder, error) {
+	f := &Forwarder{
+		logger: logger,
+	}
+	if c.IsEmpty() {
+		return f, nil
+	}
+
+	var sinks []Sink
+	closeSinks := func() {
+		for _, s := range sinks {
+			_ = s.Close()
+		}
+	}
+	for i := range c.Syslog {
+		cfg := c.Syslog[i]
+		if cfg.Name == "" {
+			cfg.Name = fmt.Sprintf("syslog_%d", i)
+		}
+		s, err := NewSyslogSink(&cfg, version)
+		if err != nil {
+			closeSinks()
+			return nil, fmt.Errorf("failed to initialize syslog sink %s: %w", cfg.Name, err)
+		}
+		sinks = append(sinks, s)
+	}
+	for i := range c.Webhook {
+		cfg := c.Webhook[i]
+		if cfg.Name == "" {
+			cfg.Name = fmt.Sprintf("webhook_%d", i)
+		}
+		s, err := NewWebhookSink(&cfg)
+		if err != nil {
+			closeSinks()
+			return nil, fmt.Errorf("failed to initialize webhook sink %s: %w", cfg.Name, err)
+		}
+		sinks = append(sinks, s)
+	}
+	for i := range c.File {
+		cfg := c.File[i]
+		if cfg.Name == "" {
+			cfg.Name = fmt.Sprintf("file_%d", i)
+		}
+		s, err := NewFileSink(&cfg)
+		if err != nil {
+			closeSinks()
+			return nil, fmt.Errorf("failed to initialize file sink %s: %w", cfg.Name, err)
+		}
+		sinks = append(sinks, s)
+	}
+
+	queueSize := c.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	for _, s := range sinks {
+		w := &sinkWorker{
+			sink:  s,
+			queue: make(chan []*Event, queueSize),
+		}
+		f.workers = append(f.workers, w)
+		f.wg.Add(1)
+		go f.run(w)
+	}
+	return f, nil
+}
+
+func (f *Forwarder) run(w *sinkWorker) {
+	defer f.wg.Done()
+	logger := f.logger.WithField("sink", w.sink.Name())
+	for events := range w.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		if err := w.sink.Send(ctx, events); err != nil {
+			w.stats.failed.Add(int64(len(events)))
+			logger.WithError(err).WithField("count", len(events)).Warn("failed to forward events")
+		} else {
+			w.stats.sent.Add(int64(len(events)))
+		}
+		cancel()
+	}
+	if err := w.sink.Close(); err != nil {
+		logger.WithError(err).Warn("failed to close sink")
+	}
+}
+
+// IsEnabled returns true if there is at least one sink to forward events to
+func (f *Forwarder) IsEnabled() bool {
+	return f != nil && len(f.workers) != 0
+}
+
+// Forward is a function to put events batch into the queue of every sink without blocking,
+// the batch is dropped for the sink if its queue is full
+func (f *Forwarder) Forward(events []*Event) {
+	if !f.IsEnabled() || len(events) == 0 {
+		return
+	}
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+	if f.isClosed {
+		return
+	}
+	for _, w := range f.workers {
+		select {
+		case w.queue <- events:
+		default:
+			w.stats.dropped.Add(int64(len(events)))
+			f.logger.WithField("sink", w.sink.Name()).WithField("count", len(events)).
+				Warn("sink queue is full, events were dropped")
+		}
+	}
+}
+
+// Close is a function to flush queued events and to close all sinks
+func (f *Forwarder) Close() {
+	if f == nil {
+		return
+	}
+	f.mx.Lock()
+	if f.isClosed {
+		f.mx.Unlock()
+		return
+	}
+	f.isClosed = true
+	for _, w := range f.workers {
+		close(w.queue)
+	}
+	f.mx.Unlock()
+	f.wg.Wait()
+}
+
+// DumpStats is a function to export the sinks counters to the metrics collector
+func (f *Forwarder) DumpStats() (map[string]float64, error) {
+	statsMap := make(map[string]float64, 3*len(f.workers))
+	for _, w := range f.workers {
+		prefix := "forwarder_" + w.sink.Name()
+		statsMap[prefix+"_sent_events"] = float64(w.stats.sent.Load())
+		statsMap[prefix+"_failed_events"] = float64(w.stats.failed.Load())
+		statsMap[prefix+"_dropped_events"] = float64(w.stats.dropped.Load())
+	}
+	return statsMap, nil
+}
+
+func marshalNDJSON(events []*Event) ([]byte, error) {
+	var buf []byte
+	for _, e := range events {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal event %s: %w", e.Uniq, err)
+		}
+		buf = append(buf, line...)
+		buf = append(buf, '\n')
+	}
+	return buf, nil
+}
@@ -0,0 +1,179 @@
+package forwarder
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"soldr/pkg/app/server/mmodule/forwarder/config"
+)
+
+func newTestEvent() *Event {
+	return &Event{
+		AgentID:    "a1b2c3",
+		GroupID:    "g1",
+		PolicyID:   "p1",
+		ModuleName: "file_reader",
+		Name:       "file_reader_line_detected",
+		Uniq:       "uniq1",
+		Data:       map[string]interface{}{"line": "a=b|c", "num": 1},
+		Date:       time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestFormatCEF(t *testing.T) {
+	msg := formatCEF(newTestEvent(), "1.0|beta")
+	expectedPrefix := `CEF:0|VXControl|SOLDR|1.0\|beta|file_reader.file_reader_line_detected|file_reader_line_detected|5|`
+	if !strings.HasPrefix(msg, expectedPrefix) {
+		t.Fatalf("unexpected CEF header: %s", msg)
+	}
+	for _, part := range []string{`data_line=a\=b|c`, `data_num=1`, `deviceExternalId=a1b2c3`, `cs3=file_reader`} {
+		if !strings.Contains(msg, part) {
+			t.Fatalf("CEF message %q does not contain %q", msg, part)
+		}
+	}
+}
+
+func TestSyslogSinkTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		size, err := readOctetCount(r)
+		if err != nil {
+			return
+		}
+		buf := make([]byte, size)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return
+		}
+		received <- string(buf)
+	}()
+
+	s, err := NewSyslogSink(&config.Syslog{Name: "siem", Network: "tcp", Address: ln.Addr().String(), Format: "leef"}, "1.0")
+	if err != nil {
+		t.Fatalf("failed to create syslog sink: %v", err)
+	}
+	defer s.Close()
+	if err := s.Send(context.Background(), []*Event{newTestEvent()}); err != nil {
+		t.Fatalf("failed to send events: %v", err)
+	}
+	select {
+	case msg := <-received:
+		if !strings.HasPrefix(msg, "<134>1 2022-10-01T12:00:00Z ") || !strings.Contains(msg, "LEEF:1.0|VXControl|SOLDR|1.0|") {
+			t.Fatalf("unexpected syslog message: %s", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("syslog message was not received")
+	}
+}
+
+func TestSyslogSinkFacility(t *testing.T) {
+	kern, local7, invalid := 0, 23, 24
+	for _, tc := range []struct {
+		facility *int
+		expected int
+	}{
+		{nil, defaultSyslogFacility},
+		{&kern, 0},
+		{&local7, 23},
+	} {
+		s, err := NewSyslogSink(&config.Syslog{Address: "127.0.0.1:514", Facility: tc.facility}, "1.0")
+		if err != nil {
+			t.Fatalf("failed to create syslog sink: %v", err)
+		}
+		if s.facility != tc.expected {
+			t.Fatalf("expected facility %d, got %d", tc.expected, s.facility)
+		}
+	}
+	if _, err := NewSyslogSink(&config.Syslog{Address: "127.0.0.1:514", Facility: &invalid}, "1.0"); err == nil {
+		t.Fatal("facility out of range must be rejected")
+	}
+}
+
+func readOctetCount(r *bufio.Reader) (int, error) {
+	prefix, err := r.ReadString(' ')
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(prefix))
+}
+
+func TestWebhookSinkRetries(t *testing.T) {
+	var calls atomic.Int32
+	var body atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("Content-Type") != ndjsonContentType || r.Header.Get("X-Token") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		body.Store(string(data))
+	}))
+	defer srv.Close()
+
+	s, err := NewWebhookSink(&config.Webhook{Name: "hook", URL: srv.URL, Headers: map[string]string{"X-Token": "secret"}})
+	if err != nil {
+		t.Fatalf("failed to create webhook sink: %v", err)
+	}
+	if err := s.Send(context.Background(), []*Event{newTestEvent(), newTestEvent()}); err != nil {
+		t.Fatalf("failed to send events: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected 2 webhook calls, got %d", calls.Load())
+	}
+	if lines := strings.Split(strings.TrimSpace(body.Load().(string)), "\n"); len(lines) != 2 {
+		t.Fatalf("expected 2 NDJSON lines, got %d", len(lines))
+	}
+}
+
+func TestForwarderFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events", "events.ndjson")
+	f, err := New(&config.Forwarder{
+		File: []config.File{{Path: path}},
+	}, "1.0", logrus.NewEntry(logrus.StandardLogger()))
+	if err != nil {
+		t.Fatalf("failed to create forwarder: %v", err)
+	}
+	f.Forward([]*Event{newTestEvent()})
+	f.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read events file: %v", err)
+	}
+	var e Event
+	if err := json.Unmarshal(data, &e); err != nil || e.Uniq != "uniq1" {
+		t.Fatalf("unexpected events file content: %s (%v)", data, err)
+	}
+	stats, _ := f.DumpStats()
+	if stats["forwarder_file_0_sent_events"] != 1 {
+		t.Fatalf("unexpected forwarder stats: %v", stats)
+	}
+}
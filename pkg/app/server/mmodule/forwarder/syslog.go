@@ -0,0 +1,315 @@
+package forwarder
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"soldr/pkg/app/server/mmodule/forwarder/config"
+)
+
+const (
+	syslogFormatCEF  = "cef"
+	syslogFormatLEEF = "leef"
+	syslogFormatJSON = "json"
+
+	// local0 facility is used by default
+	defaultSyslogFacility = 16
+	syslogSeverityInfo    = 6
+	defaultSyslogAppName  = "vxserver"
+	syslogDialTimeout     = 10 * time.Second
+
+	productVendor = "VXControl"
+	productName   = "SOLDR"
+)
+
+// SyslogSink sends events to syslog server according to RFC5424,
+// stream transports use octet counting framing from RFC6587
+type SyslogSink struct {
+	name      string
+	network   string
+	address   string
+	format    string
+	facility  int
+	appName   string
+	hostname  string
+	version   string
+	tlsConfig *tls.Config
+
+	mx   sync.Mutex
+	conn net.Conn
+}
+
+// NewSyslogSink is a function to create syslog sink, connection is established lazily
+func NewSyslogSink(c *config.Syslog, version string) (*SyslogSink, error) {
+	s := &SyslogSink{
+		name:     c.Name,
+		network:  c.Network,
+		address:  c.Address,
+		format:   strings.ToLower(c.Format),
+		facility: defaultSyslogFacility,
+		appName:  c.AppName,
+		version:  version,
+	}
+	if s.address == "" {
+		return nil, fmt.Errorf("syslog address is empty")
+	}
+	switch s.network {
+	case "":
+		s.network = "udp"
+	case "udp", "tcp":
+	case "tls":
+		s.tlsConfig = &tls.Config{
+			InsecureSkipVerify: c.TLSSkipVerify, //nolint:gosec
+			MinVersion:         tls.VersionTLS12,
+		}
+		if c.TLSCA != "" {
+			caPEM, err := os.ReadFile(c.TLSCA)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA file %s: %w", c.TLSCA, err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(caPEM) {
+				return nil, fmt.Errorf("failed to parse CA file %s", c.TLSCA)
+			}
+			s.tlsConfig.RootCAs = pool
+		}
+	default:
+		return nil, fmt.Errorf("unsupported syslog network: %s", s.network)
+	}
+	switch s.format {
+	case "":
+		s.format = syslogFormatCEF
+	case syslogFormatCEF, syslogFormatLEEF, syslogFormatJSON:
+	default:
+		return nil, fmt.Errorf("unsupported syslog format: %s", s.format)
+	}
+	if c.Facility != nil {
+		if *c.Facility < 0 || *c.Facility > 23 {
+			return nil, fmt.Errorf("syslog facility must be in range [0-23]: %d", *c.Facility)
+		}
+		s.facility = *c.Facility
+	}
+	if s.appName == "" {
+		s.appName = defaultSyslogAppName
+	}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		s.hostname = hostname
+	} else {
+		s.hostname = "-"
+	}
+	return s, nil
+}
+
+func (s *SyslogSink) Name() string {
+	return s.name
+}
+
+func (s *SyslogSink) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: syslogDialTimeout}
+	if s.tlsConfig != nil {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}
+		return tlsDialer.DialContext(ctx, "tcp", s.address)
+	}
+	return dialer.DialContext(ctx, s.network, s.address)
+}
+
+func (s *SyslogSink) Send(ctx context.Context, events []*Event) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	for _, e := range events {
+		msg, err := s.formatMessage(e)
+		if err != nil {
+			return err
+		}
+		if err = s.write(ctx, msg); err != nil {
+			// reconnect once because the stream could be closed by the server side
+			if err = s.write(ctx, msg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *SyslogSink) write(ctx context.Context, msg []byte) error {
+	if s.conn == nil {
+		conn, err := s.dial(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to connect to syslog server %s: %w", s.address, err)
+		}
+		s.conn = conn
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = s.conn.SetWriteDeadline(deadline)
+	}
+	frame := msg
+	if s.network != "udp" {
+		frame = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+	}
+	if _, err := s.conn.Write(frame); err != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+		return fmt.Errorf("failed to write message to syslog server %s: %w", s.address, err)
+	}
+	return nil
+}
+
+func (s *SyslogSink) Close() error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+func (s *SyslogSink) formatMessage(e *Event) ([]byte, error) {
+	var payload string
+	switch s.format {
+	case syslogFormatCEF:
+		payload = formatCEF(e, s.version)
+	case syslogFormatLEEF:
+		payload = formatLEEF(e, s.version)
+	case syslogFormatJSON:
+		data, err := json.Marshal(e)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal event %s: %w", e.Uniq, err)
+		}
+		payload = string(data)
+	}
+	pri := s.facility*8 + syslogSeverityInfo
+	ts := e.Date
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+	msg := fmt.Sprintf("<%d>1 %s %s %s %d %s - %s",
+		pri,
+		ts.UTC().Format(time.RFC3339Nano),
+		s.hostname,
+		s.appName,
+		os.Getpid(),
+		syslogMsgID(e.Name),
+		payload,
+	)
+	return []byte(msg), nil
+}
+
+func syslogMsgID(name string) string {
+	if name == "" {
+		return "-"
+	}
+	const maxMsgIDLen = 32
+	if len(name) > maxMsgIDLen {
+		name = name[:maxMsgIDLen]
+	}
+	return name
+}
+
+// eventExtension returns flat sorted list of event fields to be used as CEF/LEEF extension
+func eventExtension(e *Event) [][2]string {
+	ext := [][2]string{
+		{"rt", strconv.FormatInt(e.Date.UnixMilli(), 10)},
+		{"deviceExternalId", e.AgentID},
+		{"cs1Label", "group_id"},
+		{"cs1", e.GroupID},
+		{"cs2Label", "policy_id"},
+		{"cs2", e.PolicyID},
+		{"cs3Label", "module_name"},
+		{"cs3", e.ModuleName},
+		{"externalId", e.Uniq},
+	}
+	if len(e.Actions) != 0 {
+		ext = append(ext, [2]string{"act", strings.Join(e.Actions, ",")})
+	}
+	keys := make([]string, 0, len(e.Data))
+	for k := range e.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		var val string
+		switch v := e.Data[k].(type) {
+		case string:
+			val = v
+		default:
+			data, _ := json.Marshal(v)
+			val = string(data)
+		}
+		ext = append(ext, [2]string{"data_" + k, val})
+	}
+	return ext
+}
+
+var (
+	cefHeaderEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`)
+	cefExtEscaper    = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r", `\r`, "\n", `\n`)
+	leefEscaper      = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+)
+
+func formatCEF(e *Event, version string) string {
+	var sb strings.Builder
+	// CEF:Version|Device Vendor|Device Product|Device Version|Signature ID|Name|Severity|Extension
+	fmt.Fprintf(&sb, "CEF:0|%s|%s|%s|%s|%s|%d|",
+		productVendor,
+		productName,
+		cefHeaderEscaper.Replace(version),
+		cefHeaderEscaper.Replace(e.ModuleName+"."+e.Name),
+		cefHeaderEscaper.Replace(e.Name),
+		eventSeverity(e),
+	)
+	for i, kv := range eventExtension(e) {
+		if i != 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(kv[0])
+		sb.WriteByte('=')
+		sb.WriteString(cefExtEscaper.Replace(kv[1]))
+	}
+	return sb.String()
+}
+
+func formatLEEF(e *Event, version string) string {
+	var sb strings.Builder
+	// LEEF:Version|Vendor|Product|Version|EventID|Extension separated by tab
+	fmt.Fprintf(&sb, "LEEF:1.0|%s|%s|%s|%s|",
+		productVendor,
+		productName,
+		strings.ReplaceAll(version, "|", " "),
+		strings.ReplaceAll(e.Name, "|", " "),
+	)
+	fmt.Fprintf(&sb, "devTime=%s\tsev=%d", e.Date.UTC().Format(time.RFC3339), eventSeverity(e))
+	for _, kv := range eventExtension(e) {
+		sb.WriteByte('\t')
+		sb.WriteString(kv[0])
+		sb.WriteByte('=')
+		sb.WriteString(leefEscaper.Replace(kv[1]))
+	}
+	return sb.String()
+}
+
+// eventSeverity maps the event name suffix convention of the modules to CEF severity
+func eventSeverity(e *Event) int {
+	name := strings.ToLower(e.Name)
+	switch {
+	case strings.HasSuffix(name, "_error"), strings.HasSuffix(name, "_failed"):
+		return 7
+	case strings.HasSuffix(name, "_warning"), strings.HasSuffix(name, "_detected"):
+		return 5
+	default:
+		return 3
+	}
+}
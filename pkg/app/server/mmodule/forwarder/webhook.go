@@ -0,0 +1,120 @@
+package forwarder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"soldr/pkg/app/server/mmodule/forwarder/config"
+)
+
+const (
+	defaultWebhookTimeout    = 10 * time.Second
+	defaultWebhookMaxRetries = 3
+	webhookRetryBaseDelay    = 500 * time.Millisecond
+	ndjsonContentType        = "application/x-ndjson"
+)
+
+// WebhookSink posts events batch as NDJSON body to HTTP endpoint
+type WebhookSink struct {
+	name       string
+	url        string
+	headers    map[string]string
+	maxRetries int
+	client     *http.Client
+}
+
+// NewWebhookSink is a function to create webhook sink
+func NewWebhookSink(c *config.Webhook) (*WebhookSink, error) {
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse webhook URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported webhook URL scheme: %s", u.Scheme)
+	}
+	timeout := time.Duration(c.TimeoutSec) * time.Second
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	maxRetries := c.MaxRetries
+	if maxRetries < 0 {
+		maxRetries = 0
+	} else if maxRetries == 0 {
+		maxRetries = defaultWebhookMaxRetries
+	}
+	return &WebhookSink{
+		name:       c.Name,
+		url:        c.URL,
+		headers:    c.Headers,
+		maxRetries: maxRetries,
+		client:     &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (s *WebhookSink) Name() string {
+	return s.name
+}
+
+func (s *WebhookSink) Send(ctx context.Context, events []*Event) error {
+	body, err := marshalNDJSON(events)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= s.maxRetries; attempt++ {
+		if attempt != 0 {
+			delay := webhookRetryBaseDelay << (attempt - 1)
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return fmt.Errorf("webhook delivery was interrupted: %w (last error: %v)", ctx.Err(), lastErr)
+			}
+		}
+		var retryable bool
+		retryable, lastErr = s.post(ctx, body)
+		if lastErr == nil {
+			return nil
+		}
+		if !retryable {
+			return lastErr
+		}
+	}
+	return fmt.Errorf("webhook delivery failed after %d retries: %w", s.maxRetries, lastErr)
+}
+
+func (s *WebhookSink) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", ndjsonContentType)
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to send webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+}
+
+func (s *WebhookSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -218,7 +218,7 @@ func newABHListFromDBResult(results []*fetchABHListFromDBResult) (*ABHList, erro
 }
 
 // nolint: lll
-var agentBinryIDRegexp = regexp.MustCompile(`(v)?[0-9]+\.[0-9]+(\.[0-9]+)?(\.[0-9]+)?(-[a-zA-Z0-9]+)?/(((linux|darwin|windows)/(amd64|386))|(aggregate|browser|external))`)
+var agentBinryIDRegexp = regexp.MustCompile(`(v)?[0-9]+\.[0-9]+(\.[0-9]+)?(\.[0-9]+)?(-[a-zA-Z0-9]+)?/(((linux|darwin|windows)/[a-z0-9]{2,10})|(aggregate|browser|external))`)
 
 func ExtractABIFromDBBinaryPath(filePath string) (string, error) {
 	binaryIDs := agentBinryIDRegexp.FindAllString(filePath, -1)
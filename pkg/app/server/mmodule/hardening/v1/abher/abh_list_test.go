@@ -26,6 +26,7 @@ func TestExtractABIFromDBBinaryPath(t *testing.T) {
 	architectures := []string{
 		"386",
 		"amd64",
+		"arm64",
 	}
 	filePaths := []string{
 		"vxagent/%s/vxagent",
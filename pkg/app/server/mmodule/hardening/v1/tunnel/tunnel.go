@@ -6,6 +6,8 @@ import (
 
 	"soldr/pkg/protoagent"
 	"soldr/pkg/vxproto/tunnel"
+	tunnelAEAD "soldr/pkg/vxproto/tunnel/aead"
+	"soldr/pkg/vxproto/tunnel/compressor"
 	tunnelSimple "soldr/pkg/vxproto/tunnel/simple"
 )
 
@@ -15,7 +17,61 @@ func NewConfigurer() *Configurer {
 	return &Configurer{}
 }
 
-func (c *Configurer) GetTunnelConfig() (*tunnel.Config, *protoagent.TunnelConfig, error) {
+// GetTunnelConfig returns the tunnel configs of the server and the agent sides,
+// AEAD cipher is chosen if the agent has advertised it otherwise the legacy one is used
+// and the compressor is negotiated in the same way
+func (c *Configurer) GetTunnelConfig(agentOffer *tunnel.Offer) (*tunnel.Config, *protoagent.TunnelConfig, error) {
+	var (
+		tunnelConfig      *tunnel.Config
+		agentTunnelConfig *protoagent.TunnelConfig
+		err               error
+	)
+	if cipher, ok := tunnelAEAD.SelectCipher(agentOffer.Ciphers); ok {
+		tunnelConfig, agentTunnelConfig, err = getAEADTunnelConfig(cipher)
+	} else {
+		tunnelConfig, agentTunnelConfig, err = getSimpleTunnelConfig()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if name, ok := compressor.SelectCompressor(agentOffer.Compressors); ok {
+		tunnelConfig.Compression = &compressor.Config{
+			Name:             name,
+			PeerDictionaries: agentOffer.Dictionaries,
+		}
+		agentTunnelConfig.Compression = &protoagent.TunnelConfig_TunnelCompression{
+			Name:         &name,
+			Dictionaries: compressor.Dictionaries(),
+		}
+	}
+	return tunnelConfig, agentTunnelConfig, nil
+}
+
+func getAEADTunnelConfig(cipher string) (*tunnel.Config, *protoagent.TunnelConfig, error) {
+	key, err := tunnelAEAD.GenerateKey(func(buf []byte) error {
+		_, err := rand.Read(buf)
+		return err
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate the tunnel key: %w", err)
+	}
+	return &tunnel.Config{
+		AEAD: &tunnelAEAD.Config{
+			Cipher:   cipher,
+			Key:      key,
+			IsServer: true,
+		},
+	}, &protoagent.TunnelConfig{
+		Config: &protoagent.TunnelConfig_Aead{
+			Aead: &protoagent.TunnelConfig_TunnelConfigAEAD{
+				Cipher: &cipher,
+				Key:    key,
+			},
+		},
+	}, nil
+}
+
+func getSimpleTunnelConfig() (*tunnel.Config, *protoagent.TunnelConfig, error) {
 	b := make([]byte, 1)
 	if _, err := rand.Read(b); err != nil {
 		return nil, nil, fmt.Errorf("failed to get a random byte: %w", err)
@@ -23,14 +79,14 @@ func (c *Configurer) GetTunnelConfig() (*tunnel.Config, *protoagent.TunnelConfig
 	key := b[0]
 	keyAgentConfig := uint32(key)
 	return &tunnel.Config{
-			Simple: &tunnelSimple.Config{
-				Key: key,
-			},
-		}, &protoagent.TunnelConfig{
-			Config: &protoagent.TunnelConfig_Simple{
-				Simple: &protoagent.TunnelConfig_TunnelConfigSimple{
-					Key: &keyAgentConfig,
-				},
+		Simple: &tunnelSimple.Config{
+			Key: key,
+		},
+	}, &protoagent.TunnelConfig{
+		Config: &protoagent.TunnelConfig_Simple{
+			Simple: &protoagent.TunnelConfig_TunnelConfigSimple{
+				Key: &keyAgentConfig,
 			},
-		}, nil
+		},
+	}, nil
 }
@@ -37,13 +37,14 @@ func (v *ConnectionValidator) OnConnect(
 	}
 
 	logger.Debug("performing challenge")
-	if err := v.performChallenge(ctx, agentType, socket); err != nil {
+	agentOffer, err := v.performChallenge(ctx, agentType, socket)
+	if err != nil {
 		logger.WithError(err).Errorf("failed to perform the connection challenge")
 		return fmt.Errorf("connection challenge failed: %w", err)
 	}
 
 	logger.Debug("requesting connection start")
-	if err := v.requestConnectionStart(ctx, tlsConnState, socket, configurePackEncryptor); err != nil {
+	if err := v.requestConnectionStart(ctx, tlsConnState, socket, agentOffer, configurePackEncryptor); err != nil {
 		return fmt.Errorf("failed to send a request to start connection: %w", err)
 	}
 
@@ -61,26 +62,29 @@ func (v *ConnectionValidator) exchangeChallengeMessages(
 	ctx context.Context,
 	agentType vxproto.AgentType,
 	socket vxproto.IAgentSocket,
-) error {
+) (*tunnel.Offer, error) {
 	challenge, err := v.challenger.GetConnectionChallenge()
 	if err != nil {
-		return fmt.Errorf("failed to get a connection challenge: %w", err)
+		return nil, fmt.Errorf("failed to get a connection challenge: %w", err)
 	}
 	if err := v.sendConnectionChallenge(ctx, socket, challenge); err != nil {
-		return fmt.Errorf("failed to send the connection challenge: %w", err)
+		return nil, fmt.Errorf("failed to send the connection challenge: %w", err)
 	}
-	if err := v.checkConnectionChallengeResponse(ctx, agentType, socket, challenge); err != nil {
-		return fmt.Errorf("failed to check the connection challenge response: %w", err)
+	agentOffer, err := v.checkConnectionChallengeResponse(ctx, agentType, socket, challenge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check the connection challenge response: %w", err)
 	}
-	return nil
+	return agentOffer, nil
 }
 
+// performChallenge checks the connection challenge response of the agent and returns
+// the tunnel features which are supported by the agent
 func (v *ConnectionValidator) performChallenge(
 	ctx context.Context, agentType vxproto.AgentType, socket vxproto.IAgentSocket,
-) error {
-	err := v.exchangeChallengeMessages(ctx, agentType, socket)
+) (*tunnel.Offer, error) {
+	agentOffer, err := v.exchangeChallengeMessages(ctx, agentType, socket)
 	if err == nil {
-		return nil
+		return agentOffer, nil
 	}
 
 	emptyStr := ""
@@ -94,12 +98,12 @@ func (v *ConnectionValidator) performChallenge(
 	}
 	data, packErr := protoagent.PackProtoMessage(resp, protoagent.Message_AUTHENTICATION_RESPONSE)
 	if packErr != nil {
-		return fmt.Errorf("failed to pack the proto mesage (%v) while processing the error: %w", packErr, err)
+		return nil, fmt.Errorf("failed to pack the proto mesage (%v) while processing the error: %w", packErr, err)
 	}
 	if writeErr := socket.Write(ctx, data); writeErr != nil {
-		return fmt.Errorf("failed to send the authentication response (%v) while processing the error: %w", writeErr, err)
+		return nil, fmt.Errorf("failed to send the authentication response (%v) while processing the error: %w", writeErr, err)
 	}
-	return err
+	return nil, err
 }
 
 func (v *ConnectionValidator) sendConnectionChallenge(
@@ -123,18 +127,18 @@ func (v *ConnectionValidator) checkConnectionChallengeResponse(
 	agentType vxproto.AgentType,
 	socket vxproto.IAgentSocket,
 	expectedChallenge []byte,
-) error {
+) (*tunnel.Offer, error) {
 	var resp protoagent.ConnectionChallengeResponse
 	respData, err := socket.Read(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to read the message from the socket: %w", err)
+		return nil, fmt.Errorf("failed to read the message from the socket: %w", err)
 	}
 	if err := protoagent.UnpackProtoMessage(&resp, respData, protoagent.Message_CONNECTION_CHALLENGE_REQUEST); err != nil {
-		return fmt.Errorf("failed to unpack the message: %w", err)
+		return nil, fmt.Errorf("failed to unpack the message: %w", err)
 	}
 	abhs, err := v.abher.GetABHWithSocket(agentType, socket)
 	if err != nil {
-		return fmt.Errorf("failed to get the ABH: %w", err)
+		return nil, fmt.Errorf("failed to get the ABH: %w", err)
 	}
 	if err := v.challenger.CheckConnectionChallenge(
 		resp.Ct,
@@ -142,18 +146,23 @@ func (v *ConnectionValidator) checkConnectionChallengeResponse(
 		socket.GetAgentID(),
 		abhs,
 	); err != nil {
-		return fmt.Errorf("connection challenge check has failed: %w", err)
+		return nil, fmt.Errorf("connection challenge check has failed: %w", err)
 	}
-	return nil
+	return &tunnel.Offer{
+		Ciphers:      resp.GetTunnelCiphers(),
+		Compressors:  resp.GetTunnelCompressors(),
+		Dictionaries: resp.GetTunnelDictionaries(),
+	}, nil
 }
 
 func (v *ConnectionValidator) requestConnectionStart(
 	ctx context.Context,
 	tlsConnState *tls.ConnectionState,
 	socket vxproto.IAgentSocket,
+	agentOffer *tunnel.Offer,
 	configureTunnel func(c *tunnel.Config) error,
 ) error {
-	tunnelConfig, agentTunnelConfig, err := v.tunnelConfigurer.GetTunnelConfig()
+	tunnelConfig, agentTunnelConfig, err := v.tunnelConfigurer.GetTunnelConfig(agentOffer)
 	if err != nil {
 		return fmt.Errorf("failed to get a tunnel config: %w", err)
 	}
@@ -41,7 +41,7 @@ type Challenger interface {
 }
 
 type TunnelConfigurer interface {
-	GetTunnelConfig() (*tunnel.Config, *protoagent.TunnelConfig, error)
+	GetTunnelConfig(agentOffer *tunnel.Offer) (*tunnel.Config, *protoagent.TunnelConfig, error)
 }
 
 type ConnectionValidator struct {
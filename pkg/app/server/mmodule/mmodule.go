@@ -26,6 +26,8 @@ import (
 
 	"soldr/pkg/app/api/models"
 	"soldr/pkg/app/server/certs"
+	"soldr/pkg/app/server/mmodule/forwarder"
+	forwarderConfig "soldr/pkg/app/server/mmodule/forwarder/config"
 	"soldr/pkg/app/server/mmodule/hardening"
 	hardeningConfig "soldr/pkg/app/server/mmodule/hardening/config"
 	hardeningUtils "soldr/pkg/app/server/mmodule/hardening/utils"
@@ -50,6 +52,10 @@ const (
 	syncAgentsInterval = 20 * time.Second
 	// syncGroupsInterval is a time period to retrieve grpups from DB
 	syncGroupsInterval = 5 * time.Second
+	// refreshAgentInfoDelay is a time period after agent connection to request its inventory
+	refreshAgentInfoDelay = 1 * time.Minute
+	// refreshAgentInfoInterval is a time period to refresh agent inventory into DB
+	refreshAgentInfoInterval = 1 * time.Hour
 	// syncAliveStatusBatchSize is an amount agents ID which will using in SQL update request
 	syncAliveStatusBatchSize = 100
 	// lostAgentDeltaTime is amount minutes which agent was not update its connected_date
@@ -69,7 +75,8 @@ type MainModule struct {
 	groups                    *groupList
 	listen                    string
 	version                   string
-	eventsQueue               chan *models.Event
+	eventsQueue               chan *eventsQueueItem
+	forwarder                 *forwarder.Forwarder
 	msocket                   vxproto.IModuleSocket
 	wgControl                 sync.WaitGroup
 	wgReceiver                sync.WaitGroup
@@ -85,6 +92,7 @@ type MainModule struct {
 	cancelUpgradeTaskConsumer context.CancelFunc
 	certsProvider             certs.Provider
 	authenticator             *Authenticator
+	agentPlatforms            *agentPlatforms
 	connValidatorFactory      *hardening.ConnectionValidatorFactory
 	moduleConfigDecryptor     *crypto.ConfigDecryptor
 	syncModulesSemaphore      chan struct{}
@@ -92,6 +100,11 @@ type MainModule struct {
 	cancelContext context.CancelFunc
 }
 
+type eventsQueueItem struct {
+	event    *models.Event
+	fwdEvent *forwarder.Event
+}
+
 type AgentInfoDB struct {
 	GID        string `gorm:"column:gid"`
 	AuthStatus string `gorm:"column:auth_status"`
@@ -234,6 +247,10 @@ func (mm *MainModule) GetVersion() string {
 }
 
 const sqlNowFunction = "NOW()"
+
+// sqlReplaceAgentInventory merges agent information with inventory into stored one, inventory keys are removed
+// before merging because empty lists are omitted from the information and JSON_MERGE_PATCH would keep stale ones
+const sqlReplaceAgentInventory = "JSON_MERGE_PATCH(JSON_REMOVE(`info`, '$.hardware', '$.packages', '$.services'), ?)"
 const (
 	dbAgentStatusAuthorized   = "authorized"
 	dbAgentStatusUnauthorized = "unauthorized"
@@ -272,7 +289,7 @@ func (mm *MainModule) HasAgentInfoValid(ctx context.Context, asocket vxproto.IAg
 		return utilsErrors.ErrFailedResponseCorrupted
 	}
 
-	if info.Type == vxproto.VXAgent && !checkAgentInfo(info.Info) {
+	if info.Type == vxproto.VXAgent && !mm.checkAgentInfo(info.Info) {
 		log.Error("failed to validate agent Info")
 		return utilsErrors.ErrFailedResponseCorrupted
 	}
@@ -371,8 +388,9 @@ func (mm *MainModule) HasAgentInfoValid(ctx context.Context, asocket vxproto.IAg
 	return nil
 }
 
-func checkAgentInfo(info *protoagent.Information) bool {
-	switch info.GetOs().GetType() {
+func (mm *MainModule) checkAgentInfo(info *protoagent.Information) bool {
+	osType, osArch := info.GetOs().GetType(), info.GetOs().GetArch()
+	switch osType {
 	case "linux":
 	case "windows":
 	case "darwin":
@@ -380,14 +398,18 @@ func checkAgentInfo(info *protoagent.Information) bool {
 		return false
 	}
 
-	switch info.GetOs().GetArch() {
-	case "amd64":
-	case "386":
-	default:
+	if err := models.GetValidator().Var(osArch, "vxarch,required"); err != nil {
 		return false
 	}
 
-	return true
+	// any arch is accepted if it's listed in the agent binaries catalogue
+	ok, err := mm.agentPlatforms.Has(osType, osArch)
+	if err != nil {
+		logrus.WithError(err).Error("failed to check agent platform")
+		return false
+	}
+
+	return ok
 }
 
 type updateMapWithRules map[string]*struct {
@@ -462,6 +484,18 @@ func (mm *MainModule) RegisterLuaAPI(state *lua.State, config *loader.ModuleConf
 			ctx := context.Background()
 			eventCtx, eventSpan := obs.Observer.NewSpan(ctx, obs.SpanKindInternal, "push_event")
 			defer eventSpan.End()
+			// events are charged to the actions budget of the module socket to protect the events writer
+			if ms := mm.proto.GetModule(mname, gid); ms != nil {
+				if err := ms.CheckRateLimit(vxproto.PTAction); err != nil {
+					logrus.WithContext(eventCtx).WithError(err).WithFields(logrus.Fields{
+						"agent_id":    aid,
+						"group_id":    gid,
+						"policy_id":   pid,
+						"module_name": mname,
+					}).Warn("failed to push event")
+					return false
+				}
+			}
 			return mm.pushEventToQueue(eventCtx, aid, gid, pid, mname, info)
 		},
 	})
@@ -835,7 +869,21 @@ func (mm *MainModule) publishEvents(ctx context.Context) {
 	defer mm.wgControl.Done()
 
 	queue := make([]*models.Event, 0)
+	fwdQueue := make([]*forwarder.Event, 0)
+	attr := attribute.String("server_id", system.MakeAgentID())
+	queueLength, err := obs.Observer.NewInt64GaugeCounter("events_queue_length")
+	if err != nil {
+		logrus.WithContext(ctx).WithError(err).Warn("failed to create events queue length metric")
+	}
+	recordQueueLength := func() {
+		queueLength.Record(ctx, int64(len(mm.eventsQueue)+len(queue)), attr)
+	}
 	publish := func() {
+		if len(fwdQueue) != 0 {
+			// forwarding is independent of DB storing to avoid duplicates on DB retries
+			mm.forwarder.Forward(fwdQueue)
+			fwdQueue = make([]*forwarder.Event, 0)
+		}
 		if len(queue) == 0 {
 			return
 		}
@@ -858,10 +906,15 @@ func (mm *MainModule) publishEvents(ctx context.Context) {
 	defer timer.Stop()
 	for {
 		select {
-		case event := <-mm.eventsQueue:
-			queue = append(queue, event)
+		case item := <-mm.eventsQueue:
+			queue = append(queue, item.event)
+			if mm.forwarder.IsEnabled() {
+				fwdQueue = append(fwdQueue, item.fwdEvent)
+			}
+			recordQueueLength()
 		case <-timer.C:
 			publish()
+			recordQueueLength()
 		case <-ctx.Done():
 			publish()
 			return
@@ -967,13 +1020,27 @@ func (mm *MainModule) pushEventToQueue(ctx context.Context, aid, gid, pid, mname
 		return false
 	}
 
-	event := &models.Event{
-		AgentID:  agentID,
-		ModuleID: moduleID,
-		Info:     evInfo,
+	item := &eventsQueueItem{
+		event: &models.Event{
+			AgentID:  agentID,
+			ModuleID: moduleID,
+			Info:     evInfo,
+		},
+		fwdEvent: &forwarder.Event{
+			AgentID:    aid,
+			GroupID:    gid,
+			PolicyID:   pid,
+			ModuleName: mname,
+			Name:       evInfo.Name,
+			Uniq:       evInfo.Uniq,
+			Time:       evInfo.Time,
+			Actions:    evInfo.Actions,
+			Data:       evInfo.Data,
+			Date:       time.Now().UTC(),
+		},
 	}
 	select {
-	case mm.eventsQueue <- event:
+	case mm.eventsQueue <- item:
 	case <-time.NewTimer(5 * time.Second).C:
 		log.WithError(err).Error("failed to insert new event to the queue: timeout exceeded")
 		return false
@@ -1413,6 +1480,12 @@ func (mm *MainModule) controlAgent(dst string, syncRun chan struct{}, ainfo *age
 	controlSpan.End()
 
 	mxSync := &sync.Mutex{}
+	if !ainfo.info.IsOnlyForUpgrade {
+		refreshCtx, refreshCancel := context.WithCancel(ctx)
+		defer refreshCancel()
+		mm.wgExchAgent.Add(1)
+		go mm.refreshAgentInfo(refreshCtx, mxSync, dst, ainfo)
+	}
 	for {
 		if !ainfo.info.IsOnlyForUpgrade {
 			mm.runSyncAgentModules(ctx, mxSync, dst, ainfo)
@@ -1435,6 +1508,59 @@ func (mm *MainModule) controlAgent(dst string, syncRun chan struct{}, ainfo *age
 	}
 }
 
+// refreshAgentInfo is a function to request agent information with software and hardware
+// inventory periodically and to store it into DB to make it searchable by agent filters
+func (mm *MainModule) refreshAgentInfo(ctx context.Context, mxSync *sync.Mutex, dst string, ainfo *agentInfo) {
+	defer mm.wgExchAgent.Done()
+
+	timer := time.NewTimer(refreshAgentInfoDelay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return
+		}
+		if err := mm.updateAgentInfo(ctx, mxSync, dst, ainfo); err != nil {
+			genAgentMessage(ctx, ainfo).WithError(err).Warn("failed to refresh agent information")
+		}
+		timer.Reset(refreshAgentInfoInterval)
+	}
+}
+
+func (mm *MainModule) updateAgentInfo(ctx context.Context, mxSync *sync.Mutex, dst string, ainfo *agentInfo) error {
+	infoCtx, infoSpan := obs.Observer.NewSpan(ctx, obs.SpanKindInternal, "refresh_agent_info")
+	defer infoSpan.End()
+
+	// agent connection is used by modules synchronization so it must be exclusive
+	mxSync.Lock()
+	info, err := mm.getInformation(infoCtx, dst)
+	mxSync.Unlock()
+	if err != nil {
+		return err
+	}
+	if !mm.checkAgentInfo(info) {
+		return fmt.Errorf("agent information is corrupted")
+	}
+
+	jinfo, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("failed to marshal agent information: %w", err)
+	}
+	err = mm.gdbc.
+		Scopes(agentWithHash(ainfo.info.ID)).
+		UpdateColumns(map[string]interface{}{
+			"info":       gorm.Expr(sqlReplaceAgentInventory, jinfo),
+			"updated_at": gorm.Expr(sqlNowFunction),
+		}).
+		Error
+	if err != nil {
+		return fmt.Errorf("failed to store agent information into DB: %w", err)
+	}
+
+	return nil
+}
+
 func (mm *MainModule) runSyncAgentModules(ctx context.Context, mxSync *sync.Mutex, dst string, ainfo *agentInfo) {
 	// TODO: here need use Context to avoid hanging on receive on closed socket
 	mm.wgExchAgent.Add(1)
@@ -1820,6 +1946,8 @@ func New(
 	tracerClient otlptrace.Client,
 	metricsClient otlpmetric.Client,
 	maxConcSyncingAgents int,
+	agentRateLimits vxproto.RateLimits,
+	forwarderConf *forwarderConfig.Forwarder,
 	logger *logrus.Entry,
 ) (mm *MainModule, err error) {
 	mm = &MainModule{
@@ -1837,11 +1965,12 @@ func New(
 			mutex: &sync.Mutex{},
 		},
 		mutexAgent:           &sync.Mutex{},
-		eventsQueue:          make(chan *models.Event, 100),
+		eventsQueue:          make(chan *eventsQueueItem, 100),
 		quitSyncAgents:       make(chan struct{}),
 		quitSyncGroups:       make(chan struct{}),
 		certsProvider:        certsProvider,
 		authenticator:        newAuthenticator(),
+		agentPlatforms:       newAgentPlatforms(gdb),
 		tracerClient:         tracerClient,
 		meterClient:          metricsClient,
 		syncModulesSemaphore: make(chan struct{}, maxConcSyncingAgents),
@@ -1858,6 +1987,7 @@ func New(
 	if err != nil {
 		return mm, fmt.Errorf("failed initialize VXProto object: %w", err)
 	}
+	mm.proto.SetAgentRateLimits(agentRateLimits)
 
 	mm.cnt = controller.NewController(mm, cl, fl, mm.proto)
 	if err = mm.cnt.Load(); err != nil {
@@ -1901,6 +2031,11 @@ func New(
 
 	mm.moduleConfigDecryptor = crypto.NewConfigDecryptor()
 
+	mm.forwarder, err = forwarder.New(forwarderConf, version, logger.WithField("component", "events_forwarder"))
+	if err != nil {
+		return mm, fmt.Errorf("failed to initialize the events forwarder: %w", err)
+	}
+
 	return mm, nil
 }
 
@@ -1935,6 +2070,11 @@ func (mm *MainModule) Start(serverConfig *vxproto.ServerConfig) error {
 	if err := obs.Observer.StartDumperMetricCollect(mm.proto, vxserverServiceName, mm.version, attr); err != nil {
 		logrus.WithError(err).Warn("failed to start dumper metrics collect")
 	}
+	if mm.forwarder.IsEnabled() {
+		if err := obs.Observer.StartDumperMetricCollect(mm.forwarder, vxserverServiceName, mm.version, attr); err != nil {
+			logrus.WithError(err).Warn("failed to start events forwarder metrics collect")
+		}
+	}
 
 	if !mm.proto.AddModule(mm.msocket) {
 		return fmt.Errorf("failed module socket register")
@@ -2047,6 +2187,7 @@ func (mm *MainModule) Stop() error {
 	}
 
 	mm.upgradeTaskConsumer.Close(stopCtx)
+	mm.forwarder.Close()
 
 	stopSpan.End()
 	obs.Observer.Flush(context.Background())
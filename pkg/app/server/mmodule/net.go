@@ -391,17 +391,15 @@ func (mm *MainModule) getModulesInfoFromDB() ([]moduleChecksumsInfo, error) {
 }
 
 // getInformation is API functions which execute remote logic
-//
-//lint:ignore U1000 it'll be use in the future
 func (mm *MainModule) getInformation(ctx context.Context, dst string) (*protoagent.Information, error) {
-	message, err := mm.requestAgent(ctx, dst, protoagent.Message_GET_INFORMATION, []byte{})
-	if err != nil {
-		return nil, err
-	}
-
 	var infoMessage protoagent.Information
-	if err = proto.Unmarshal(message.Payload, &infoMessage); err != nil {
-		return nil, fmt.Errorf("error unmarshal response packet of information: %w", err)
+	err := mm.requestAgentWithDestStruct(
+		ctx, dst,
+		protoagent.Message_GET_INFORMATION, []byte{},
+		protoagent.Message_INFORMATION_RESULT, &infoMessage,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent information: %w", err)
 	}
 
 	return &infoMessage, nil
@@ -61,7 +61,7 @@ type VM interface {
 	ProcessInitConnectionResponse(resp []byte) error
 	ResetInitConnection()
 
-	ProcessConnectionChallengeRequest(ctx context.Context, req []byte) ([]byte, error)
+	ProcessConnectionChallengeRequest(ctx context.Context, req []byte, encryptor vxprotoTunnel.PackEncryptor) ([]byte, error)
 	ProcessConnectionRequest(req []byte, encryptor vxprotoTunnel.PackEncryptor) ([]byte, error)
 
 	GeneratePingResponse(nonce []byte) ([]byte, error)
@@ -421,7 +421,11 @@ func (v *vm) GetTLSConfigForConnection() (*tls.Config, error) {
 	}, nil
 }
 
-func (v *vm) ProcessConnectionChallengeRequest(ctx context.Context, req []byte) ([]byte, error) {
+func (v *vm) ProcessConnectionChallengeRequest(
+	ctx context.Context,
+	req []byte,
+	packEncryptor vxprotoTunnel.PackEncryptor,
+) ([]byte, error) {
 	var connChallengeReq protoagent.ConnectionChallengeRequest
 	if err := protoagent.UnpackProtoMessage(&connChallengeReq, req, protoagent.Message_CONNECTION_CHALLENGE_REQUEST); err != nil {
 		return nil, fmt.Errorf("failed to unpack the connection challenge request: %w", err)
@@ -430,8 +434,12 @@ func (v *vm) ProcessConnectionChallengeRequest(ctx context.Context, req []byte)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare the connection challenge response: %w", err)
 	}
+	offer := vxprotoTunnel.GetOffer(packEncryptor)
 	connChallengeResp := &protoagent.ConnectionChallengeResponse{
-		Ct: ct,
+		Ct:                 ct,
+		TunnelCiphers:      offer.Ciphers,
+		TunnelCompressors:  offer.Compressors,
+		TunnelDictionaries: offer.Dictionaries,
 	}
 	msg, err := protoagent.PackProtoMessage(connChallengeResp, protoagent.Message_CONNECTION_CHALLENGE_REQUEST)
 	if err != nil {
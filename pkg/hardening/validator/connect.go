@@ -70,7 +70,7 @@ func (v *Validator) doHandshakeWithServer(
 	if err != nil {
 		return fmt.Errorf("reading the handshake message from the server has failed")
 	}
-	connChallengeResp, err := v.vm.ProcessConnectionChallengeRequest(ctx, msg)
+	connChallengeResp, err := v.vm.ProcessConnectionChallengeRequest(ctx, msg, encrypter)
 	if err != nil {
 		return fmt.Errorf("failed to process the connection challenge request: %w", err)
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/vxcontrol/luar"
@@ -11,6 +12,7 @@ import (
 	"soldr/pkg/lua"
 	"soldr/pkg/protoagent"
 	"soldr/pkg/vxproto"
+	compressorZstd "soldr/pkg/vxproto/tunnel/compressor/zstd"
 )
 
 type deferredModuleCallback func() bool
@@ -61,6 +63,22 @@ func NewState(mc *ModuleConfig, mi *ModuleItem, p vxproto.IVXProto) (*ModuleStat
 		return p.DelModule(socket)
 	}
 
+	// budgets of the module packets are defined by the module arguments e.g. "rate_limits": ["data=100:200"]
+	if args, ok := mi.args[vxproto.RateLimitsArg]; ok {
+		limits, err := vxproto.ParseRateLimits(strings.Join(args, ","))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the rate limits of the module '%s': %w", mc.Name, err)
+		}
+		socket.SetRateLimits(limits)
+	}
+
+	// the trained dictionary is used to compress packets of the module if the peer has the same one
+	if dict, ok := mi.files[compressorZstd.DictionaryFile]; ok {
+		if _, err := compressorZstd.RegisterDictionary(mc.Name, dict); err != nil {
+			return nil, fmt.Errorf("failed to register the compression dictionary of the module '%s': %w", mc.Name, err)
+		}
+	}
+
 	var err error
 	if ms.luaState, err = lua.NewState(mi.files); err != nil {
 		return nil, fmt.Errorf("failed to create a new lua state: %w", err)
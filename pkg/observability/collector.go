@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 
@@ -136,11 +137,17 @@ func startDumperMetricCollect(stats IDumper, meter metric.Meter, attrs []attribu
 
 	for key := range lastStats {
 		metricName := key
-		meter.NewFloat64CounterObserver(metricName, func(ctx context.Context, m metric.Float64ObserverResult) {
+		observe := func(ctx context.Context, m metric.Float64ObserverResult) {
 			if value, ok := getProtoStats()[metricName]; ok {
 				m.Observe(value, attrs...)
 			}
-		})
+		}
+		// ratios aren't monotonic so they are exported as gauges
+		if strings.HasSuffix(metricName, "_ratio") {
+			meter.NewFloat64GaugeObserver(metricName, observe)
+		} else {
+			meter.NewFloat64CounterObserver(metricName, observe)
+		}
 	}
 
 	return nil
@@ -109,6 +109,29 @@ func NewMeterProvider(
 	service,
 	version string,
 	opts ...attribute.KeyValue,
+) (*controller.Controller, error) {
+	return newMeterProvider(ctx, client, false, service, version, opts...)
+}
+
+// NewPullableMeterProvider is constructor for otlp sdk metrics provider with custom otlp client
+// which keeps all reported instruments into the checkpoint to serve them via metrics endpoint
+func NewPullableMeterProvider(
+	ctx context.Context,
+	client otlpmetric.Client,
+	service,
+	version string,
+	opts ...attribute.KeyValue,
+) (*controller.Controller, error) {
+	return newMeterProvider(ctx, client, true, service, version, opts...)
+}
+
+func newMeterProvider(
+	ctx context.Context,
+	client otlpmetric.Client,
+	memory bool,
+	service,
+	version string,
+	opts ...attribute.KeyValue,
 ) (*controller.Controller, error) {
 	exporter, err := otlpmetric.New(ctx, client)
 	if err != nil {
@@ -124,6 +147,7 @@ func NewMeterProvider(
 		processor.NewFactory(
 			simple.NewWithHistogramDistribution(),
 			exporter,
+			processor.WithMemory(memory),
 		),
 		controller.WithExporter(exporter),
 		controller.WithCollectPeriod(defCollectPeriod),
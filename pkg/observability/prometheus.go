@@ -0,0 +1,194 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+)
+
+const (
+	// MetricsPath is a path of the pull-based metrics endpoint in Prometheus text format
+	MetricsPath = "/metrics"
+
+	defMetricsReadTimeout     = 10 * time.Second
+	defMetricsShutdownTimeout = 5 * time.Second
+)
+
+// metricsCollector is a Prometheus collector which exposes the last checkpoint of the metrics provider,
+// so the same meters are pushed via otlp client and pulled via metrics endpoint
+type metricsCollector struct {
+	mprovider *controller.Controller
+	errDesc   *prometheus.Desc
+}
+
+func newMetricsCollector(mprovider *controller.Controller) *metricsCollector {
+	return &metricsCollector{
+		mprovider: mprovider,
+		errDesc:   prometheus.NewDesc("otel_metrics_collect_error", "failed to read metrics checkpoint", nil, nil),
+	}
+}
+
+// Describe sends nothing because instruments are registered dynamically (e.g. by Lua modules),
+// it makes the collector unchecked one
+func (mc *metricsCollector) Describe(ch chan<- *prometheus.Desc) {}
+
+// Collect converts all records of the last checkpoint to Prometheus const metrics
+func (mc *metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	err := mc.mprovider.ForEach(func(_ instrumentation.Library, reader export.Reader) error {
+		return reader.ForEach(aggregation.CumulativeTemporalitySelector(), func(record export.Record) error {
+			metric, err := convertMetricRecord(record)
+			if errors.Is(err, aggregation.ErrNoData) {
+				return nil
+			} else if err != nil {
+				logrus.WithError(err).Debugf("failed to convert metric '%s'", record.Descriptor().Name())
+				return nil
+			}
+			ch <- metric
+			return nil
+		})
+	})
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(mc.errDesc, err)
+	}
+}
+
+func convertMetricRecord(record export.Record) (prometheus.Metric, error) {
+	desc := record.Descriptor()
+	kind := desc.NumberKind()
+	names, values := convertMetricLabels(record.Labels())
+	help := desc.Description()
+	if help == "" {
+		help = desc.Name()
+	}
+	pdesc := prometheus.NewDesc(sanitizeMetricName(desc.Name()), help, names, nil)
+
+	switch agg := record.Aggregation().(type) {
+	case aggregation.Histogram:
+		buckets, err := agg.Histogram()
+		if err != nil {
+			return nil, err
+		}
+		count, err := agg.Count()
+		if err != nil {
+			return nil, err
+		}
+		sum, err := agg.Sum()
+		if err != nil {
+			return nil, err
+		}
+		var total uint64
+		cumulative := make(map[float64]uint64, len(buckets.Boundaries))
+		for idx, boundary := range buckets.Boundaries {
+			total += buckets.Counts[idx]
+			cumulative[boundary] = total
+		}
+		return prometheus.NewConstHistogram(pdesc, count, sum.CoerceToFloat64(kind), cumulative, values...)
+	case aggregation.LastValue:
+		value, _, err := agg.LastValue()
+		if err != nil {
+			return nil, err
+		}
+		return prometheus.NewConstMetric(pdesc, prometheus.GaugeValue, value.CoerceToFloat64(kind), values...)
+	case aggregation.Sum:
+		value, err := agg.Sum()
+		if err != nil {
+			return nil, err
+		}
+		vtype := prometheus.GaugeValue
+		if desc.InstrumentKind().Monotonic() {
+			vtype = prometheus.CounterValue
+		}
+		return prometheus.NewConstMetric(pdesc, vtype, value.CoerceToFloat64(kind), values...)
+	default:
+		return nil, fmt.Errorf("unsupported aggregation kind '%s'", agg.Kind())
+	}
+}
+
+func convertMetricLabels(labels *attribute.Set) ([]string, []string) {
+	names := make([]string, 0, labels.Len())
+	values := make([]string, 0, labels.Len())
+	for iter := labels.Iter(); iter.Next(); {
+		label := iter.Label()
+		names = append(names, sanitizeMetricName(string(label.Key)))
+		values = append(values, label.Value.Emit())
+	}
+	return names, values
+}
+
+// sanitizeMetricName replaces all symbols which are not allowed by Prometheus data model,
+// e.g. Lua modules may use dashes and dots into metric names
+func sanitizeMetricName(name string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == ':':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+	if sanitized == "" || (sanitized[0] >= '0' && sanitized[0] <= '9') {
+		sanitized = "_" + sanitized
+	}
+	return sanitized
+}
+
+// NewMetricsHandler returns http handler to expose metrics of the provider in Prometheus text format
+func NewMetricsHandler(mprovider *controller.Controller) (http.Handler, error) {
+	if mprovider == nil {
+		return nil, fmt.Errorf("metrics provider is not initialized")
+	}
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(newMetricsCollector(mprovider)); err != nil {
+		return nil, fmt.Errorf("failed to register metrics collector: %w", err)
+	}
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		ErrorHandling: promhttp.ContinueOnError,
+	}), nil
+}
+
+// StartMetricsServer runs http server on the listen address to serve metrics endpoint
+// and returns function to stop it
+func StartMetricsServer(listen string, mprovider *controller.Controller) (func(), error) {
+	handler, err := NewMetricsHandler(mprovider)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen metrics endpoint on '%s': %w", listen, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, handler)
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: defMetricsReadTimeout,
+	}
+	go func() {
+		if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.WithError(err).Error("metrics endpoint was stopped unexpectedly")
+		}
+	}()
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), defMetricsShutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			logrus.WithError(err).Warn("failed to stop metrics endpoint")
+		}
+	}, nil
+}
@@ -0,0 +1,107 @@
+package observability_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	obs "soldr/pkg/observability"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	ctx := context.Background()
+	clientCfg := &obs.HookClientConfig{
+		ResendTimeout:   500 * time.Millisecond,
+		QueueSizeLimit:  obs.DefaultQueueSizeLimit,
+		PacketSizeLimit: obs.DefaultPacketSizeLimit,
+		UploadCallback: func(ctx context.Context, metrics [][]byte) error {
+			return nil
+		},
+	}
+
+	client := obs.NewHookMeterClient(clientCfg)
+	controller, err := obs.NewPullableMeterProvider(ctx, client, service, version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	obs.InitObserver(ctx, nil, controller, nil, client, service, version, nil)
+	defer obs.Observer.Close()
+
+	if _, err = obs.NewMetricsHandler(nil); err == nil {
+		t.Fatalf("error on creating metrics handler without provider")
+	}
+	handler, err := obs.NewMetricsHandler(controller)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = obs.Observer.StartDumperMetricCollect(&dumper{}, service, version); err != nil {
+		t.Fatal(err)
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("module_name", "test_module"),
+		attribute.String("agent_id", "abcdef"),
+	}
+	counter, err := obs.Observer.NewInt64Counter("test-int64-counter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter.Add(ctx, 10, attrs...)
+	counter.Add(ctx, 5, attrs...)
+	gauge, err := obs.Observer.NewFloat64GaugeCounter("test.float64.gauge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gauge.Record(ctx, 12.5, attrs...)
+	gauge.Record(ctx, 7.5, attrs...)
+	histogram, err := obs.Observer.NewInt64Histogram("test_int64_histogram")
+	if err != nil {
+		t.Fatal(err)
+	}
+	histogram.Record(ctx, 3)
+	histogram.Record(ctx, 300)
+	obs.Observer.Flush(ctx)
+
+	scrape := func() string {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, obs.MetricsPath, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("unexpected status code of metrics endpoint: %d", rec.Code)
+		}
+		body, err := io.ReadAll(rec.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(body)
+	}
+
+	body := scrape()
+	expected := []string{
+		"# TYPE test_int64_counter counter",
+		`test_int64_counter{agent_id="abcdef",module_name="test_module"} 15`,
+		"# TYPE test_float64_gauge gauge",
+		`test_float64_gauge{agent_id="abcdef",module_name="test_module"} 7.5`,
+		"# TYPE test_int64_histogram histogram",
+		"test_int64_histogram_count 2",
+		"test_int64_histogram_sum 303",
+		"# TYPE test_metric counter",
+		`test_metric{service_name="vxcommon",service_version="v1.0.0-develop"} 100`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(body, line) {
+			t.Fatalf("metrics endpoint output doesn't contain '%s':\n%s", line, body)
+		}
+	}
+
+	// instruments which weren't updated during last collection must be kept
+	obs.Observer.Flush(ctx)
+	if body = scrape(); !strings.Contains(body, `test_int64_counter{agent_id="abcdef",module_name="test_module"} 15`) {
+		t.Fatalf("metrics endpoint output lost not updated counter:\n%s", body)
+	}
+}
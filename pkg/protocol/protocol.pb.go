@@ -147,6 +147,68 @@ func (Packet_Content_MsgType) EnumDescriptor() ([]byte, []int) {
 	return file_protocol_protocol_proto_rawDescGZIP(), []int{0, 0, 1}
 }
 
+type Packet_Content_Stream_Kind int32
+
+const (
+	Packet_Content_Stream_CHUNK  Packet_Content_Stream_Kind = 0
+	Packet_Content_Stream_ACK    Packet_Content_Stream_Kind = 1
+	Packet_Content_Stream_RESUME Packet_Content_Stream_Kind = 2
+	Packet_Content_Stream_RETRY  Packet_Content_Stream_Kind = 3
+)
+
+// Enum value maps for Packet_Content_Stream_Kind.
+var (
+	Packet_Content_Stream_Kind_name = map[int32]string{
+		0: "CHUNK",
+		1: "ACK",
+		2: "RESUME",
+		3: "RETRY",
+	}
+	Packet_Content_Stream_Kind_value = map[string]int32{
+		"CHUNK":  0,
+		"ACK":    1,
+		"RESUME": 2,
+		"RETRY":  3,
+	}
+)
+
+func (x Packet_Content_Stream_Kind) Enum() *Packet_Content_Stream_Kind {
+	p := new(Packet_Content_Stream_Kind)
+	*p = x
+	return p
+}
+
+func (x Packet_Content_Stream_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Packet_Content_Stream_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_protocol_protocol_proto_enumTypes[2].Descriptor()
+}
+
+func (Packet_Content_Stream_Kind) Type() protoreflect.EnumType {
+	return &file_protocol_protocol_proto_enumTypes[2]
+}
+
+func (x Packet_Content_Stream_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *Packet_Content_Stream_Kind) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = Packet_Content_Stream_Kind(num)
+	return nil
+}
+
+// Deprecated: Use Packet_Content_Stream_Kind.Descriptor instead.
+func (Packet_Content_Stream_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_protocol_protocol_proto_rawDescGZIP(), []int{0, 0, 1, 0}
+}
+
 type Packet struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -159,6 +221,8 @@ type Packet struct {
 	Content     *Packet_Content `protobuf:"bytes,5,req,name=content" json:"content,omitempty"`
 	TraceId     *string         `protobuf:"bytes,6,opt,name=trace_id,json=traceId" json:"trace_id,omitempty"`
 	PspanId     *string         `protobuf:"bytes,7,opt,name=pspan_id,json=pspanId" json:"pspan_id,omitempty"`
+	Sequence    *uint64         `protobuf:"varint,8,opt,name=sequence" json:"sequence,omitempty"`
+	Session     []byte          `protobuf:"bytes,9,opt,name=session" json:"session,omitempty"`
 }
 
 func (x *Packet) Reset() {
@@ -242,6 +306,20 @@ func (x *Packet) GetPspanId() string {
 	return ""
 }
 
+func (x *Packet) GetSequence() uint64 {
+	if x != nil && x.Sequence != nil {
+		return *x.Sequence
+	}
+	return 0
+}
+
+func (x *Packet) GetSession() []byte {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
 type Packet_Content struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -253,6 +331,7 @@ type Packet_Content struct {
 	Part    *Packet_Content_Part    `protobuf:"bytes,4,opt,name=part" json:"part,omitempty"`
 	Uniq    *string                 `protobuf:"bytes,5,opt,name=uniq" json:"uniq,omitempty"`
 	MsgType *Packet_Content_MsgType `protobuf:"varint,6,opt,name=msg_type,json=msgType,enum=protocol.Packet_Content_MsgType" json:"msg_type,omitempty"`
+	Stream  *Packet_Content_Stream  `protobuf:"bytes,7,opt,name=stream" json:"stream,omitempty"`
 }
 
 // Default values for Packet_Content fields.
@@ -334,6 +413,13 @@ func (x *Packet_Content) GetMsgType() Packet_Content_MsgType {
 	return Packet_Content_DEBUG
 }
 
+func (x *Packet_Content) GetStream() *Packet_Content_Stream {
+	if x != nil {
+		return x.Stream
+	}
+	return nil
+}
+
 type Packet_Content_Part struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -389,12 +475,104 @@ func (x *Packet_Content_Part) GetTotal() int32 {
 	return 0
 }
 
+type Packet_Content_Stream struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind        *Packet_Content_Stream_Kind `protobuf:"varint,1,req,name=kind,enum=protocol.Packet_Content_Stream_Kind,def=0" json:"kind,omitempty"`
+	Offset      *int64                      `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+	Size        *int64                      `protobuf:"varint,3,opt,name=size" json:"size,omitempty"`
+	ChunkSha256 []byte                      `protobuf:"bytes,4,opt,name=chunk_sha256,json=chunkSha256" json:"chunk_sha256,omitempty"`
+	FileSha256  []byte                      `protobuf:"bytes,5,opt,name=file_sha256,json=fileSha256" json:"file_sha256,omitempty"`
+	Complete    *bool                       `protobuf:"varint,6,opt,name=complete" json:"complete,omitempty"`
+}
+
+// Default values for Packet_Content_Stream fields.
+const (
+	Default_Packet_Content_Stream_Kind = Packet_Content_Stream_CHUNK
+)
+
+func (x *Packet_Content_Stream) Reset() {
+	*x = Packet_Content_Stream{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocol_protocol_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Packet_Content_Stream) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Packet_Content_Stream) ProtoMessage() {}
+
+func (x *Packet_Content_Stream) ProtoReflect() protoreflect.Message {
+	mi := &file_protocol_protocol_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Packet_Content_Stream.ProtoReflect.Descriptor instead.
+func (*Packet_Content_Stream) Descriptor() ([]byte, []int) {
+	return file_protocol_protocol_proto_rawDescGZIP(), []int{0, 0, 1}
+}
+
+func (x *Packet_Content_Stream) GetKind() Packet_Content_Stream_Kind {
+	if x != nil && x.Kind != nil {
+		return *x.Kind
+	}
+	return Default_Packet_Content_Stream_Kind
+}
+
+func (x *Packet_Content_Stream) GetOffset() int64 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *Packet_Content_Stream) GetSize() int64 {
+	if x != nil && x.Size != nil {
+		return *x.Size
+	}
+	return 0
+}
+
+func (x *Packet_Content_Stream) GetChunkSha256() []byte {
+	if x != nil {
+		return x.ChunkSha256
+	}
+	return nil
+}
+
+func (x *Packet_Content_Stream) GetFileSha256() []byte {
+	if x != nil {
+		return x.FileSha256
+	}
+	return nil
+}
+
+func (x *Packet_Content_Stream) GetComplete() bool {
+	if x != nil && x.Complete != nil {
+		return *x.Complete
+	}
+	return false
+}
+
 var File_protocol_protocol_proto protoreflect.FileDescriptor
 
 var file_protocol_protocol_proto_rawDesc = []byte{
 	0x0a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x63, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x63, 0x6f, 0x6c, 0x22, 0xf9, 0x04, 0x0a, 0x06, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x16,
+	0x63, 0x6f, 0x6c, 0x22, 0xf3, 0x07, 0x0a, 0x06, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x16,
 	0x0a, 0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x06,
 	0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
 	0x18, 0x02, 0x20, 0x02, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x20,
@@ -408,33 +586,57 @@ var file_protocol_protocol_proto_rawDesc = []byte{
 	0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x06,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49, 0x64, 0x12, 0x19, 0x0a,
 	0x08, 0x70, 0x73, 0x70, 0x61, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x70, 0x73, 0x70, 0x61, 0x6e, 0x49, 0x64, 0x1a, 0x94, 0x03, 0x0a, 0x07, 0x43, 0x6f, 0x6e,
-	0x74, 0x65, 0x6e, 0x74, 0x12, 0x37, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x02,
-	0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x50, 0x61,
-	0x63, 0x6b, 0x65, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x2e, 0x54, 0x79, 0x70,
-	0x65, 0x3a, 0x04, 0x44, 0x41, 0x54, 0x41, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x02, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x04, 0x70, 0x61, 0x72, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x50,
-	0x61, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x61,
-	0x72, 0x74, 0x52, 0x04, 0x70, 0x61, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69, 0x71,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x71, 0x12, 0x3b, 0x0a, 0x08,
-	0x6d, 0x73, 0x67, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
-	0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x2e, 0x4d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x07, 0x6d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x34, 0x0a, 0x04, 0x50, 0x61, 0x72,
-	0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x02, 0x28,
-	0x05, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74,
-	0x61, 0x6c, 0x18, 0x02, 0x20, 0x02, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22,
-	0x36, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x41, 0x54, 0x41, 0x10,
-	0x00, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x54,
-	0x45, 0x58, 0x54, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x53, 0x47, 0x10, 0x03, 0x12, 0x07,
-	0x0a, 0x03, 0x41, 0x43, 0x54, 0x10, 0x04, 0x22, 0x36, 0x0a, 0x07, 0x4d, 0x73, 0x67, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x45, 0x42, 0x55, 0x47, 0x10, 0x00, 0x12, 0x08, 0x0a,
-	0x04, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x41, 0x52, 0x4e, 0x49,
-	0x4e, 0x47, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x42,
-	0x0c, 0x5a, 0x0a, 0x2e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x07, 0x70, 0x73, 0x70, 0x61, 0x6e, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0xd8,
+	0x05, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x37, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x3a, 0x04, 0x44, 0x41, 0x54, 0x41, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x02, 0x28,
+	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x04, 0x70,
+	0x61, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x52, 0x04, 0x70, 0x61, 0x72, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x75, 0x6e, 0x69, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e,
+	0x69, 0x71, 0x12, 0x3b, 0x0a, 0x08, 0x6d, 0x73, 0x67, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e,
+	0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x2e, 0x4d,
+	0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x37, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x1a, 0x34, 0x0a, 0x04, 0x50, 0x61, 0x72, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x02, 0x28, 0x05,
+	0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x18, 0x02, 0x20, 0x02, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x1a, 0x88,
+	0x02, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x3f, 0x0a, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x02, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6c, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x3a, 0x05, 0x43,
+	0x48, 0x55, 0x4e, 0x4b, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f,
+	0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x53, 0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x69, 0x6c,
+	0x65, 0x5f, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a,
+	0x66, 0x69, 0x6c, 0x65, 0x53, 0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x22, 0x31, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x09,
+	0x0a, 0x05, 0x43, 0x48, 0x55, 0x4e, 0x4b, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x43, 0x4b,
+	0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x45, 0x53, 0x55, 0x4d, 0x45, 0x10, 0x02, 0x12, 0x09,
+	0x0a, 0x05, 0x52, 0x45, 0x54, 0x52, 0x59, 0x10, 0x03, 0x22, 0x36, 0x0a, 0x04, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x41, 0x54, 0x41, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x46,
+	0x49, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x45, 0x58, 0x54, 0x10, 0x02, 0x12,
+	0x07, 0x0a, 0x03, 0x4d, 0x53, 0x47, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x43, 0x54, 0x10,
+	0x04, 0x22, 0x36, 0x0a, 0x07, 0x4d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05,
+	0x44, 0x45, 0x42, 0x55, 0x47, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e, 0x46, 0x4f, 0x10,
+	0x01, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x09,
+	0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x42, 0x0c, 0x5a, 0x0a, 0x2e, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
 }
 
 var (
@@ -449,25 +651,29 @@ func file_protocol_protocol_proto_rawDescGZIP() []byte {
 	return file_protocol_protocol_proto_rawDescData
 }
 
-var file_protocol_protocol_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_protocol_protocol_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_protocol_protocol_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_protocol_protocol_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_protocol_protocol_proto_goTypes = []interface{}{
-	(Packet_Content_Type)(0),    // 0: protocol.Packet.Content.Type
-	(Packet_Content_MsgType)(0), // 1: protocol.Packet.Content.MsgType
-	(*Packet)(nil),              // 2: protocol.Packet
-	(*Packet_Content)(nil),      // 3: protocol.Packet.Content
-	(*Packet_Content_Part)(nil), // 4: protocol.Packet.Content.Part
+	(Packet_Content_Type)(0),        // 0: protocol.Packet.Content.Type
+	(Packet_Content_MsgType)(0),     // 1: protocol.Packet.Content.MsgType
+	(Packet_Content_Stream_Kind)(0), // 2: protocol.Packet.Content.Stream.Kind
+	(*Packet)(nil),                  // 3: protocol.Packet
+	(*Packet_Content)(nil),          // 4: protocol.Packet.Content
+	(*Packet_Content_Part)(nil),     // 5: protocol.Packet.Content.Part
+	(*Packet_Content_Stream)(nil),   // 6: protocol.Packet.Content.Stream
 }
 var file_protocol_protocol_proto_depIdxs = []int32{
-	3, // 0: protocol.Packet.content:type_name -> protocol.Packet.Content
+	4, // 0: protocol.Packet.content:type_name -> protocol.Packet.Content
 	0, // 1: protocol.Packet.Content.type:type_name -> protocol.Packet.Content.Type
-	4, // 2: protocol.Packet.Content.part:type_name -> protocol.Packet.Content.Part
+	5, // 2: protocol.Packet.Content.part:type_name -> protocol.Packet.Content.Part
 	1, // 3: protocol.Packet.Content.msg_type:type_name -> protocol.Packet.Content.MsgType
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	6, // 4: protocol.Packet.Content.stream:type_name -> protocol.Packet.Content.Stream
+	2, // 5: protocol.Packet.Content.Stream.kind:type_name -> protocol.Packet.Content.Stream.Kind
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_protocol_protocol_proto_init() }
@@ -512,14 +718,26 @@ func file_protocol_protocol_proto_init() {
 				return nil
 			}
 		}
+		file_protocol_protocol_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Packet_Content_Stream); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protocol_protocol_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   3,
+			NumEnums:      3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
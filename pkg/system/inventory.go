@@ -0,0 +1,157 @@
+package system
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/shirou/gopsutil/v3/net"
+	"google.golang.org/protobuf/proto"
+
+	"soldr/pkg/protoagent"
+	"soldr/pkg/utils"
+)
+
+const (
+	PackageSourceDpkg         = "dpkg"
+	PackageSourceRpm          = "rpm"
+	PackageSourceRegistry     = "registry"
+	PackageSourceApplications = "applications"
+)
+
+// GetAgentInfoWithInventory returns the agent information with software and hardware inventory,
+// it takes much more time and space than GetAgentInfo so it's used only on GET_INFORMATION command
+func GetAgentInfoWithInventory(ctx context.Context) (*protoagent.Information, error) {
+	info, err := GetAgentInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info.Hardware = getHardwareInformation(ctx)
+	info.Packages = getPackagesInformation(ctx)
+	info.Services = getServicesInformation(ctx)
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+func getHardwareInformation(ctx context.Context) *protoagent.Information_Hardware {
+	return &protoagent.Information_Hardware{
+		Cpu:        getCPUInformation(ctx),
+		Memory:     getMemoryInformation(ctx),
+		Disks:      getDisksInformation(ctx),
+		Interfaces: getInterfacesInformation(ctx),
+	}
+}
+
+func getCPUInformation(ctx context.Context) *protoagent.Information_CPU {
+	info := &protoagent.Information_CPU{}
+	if stats, err := cpu.InfoWithContext(ctx); err == nil && len(stats) != 0 {
+		info.Model = utils.GetRef(strings.TrimSpace(stats[0].ModelName))
+	}
+	if cores, err := cpu.CountsWithContext(ctx, false); err == nil {
+		info.Cores = proto.Uint32(uint32(cores))
+	}
+	if threads, err := cpu.CountsWithContext(ctx, true); err == nil {
+		info.Threads = proto.Uint32(uint32(threads))
+	}
+	return info
+}
+
+func getMemoryInformation(ctx context.Context) *protoagent.Information_Memory {
+	stat, err := mem.VirtualMemoryWithContext(ctx)
+	if err != nil {
+		return nil
+	}
+	return &protoagent.Information_Memory{
+		Total:     proto.Uint64(stat.Total),
+		Available: proto.Uint64(stat.Available),
+	}
+}
+
+func getDisksInformation(ctx context.Context) []*protoagent.Information_Disk {
+	partitions, err := disk.PartitionsWithContext(ctx, false)
+	if err != nil {
+		return nil
+	}
+
+	disks := make([]*protoagent.Information_Disk, 0, len(partitions))
+	for _, partition := range partitions {
+		info := &protoagent.Information_Disk{
+			Mountpoint: utils.GetRef(partition.Mountpoint),
+			Device:     utils.GetRef(partition.Device),
+			Fstype:     utils.GetRef(partition.Fstype),
+		}
+		if usage, err := disk.UsageWithContext(ctx, partition.Mountpoint); err == nil {
+			info.Total = proto.Uint64(usage.Total)
+			info.Free = proto.Uint64(usage.Free)
+		}
+		disks = append(disks, info)
+	}
+	return disks
+}
+
+func getInterfacesInformation(ctx context.Context) []*protoagent.Information_Interface {
+	ifaces, err := net.InterfacesWithContext(ctx)
+	if err != nil {
+		return nil
+	}
+
+	interfaces := make([]*protoagent.Information_Interface, 0, len(ifaces))
+	for _, iface := range ifaces {
+		// loopback and tunnel interfaces have no hardware address
+		if iface.HardwareAddr == "" {
+			continue
+		}
+		ips := make([]string, 0, len(iface.Addrs))
+		for _, addr := range iface.Addrs {
+			ips = append(ips, addr.Addr)
+		}
+		interfaces = append(interfaces, &protoagent.Information_Interface{
+			Name: utils.GetRef(iface.Name),
+			Mac:  utils.GetRef(iface.HardwareAddr),
+			Ips:  ips,
+		})
+	}
+	return interfaces
+}
+
+func sortPackages(packages []*protoagent.Information_Package) []*protoagent.Information_Package {
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].GetName() == packages[j].GetName() {
+			return packages[i].GetVersion() < packages[j].GetVersion()
+		}
+		return packages[i].GetName() < packages[j].GetName()
+	})
+	return packages
+}
+
+func sortServices(services []*protoagent.Information_Service) []*protoagent.Information_Service {
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].GetName() < services[j].GetName()
+	})
+	return services
+}
+
+// parseTabSeparatedList is function to parse output of package managers which is formatted as
+// one item per line with tab separated fields
+func parseTabSeparatedList(output string, fields int) [][]string {
+	var result [][]string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		parts := strings.SplitN(line, "\t", fields)
+		if len(parts) != fields || parts[0] == "" {
+			continue
+		}
+		result = append(result, parts)
+	}
+	return result
+}
@@ -0,0 +1,97 @@
+//go:build darwin
+// +build darwin
+
+package system
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"soldr/pkg/protoagent"
+	"soldr/pkg/utils"
+)
+
+const applicationsDir = "/Applications"
+
+func getPackagesInformation(ctx context.Context) []*protoagent.Information_Package {
+	packages := make([]*protoagent.Information_Package, 0)
+	apps, err := filepath.Glob(filepath.Join(applicationsDir, "*.app"))
+	if err != nil {
+		return packages
+	}
+
+	for _, app := range apps {
+		if ctx.Err() != nil {
+			break
+		}
+		name := strings.TrimSuffix(filepath.Base(app), ".app")
+		packages = append(packages, &protoagent.Information_Package{
+			Name:    utils.GetRef(name),
+			Version: utils.GetRef(readBundleVersion(filepath.Join(app, "Contents", "Info.plist"))),
+			Source:  utils.GetRef(PackageSourceApplications),
+		})
+	}
+
+	return sortPackages(packages)
+}
+
+// readBundleVersion returns value of CFBundleShortVersionString key from XML property list,
+// binary property lists are skipped and empty version is returned for them
+func readBundleVersion(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil || !bytes.HasPrefix(bytes.TrimSpace(data), []byte("<?xml")) {
+		return ""
+	}
+
+	var (
+		isVersionKey bool
+		tagName      string
+	)
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return ""
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			tagName = t.Name.Local
+		case xml.EndElement:
+			tagName = ""
+		case xml.CharData:
+			value := strings.TrimSpace(string(t))
+			switch {
+			case tagName == "key":
+				isVersionKey = value == "CFBundleShortVersionString"
+			case tagName == "string" && isVersionKey:
+				return value
+			}
+		}
+	}
+}
+
+func getServicesInformation(ctx context.Context) []*protoagent.Information_Service {
+	services := make([]*protoagent.Information_Service, 0)
+	output, err := execInventoryCmd(ctx, "launchctl", "list")
+	if err != nil {
+		return services
+	}
+
+	// each line has the format: PID Status Label, the first line is header
+	for _, fields := range parseTabSeparatedList(output, 3) {
+		if fields[0] == "PID" || fields[0] == "-" {
+			continue
+		}
+		services = append(services, &protoagent.Information_Service{
+			Name:   utils.GetRef(strings.TrimSpace(fields[2])),
+			Status: utils.GetRef("running"),
+		})
+	}
+
+	return sortServices(services)
+}
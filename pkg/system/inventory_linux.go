@@ -0,0 +1,67 @@
+//go:build linux
+// +build linux
+
+package system
+
+import (
+	"context"
+	"strings"
+
+	"soldr/pkg/protoagent"
+	"soldr/pkg/utils"
+)
+
+func getPackagesInformation(ctx context.Context) []*protoagent.Information_Package {
+	managers := []struct {
+		source string
+		cmd    string
+		args   []string
+	}{
+		{PackageSourceDpkg, "dpkg-query", []string{"-W", "-f", "${Package}\t${Version}\t${Status}\n"}},
+		{PackageSourceRpm, "rpm", []string{"-qa", "--qf", "%{NAME}\t%{VERSION}-%{RELEASE}\tinstalled\n"}},
+	}
+
+	packages := make([]*protoagent.Information_Package, 0)
+	for _, manager := range managers {
+		output, err := execInventoryCmd(ctx, manager.cmd, manager.args...)
+		if err != nil {
+			continue
+		}
+		for _, fields := range parseTabSeparatedList(output, 3) {
+			// dpkg keeps removed packages with config files until purge
+			if manager.source == PackageSourceDpkg && !strings.HasSuffix(fields[2], " installed") {
+				continue
+			}
+			packages = append(packages, &protoagent.Information_Package{
+				Name:    utils.GetRef(fields[0]),
+				Version: utils.GetRef(fields[1]),
+				Source:  utils.GetRef(manager.source),
+			})
+		}
+	}
+
+	return sortPackages(packages)
+}
+
+func getServicesInformation(ctx context.Context) []*protoagent.Information_Service {
+	services := make([]*protoagent.Information_Service, 0)
+	output, err := execInventoryCmd(ctx, "systemctl", "list-units", "--type=service", "--state=running",
+		"--no-legend", "--no-pager", "--plain")
+	if err != nil {
+		return services
+	}
+
+	// each line has the format: UNIT LOAD ACTIVE SUB DESCRIPTION
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		services = append(services, &protoagent.Information_Service{
+			Name:   utils.GetRef(strings.TrimSuffix(fields[0], ".service")),
+			Status: utils.GetRef(fields[3]),
+		})
+	}
+
+	return sortServices(services)
+}
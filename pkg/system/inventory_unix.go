@@ -0,0 +1,32 @@
+//go:build !windows
+// +build !windows
+
+package system
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const inventoryCmdTimeout = time.Minute
+
+// execInventoryCmd runs the command to collect inventory and returns its output,
+// it's limited by time because package managers may hang on locked databases
+func execInventoryCmd(ctx context.Context, scmd string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, inventoryCmdTimeout)
+	defer cancel()
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, scmd, args...)
+	cmd.Stdin = strings.NewReader("")
+	cmd.Stdout = &stdout
+
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+
+	return stdout.String(), nil
+}
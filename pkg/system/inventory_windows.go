@@ -0,0 +1,120 @@
+//go:build windows
+// +build windows
+
+package system
+
+import (
+	"context"
+
+	"golang.org/x/sys/windows/registry"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+
+	"soldr/pkg/protoagent"
+	"soldr/pkg/utils"
+)
+
+var uninstallKeys = []struct {
+	root registry.Key
+	path string
+}{
+	{registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\Windows\CurrentVersion\Uninstall`},
+	{registry.LOCAL_MACHINE, `SOFTWARE\WOW6432Node\Microsoft\Windows\CurrentVersion\Uninstall`},
+	{registry.CURRENT_USER, `SOFTWARE\Microsoft\Windows\CurrentVersion\Uninstall`},
+}
+
+func getPackagesInformation(ctx context.Context) []*protoagent.Information_Package {
+	packages := make([]*protoagent.Information_Package, 0)
+	uniq := make(map[string]struct{})
+	for _, uninstall := range uninstallKeys {
+		k, err := registry.OpenKey(uninstall.root, uninstall.path, registry.ENUMERATE_SUB_KEYS)
+		if err != nil {
+			continue
+		}
+		names, err := k.ReadSubKeyNames(-1)
+		k.Close()
+		if err != nil {
+			continue
+		}
+
+		for _, name := range names {
+			if ctx.Err() != nil {
+				return sortPackages(packages)
+			}
+			pkg := readUninstallKey(uninstall.root, uninstall.path+`\`+name)
+			if pkg == nil {
+				continue
+			}
+			key := pkg.GetName() + "|" + pkg.GetVersion()
+			if _, ok := uniq[key]; ok {
+				continue
+			}
+			uniq[key] = struct{}{}
+			packages = append(packages, pkg)
+		}
+	}
+
+	return sortPackages(packages)
+}
+
+// readUninstallKey returns installed package from the uninstall registry key,
+// system components and updates are skipped as they are not shown in the programs list
+func readUninstallKey(root registry.Key, path string) *protoagent.Information_Package {
+	k, err := registry.OpenKey(root, path, registry.QUERY_VALUE)
+	if err != nil {
+		return nil
+	}
+	defer k.Close()
+
+	name, _, err := k.GetStringValue("DisplayName")
+	if err != nil || name == "" {
+		return nil
+	}
+	if isSystem, _, err := k.GetIntegerValue("SystemComponent"); err == nil && isSystem == 1 {
+		return nil
+	}
+	if parent, _, err := k.GetStringValue("ParentKeyName"); err == nil && parent != "" {
+		return nil
+	}
+	version, _, _ := k.GetStringValue("DisplayVersion")
+
+	return &protoagent.Information_Package{
+		Name:    utils.GetRef(name),
+		Version: utils.GetRef(version),
+		Source:  utils.GetRef(PackageSourceRegistry),
+	}
+}
+
+func getServicesInformation(ctx context.Context) []*protoagent.Information_Service {
+	services := make([]*protoagent.Information_Service, 0)
+	m, err := mgr.Connect()
+	if err != nil {
+		return services
+	}
+	defer m.Disconnect()
+
+	names, err := m.ListServices()
+	if err != nil {
+		return services
+	}
+	for _, name := range names {
+		if ctx.Err() != nil {
+			break
+		}
+		s, err := m.OpenService(name)
+		if err != nil {
+			continue
+		}
+		status, err := s.Query()
+		s.Close()
+		if err != nil || status.State != svc.Running {
+			continue
+		}
+		services = append(services, &protoagent.Information_Service{
+			Name:   utils.GetRef(name),
+			Status: utils.GetRef("running"),
+		})
+	}
+
+	return sortServices(services)
+}
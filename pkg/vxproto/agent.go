@@ -143,6 +143,11 @@ type agentSocket struct {
 	packEncrypter    tunnel.PackEncryptor
 	pinger           Pinger
 	connectionPolicy ConnectionPolicy
+	fileGate         packetsGate
+	limiter          *rateLimiter
+	files            fileAdmissions
+	sequencer        packetsSequencer
+	replay           replayWindow
 	IConnection
 	IVaildator
 	IMMInformator
@@ -253,6 +258,15 @@ func (as *agentSocket) recvPacket(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	// packets are dropped instead of the error because the error closes the agent connection
+	if err := as.replay.check(packet.session, packet.seq); err != nil {
+		as.incStats(recvReplayedPackets, 1)
+		return nil
+	}
+	if !as.allowPacket(packet) {
+		as.incStats(recvThrottledPackets, 1)
+		return nil
+	}
 
 	err = as.IProtoIO.recvPacket(ctx, packet)
 	if err != nil {
@@ -262,16 +276,47 @@ func (as *agentSocket) recvPacket(ctx context.Context) error {
 	return nil
 }
 
+// allowPacket is function which takes a token from the budget of the received packet type,
+// the file transfer is charged once by its first received chunk as it's done on the sending side
+func (as *agentSocket) allowPacket(packet *Packet) bool {
+	if packet.PType != PTFile || !as.limiter.isLimited(PTFile) {
+		return as.limiter.allow(packet.PType)
+	}
+	if !packet.isFileChunk() {
+		// control packets of the file stream (acknowledgements and resume requests) aren't charged
+		return true
+	}
+	id, resumable, ok := packet.getFileTransfer()
+	if !ok {
+		return as.limiter.allow(PTFile)
+	}
+	return as.files.admit(id, resumable, func() bool {
+		return as.limiter.allow(PTFile)
+	})
+}
+
 // sendPacket is function for sending packet to other side
 // Result is the success of packet sending otherwise will raise error
 func (as *agentSocket) sendPacket(ctx context.Context, packet *Packet) error {
+	if packet.isFileChunk() {
+		if err := as.fileGate.waitFileChunk(ctx); err != nil {
+			return fmt.Errorf("failed to wait for the file chunk sending: %w", err)
+		}
+	} else {
+		defer as.fileGate.enterRegular()()
+	}
+
+	var err error
+	if packet.session, packet.seq, err = as.sequencer.next(); err != nil {
+		return err
+	}
 	packetData, err := packet.toBytesPB()
 	if err != nil {
 		return err
 	}
 	as.incStats(sendPayloadBytes, int64(len(packetData)))
 
-	packetData, err = as.packEncrypter.Encrypt(packetData)
+	packetData, err = tunnel.EncryptModule(as.packEncrypter, packet.Module, packetData)
 	if err != nil {
 		return fmt.Errorf("failed to encrypt the packet data: %w", err)
 	}
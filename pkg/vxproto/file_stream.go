@@ -0,0 +1,713 @@
+package vxproto
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// FileStreamWindowSize is a max number of the file chunks which are sent without acknowledgement
+	FileStreamWindowSize = 8
+	// FileStreamPartialTTL is a time to live of the partially received file in the vx-store
+	FileStreamPartialTTL = time.Hour * 24
+	// fileStreamMaxResumes is a max number of the resume and retry requests without progress of the transfer
+	fileStreamMaxResumes = 20
+	// fileStoreCleanupInterval is a min time period between cleanups of the orphaned files in the vx-store
+	fileStoreCleanupInterval = time.Hour
+	// fileChunkMaxDelay is a max time to wait sending of the regular packets before the file chunk
+	fileChunkMaxDelay = time.Second
+	// fileChunkPollInterval is a time period to check the regular packets sending
+	fileChunkPollInterval = time.Millisecond * 5
+	// fileStreamLegacyPeerTTL is a time period to send files without the first chunk acknowledgement
+	// to the receiver which didn't acknowledge it before
+	fileStreamLegacyPeerTTL = time.Minute * 10
+)
+
+var (
+	// fileStreamAckTimeout is a time to wait acknowledgement from the receiver before the resume request,
+	// it covers the reconnection of the agent because packets are deferred until the agent is connected
+	fileStreamAckTimeout = time.Second * 30
+	// fileStreamProbeTimeout is a time to wait acknowledgement of the first chunk from the unknown receiver
+	fileStreamProbeTimeout = time.Second * 3
+)
+
+var (
+	errFileStreamAborted = errors.New("file transfer was aborted by the receiver")
+	fileStreamIDRegexp   = regexp.MustCompile(`^[0-9a-f]{32}$`)
+	lastFileStoreCleanup int64
+)
+
+// fileStreams keeps state of the outgoing file transfers of the module socket
+type fileStreams struct {
+	mx     sync.Mutex
+	acks   map[string]chan *fileStream
+	peers  map[string]struct{}
+	legacy map[string]time.Time
+}
+
+func newFileStreams() *fileStreams {
+	return &fileStreams{
+		acks:   make(map[string]chan *fileStream),
+		peers:  make(map[string]struct{}),
+		legacy: make(map[string]time.Time),
+	}
+}
+
+func (fss *fileStreams) register(id string) chan *fileStream {
+	fss.mx.Lock()
+	defer fss.mx.Unlock()
+	acks := make(chan *fileStream, FileStreamWindowSize*2)
+	fss.acks[id] = acks
+	return acks
+}
+
+func (fss *fileStreams) unregister(id string) {
+	fss.mx.Lock()
+	defer fss.mx.Unlock()
+	delete(fss.acks, id)
+}
+
+// deliver passes the receiver response to the outgoing transfer and returns false if it's unknown,
+// acknowledgements are cumulative so the response is dropped if the transfer doesn't read them in time
+func (fss *fileStreams) deliver(src, id string, stream *fileStream) bool {
+	fss.mx.Lock()
+	defer fss.mx.Unlock()
+	// the receiver which acknowledges file chunks supports resumable transfers
+	fss.peers[src] = struct{}{}
+	delete(fss.legacy, src)
+	acks, ok := fss.acks[id]
+	if !ok {
+		return false
+	}
+	select {
+	case acks <- stream:
+	default:
+	}
+	return true
+}
+
+// isStreamPeer returns true if the destination acknowledges file chunks,
+// old receivers don't do it so the file is sent to them without flow control
+func (fss *fileStreams) isStreamPeer(dst string) bool {
+	fss.mx.Lock()
+	defer fss.mx.Unlock()
+	_, ok := fss.peers[dst]
+	return ok
+}
+
+func (fss *fileStreams) markLegacyPeer(dst string) {
+	fss.mx.Lock()
+	defer fss.mx.Unlock()
+	fss.legacy[dst] = time.Now()
+}
+
+func (fss *fileStreams) isLegacyPeer(dst string) bool {
+	fss.mx.Lock()
+	defer fss.mx.Unlock()
+	ts, ok := fss.legacy[dst]
+	return ok && time.Since(ts) < fileStreamLegacyPeerTTL
+}
+
+// fileSource is a reader of the file content which is sent by chunks
+type fileSource struct {
+	size int
+	read func(left, right int) ([]byte, error)
+}
+
+func (src *fileSource) chunks() int {
+	nChunks := src.size / MaxFilePacketChunkSize
+	if nChunks*MaxFilePacketChunkSize < src.size || src.size == 0 {
+		nChunks += 1
+	}
+	return nChunks
+}
+
+func (src *fileSource) readChunk(ix int) ([]byte, error) {
+	left := ix * MaxFilePacketChunkSize
+	right := (ix + 1) * MaxFilePacketChunkSize
+	if right > src.size {
+		right = src.size
+	}
+	data, err := src.read(left, right)
+	if err != nil {
+		return nil, fmt.Errorf("the read callback has returned an error: %w", err)
+	}
+	return data, nil
+}
+
+func (src *fileSource) checksum() ([]byte, error) {
+	hash := sha256.New()
+	for ix := 0; ix < src.chunks(); ix++ {
+		data, err := src.readChunk(ix)
+		if err != nil {
+			return nil, err
+		}
+		hash.Write(data)
+	}
+	return hash.Sum(nil), nil
+}
+
+// fileTransfer is a state of the resumable file transfer on the sender side
+type fileTransfer struct {
+	ms         *moduleSocket
+	dst        string
+	name       string
+	id         string
+	src        *fileSource
+	fileSHA256 []byte
+	acks       chan *fileStream
+	next       int
+	acked      int64
+	resumes    int
+	probed     bool
+}
+
+// sendFileStreamWithResume sends the file by chunks with checksums and waits acknowledgements from the receiver,
+// the transfer is continued from the received part after the resume request if the chunks are lost
+// (e.g. on reconnection of the agent) and the number of the unacknowledged chunks is limited by the window
+func (ms *moduleSocket) sendFileStreamWithResume(ctx context.Context, dst, name string, src *fileSource) error {
+	fileSHA256, err := src.checksum()
+	if err != nil {
+		return fmt.Errorf("failed to calculate the file checksum: %w", err)
+	}
+	id, err := newFileStreamID()
+	if err != nil {
+		return err
+	}
+	t := &fileTransfer{
+		ms:         ms,
+		dst:        dst,
+		name:       name,
+		id:         id,
+		src:        src,
+		fileSHA256: fileSHA256,
+		acks:       ms.streams.register(id),
+	}
+	defer ms.streams.unregister(id)
+	return t.run(ctx)
+}
+
+func newFileStreamID() (string, error) {
+	uniqRaw := make([]byte, 16)
+	if _, err := rand.Read(uniqRaw); err != nil {
+		return "", fmt.Errorf("failed to get a random unique value: %w", err)
+	}
+	return hex.EncodeToString(uniqRaw), nil
+}
+
+func (t *fileTransfer) run(ctx context.Context) error {
+	nChunks := t.src.chunks()
+	timer := time.NewTimer(fileStreamAckTimeout)
+	defer timer.Stop()
+	resetTimer := func() {
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(fileStreamAckTimeout)
+	}
+
+	handle := func(stream *fileStream) (bool, error) {
+		done, progress, err := t.handleResponse(stream)
+		if progress {
+			t.resumes = 0
+			resetTimer()
+		}
+		return done, err
+	}
+
+	for {
+		// responses of the receiver are processed before sending the next chunk to apply retry requests
+		for drained := false; !drained; {
+			select {
+			case stream := <-t.acks:
+				if done, err := handle(stream); done || err != nil {
+					return err
+				}
+			default:
+				drained = true
+			}
+		}
+
+		isStreamPeer := t.ms.streams.isStreamPeer(t.dst)
+		if !isStreamPeer && !t.probed && t.next == 1 && !t.ms.streams.isLegacyPeer(t.dst) {
+			// the unknown receiver is checked by the acknowledgement of the first chunk,
+			// old receivers don't send it and the file is sent to them as before
+			t.probed = true
+			select {
+			case stream := <-t.acks:
+				if done, err := handle(stream); done || err != nil {
+					return err
+				}
+				continue
+			case <-time.After(fileStreamProbeTimeout):
+				t.ms.streams.markLegacyPeer(t.dst)
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		inflight := int64(t.next*MaxFilePacketChunkSize) - t.acked
+		if t.next < nChunks && (!isStreamPeer || inflight < FileStreamWindowSize*MaxFilePacketChunkSize) {
+			if err := t.sendChunk(ctx, t.next, nChunks); err != nil {
+				return err
+			}
+			t.next++
+			resetTimer()
+			continue
+		}
+		if !isStreamPeer {
+			// old receiver doesn't acknowledge chunks so the transfer is finished on the sender side
+			return nil
+		}
+
+		select {
+		case stream := <-t.acks:
+			if done, err := handle(stream); done || err != nil {
+				return err
+			}
+		case <-timer.C:
+			if t.resumes++; t.resumes > fileStreamMaxResumes {
+				return fmt.Errorf("file transfer '%s' has no progress after %d resume requests", t.id, fileStreamMaxResumes)
+			}
+			if err := t.sendResumeRequest(ctx); err != nil {
+				return err
+			}
+			timer.Reset(fileStreamAckTimeout)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// handleResponse processes the acknowledgement or the retry request of the receiver and returns
+// true if the whole file was received and the progress flag if the receiver has got new data
+func (t *fileTransfer) handleResponse(stream *fileStream) (bool, bool, error) {
+	switch stream.kind {
+	case fileStreamAck:
+		if stream.complete {
+			return true, true, nil
+		}
+		if stream.offset > t.acked {
+			t.acked = stream.offset
+			return false, true, nil
+		}
+		return false, false, nil
+	case fileStreamRetry:
+		// the receiver asks to continue from its contiguous received part (e.g. on the corrupted chunk)
+		if stream.offset < 0 || stream.offset > int64(t.src.size) {
+			return false, false, errFileStreamAborted
+		}
+		if t.resumes++; t.resumes > fileStreamMaxResumes {
+			return false, false, fmt.Errorf("file transfer '%s' was resumed too many times", t.id)
+		}
+		t.acked = stream.offset
+		t.next = int(stream.offset / MaxFilePacketChunkSize)
+		return false, false, nil
+	default:
+		return false, false, fmt.Errorf("unexpected file stream response kind %d", stream.kind)
+	}
+}
+
+func (t *fileTransfer) sendChunk(ctx context.Context, ix, nChunks int) error {
+	data, err := t.src.readChunk(ix)
+	if err != nil {
+		return err
+	}
+	chunkSHA256 := sha256.Sum256(data)
+	part := &File{
+		Data: data,
+		Name: t.name,
+		Uniq: t.id + ":" + strconv.Itoa(ix+1) + ":" + strconv.Itoa(nChunks),
+		stream: &fileStream{
+			kind:        fileStreamChunk,
+			offset:      int64(ix * MaxFilePacketChunkSize),
+			size:        int64(t.src.size),
+			chunkSHA256: chunkSHA256[:],
+			fileSHA256:  t.fileSHA256,
+		},
+	}
+	if err := t.ms.sendPacket(ctx, t.dst, PTFile, part); err != nil {
+		return fmt.Errorf("failed to send a packet: %w", err)
+	}
+	return nil
+}
+
+func (t *fileTransfer) sendResumeRequest(ctx context.Context) error {
+	req := &File{
+		Data: []byte{},
+		Name: t.name,
+		Uniq: t.id,
+		stream: &fileStream{
+			kind: fileStreamResume,
+			size: int64(t.src.size),
+		},
+	}
+	if err := t.ms.sendPacket(ctx, t.dst, PTFile, req); err != nil {
+		return fmt.Errorf("failed to send the file stream request: %w", err)
+	}
+	return nil
+}
+
+// fileStreamMeta is a state of the resumable file transfer on the receiver side which is stored in the vx-store
+type fileStreamMeta struct {
+	Size       int64  `json:"size"`
+	Total      int    `json:"total"`
+	FileSHA256 string `json:"file_sha256"`
+	Contiguous int    `json:"contiguous"`
+	Received   []int  `json:"received,omitempty"`
+	Complete   bool   `json:"complete,omitempty"`
+}
+
+func (m *fileStreamMeta) isReceived(chunk int) bool {
+	if chunk <= m.Contiguous {
+		return true
+	}
+	for _, c := range m.Received {
+		if c == chunk {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *fileStreamMeta) addChunk(chunk int) {
+	m.Received = append(m.Received, chunk)
+	for {
+		found := false
+		for idx, c := range m.Received {
+			if c == m.Contiguous+1 {
+				m.Contiguous = c
+				m.Received = append(m.Received[:idx], m.Received[idx+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return
+		}
+	}
+}
+
+func (m *fileStreamMeta) receivedBytes() int64 {
+	received := int64(m.Contiguous) * MaxFilePacketChunkSize
+	if received > m.Size {
+		return m.Size
+	}
+	return received
+}
+
+func loadFileStreamMeta(path string) (*fileStreamMeta, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var meta fileStreamMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse the file stream meta: %w", err)
+	}
+	return &meta, nil
+}
+
+func storeFileStreamMeta(path string, meta *fileStreamMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// parseFileStreamPacket processes the packet of the resumable file transfer and returns true
+// if the whole file was received and verified, the file packet is forwarded to the module in this case
+func (ms *moduleSocket) parseFileStreamPacket(ctx context.Context, packet *Packet) (bool, error) {
+	file := packet.GetFile()
+	uniqArr := strings.Split(file.Uniq, ":")
+	if !fileStreamIDRegexp.MatchString(uniqArr[0]) {
+		return false, fmt.Errorf("failed to parse the file stream unique identifier")
+	}
+	id := uniqArr[0]
+
+	switch file.stream.kind {
+	case fileStreamAck, fileStreamRetry:
+		// responses to the transfer which is already finished are skipped
+		if ms.streams != nil {
+			ms.streams.deliver(packet.Src, id, file.stream)
+		}
+		return false, nil
+	case fileStreamResume:
+		return false, ms.replyFileStreamState(ctx, packet, id)
+	case fileStreamChunk:
+		if len(uniqArr) != 3 {
+			return false, fmt.Errorf("failed to parse the packet unique identifier")
+		}
+		return ms.storeFileStreamChunk(ctx, packet, id, uniqArr[1], uniqArr[2])
+	default:
+		return false, fmt.Errorf("unknown file stream packet kind %d", file.stream.kind)
+	}
+}
+
+func (ms *moduleSocket) storeFileStreamChunk(ctx context.Context, packet *Packet, id, cur, total string) (bool, error) {
+	file := packet.GetFile()
+	stream := file.stream
+	curChunk, err := strconv.Atoi(cur)
+	if err != nil {
+		return false, err
+	}
+	totalChunks, err := strconv.Atoi(total)
+	if err != nil {
+		return false, err
+	}
+	if curChunk < 1 || curChunk > totalChunks || stream.size < 0 ||
+		int64(curChunk-1)*MaxFilePacketChunkSize+int64(len(file.Data)) > stream.size {
+		return false, fmt.Errorf("file stream chunk %d of %d is out of the file bounds", curChunk, totalChunks)
+	}
+
+	tempDir := filepath.Join(os.TempDir(), FileStorePrefix)
+	if err := os.MkdirAll(tempDir, 0700); err != nil {
+		return false, err
+	}
+	tempFile := filepath.Join(tempDir, id)
+	metaFile := tempFile + ".meta"
+	meta, err := loadFileStreamMeta(metaFile)
+	if err != nil {
+		return false, err
+	}
+	if meta == nil {
+		cleanupFileStoreLazily(tempDir)
+		meta = &fileStreamMeta{
+			Size:       stream.size,
+			Total:      totalChunks,
+			FileSHA256: hex.EncodeToString(stream.fileSHA256),
+		}
+	}
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"component": "file_stream",
+		"module":    ms.GetName(),
+		"src":       packet.Src,
+		"uniq":      id,
+		"chunk":     curChunk,
+	})
+
+	switch {
+	case meta.Complete || meta.isReceived(curChunk):
+		// retransmitted chunk after the lost acknowledgement
+		return false, ms.sendFileStreamResponse(ctx, packet, id, fileStreamAck, meta)
+	case meta.Size != stream.size || meta.Total != totalChunks:
+		return false, fmt.Errorf("file stream chunk doesn't match the transfer state")
+	}
+	if checksum := sha256.Sum256(file.Data); !bytes.Equal(checksum[:], stream.chunkSHA256) {
+		logger.Warn("file stream chunk is corrupted, requesting it again")
+		return false, ms.sendFileStreamResponse(ctx, packet, id, fileStreamRetry, meta)
+	}
+
+	fileHandle, err := os.OpenFile(tempFile, os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return false, err
+	}
+	offset := int64(curChunk-1) * MaxFilePacketChunkSize
+	if n, err := fileHandle.WriteAt(file.Data, offset); err != nil {
+		fileHandle.Close()
+		return false, err
+	} else if n != len(file.Data) {
+		fileHandle.Close()
+		return false, fmt.Errorf("failed to write the whole packet data")
+	}
+	if err := fileHandle.Close(); err != nil {
+		return false, err
+	}
+
+	meta.addChunk(curChunk)
+	if meta.Contiguous == meta.Total {
+		if err := verifyFileChecksum(tempFile, meta.FileSHA256); err != nil {
+			logger.WithError(err).Warn("received file is corrupted, requesting it from the beginning")
+			if err := os.Remove(tempFile); err != nil {
+				return false, err
+			}
+			if err := os.Remove(metaFile); err != nil {
+				return false, err
+			}
+			return false, ms.sendFileStreamResponse(ctx, packet, id, fileStreamRetry, &fileStreamMeta{})
+		}
+		meta.Complete = true
+	}
+	// the meta file of the complete transfer is kept to acknowledge retransmitted chunks,
+	// it's removed by the vx-store cleanup later
+	if err := storeFileStreamMeta(metaFile, meta); err != nil {
+		return false, err
+	}
+	if err := ms.sendFileStreamResponse(ctx, packet, id, fileStreamAck, meta); err != nil {
+		return false, err
+	}
+	if !meta.Complete {
+		return false, nil
+	}
+
+	file.Data = nil
+	file.Path = tempFile
+	file.Uniq = id
+	file.stream = nil
+	return true, nil
+}
+
+// replyFileStreamState answers to the resume request of the sender by the received part of the file
+func (ms *moduleSocket) replyFileStreamState(ctx context.Context, packet *Packet, id string) error {
+	metaFile := filepath.Join(os.TempDir(), FileStorePrefix, id+".meta")
+	meta, err := loadFileStreamMeta(metaFile)
+	if err != nil {
+		return err
+	}
+	if meta == nil {
+		meta = &fileStreamMeta{}
+	}
+	kind := fileStreamRetry
+	if meta.Complete {
+		kind = fileStreamAck
+	}
+	return ms.sendFileStreamResponse(ctx, packet, id, kind, meta)
+}
+
+func (ms *moduleSocket) sendFileStreamResponse(
+	ctx context.Context, packet *Packet, id string, kind fileStreamKind, meta *fileStreamMeta,
+) error {
+	resp := &File{
+		Data: []byte{},
+		Name: packet.GetFile().Name,
+		Uniq: id,
+		stream: &fileStream{
+			kind:     kind,
+			offset:   meta.receivedBytes(),
+			size:     meta.Size,
+			complete: meta.Complete,
+		},
+	}
+	if err := ms.sendPacket(ctx, packet.Src, PTFile, resp); err != nil {
+		return fmt.Errorf("failed to send the file stream response: %w", err)
+	}
+	return nil
+}
+
+func verifyFileChecksum(path, expected string) error {
+	fh, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, fh); err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != expected {
+		return fmt.Errorf("file checksum mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+// cleanupFileStoreLazily removes orphaned files of the interrupted transfers not often than once per interval
+func cleanupFileStoreLazily(tempDir string) {
+	now := time.Now().Unix()
+	last := atomic.LoadInt64(&lastFileStoreCleanup)
+	if now-last < int64(fileStoreCleanupInterval/time.Second) {
+		return
+	}
+	if !atomic.CompareAndSwapInt64(&lastFileStoreCleanup, last, now) {
+		return
+	}
+	go cleanupOrphanedFileStreams(tempDir, FileStreamPartialTTL)
+}
+
+// cleanupOrphanedFileStreams removes the partially received files and their meta files which weren't updated
+// for the TTL, the received file of the complete transfer belongs to the module so only its meta file is removed
+func cleanupOrphanedFileStreams(tempDir string, ttl time.Duration) {
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".meta") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < ttl {
+			continue
+		}
+		metaFile := filepath.Join(tempDir, entry.Name())
+		// meta files of the legacy transfers have another format so they are always partial
+		if meta, err := loadFileStreamMeta(metaFile); err != nil || meta == nil || !meta.Complete {
+			dataFile := strings.TrimSuffix(metaFile, ".meta")
+			if err := os.Remove(dataFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+				logrus.Warnf("failed to remove the orphaned file %s from the vx-store directory: %v", dataFile, err)
+			}
+		}
+		if err := os.Remove(metaFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logrus.Warnf("failed to remove the orphaned file %s from the vx-store directory: %v", metaFile, err)
+		}
+	}
+}
+
+// packetsGate gives precedence to the regular packets over the file chunks on the same socket,
+// the file chunk waits while the regular packets are being sent but not longer than the limit
+type packetsGate struct {
+	regular int32
+}
+
+func (g *packetsGate) enterRegular() func() {
+	atomic.AddInt32(&g.regular, 1)
+	return func() {
+		atomic.AddInt32(&g.regular, -1)
+	}
+}
+
+func (g *packetsGate) waitFileChunk(ctx context.Context) error {
+	deadline := time.Now().Add(fileChunkMaxDelay)
+	for atomic.LoadInt32(&g.regular) > 0 && time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(fileChunkPollInterval):
+		}
+	}
+	return nil
+}
+
+// isFileChunk returns true if the packet carries file content
+func (p *Packet) isFileChunk() bool {
+	if p.PType != PTFile {
+		return false
+	}
+	file, ok := p.Payload.(*File)
+	return ok && (file.stream == nil || file.stream.kind == fileStreamChunk)
+}
+
+// getFileTransfer returns identifier of the transfer which the file chunk belongs to and true
+// if the transfer can be resumed, the whole file packet doesn't belong to any transfer
+func (p *Packet) getFileTransfer() (string, bool, bool) {
+	file, ok := p.Payload.(*File)
+	if !ok {
+		return "", false, false
+	}
+	uniqArr := strings.Split(file.Uniq, ":")
+	if len(uniqArr) != 3 || uniqArr[0] == "" {
+		return "", false, false
+	}
+	return uniqArr[0], file.stream != nil, true
+}
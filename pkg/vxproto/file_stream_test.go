@@ -0,0 +1,246 @@
+package vxproto
+
+import (
+	"context"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fileStreamLink is a fake network connection between two module sockets,
+// the filter may drop (returns nil) or modify packets which are sent via the link
+type fileStreamLink struct {
+	src    string
+	peer   *moduleSocket
+	queue  chan *Packet
+	filter func(p *Packet) *Packet
+	mx     sync.Mutex
+}
+
+func (l *fileStreamLink) recvPacket(ctx context.Context, packet *Packet) error {
+	return nil
+}
+
+func (l *fileStreamLink) sendPacket(ctx context.Context, packet *Packet) error {
+	data, err := packet.toBytesPB()
+	if err != nil {
+		return err
+	}
+	p, err := (&Packet{}).fromBytesPB(data)
+	if err != nil {
+		return err
+	}
+	p.Src = l.src
+	l.mx.Lock()
+	filter := l.filter
+	l.mx.Unlock()
+	if filter != nil {
+		if p = filter(p); p == nil {
+			return nil
+		}
+	}
+	l.queue <- p
+	return nil
+}
+
+func (l *fileStreamLink) setFilter(filter func(p *Packet) *Packet) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	l.filter = filter
+}
+
+func (l *fileStreamLink) serve(ctx context.Context, t *testing.T) {
+	for {
+		select {
+		case p := <-l.queue:
+			if err := l.peer.recvPacket(ctx, p); err != nil {
+				t.Errorf("failed to receive the packet: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// makeFileStreamPair returns the sender and the receiver module sockets which are linked to each other
+func makeFileStreamPair(ctx context.Context, t *testing.T) (*moduleSocket, *moduleSocket, *fileStreamLink) {
+	vxp, err := getVXProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender := &moduleSocket{name: "test", router: newRouter(), streams: newFileStreams(), IIMC: vxp}
+	receiver := &moduleSocket{name: "test", router: newRouter(), streams: newFileStreams(), IIMC: vxp}
+	toReceiver := &fileStreamLink{src: serverToken, peer: receiver, queue: make(chan *Packet, 1024)}
+	toSender := &fileStreamLink{src: agentToken, peer: sender, queue: make(chan *Packet, 1024)}
+	sender.IProtoIO, receiver.IProtoIO = toReceiver, toSender
+	go toReceiver.serve(ctx, t)
+	go toSender.serve(ctx, t)
+	return sender, receiver, toReceiver
+}
+
+func setFileStreamTimeouts(t *testing.T) {
+	ackTimeout, probeTimeout := fileStreamAckTimeout, fileStreamProbeTimeout
+	fileStreamAckTimeout, fileStreamProbeTimeout = time.Millisecond*200, time.Millisecond*200
+	t.Cleanup(func() {
+		fileStreamAckTimeout, fileStreamProbeTimeout = ackTimeout, probeTimeout
+	})
+}
+
+func makeFileStreamData(t *testing.T, nChunks int) []byte {
+	data := make([]byte, MaxFilePacketChunkSize*nChunks-MaxFilePacketChunkSize/2)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func sendAndCheckFileStream(ctx context.Context, t *testing.T, sender, receiver *moduleSocket, data []byte) {
+	t.Helper()
+	if err := sender.SendFileTo(ctx, agentToken, &File{Data: data, Name: "test_file_name.tmp"}); err != nil {
+		t.Fatalf("failed to send the file: %v", err)
+	}
+	file, err := receiver.RecvFileFrom(ctx, serverToken, 5000)
+	if err != nil {
+		t.Fatalf("failed to receive the file: %v", err)
+	}
+	defer os.Remove(file.Path)
+	defer os.Remove(file.Path + ".meta")
+	if file.Name != "test_file_name.tmp" || file.Data != nil || !checkFileData(file.Path, data) {
+		t.Fatalf("received file doesn't match the original one")
+	}
+}
+
+func TestFileStreamResumeAfterLostChunks(t *testing.T) {
+	setFileStreamTimeouts(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sender, receiver, link := makeFileStreamPair(ctx, t)
+
+	// chunks are lost once in the middle of the transfer like on the agent reconnection
+	var lost sync.Map
+	link.setFilter(func(p *Packet) *Packet {
+		file := p.GetFile()
+		if file.stream == nil || file.stream.kind != fileStreamChunk {
+			return p
+		}
+		offset := file.stream.offset
+		if offset >= 2*MaxFilePacketChunkSize && offset < 5*MaxFilePacketChunkSize {
+			if _, ok := lost.LoadOrStore(offset, struct{}{}); !ok {
+				return nil
+			}
+		}
+		return p
+	})
+	sendAndCheckFileStream(ctx, t, sender, receiver, makeFileStreamData(t, 12))
+	if !sender.streams.isStreamPeer(agentToken) {
+		t.Fatalf("receiver must be known as the stream peer")
+	}
+}
+
+func TestFileStreamCorruptedChunk(t *testing.T) {
+	setFileStreamTimeouts(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sender, receiver, link := makeFileStreamPair(ctx, t)
+
+	corrupted := false
+	link.setFilter(func(p *Packet) *Packet {
+		file := p.GetFile()
+		if file.stream != nil && file.stream.kind == fileStreamChunk && file.stream.offset > 0 && !corrupted {
+			corrupted = true
+			file.Data = append([]byte{}, file.Data...)
+			file.Data[0] ^= 0xff
+		}
+		return p
+	})
+	sendAndCheckFileStream(ctx, t, sender, receiver, makeFileStreamData(t, 4))
+	if !corrupted {
+		t.Fatalf("chunk wasn't corrupted by the link")
+	}
+}
+
+func TestFileStreamLegacyReceiver(t *testing.T) {
+	setFileStreamTimeouts(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sender, receiver, link := makeFileStreamPair(ctx, t)
+
+	// old receiver doesn't know about the stream field of the file packet
+	link.setFilter(func(p *Packet) *Packet {
+		p.GetFile().stream = nil
+		return p
+	})
+	sendAndCheckFileStream(ctx, t, sender, receiver, makeFileStreamData(t, 3))
+	if sender.streams.isStreamPeer(agentToken) || !sender.streams.isLegacyPeer(agentToken) {
+		t.Fatalf("receiver must be known as the legacy peer")
+	}
+}
+
+func TestCleanupOrphanedFileStreams(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFile := func(name string, data []byte, age time.Duration) string {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+		ts := time.Now().Add(-age)
+		if err := os.Chtimes(path, ts, ts); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	exists := func(path string) bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}
+
+	partial := writeFile("partial", []byte("data"), time.Hour*2)
+	partialMeta := writeFile("partial.meta", []byte(`{"size":8,"total":1}`), time.Hour*2)
+	legacy := writeFile("legacy", []byte("data"), time.Hour*2)
+	legacyMeta := writeFile("legacy.meta", []byte(`["1"]`), time.Hour*2)
+	complete := writeFile("complete", []byte("data"), time.Hour*2)
+	completeMeta := writeFile("complete.meta", []byte(`{"size":4,"total":1,"complete":true}`), time.Hour*2)
+	active := writeFile("active", []byte("data"), 0)
+	activeMeta := writeFile("active.meta", []byte(`{"size":8,"total":1}`), 0)
+
+	cleanupOrphanedFileStreams(tempDir, time.Hour)
+	for _, path := range []string{partial, partialMeta, legacy, legacyMeta, completeMeta} {
+		if exists(path) {
+			t.Errorf("orphaned file %s must be removed", filepath.Base(path))
+		}
+	}
+	for _, path := range []string{complete, active, activeMeta} {
+		if !exists(path) {
+			t.Errorf("file %s must be kept", filepath.Base(path))
+		}
+	}
+}
+
+func TestPacketsGate(t *testing.T) {
+	var gate packetsGate
+	leave := gate.enterRegular()
+	start := time.Now()
+	go func() {
+		time.Sleep(time.Millisecond * 50)
+		leave()
+	}()
+	if err := gate.waitFileChunk(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Millisecond*50 || elapsed >= fileChunkMaxDelay {
+		t.Fatalf("file chunk must wait the regular packet: %s", elapsed)
+	}
+
+	// the file chunk isn't starved by the regular packets
+	defer gate.enterRegular()()
+	start = time.Now()
+	if err := gate.waitFileChunk(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < fileChunkMaxDelay {
+		t.Fatalf("file chunk must wait no longer than the limit: %s", elapsed)
+	}
+}
@@ -37,6 +37,8 @@ type IModuleSocket interface {
 	SendTextTo(ctx context.Context, dst string, text *Text) error
 	SendMsgTo(ctx context.Context, dst string, msg *Msg) error
 	SendActionTo(ctx context.Context, dst string, act *Action) error
+	SetRateLimits(limits RateLimits)
+	CheckRateLimit(pType PacketType) error
 	Close(ctx context.Context)
 	IRouter
 	IIMC
@@ -55,6 +57,8 @@ type moduleSocket struct {
 	groupID  string
 	imcToken string
 	router   *recvRouter
+	streams  *fileStreams
+	limiter  *rateLimiter
 	closer   func(ctx context.Context)
 	IDefaultReceiver
 	IProtoStats
@@ -212,40 +216,6 @@ func (ms *moduleSocket) RecvActionFrom(ctx context.Context, src string, timeout
 }
 
 func (ms *moduleSocket) sendFileStream(ctx context.Context, dst string, file *File) error {
-	uniqRaw := make([]byte, 32)
-	if _, err := rand.Read(uniqRaw); err != nil {
-		return fmt.Errorf("failed to get a random unique value: %w", err)
-	}
-	uniqHash := md5.Sum(uniqRaw)
-	uniq := hex.EncodeToString(uniqHash[:]) + ":"
-	sendFile := func(dataLen int, read func(left, right int) ([]byte, error)) error {
-		nPackets := dataLen / MaxFilePacketChunkSize
-		if nPackets*MaxFilePacketChunkSize < dataLen || dataLen == 0 {
-			nPackets += 1
-		}
-		lastN := strconv.Itoa(nPackets)
-		for ix := 0; ix < nPackets; ix++ {
-			left := ix * MaxFilePacketChunkSize
-			right := (ix + 1) * MaxFilePacketChunkSize
-			if right > dataLen {
-				right = dataLen
-			}
-			curN := strconv.Itoa(ix + 1)
-			data, err := read(left, right)
-			if err != nil {
-				return fmt.Errorf("the read callback has returned an error: %w", err)
-			}
-			part := &File{
-				Data: data,
-				Name: file.Name,
-				Uniq: uniq + curN + ":" + lastN,
-			}
-			if err := ms.sendPacket(ctx, dst, PTFile, part); err != nil {
-				return fmt.Errorf("failed to send a packet: %w", err)
-			}
-		}
-		return nil
-	}
 	dataLen := len(file.Data)
 	checkIndices := func(left int, right int, dataLen int) error {
 		if left < 0 {
@@ -267,7 +237,7 @@ func (ms *moduleSocket) sendFileStream(ctx context.Context, dst string, file *Fi
 			}
 			return file.Data[left:right], nil
 		}
-		return sendFile(dataLen, read)
+		return ms.sendFileSource(ctx, dst, file.Name, &fileSource{size: dataLen, read: read})
 	} else {
 		// Send data from file path
 		fh, err := os.Open(file.Path)
@@ -295,8 +265,39 @@ func (ms *moduleSocket) sendFileStream(ctx context.Context, dst string, file *Fi
 			}
 			return buf, nil
 		}
-		return sendFile(dataLen, read)
+		return ms.sendFileSource(ctx, dst, file.Name, &fileSource{size: dataLen, read: read})
+	}
+}
+
+func (ms *moduleSocket) sendFileSource(ctx context.Context, dst, name string, src *fileSource) error {
+	// files to the local modules aren't sent over the network so they don't need checksums and resume
+	if ms.streams != nil && !ms.HasIMCTokenFormat(dst) && !ms.HasIMCTopicFormat(dst) {
+		return ms.sendFileStreamWithResume(ctx, dst, name, src)
+	}
+
+	uniqRaw := make([]byte, 32)
+	if _, err := rand.Read(uniqRaw); err != nil {
+		return fmt.Errorf("failed to get a random unique value: %w", err)
+	}
+	uniqHash := md5.Sum(uniqRaw)
+	uniq := hex.EncodeToString(uniqHash[:]) + ":"
+	nPackets := src.chunks()
+	lastN := strconv.Itoa(nPackets)
+	for ix := 0; ix < nPackets; ix++ {
+		data, err := src.readChunk(ix)
+		if err != nil {
+			return err
+		}
+		part := &File{
+			Data: data,
+			Name: name,
+			Uniq: uniq + strconv.Itoa(ix+1) + ":" + lastN,
+		}
+		if err := ms.sendPacket(ctx, dst, PTFile, part); err != nil {
+			return fmt.Errorf("failed to send a packet: %w", err)
+		}
 	}
+	return nil
 }
 
 func (ms *moduleSocket) updateMeta(metaFile, chunk, total string) (bool, error) {
@@ -353,6 +354,9 @@ func (ms *moduleSocket) parseFileStream(ctx context.Context, packet *Packet) (bo
 	if file == nil {
 		return false, fmt.Errorf("failed to cast the packet to the file")
 	}
+	if file.stream != nil {
+		return ms.parseFileStreamPacket(ctx, packet)
+	}
 
 	if file.Uniq == "" {
 		// Send direct messages as a file type (only internal communication)
@@ -477,27 +481,58 @@ func (ms *moduleSocket) sendPacket(ctx context.Context, dst string, pType Packet
 	return ms.IProtoIO.sendPacket(ctx, packet)
 }
 
+// SetRateLimits is function which replaces the budgets of the packets which are sent by the module
+func (ms *moduleSocket) SetRateLimits(limits RateLimits) {
+	if ms.limiter == nil {
+		ms.limiter = newRateLimiter(limits)
+		return
+	}
+	ms.limiter.setLimits(limits)
+}
+
+// CheckRateLimit is function which takes a token from the budget of the packet type
+// Result is ErrRateLimited if the budget is exhausted
+func (ms *moduleSocket) CheckRateLimit(pType PacketType) error {
+	if ms.limiter.allow(pType) {
+		return nil
+	}
+	ms.incStats(sendThrottledPackets, 1)
+	return fmt.Errorf("module '%s' can't send the %s packet: %w", ms.name, pType, ErrRateLimited)
+}
+
+// sendLimitedPacket is function which sends the packet of the module if its budget allows it
+func (ms *moduleSocket) sendLimitedPacket(ctx context.Context, dst string, pType PacketType, payload interface{}) error {
+	if err := ms.CheckRateLimit(pType); err != nil {
+		return err
+	}
+	return ms.sendPacket(ctx, dst, pType, payload)
+}
+
 // SendDataTo use in ms API to send data to agent
 func (ms *moduleSocket) SendDataTo(ctx context.Context, dst string, data *Data) error {
-	return ms.sendPacket(ctx, dst, PTData, data)
+	return ms.sendLimitedPacket(ctx, dst, PTData, data)
 }
 
 // SendFileTo use in ms API to send file to agent
+// The file is charged to the budget once regardless of the number of its chunks
 func (ms *moduleSocket) SendFileTo(ctx context.Context, dst string, file *File) error {
+	if err := ms.CheckRateLimit(PTFile); err != nil {
+		return err
+	}
 	return ms.sendFileStream(ctx, dst, file)
 }
 
 // SendTextTo use in ms API to send text to agent
 func (ms *moduleSocket) SendTextTo(ctx context.Context, dst string, text *Text) error {
-	return ms.sendPacket(ctx, dst, PTText, text)
+	return ms.sendLimitedPacket(ctx, dst, PTText, text)
 }
 
 // SendMsgTo use in ms API to send message to agent
 func (ms *moduleSocket) SendMsgTo(ctx context.Context, dst string, msg *Msg) error {
-	return ms.sendPacket(ctx, dst, PTMsg, msg)
+	return ms.sendLimitedPacket(ctx, dst, PTMsg, msg)
 }
 
 // SendActionTo use in ms API to send action to agent
 func (ms *moduleSocket) SendActionTo(ctx context.Context, dst string, act *Action) error {
-	return ms.sendPacket(ctx, dst, PTAction, act)
+	return ms.sendLimitedPacket(ctx, dst, PTAction, act)
 }
@@ -270,6 +270,10 @@ func newUpgradePacketChecker() *upgradePacketChecker {
 		if err := checkPacket(p, true); err != nil {
 			return err
 		}
+		// the agent acknowledges the chunks of the upgrade file
+		if p.PType == PTFile && p.GetFile().IsUpgrader() && !p.isFileChunk() {
+			return nil
+		}
 		if p.PType != PTData {
 			return fmt.Errorf(
 				"got packet of type %d, but only data packets (of type %d) are allowed for this connection",
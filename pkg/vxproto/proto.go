@@ -67,6 +67,7 @@ type IAgents interface {
 	GetAgentByDst(dst string) IAgentSocket
 	DropAgent(ctx context.Context, aid string)
 	MoveAgent(ctx context.Context, aid, gid string)
+	SetAgentRateLimits(limits RateLimits)
 }
 
 // IModules is vxproto interface to get and set modules objects
@@ -194,6 +195,7 @@ type vxProto struct {
 	spqueue     []*Packet
 	mxqueue     *sync.Mutex
 	wgqueue     sync.WaitGroup
+	agentLimits RateLimits
 	ProtoStats
 	IMainModule
 }
@@ -243,6 +245,7 @@ func New(mmodule IMainModule) (IVXProto, error) {
 			}
 		}
 	}
+	cleanupOrphanedFileStreams(tempDir, FileStreamPartialTTL)
 
 	return &vxProto{
 		IMainModule: mmodule,
@@ -532,6 +535,8 @@ func (vxp *vxProto) NewModule(name, gid string) IModuleSocket {
 		groupID:          gid,
 		imcToken:         imcToken,
 		router:           newRouter(),
+		streams:          newFileStreams(),
+		limiter:          newRateLimiter(nil),
 		IIMC:             vxp,
 		IRouter:          vxp,
 		IProtoStats:      vxp,
@@ -549,7 +554,9 @@ func (vxp *vxProto) GetModule(name, gid string) IModuleSocket {
 	defer vxp.mutex.RUnlock()
 
 	if module, ok := vxp.modules[name]; ok {
-		return module[gid]
+		if ms, ok := module[gid]; ok {
+			return ms
+		}
 	}
 
 	return nil
@@ -737,6 +744,25 @@ func (vxp *vxProto) MoveAgent(ctx context.Context, aid, gid string) {
 	}
 }
 
+// SetAgentRateLimits is function which replaces the budgets of the packets which are received from each agent
+// The budgets are applied to the connected agents and to the new connections
+func (vxp *vxProto) SetAgentRateLimits(limits RateLimits) {
+	vxp.mutex.Lock()
+	defer vxp.mutex.Unlock()
+
+	vxp.agentLimits = limits
+	for _, asocket := range vxp.agents {
+		asocket.limiter.setLimits(limits)
+	}
+}
+
+func (vxp *vxProto) getAgentRateLimits() RateLimits {
+	vxp.mutex.RLock()
+	defer vxp.mutex.RUnlock()
+
+	return vxp.agentLimits
+}
+
 // getCRC32 is function for making CRC32 bytes from data bytes
 func (vxp *vxProto) getCRC32(data []byte) []byte {
 	crc32q := crc32.MakeTable(0xD5828281)
@@ -40,6 +40,7 @@ func (vxp *vxProto) InitConnection(ctx context.Context, connValidator AgentConne
 type CommonConfig struct {
 	Host      string
 	TLSConfig *tls.Config
+	Proxy     *ProxyConfig
 }
 
 type ServerAPIVersionsConfig map[string]*ServerAPIConfig
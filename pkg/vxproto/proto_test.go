@@ -1337,12 +1337,15 @@ func TestUsingProtoStatsViaFakeSocket(t *testing.T) {
 	if val, ok := stats["proto_send_num_packets"]; !ok || val != float64(numPackets) {
 		t.Fatal("Failed to compare of sent packets number")
 	}
-	if val, ok := stats["proto_send_payload_bytes"]; !ok || val != 171*float64(numPackets) {
+	if val, ok := stats["proto_send_payload_bytes"]; !ok || val != 191*float64(numPackets) {
 		t.Fatal("Failed to compare of sent payload bytes")
 	}
-	if val, ok := stats["proto_send_net_bytes"]; !ok || val != 95*float64(numPackets) {
+	if val, ok := stats["proto_send_net_bytes"]; !ok || val != 102*float64(numPackets) {
 		t.Fatal("Failed to compare of sent bytes via network socket")
 	}
+	if val, ok := stats["proto_send_compression_ratio"]; !ok || val != 191.0/102.0 {
+		t.Fatal("Failed to compare of sent packets compression ratio")
+	}
 }
 
 func TestIMCSendRecvPackets(t *testing.T) {
@@ -0,0 +1,296 @@
+package vxproto
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/net/proxy"
+)
+
+const (
+	defaultProxyDialTimeout = 30 * time.Second
+	// maxProxyAuthRounds limits challenge-response exchanges with HTTP proxy on one connection
+	maxProxyAuthRounds = 3
+)
+
+// ProxyAuthenticator is a hook to authenticate the agent on HTTP proxy server, it's called before
+// each CONNECT request with the previous proxy response (nil for the first request) and returns
+// the value of Proxy-Authorization header, all requests are sent over the same proxy connection
+// while the proxy keeps it alive so connection-oriented schemes (e.g. NTLM) may be implemented
+type ProxyAuthenticator interface {
+	ProxyAuthorization(ctx context.Context, proxyURL *url.URL, resp *http.Response) (string, error)
+}
+
+// ProxyConfig is a set of options to connect to the server through HTTP CONNECT or SOCKS5 proxy
+type ProxyConfig struct {
+	// URL is an explicit proxy URL with http, https, socks5 or socks5h scheme,
+	// user info of the URL is used for basic (or SOCKS5) authentication
+	URL string
+	// FromEnvironment enables HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables,
+	// explicit URL takes precedence over the environment
+	FromEnvironment bool
+	// Bypass is a list of destinations to connect directly, it has NO_PROXY format:
+	// host names, domain suffixes (".example.com" or "*.example.com"), IP addresses and CIDRs
+	Bypass []string
+	// Authenticator is an optional hook to authenticate on HTTP proxy
+	Authenticator ProxyAuthenticator
+}
+
+// Valid is function to check explicit proxy URL
+func (c *ProxyConfig) Valid() error {
+	if c == nil || c.URL == "" {
+		return nil
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return fmt.Errorf("failed to parse proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return fmt.Errorf("unsupported proxy URL scheme '%s'", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("proxy URL has no host")
+	}
+	return nil
+}
+
+// getProxyURL returns proxy URL to connect to the target URL or nil to connect directly
+func (c *ProxyConfig) getProxyURL(target *url.URL) (*url.URL, error) {
+	if c == nil {
+		return nil, nil
+	}
+	if err := c.Valid(); err != nil {
+		return nil, err
+	}
+	cfg := &httpproxy.Config{}
+	if c.FromEnvironment {
+		cfg = httpproxy.FromEnvironment()
+	}
+	if c.URL != "" {
+		// SOCKS5 dialer always passes host names to the proxy so socks5h is the same as socks5
+		proxyURL := c.URL
+		if strings.HasPrefix(proxyURL, "socks5h://") {
+			proxyURL = "socks5://" + strings.TrimPrefix(proxyURL, "socks5h://")
+		}
+		cfg.HTTPProxy = proxyURL
+		cfg.HTTPSProxy = proxyURL
+	}
+	if len(c.Bypass) != 0 {
+		cfg.NoProxy = strings.Join(append([]string{cfg.NoProxy}, c.Bypass...), ",")
+	}
+
+	// websocket schemes are mapped to the http ones to select proxy by the environment
+	reqURL := *target
+	switch target.Scheme {
+	case "ws":
+		reqURL.Scheme = "http"
+	default:
+		reqURL.Scheme = "https"
+	}
+	return cfg.ProxyFunc()(&reqURL)
+}
+
+// NewProxyDialContext returns function to open TCP connections to the target URL host
+// according to the proxy config, the connection is direct if proxy isn't used for the target
+func NewProxyDialContext(
+	config *ProxyConfig,
+	target *url.URL,
+) (func(ctx context.Context, network, addr string) (net.Conn, error), error) {
+	forward := &net.Dialer{Timeout: defaultProxyDialTimeout}
+	proxyURL, err := config.getProxyURL(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proxy for '%s': %w", target.Host, err)
+	}
+	if proxyURL == nil {
+		return forward.DialContext, nil
+	}
+
+	switch proxyURL.Scheme {
+	case "socks5":
+		dialer, err := proxy.FromURL(proxyURL, forward)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create SOCKS5 proxy dialer: %w", err)
+		}
+		contextDialer, ok := dialer.(proxy.ContextDialer)
+		if !ok {
+			return nil, fmt.Errorf("SOCKS5 proxy dialer doesn't support context")
+		}
+		return contextDialer.DialContext, nil
+	case "http", "https":
+		d := &httpProxyDialer{
+			proxyURL: proxyURL,
+			auth:     config.Authenticator,
+			forward:  forward,
+		}
+		return d.DialContext, nil
+	default:
+		return nil, fmt.Errorf("unsupported proxy URL scheme '%s'", proxyURL.Scheme)
+	}
+}
+
+// newWSDialer returns websocket dialer to connect to the target URL with the common config
+func newWSDialer(config *CommonConfig, target *url.URL) (*websocket.Dialer, error) {
+	dialContext, err := NewProxyDialContext(config.Proxy, target)
+	if err != nil {
+		return nil, err
+	}
+	return &websocket.Dialer{
+		NetDialContext:  dialContext,
+		TLSClientConfig: config.TLSConfig,
+	}, nil
+}
+
+type httpProxyDialer struct {
+	proxyURL *url.URL
+	auth     ProxyAuthenticator
+	forward  *net.Dialer
+}
+
+func (d *httpProxyDialer) dialProxy(ctx context.Context) (net.Conn, error) {
+	addr := d.proxyURL.Host
+	if d.proxyURL.Port() == "" {
+		port := "80"
+		if d.proxyURL.Scheme == "https" {
+			port = "443"
+		}
+		addr = net.JoinHostPort(d.proxyURL.Hostname(), port)
+	}
+	conn, err := d.forward.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to proxy '%s': %w", addr, err)
+	}
+	if d.proxyURL.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{
+			ServerName: d.proxyURL.Hostname(),
+			MinVersion: tls.VersionTLS12,
+		})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to establish TLS connection to proxy '%s': %w", addr, err)
+		}
+		conn = tlsConn
+	}
+	return conn, nil
+}
+
+func (d *httpProxyDialer) getAuthorization(ctx context.Context, resp *http.Response) (string, error) {
+	if d.auth != nil {
+		return d.auth.ProxyAuthorization(ctx, d.proxyURL, resp)
+	}
+	if user := d.proxyURL.User; user != nil && resp == nil {
+		password, _ := user.Password()
+		creds := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		return "Basic " + creds, nil
+	}
+	return "", nil
+}
+
+// DialContext opens the tunnel to the address through HTTP proxy by CONNECT method
+func (d *httpProxyDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	var (
+		conn net.Conn
+		resp *http.Response
+		err  error
+	)
+	defer func() {
+		if err != nil && conn != nil {
+			conn.Close()
+		}
+	}()
+
+	for round := 0; round < maxProxyAuthRounds; round++ {
+		var authorization string
+		if authorization, err = d.getAuthorization(ctx, resp); err != nil {
+			return nil, fmt.Errorf("failed to authenticate on proxy: %w", err)
+		}
+		if resp != nil && authorization == "" {
+			break
+		}
+		if conn == nil {
+			if conn, err = d.dialProxy(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		var br *bufio.Reader
+		if resp, br, err = d.connect(ctx, conn, addr, authorization); err != nil {
+			return nil, err
+		}
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			if br.Buffered() != 0 {
+				return &bufferedConn{Conn: conn, r: br}, nil
+			}
+			return conn, nil
+		case resp.StatusCode != http.StatusProxyAuthRequired:
+			err = fmt.Errorf("proxy refused connection to '%s': %s", addr, resp.Status)
+			return nil, err
+		case resp.Close:
+			// the next authentication round must be on the new proxy connection
+			conn.Close()
+			conn = nil
+		}
+	}
+
+	err = fmt.Errorf("proxy authentication required to connect to '%s'", addr)
+	return nil, err
+}
+
+func (d *httpProxyDialer) connect(
+	ctx context.Context, conn net.Conn, addr, authorization string,
+) (*http.Response, *bufio.Reader, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	} else {
+		_ = conn.SetDeadline(time.Now().Add(defaultProxyDialTimeout))
+	}
+	defer func() { _ = conn.SetDeadline(time.Time{}) }()
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if authorization != "" {
+		req.Header.Set("Proxy-Authorization", authorization)
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, nil, fmt.Errorf("failed to send CONNECT request to proxy: %w", err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read CONNECT response from proxy: %w", err)
+	}
+	// body of the challenge response must be read out to reuse the connection
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	return resp, br, nil
+}
+
+// bufferedConn keeps data which was read from the proxy connection after CONNECT response
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
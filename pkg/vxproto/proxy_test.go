@@ -0,0 +1,316 @@
+package vxproto
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// proxyTestHost is a server host which is resolved by the test proxies only
+const proxyTestHost = "vxserver.test"
+
+func newEchoWSServer(t *testing.T) string {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		for {
+			mt, data, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err = ws.WriteMessage(mt, data); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv.Listener.Addr().String()
+}
+
+// newConnectProxy runs HTTP CONNECT proxy which tunnels all connections to the backend address,
+// the check function validates Proxy-Authorization header and returns challenge on failure
+func newConnectProxy(t *testing.T, backend string, check func(auth string) (bool, string)) (string, *int32) {
+	t.Helper()
+	var tunnels int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if host, _, _ := net.SplitHostPort(r.Host); host != proxyTestHost {
+			http.Error(w, "unknown host", http.StatusBadGateway)
+			return
+		}
+		if ok, challenge := check(r.Header.Get("Proxy-Authorization")); !ok {
+			w.Header().Set("Proxy-Authenticate", challenge)
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		dst, err := net.Dial("tcp", backend)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		conn, brw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			dst.Close()
+			return
+		}
+		atomic.AddInt32(&tunnels, 1)
+		go pipeConns(conn, brw.Reader, dst)
+	}))
+	t.Cleanup(srv.Close)
+	return "http://" + srv.Listener.Addr().String(), &tunnels
+}
+
+// newSocks5Proxy runs SOCKS5 proxy with username/password authentication which tunnels
+// all connections to the backend address
+func newSocks5Proxy(t *testing.T, backend, user, password string) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSocks5(conn, backend, user, password)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func serveSocks5(conn net.Conn, backend, user, password string) {
+	br := bufio.NewReader(conn)
+	readBytes := func(n int) []byte {
+		buf := make([]byte, n)
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return nil
+		}
+		return buf
+	}
+	// greeting: only username/password method is accepted
+	hdr := readBytes(2)
+	if hdr == nil || readBytes(int(hdr[1])) == nil {
+		conn.Close()
+		return
+	}
+	_, _ = conn.Write([]byte{5, 2})
+	// username/password subnegotiation
+	ver := readBytes(2)
+	if ver == nil {
+		conn.Close()
+		return
+	}
+	uname := readBytes(int(ver[1]))
+	plen := readBytes(1)
+	if plen == nil {
+		conn.Close()
+		return
+	}
+	passwd := readBytes(int(plen[0]))
+	if string(uname) != user || string(passwd) != password {
+		_, _ = conn.Write([]byte{1, 1})
+		conn.Close()
+		return
+	}
+	_, _ = conn.Write([]byte{1, 0})
+	// connect request with domain address type
+	req := readBytes(4)
+	if req == nil || req[1] != 1 || req[3] != 3 {
+		conn.Close()
+		return
+	}
+	hlen := readBytes(1)
+	if hlen == nil {
+		conn.Close()
+		return
+	}
+	host, port := readBytes(int(hlen[0])), readBytes(2)
+	dst, err := net.Dial("tcp", backend)
+	if string(host) != proxyTestHost || port == nil || err != nil {
+		_, _ = conn.Write([]byte{5, 4, 0, 1, 0, 0, 0, 0, 0, 0})
+		conn.Close()
+		return
+	}
+	_, _ = conn.Write([]byte{5, 0, 0, 1, 127, 0, 0, 1, 0, 0})
+	pipeConns(conn, br, dst)
+}
+
+func pipeConns(conn net.Conn, r io.Reader, dst net.Conn) {
+	go func() {
+		_, _ = io.Copy(dst, r)
+		dst.Close()
+	}()
+	_, _ = io.Copy(conn, dst)
+	conn.Close()
+}
+
+func checkEchoThroughProxy(t *testing.T, config *ProxyConfig, port string) error {
+	t.Helper()
+	target := &url.URL{Scheme: "ws", Host: net.JoinHostPort(proxyTestHost, port), Path: "/"}
+	dialer, err := newWSDialer(&CommonConfig{Host: target.Host, Proxy: config}, target)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ws, _, err := dialer.DialContext(ctx, target.String(), nil)
+	if err != nil {
+		return err
+	}
+	defer ws.Close()
+	if err = ws.WriteMessage(websocket.BinaryMessage, []byte("ping")); err != nil {
+		return err
+	}
+	_, data, err := ws.ReadMessage()
+	if err != nil {
+		return err
+	}
+	if string(data) != "ping" {
+		return fmt.Errorf("unexpected echo message: %s", string(data))
+	}
+	return nil
+}
+
+func TestProxyHTTPConnectBasicAuth(t *testing.T) {
+	backend := newEchoWSServer(t)
+	_, port, _ := net.SplitHostPort(backend)
+	expected := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:secret"))
+	proxyAddr, tunnels := newConnectProxy(t, backend, func(auth string) (bool, string) {
+		return auth == expected, `Basic realm="proxy"`
+	})
+
+	proxyURL, _ := url.Parse(proxyAddr)
+	proxyURL.User = url.UserPassword("user", "secret")
+	if err := checkEchoThroughProxy(t, &ProxyConfig{URL: proxyURL.String()}, port); err != nil {
+		t.Fatalf("failed to connect through HTTP proxy: %v", err)
+	}
+	if atomic.LoadInt32(tunnels) != 1 {
+		t.Fatalf("connection wasn't tunneled through the proxy")
+	}
+
+	proxyURL.User = url.UserPassword("user", "wrong")
+	if err := checkEchoThroughProxy(t, &ProxyConfig{URL: proxyURL.String()}, port); err == nil {
+		t.Fatalf("connection with wrong proxy credentials must fail")
+	}
+}
+
+type challengeAuthenticator struct {
+	rounds int
+}
+
+func (a *challengeAuthenticator) ProxyAuthorization(
+	_ context.Context, _ *url.URL, resp *http.Response,
+) (string, error) {
+	a.rounds++
+	if resp == nil {
+		return "Challenge negotiate", nil
+	}
+	nonce := resp.Header.Get("Proxy-Authenticate")
+	return "Challenge response=" + nonce, nil
+}
+
+func TestProxyHTTPConnectAuthenticatorHook(t *testing.T) {
+	backend := newEchoWSServer(t)
+	_, port, _ := net.SplitHostPort(backend)
+	var nonce int32
+	proxyAddr, _ := newConnectProxy(t, backend, func(auth string) (bool, string) {
+		current := "Challenge nonce" + strconv.Itoa(int(atomic.LoadInt32(&nonce)))
+		if auth == "Challenge response="+current {
+			return true, ""
+		}
+		return false, "Challenge nonce" + strconv.Itoa(int(atomic.AddInt32(&nonce, 1)))
+	})
+
+	auth := &challengeAuthenticator{}
+	if err := checkEchoThroughProxy(t, &ProxyConfig{URL: proxyAddr, Authenticator: auth}, port); err != nil {
+		t.Fatalf("failed to connect through HTTP proxy with authenticator: %v", err)
+	}
+	if auth.rounds != 2 {
+		t.Fatalf("unexpected amount of authentication rounds: %d", auth.rounds)
+	}
+}
+
+func TestProxySocks5(t *testing.T) {
+	backend := newEchoWSServer(t)
+	_, port, _ := net.SplitHostPort(backend)
+	proxyAddr := newSocks5Proxy(t, backend, "user", "secret")
+
+	config := &ProxyConfig{URL: "socks5h://user:secret@" + proxyAddr}
+	if err := checkEchoThroughProxy(t, config, port); err != nil {
+		t.Fatalf("failed to connect through SOCKS5 proxy: %v", err)
+	}
+	config = &ProxyConfig{URL: "socks5h://user:wrong@" + proxyAddr}
+	if err := checkEchoThroughProxy(t, config, port); err == nil {
+		t.Fatalf("connection with wrong SOCKS5 credentials must fail")
+	}
+}
+
+func TestProxyEnvironmentAndBypass(t *testing.T) {
+	backend := newEchoWSServer(t)
+	_, port, _ := net.SplitHostPort(backend)
+	proxyAddr, tunnels := newConnectProxy(t, backend, func(string) (bool, string) {
+		return true, ""
+	})
+	t.Setenv("HTTP_PROXY", proxyAddr)
+	t.Setenv("HTTPS_PROXY", proxyAddr)
+	t.Setenv("NO_PROXY", "")
+
+	if err := checkEchoThroughProxy(t, &ProxyConfig{FromEnvironment: true}, port); err != nil {
+		t.Fatalf("failed to connect through proxy from environment: %v", err)
+	}
+	if atomic.LoadInt32(tunnels) != 1 {
+		t.Fatalf("connection wasn't tunneled through the proxy from environment")
+	}
+
+	target := &url.URL{Scheme: "wss", Host: net.JoinHostPort(proxyTestHost, port)}
+	cases := []struct {
+		config  *ProxyConfig
+		proxied bool
+	}{
+		{nil, false},
+		{&ProxyConfig{}, false},
+		{&ProxyConfig{FromEnvironment: true}, true},
+		{&ProxyConfig{FromEnvironment: true, Bypass: []string{"*.test"}}, false},
+		{&ProxyConfig{URL: "socks5://127.0.0.1:1080", Bypass: []string{proxyTestHost}}, false},
+		{&ProxyConfig{URL: "socks5://127.0.0.1:1080", Bypass: []string{"other.test"}}, true},
+	}
+	for idx, tc := range cases {
+		proxyURL, err := tc.config.getProxyURL(target)
+		if err != nil {
+			t.Fatalf("case %d: unexpected error: %v", idx, err)
+		}
+		if (proxyURL != nil) != tc.proxied {
+			t.Fatalf("case %d: unexpected proxy URL: %v", idx, proxyURL)
+		}
+	}
+
+	for _, invalid := range []string{"ftp://127.0.0.1:21", "http://", "://"} {
+		if err := (&ProxyConfig{URL: invalid}).Valid(); err == nil {
+			t.Fatalf("invalid proxy URL '%s' must be rejected", invalid)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package vxproto
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// fileAdmissionIdleTTL is a time to remember the received file transfer after its last chunk
+	fileAdmissionIdleTTL = time.Minute * 10
+	// fileAdmissionsMaxSize is a max number of the remembered file transfers of the agent socket
+	fileAdmissionsMaxSize = 1024
+)
+
+// RateLimitsArg is a name of the module argument which contains budgets of the module socket
+const RateLimitsArg = "rate_limits"
+
+// ErrRateLimited is returned to the module when the budget of the packet type is exhausted
+var ErrRateLimited = fmt.Errorf("rate limit is exceeded")
+
+// RateLimit is a token bucket budget: Rate packets per second on average and up to Burst packets at once
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimits is a set of budgets per packet type, packets of the type without budget aren't limited
+type RateLimits map[PacketType]RateLimit
+
+var rateLimitPacketTypes = map[string]PacketType{
+	"data":   PTData,
+	"file":   PTFile,
+	"text":   PTText,
+	"msg":    PTMsg,
+	"action": PTAction,
+}
+
+// ParseRateLimits parses comma separated list of budgets in the format 'type=rate[:burst]'
+// e.g. 'data=100:200,action=20', the burst is equal to the rate by default
+func ParseRateLimits(value string) (RateLimits, error) {
+	limits := make(RateLimits)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, budget, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("rate limit '%s' must be in the format 'type=rate[:burst]'", item)
+		}
+		pType, ok := rateLimitPacketTypes[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown packet type '%s' of the rate limit", name)
+		}
+		rateValue, burstValue, hasBurst := strings.Cut(budget, ":")
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rateValue), 64)
+		if err != nil || rate <= 0 || math.IsInf(rate, 0) {
+			return nil, fmt.Errorf("rate of the '%s' rate limit must be a positive number", name)
+		}
+		burst := int(math.Ceil(rate))
+		if hasBurst {
+			if burst, err = strconv.Atoi(strings.TrimSpace(burstValue)); err != nil || burst <= 0 {
+				return nil, fmt.Errorf("burst of the '%s' rate limit must be a positive integer", name)
+			}
+		}
+		limits[pType] = RateLimit{Rate: rate, Burst: burst}
+	}
+	return limits, nil
+}
+
+type tokenBucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+		last:   now,
+	}
+}
+
+func (b *tokenBucket) take(now time.Time) bool {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed.Seconds()*b.limit.Rate)
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// rateLimiter keeps separate budgets for each packet type, nil limiter allows all packets
+type rateLimiter struct {
+	mx      sync.Mutex
+	buckets map[PacketType]*tokenBucket
+}
+
+func newRateLimiter(limits RateLimits) *rateLimiter {
+	rl := &rateLimiter{}
+	rl.setLimits(limits)
+	return rl
+}
+
+func (rl *rateLimiter) setLimits(limits RateLimits) {
+	now := time.Now()
+	buckets := make(map[PacketType]*tokenBucket, len(limits))
+	for pType, limit := range limits {
+		buckets[pType] = newTokenBucket(limit, now)
+	}
+	rl.mx.Lock()
+	defer rl.mx.Unlock()
+	rl.buckets = buckets
+}
+
+// isLimited returns true if the packet type has a budget
+func (rl *rateLimiter) isLimited(pType PacketType) bool {
+	if rl == nil {
+		return false
+	}
+	rl.mx.Lock()
+	defer rl.mx.Unlock()
+	_, ok := rl.buckets[pType]
+	return ok
+}
+
+func (rl *rateLimiter) allow(pType PacketType) bool {
+	if rl == nil {
+		return true
+	}
+	rl.mx.Lock()
+	defer rl.mx.Unlock()
+	bucket, ok := rl.buckets[pType]
+	if !ok {
+		return true
+	}
+	return bucket.take(time.Now())
+}
+
+type fileAdmission struct {
+	allowed bool
+	last    time.Time
+}
+
+// fileAdmissions keeps the received file transfers which were charged by the file budget,
+// chunks of the admitted transfer aren't charged and chunks of the throttled transfer are dropped
+type fileAdmissions struct {
+	mx        sync.Mutex
+	transfers map[string]*fileAdmission
+}
+
+// admit returns true if the chunk of the transfer is allowed, the first seen chunk of the transfer is charged,
+// the throttled resumable transfer isn't remembered so the sender can resume it when the budget is refilled
+func (fa *fileAdmissions) admit(id string, resumable bool, charge func() bool) bool {
+	now := time.Now()
+	fa.mx.Lock()
+	defer fa.mx.Unlock()
+
+	if t, ok := fa.transfers[id]; ok && now.Sub(t.last) < fileAdmissionIdleTTL {
+		t.last = now
+		return t.allowed
+	}
+
+	allowed := charge()
+	if allowed || !resumable {
+		fa.store(id, &fileAdmission{allowed: allowed, last: now})
+	}
+	return allowed
+}
+
+// store remembers the transfer if there is a room after removing the idle transfers,
+// the chunks of the transfer which isn't remembered are charged so the budget is kept anyway
+func (fa *fileAdmissions) store(id string, t *fileAdmission) {
+	if fa.transfers == nil {
+		fa.transfers = make(map[string]*fileAdmission)
+	}
+	if len(fa.transfers) >= fileAdmissionsMaxSize {
+		for tid, ft := range fa.transfers {
+			if t.last.Sub(ft.last) >= fileAdmissionIdleTTL {
+				delete(fa.transfers, tid)
+			}
+		}
+	}
+	if _, ok := fa.transfers[id]; ok || len(fa.transfers) < fileAdmissionsMaxSize {
+		fa.transfers[id] = t
+	}
+}
@@ -0,0 +1,177 @@
+package vxproto
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	limits, err := ParseRateLimits("data=100:200, Action=0.5,file=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := RateLimits{
+		PTData:   {Rate: 100, Burst: 200},
+		PTAction: {Rate: 0.5, Burst: 1},
+		PTFile:   {Rate: 2, Burst: 2},
+	}
+	if len(limits) != len(expected) {
+		t.Fatalf("unexpected number of the parsed limits: %d", len(limits))
+	}
+	for pType, limit := range expected {
+		if limits[pType] != limit {
+			t.Fatalf("unexpected limit of the %s packets: %+v", pType, limits[pType])
+		}
+	}
+	if limits, err := ParseRateLimits(""); err != nil || len(limits) != 0 {
+		t.Fatalf("empty value must disable limits")
+	}
+	for _, value := range []string{"data", "control=10", "data=0", "data=-1", "data=ten", "data=10:0", "data=10:1.5"} {
+		if _, err := ParseRateLimits(value); err == nil {
+			t.Fatalf("invalid value '%s' must be rejected", value)
+		}
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(RateLimit{Rate: 10, Burst: 2}, now)
+	for i := 0; i < 2; i++ {
+		if !bucket.take(now) {
+			t.Fatalf("burst must be allowed")
+		}
+	}
+	if bucket.take(now) {
+		t.Fatalf("packet over the burst must be throttled")
+	}
+	if !bucket.take(now.Add(time.Millisecond*100)) || bucket.take(now.Add(time.Millisecond*100)) {
+		t.Fatalf("bucket must be refilled by one token in 100ms")
+	}
+	// idle time doesn't accumulate tokens over the burst
+	later := now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		if !bucket.take(later) {
+			t.Fatalf("burst must be allowed after idle time")
+		}
+	}
+	if bucket.take(later) {
+		t.Fatalf("packet over the burst must be throttled after idle time")
+	}
+}
+
+func TestModuleSocketRateLimit(t *testing.T) {
+	ctx := context.Background()
+	proto, err := getVXProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer proto.Close(ctx)
+
+	moduleSocket := proto.NewModule("test", groupID)
+	moduleSocket.SetRateLimits(RateLimits{PTData: {Rate: 0.001, Burst: 2}})
+	dst := proto.MakeIMCToken("unknown", groupID)
+	for i := 0; i < 2; i++ {
+		if err := moduleSocket.SendDataTo(ctx, dst, &Data{Data: []byte("test")}); !errors.Is(err, ErrDstUnreachable) {
+			t.Fatalf("packet within the budget must be sent: %v", err)
+		}
+	}
+	if err := moduleSocket.SendDataTo(ctx, dst, &Data{Data: []byte("test")}); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("packet over the budget must be rejected: %v", err)
+	}
+	// other packet types have separate budgets
+	if err := moduleSocket.SendTextTo(ctx, dst, &Text{Data: []byte("test")}); !errors.Is(err, ErrDstUnreachable) {
+		t.Fatalf("packet without budget must be sent: %v", err)
+	}
+	if err := moduleSocket.CheckRateLimit(PTAction); err != nil {
+		t.Fatalf("packet without budget must be allowed: %v", err)
+	}
+
+	stats, err := proto.DumpStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val := stats["proto_send_throttled_packets"]; val != 1 {
+		t.Fatalf("unexpected number of the throttled packets: %v", val)
+	}
+
+	moduleSocket.SetRateLimits(nil)
+	if err := moduleSocket.CheckRateLimit(PTData); err != nil {
+		t.Fatalf("packet must be allowed after the limits reset: %v", err)
+	}
+}
+
+func TestAgentSocketFileRateLimit(t *testing.T) {
+	as := &agentSocket{limiter: newRateLimiter(RateLimits{PTFile: {Rate: 0.001, Burst: 2}})}
+	chunk := func(id string, offset int64) *Packet {
+		return &Packet{PType: PTFile, Payload: &File{
+			Uniq:   id + ":" + strconv.FormatInt(offset/MaxFilePacketChunkSize+1, 10) + ":3",
+			stream: &fileStream{kind: fileStreamChunk, offset: offset},
+		}}
+	}
+	legacyChunk := func(id, cur string) *Packet {
+		return &Packet{PType: PTFile, Payload: &File{Uniq: id + ":" + cur + ":3"}}
+	}
+	allowed := func(packets ...*Packet) int {
+		var n int
+		for _, packet := range packets {
+			if as.allowPacket(packet) {
+				n++
+			}
+		}
+		return n
+	}
+
+	streamID, legacyID := "0123456789abcdef0123456789abcdef", "fedcba9876543210fedcba9876543210"
+	if allowed(chunk(streamID, 0), legacyChunk(legacyID, "1")) != 2 {
+		t.Fatalf("first chunks of the files within the budget must be allowed")
+	}
+	// the rest chunks and the stream control packets of the admitted files aren't charged
+	if n := allowed(
+		chunk(streamID, MaxFilePacketChunkSize),
+		chunk(streamID, 0),
+		chunk(streamID, MaxFilePacketChunkSize*2),
+		&Packet{PType: PTFile, Payload: &File{Uniq: streamID, stream: &fileStream{kind: fileStreamResume}}},
+		&Packet{PType: PTFile, Payload: &File{Uniq: streamID, stream: &fileStream{kind: fileStreamAck}}},
+		legacyChunk(legacyID, "2"),
+		legacyChunk(legacyID, "3"),
+	); n != 7 {
+		t.Fatalf("packets of the admitted files must be allowed, allowed %d of 7", n)
+	}
+
+	// chunks of the transfers which weren't admitted are charged regardless of their position
+	var flood []*Packet
+	for ix := 0; ix < 100; ix++ {
+		id := strconv.Itoa(ix)
+		flood = append(flood, chunk(id, MaxFilePacketChunkSize), legacyChunk(id+"-legacy", "2"), &Packet{
+			PType: PTFile, Payload: &File{Uniq: id},
+		})
+	}
+	if n := allowed(flood...); n != 0 {
+		t.Fatalf("flood of the file chunks over the budget must be throttled, allowed %d", n)
+	}
+
+	// chunks of the throttled legacy transfer are dropped even if the budget is refilled,
+	// the throttled stream transfer is charged again when it's resumed by the sender
+	legacyID, streamID = "00000000000000000000000000000000", "11111111111111111111111111111111"
+	if allowed(legacyChunk(legacyID, "1"), chunk(streamID, 0)) != 0 {
+		t.Fatalf("first chunks of the files over the budget must be throttled")
+	}
+	as.limiter.setLimits(RateLimits{PTFile: {Rate: 0.001, Burst: 2}})
+	if allowed(legacyChunk(legacyID, "2"), legacyChunk(legacyID, "3")) != 0 {
+		t.Fatalf("chunks of the throttled legacy file must be dropped")
+	}
+	if allowed(chunk(streamID, MaxFilePacketChunkSize), chunk(streamID, MaxFilePacketChunkSize*2)) != 2 {
+		t.Fatalf("resumed stream file within the budget must be allowed")
+	}
+
+	if !as.allowPacket(&Packet{PType: PTData, Payload: &Data{}}) {
+		t.Fatalf("packet without budget must be allowed")
+	}
+	as.limiter.setLimits(nil)
+	if allowed(chunk("unlimited", MaxFilePacketChunkSize), legacyChunk("unlimited", "2")) != 2 {
+		t.Fatalf("file chunks without budget must be allowed")
+	}
+}
@@ -0,0 +1,110 @@
+package vxproto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// replayWindowSize is a number of the latest sequence numbers which are remembered by the receiver,
+	// packets which are reordered by the concurrent senders are accepted within this window
+	replayWindowSize = 1024
+	// packetSessionSize is a size of the random nonce which binds the sequence numbers to the connection
+	packetSessionSize = 16
+)
+
+var (
+	errPacketReplayed    = fmt.Errorf("packet with this sequence number was already received")
+	errPacketTooOld      = fmt.Errorf("packet sequence number is out of the replay window")
+	errPacketSession     = fmt.Errorf("packet belongs to another session")
+	errPacketUnsequenced = fmt.Errorf("packet without sequence number from the peer which numbers packets")
+)
+
+// packetsSequencer numbers the packets which are sent to the connection
+type packetsSequencer struct {
+	once    sync.Once
+	err     error
+	session []byte
+	last    atomic.Uint64
+}
+
+func (s *packetsSequencer) next() ([]byte, uint64, error) {
+	s.once.Do(func() {
+		s.session = make([]byte, packetSessionSize)
+		if _, err := rand.Read(s.session); err != nil {
+			s.err = fmt.Errorf("failed to make the packets session: %w", err)
+		}
+	})
+	if s.err != nil {
+		return nil, 0, s.err
+	}
+	return s.session, s.last.Add(1), nil
+}
+
+// replayWindow is a sliding window of the sequence numbers which were received from the connection,
+// the session of the peer is pinned by the first numbered packet
+// and packets without numbers are accepted only from the old peers which don't number them
+type replayWindow struct {
+	mx      sync.Mutex
+	session []byte
+	last    uint64
+	bitmap  [replayWindowSize / 64]uint64
+}
+
+func (w *replayWindow) check(session []byte, seq uint64) error {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+
+	if seq == 0 {
+		if w.session != nil {
+			return errPacketUnsequenced
+		}
+		return nil
+	}
+	if w.session == nil {
+		if len(session) != packetSessionSize {
+			return errPacketSession
+		}
+		w.session = append([]byte{}, session...)
+	} else if !bytes.Equal(w.session, session) {
+		return errPacketSession
+	}
+
+	if seq > w.last {
+		if seq-w.last >= replayWindowSize {
+			w.bitmap = [replayWindowSize / 64]uint64{}
+		} else {
+			for s := w.last + 1; s < seq; s++ {
+				w.setBit(s, false)
+			}
+		}
+		w.last = seq
+		w.setBit(seq, true)
+		return nil
+	}
+	if w.last-seq >= replayWindowSize {
+		return errPacketTooOld
+	}
+	if w.getBit(seq) {
+		return errPacketReplayed
+	}
+	w.setBit(seq, true)
+	return nil
+}
+
+func (w *replayWindow) getBit(seq uint64) bool {
+	bit := seq % replayWindowSize
+	return w.bitmap[bit/64]&(1<<(bit%64)) != 0
+}
+
+func (w *replayWindow) setBit(seq uint64, value bool) {
+	bit := seq % replayWindowSize
+	if value {
+		w.bitmap[bit/64] |= 1 << (bit % 64)
+	} else {
+		w.bitmap[bit/64] &^= 1 << (bit % 64)
+	}
+}
@@ -0,0 +1,124 @@
+package vxproto
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestReplayWindow(t *testing.T) {
+	session := bytes.Repeat([]byte{1}, packetSessionSize)
+	otherSession := bytes.Repeat([]byte{2}, packetSessionSize)
+
+	var legacy replayWindow
+	for i := 0; i < 2; i++ {
+		if err := legacy.check(nil, 0); err != nil {
+			t.Fatalf("packet of the legacy peer must be accepted: %v", err)
+		}
+	}
+
+	var w replayWindow
+	for _, step := range []struct {
+		session []byte
+		seq     uint64
+		err     error
+	}{
+		{session, 1, nil},
+		{session, 3, nil},
+		{session, 2, nil},
+		{session, 2, errPacketReplayed},
+		{session, 3, errPacketReplayed},
+		{session, 1, errPacketReplayed},
+		{session, replayWindowSize + 1, nil},
+		{session, 5, nil},
+		{session, 1, errPacketTooOld},
+		{session, replayWindowSize*3 + 7, nil},
+		{session, replayWindowSize + 1, errPacketTooOld},
+		{session, replayWindowSize*3 + 6, nil},
+		{session, replayWindowSize*3 + 7, errPacketReplayed},
+		{otherSession, replayWindowSize*3 + 8, errPacketSession},
+		{nil, 0, errPacketUnsequenced},
+	} {
+		if err := w.check(step.session, step.seq); !errors.Is(err, step.err) {
+			t.Fatalf("unexpected result of the packet %d check: %v (expected %v)", step.seq, err, step.err)
+		}
+	}
+}
+
+// packetsRecorder is a fake proto which keeps the packets received from the agent socket
+type packetsRecorder struct {
+	mx      sync.Mutex
+	packets []*Packet
+}
+
+func (r *packetsRecorder) recvPacket(ctx context.Context, packet *Packet) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	r.packets = append(r.packets, packet)
+	return nil
+}
+
+func (r *packetsRecorder) sendPacket(ctx context.Context, packet *Packet) error {
+	return nil
+}
+
+func captureFrames(ctx context.Context, t *testing.T, vxp *vxProto, num int) [][]byte {
+	t.Helper()
+	sender := makeAgentSocket(vxp)
+	socket := &FakeSocket{isConnected: true, SendChan: make(chan []byte, num), Ctx: ctx}
+	sender.IConnection = socket
+	frames := make([][]byte, 0, num)
+	for i := 0; i < num; i++ {
+		if err := sender.sendPacket(ctx, makePacket(PTData, &Data{Data: []byte{byte(i)}})); err != nil {
+			t.Fatal(err)
+		}
+		frames = append(frames, <-socket.SendChan)
+	}
+	return frames
+}
+
+func TestAgentSocketReplayedFrames(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	vxp, err := getVXProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer vxp.Close(ctx)
+
+	frames := captureFrames(ctx, t, vxp, 5)
+	oldSessionFrames := captureFrames(ctx, t, vxp, 6)
+
+	recorder := &packetsRecorder{}
+	receiver := makeAgentSocket(vxp)
+	socket := &FakeSocket{isConnected: true, RecvChan: make(chan []byte, 1), Ctx: ctx}
+	receiver.IConnection, receiver.IProtoIO = socket, recorder
+	// frames are reordered, replayed and injected from the other session of the same peer
+	for _, frame := range [][]byte{
+		frames[0], frames[2], frames[1], frames[4], frames[3],
+		frames[1], frames[4], frames[0],
+		oldSessionFrames[5],
+	} {
+		socket.RecvChan <- frame
+		if err := receiver.recvPacket(ctx); err != nil {
+			t.Fatalf("replayed frame mustn't break the connection: %v", err)
+		}
+	}
+
+	received := make([]byte, 0, len(recorder.packets))
+	for _, packet := range recorder.packets {
+		received = append(received, packet.GetData().Data[0])
+	}
+	if !bytes.Equal(received, []byte{0, 2, 1, 4, 3}) {
+		t.Fatalf("unexpected order of the received packets: %v", received)
+	}
+	stats, err := vxp.DumpStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val := stats["proto_recv_replayed_packets"]; val != 4 {
+		t.Fatalf("unexpected number of the dropped packets: %v", val)
+	}
+}
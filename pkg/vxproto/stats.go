@@ -13,6 +13,9 @@ const (
 	sendNetBytes
 	recvPayloadBytes
 	sendPayloadBytes
+	recvThrottledPackets
+	sendThrottledPackets
+	recvReplayedPackets
 )
 
 type ProtoStats struct {
@@ -22,6 +25,12 @@ type ProtoStats struct {
 	SendNetBytes     atomic.Int64
 	RecvPayloadBytes atomic.Int64
 	SendPayloadBytes atomic.Int64
+	// RecvThrottledPackets is a number of packets which were dropped by the agent connection budgets
+	RecvThrottledPackets atomic.Int64
+	// SendThrottledPackets is a number of packets which were rejected by the module socket budgets
+	SendThrottledPackets atomic.Int64
+	// RecvReplayedPackets is a number of packets which were dropped by the replay protection
+	RecvReplayedPackets atomic.Int64
 }
 
 func (s *ProtoStats) incStats(m metricType, val int64) {
@@ -38,16 +47,35 @@ func (s *ProtoStats) incStats(m metricType, val int64) {
 		s.RecvPayloadBytes.Add(val)
 	case sendPayloadBytes:
 		s.SendPayloadBytes.Add(val)
+	case recvThrottledPackets:
+		s.RecvThrottledPackets.Add(val)
+	case sendThrottledPackets:
+		s.SendThrottledPackets.Add(val)
+	case recvReplayedPackets:
+		s.RecvReplayedPackets.Add(val)
 	}
 }
 
 func (s *ProtoStats) DumpStats() (map[string]float64, error) {
-	statsMap := make(map[string]float64, 6)
+	statsMap := make(map[string]float64, 11)
 	statsMap["proto_recv_num_packets"] = float64(s.RecvNumPackets.Load())
 	statsMap["proto_send_num_packets"] = float64(s.SendNumPackets.Load())
 	statsMap["proto_recv_net_bytes"] = float64(s.RecvNetBytes.Load())
 	statsMap["proto_send_net_bytes"] = float64(s.SendNetBytes.Load())
 	statsMap["proto_recv_payload_bytes"] = float64(s.RecvPayloadBytes.Load())
 	statsMap["proto_send_payload_bytes"] = float64(s.SendPayloadBytes.Load())
+	statsMap["proto_recv_throttled_packets"] = float64(s.RecvThrottledPackets.Load())
+	statsMap["proto_send_throttled_packets"] = float64(s.SendThrottledPackets.Load())
+	statsMap["proto_recv_replayed_packets"] = float64(s.RecvReplayedPackets.Load())
+	// compression ratio of the tunnel is a ratio of the payload to the bytes which are transferred via network
+	statsMap["proto_recv_compression_ratio"] = compressionRatio(s.RecvPayloadBytes.Load(), s.RecvNetBytes.Load())
+	statsMap["proto_send_compression_ratio"] = compressionRatio(s.SendPayloadBytes.Load(), s.SendNetBytes.Load())
 	return statsMap, nil
 }
+
+func compressionRatio(payloadBytes, netBytes int64) float64 {
+	if netBytes == 0 {
+		return 1
+	}
+	return float64(payloadBytes) / float64(netBytes)
+}
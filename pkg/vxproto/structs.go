@@ -108,10 +108,63 @@ func (data *Data) toPB() *protocol.Packet_Content {
 
 // File is simple protocol type that used for file content sending
 type File struct {
-	Data []byte `json:"data,omitempty"`
-	Name string `json:"name"`
-	Path string `json:"path"`
-	Uniq string `json:"uniq"`
+	Data   []byte `json:"data,omitempty"`
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Uniq   string `json:"uniq"`
+	stream *fileStream
+}
+
+// fileStreamKind is type of the file stream packet
+type fileStreamKind int32
+
+// Enumerate file stream packet kinds
+const (
+	fileStreamChunk  fileStreamKind = 0
+	fileStreamAck    fileStreamKind = 1
+	fileStreamResume fileStreamKind = 2
+	fileStreamRetry  fileStreamKind = 3
+)
+
+// fileStream is control information of the resumable file transfer which is attached to the file packet,
+// chunk packet has the chunk offset and checksums and it's answered by acknowledgement with the size
+// of the received part of the file, resume is a request of the sender to get the received part
+// and retry is a request of the receiver to continue the transfer from the received part
+type fileStream struct {
+	kind        fileStreamKind
+	offset      int64
+	size        int64
+	chunkSHA256 []byte
+	fileSHA256  []byte
+	complete    bool
+}
+
+// fromPB is converter from Protobuf to fileStream structure
+func (fs *fileStream) fromPB(stream *protocol.Packet_Content_Stream) *fileStream {
+	fs.kind = fileStreamKind(stream.GetKind())
+	fs.offset = stream.GetOffset()
+	fs.size = stream.GetSize()
+	fs.chunkSHA256 = stream.GetChunkSha256()
+	fs.fileSHA256 = stream.GetFileSha256()
+	fs.complete = stream.GetComplete()
+
+	return fs
+}
+
+// toPB is converter from fileStream to Protobuf structure
+func (fs *fileStream) toPB() *protocol.Packet_Content_Stream {
+	stream := &protocol.Packet_Content_Stream{
+		Kind:        protocol.Packet_Content_Stream_Kind(fs.kind).Enum(),
+		Offset:      proto.Int64(fs.offset),
+		Size:        proto.Int64(fs.size),
+		ChunkSha256: fs.chunkSHA256,
+		FileSha256:  fs.fileSHA256,
+	}
+	if fs.complete {
+		stream.Complete = proto.Bool(true)
+	}
+
+	return stream
 }
 
 // fromPB is converter from Protobuf to File structure
@@ -119,6 +172,9 @@ func (file *File) fromPB(content *protocol.Packet_Content) *File {
 	file.Data = content.GetData()
 	file.Name = content.GetName()
 	file.Uniq = content.GetUniq()
+	if stream := content.GetStream(); stream != nil {
+		file.stream = (&fileStream{}).fromPB(stream)
+	}
 
 	return file
 }
@@ -131,6 +187,9 @@ func (file *File) toPB() *protocol.Packet_Content {
 		Uniq: &file.Uniq,
 		Type: protocol.Packet_Content_FILE.Enum(),
 	}
+	if file.stream != nil {
+		content.Stream = file.stream.toPB()
+	}
 
 	return content
 }
@@ -341,6 +400,9 @@ type Packet struct {
 	Payload interface{} `json:"content"`
 	ctx     context.Context
 	ackChan chan struct{}
+	// seq and session are set per connection to protect the packets stream from replay
+	seq     uint64
+	session []byte
 }
 
 // GetData is function that cast payload to Data struct and return it
@@ -400,6 +462,8 @@ func (p *Packet) fromPB(packet *protocol.Packet) (*Packet, error) {
 	p.Src = packet.GetSource()
 	p.Dst = packet.GetDestination()
 	p.TS = packet.GetTimestamp()
+	p.seq = packet.GetSequence()
+	p.session = packet.GetSession()
 	content := packet.GetContent()
 	switch content.GetType() {
 	case protocol.Packet_Content_DATA:
@@ -533,7 +597,7 @@ func (p *Packet) toPB() (*protocol.Packet, error) {
 	spanCtx := obs.Observer.SpanContextFromContext(p.ctx)
 	traceID := spanCtx.TraceID().String()
 	pspanID := spanCtx.SpanID().String()
-	return &protocol.Packet{
+	packet := &protocol.Packet{
 		Module:      &p.Module,
 		Source:      &p.Src,
 		Destination: &p.Dst,
@@ -541,7 +605,12 @@ func (p *Packet) toPB() (*protocol.Packet, error) {
 		TraceId:     &traceID,
 		PspanId:     &pspanID,
 		Content:     content,
-	}, nil
+	}
+	if p.seq != 0 {
+		packet.Sequence = &p.seq
+		packet.Session = p.session
+	}
+	return packet, nil
 }
 
 // toBytesPB is converter from Packet structure to bytes array (Protobuf)
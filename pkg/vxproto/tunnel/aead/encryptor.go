@@ -0,0 +1,228 @@
+package aead
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+
+	"soldr/pkg/protoagent"
+	"soldr/pkg/vxproto/tunnel/compressor"
+	compressorSimple "soldr/pkg/vxproto/tunnel/compressor/simple"
+)
+
+const (
+	CipherAES256GCM        = "aes-256-gcm"
+	CipherChaCha20Poly1305 = "chacha20-poly1305"
+
+	// KeyLen is a length of the key material which is sent to the agent on connection start
+	KeyLen = 32
+
+	aes256KeyLen    = 32
+	noncePrefixLen  = 4
+	nonceCounterLen = 8
+	nonceLen        = noncePrefixLen + nonceCounterLen
+	keyInfoPrefix   = "vxproto tunnel "
+	keyInfoToAgent  = " server-to-agent"
+	keyInfoToServer = " agent-to-server"
+)
+
+// SupportedCiphers is a list of the supported ciphers in order of preference
+var SupportedCiphers = []string{CipherAES256GCM, CipherChaCha20Poly1305}
+
+// IsSupportedCipher returns true if the cipher name is known
+func IsSupportedCipher(name string) bool {
+	for _, c := range SupportedCiphers {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectCipher returns the first supported cipher from the list which is ordered by the peer preference
+func SelectCipher(offered []string) (string, bool) {
+	for _, name := range offered {
+		if IsSupportedCipher(name) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func GenerateKey(rand func(buf []byte) error) ([]byte, error) {
+	buf := make([]byte, KeyLen)
+	if err := rand(buf); err != nil {
+		return nil, fmt.Errorf("calling rand failed: %w", err)
+	}
+	return buf, nil
+}
+
+type Config struct {
+	Cipher string
+	// Key is a key material of the connection, the keys of both directions are derived from it
+	Key []byte
+	// IsServer selects the direction of the keys, the server seals packets by the key
+	// which the agent uses to open them and vice versa
+	IsServer bool
+	// Compressor is the negotiated compressor of the connection, the lz4 one is used by default
+	Compressor compressor.Compressor
+}
+
+// Encryptor compresses and seals every packet by AEAD cipher with a unique nonce,
+// the nonce is a random prefix of the encryptor and the packet counter, it's sent before the ciphertext
+type Encryptor struct {
+	isServer bool
+	state    *state
+	stateMux *sync.RWMutex
+
+	compressor compressor.Compressor
+}
+
+type state struct {
+	// counter is the first field to be 64-bit aligned for atomic operations on 32-bit platforms
+	counter uint64
+	seal    cipher.AEAD
+	open    cipher.AEAD
+	prefix  [noncePrefixLen]byte
+}
+
+func New(c *Config) (*Encryptor, error) {
+	s, err := newState(c.Cipher, c.Key, c.IsServer)
+	if err != nil {
+		return nil, err
+	}
+	comp := c.Compressor
+	if comp == nil {
+		comp = compressorSimple.NewCompressor()
+	}
+	return &Encryptor{
+		isServer:   c.IsServer,
+		state:      s,
+		stateMux:   &sync.RWMutex{},
+		compressor: comp,
+	}, nil
+}
+
+func newState(name string, key []byte, isServer bool) (*state, error) {
+	if len(key) < KeyLen {
+		return nil, fmt.Errorf("key material is too short: expected at least %d bytes, got %d", KeyLen, len(key))
+	}
+	toAgent, err := newCipher(name, key, keyInfoToAgent)
+	if err != nil {
+		return nil, err
+	}
+	toServer, err := newCipher(name, key, keyInfoToServer)
+	if err != nil {
+		return nil, err
+	}
+	s := &state{seal: toServer, open: toAgent}
+	if isServer {
+		s.seal, s.open = toAgent, toServer
+	}
+	// the random prefix separates nonces of the encryptors which share the same key
+	if _, err := io.ReadFull(rand.Reader, s.prefix[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate the nonce prefix: %w", err)
+	}
+	return s, nil
+}
+
+func newCipher(name string, key []byte, direction string) (cipher.AEAD, error) {
+	var keyLen int
+	switch name {
+	case CipherAES256GCM:
+		keyLen = aes256KeyLen
+	case CipherChaCha20Poly1305:
+		keyLen = chacha20poly1305.KeySize
+	default:
+		return nil, fmt.Errorf("unsupported cipher '%s'", name)
+	}
+	derivedKey := make([]byte, keyLen)
+	kdf := hkdf.New(sha256.New, key, nil, []byte(keyInfoPrefix+name+direction))
+	if _, err := io.ReadFull(kdf, derivedKey); err != nil {
+		return nil, fmt.Errorf("failed to derive the key: %w", err)
+	}
+	if name == CipherChaCha20Poly1305 {
+		aead, err := chacha20poly1305.New(derivedKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize a new ChaCha20-Poly1305 cipher: %w", err)
+		}
+		return aead, nil
+	}
+	block, err := aes.NewCipher(derivedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize a new AES cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize a new GCM cipher: %w", err)
+	}
+	return aead, nil
+}
+
+func (e *Encryptor) Encrypt(data []byte) ([]byte, error) {
+	return e.EncryptModule("", data)
+}
+
+// EncryptModule encrypts the packet of the module which may be compressed by the module dictionary
+func (e *Encryptor) EncryptModule(module string, data []byte) ([]byte, error) {
+	compressedData, err := compressor.CompressModule(e.compressor, module, data)
+	if err != nil {
+		return nil, err
+	}
+
+	s := e.getState()
+	counter := atomic.AddUint64(&s.counter, 1)
+	if counter == 0 {
+		return nil, fmt.Errorf("nonce counter is exhausted, the tunnel must be reset")
+	}
+	buf := make([]byte, nonceLen, nonceLen+len(compressedData)+s.seal.Overhead())
+	copy(buf, s.prefix[:])
+	binary.BigEndian.PutUint64(buf[noncePrefixLen:], counter)
+	return s.seal.Seal(buf, buf[:nonceLen], compressedData, nil), nil
+}
+
+func (e *Encryptor) Decrypt(data []byte) ([]byte, error) {
+	s := e.getState()
+	if len(data) < nonceLen+s.open.Overhead() {
+		return nil, fmt.Errorf("the packet is too short: %d bytes", len(data))
+	}
+	compressedData, err := s.open.Open(nil, data[:nonceLen], data[nonceLen:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate the packet: %w", err)
+	}
+	data, err = e.compressor.Decompress(compressedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress the data: %w", err)
+	}
+	return data, nil
+}
+
+func (e *Encryptor) Reset(config *protoagent.TunnelConfig) error {
+	c := config.GetAead()
+	if c == nil {
+		return fmt.Errorf("passed config is not of the type *TunnelConfig_Aead")
+	}
+	s, err := newState(c.GetCipher(), c.GetKey(), e.isServer)
+	if err != nil {
+		return fmt.Errorf("failed to reset the AEAD cipher: %w", err)
+	}
+	e.stateMux.Lock()
+	defer e.stateMux.Unlock()
+	e.state = s
+	return nil
+}
+
+func (e *Encryptor) getState() *state {
+	e.stateMux.RLock()
+	defer e.stateMux.RUnlock()
+	return e.state
+}
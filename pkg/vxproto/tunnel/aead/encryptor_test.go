@@ -0,0 +1,151 @@
+package aead
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"soldr/pkg/protoagent"
+)
+
+func newTestPair(t *testing.T, cipher string) (*Encryptor, *Encryptor) {
+	t.Helper()
+	key := make([]byte, KeyLen)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	server, err := New(&Config{Cipher: cipher, Key: key, IsServer: true})
+	if err != nil {
+		t.Fatalf("failed to create the server encryptor: %v", err)
+	}
+	agent, err := New(&Config{Cipher: cipher, Key: key})
+	if err != nil {
+		t.Fatalf("failed to create the agent encryptor: %v", err)
+	}
+	return server, agent
+}
+
+func TestRoundTrip(t *testing.T) {
+	data := [][]byte{
+		[]byte("short"),
+		bytes.Repeat([]byte("compressible data "), 1024),
+	}
+	random := make([]byte, 4096)
+	if _, err := rand.Read(random); err != nil {
+		t.Fatal(err)
+	}
+	data = append(data, random)
+
+	for _, cipher := range SupportedCiphers {
+		server, agent := newTestPair(t, cipher)
+		for _, pair := range [][2]*Encryptor{{server, agent}, {agent, server}} {
+			for _, pt := range data {
+				ct, err := pair[0].Encrypt(pt)
+				if err != nil {
+					t.Fatalf("%s: failed to encrypt: %v", cipher, err)
+				}
+				res, err := pair[1].Decrypt(ct)
+				if err != nil {
+					t.Fatalf("%s: failed to decrypt: %v", cipher, err)
+				}
+				if !bytes.Equal(res, pt) {
+					t.Fatalf("%s: decrypted data doesn't match the original one", cipher)
+				}
+			}
+		}
+	}
+}
+
+func TestUniqueNonces(t *testing.T) {
+	server, _ := newTestPair(t, CipherAES256GCM)
+	first, err := server.Encrypt([]byte("packet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := server.Encrypt([]byte("packet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(first[:nonceLen], second[:nonceLen]) || bytes.Equal(first, second) {
+		t.Fatalf("the same packet must be sealed with different nonces")
+	}
+}
+
+func TestTamperDetection(t *testing.T) {
+	for _, cipher := range SupportedCiphers {
+		server, agent := newTestPair(t, cipher)
+		ct, err := server.Encrypt([]byte("tamper-proof packet"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// modification of nonce, ciphertext and tag must be detected
+		for _, idx := range []int{0, nonceLen - 1, nonceLen, len(ct) / 2, len(ct) - 1} {
+			tampered := append([]byte{}, ct...)
+			tampered[idx] ^= 0x01
+			if _, err := agent.Decrypt(tampered); err == nil {
+				t.Fatalf("%s: modification of byte %d isn't detected", cipher, idx)
+			}
+		}
+		if _, err := agent.Decrypt(ct[:len(ct)-1]); err == nil {
+			t.Fatalf("%s: truncated packet isn't detected", cipher)
+		}
+		if _, err := agent.Decrypt(ct[:nonceLen]); err == nil {
+			t.Fatalf("%s: packet without ciphertext isn't detected", cipher)
+		}
+		// the packet which is sealed by the server can't be reflected back to it
+		if _, err := server.Decrypt(ct); err == nil {
+			t.Fatalf("%s: reflected packet isn't detected", cipher)
+		}
+		if _, err := agent.Decrypt(ct); err != nil {
+			t.Fatalf("%s: original packet must be decrypted: %v", cipher, err)
+		}
+	}
+}
+
+func TestDifferentKeys(t *testing.T) {
+	server, _ := newTestPair(t, CipherChaCha20Poly1305)
+	_, agent := newTestPair(t, CipherChaCha20Poly1305)
+	ct, err := server.Encrypt([]byte("packet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := agent.Decrypt(ct); err == nil {
+		t.Fatalf("packet sealed by the other key must not be decrypted")
+	}
+}
+
+func TestReset(t *testing.T) {
+	server, agent := newTestPair(t, CipherAES256GCM)
+	key := make([]byte, KeyLen)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	cipher := CipherChaCha20Poly1305
+	err := agent.Reset(&protoagent.TunnelConfig{
+		Config: &protoagent.TunnelConfig_Aead{
+			Aead: &protoagent.TunnelConfig_TunnelConfigAEAD{Cipher: &cipher, Key: key},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to reset the encryptor: %v", err)
+	}
+	ct, err := server.Encrypt([]byte("packet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := agent.Decrypt(ct); err == nil {
+		t.Fatalf("packet sealed by the previous key must not be decrypted")
+	}
+
+	if err := agent.Reset(&protoagent.TunnelConfig{}); err == nil {
+		t.Fatalf("reset by the config of other type must fail")
+	}
+	for _, c := range []*Config{
+		{Cipher: "rc4", Key: key},
+		{Cipher: CipherAES256GCM, Key: key[:16]},
+	} {
+		if _, err := New(c); err == nil {
+			t.Fatalf("invalid config must be rejected: %s with %d bytes key", c.Cipher, len(c.Key))
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package compressor
+
+import (
+	"fmt"
+
+	"soldr/pkg/vxproto/tunnel/compressor/simple"
+	"soldr/pkg/vxproto/tunnel/compressor/zstd"
+)
+
+const (
+	LZ4  = "lz4"
+	Zstd = "zstd"
+)
+
+// SupportedCompressors is a list of the supported compressors in order of preference
+var SupportedCompressors = []string{Zstd, LZ4}
+
+type Compressor interface {
+	Compress(data []byte) ([]byte, error)
+	Decompress(compressedData []byte) ([]byte, error)
+}
+
+// ModuleCompressor is implemented by the compressors which use the trained dictionary of the module
+type ModuleCompressor interface {
+	CompressModule(module string, data []byte) ([]byte, error)
+}
+
+type Config struct {
+	Name string
+	// PeerDictionaries is a list of IDs of the dictionaries which the peer can use to decompress data
+	PeerDictionaries []uint32
+}
+
+// New returns the compressor by the config, the legacy lz4 compressor is used by default
+func New(c *Config) (Compressor, error) {
+	if c == nil {
+		return simple.NewCompressor(), nil
+	}
+	switch c.Name {
+	case LZ4:
+		return simple.NewCompressor(), nil
+	case Zstd:
+		return zstd.New(&zstd.Config{PeerDictionaries: c.PeerDictionaries}), nil
+	default:
+		return nil, fmt.Errorf("unsupported compressor '%s'", c.Name)
+	}
+}
+
+// SelectCompressor returns the first supported compressor from the list which is ordered by the peer preference
+func SelectCompressor(offered []string) (string, bool) {
+	for _, name := range offered {
+		for _, c := range SupportedCompressors {
+			if c == name {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Dictionaries returns IDs of the registered dictionaries which may be used to decompress data
+func Dictionaries() []uint32 {
+	return zstd.Dictionaries()
+}
+
+// CompressModule compresses data of the module by its dictionary if the compressor supports it
+func CompressModule(c Compressor, module string, data []byte) ([]byte, error) {
+	if mc, ok := c.(ModuleCompressor); ok && module != "" {
+		return mc.CompressModule(module, data)
+	}
+	return c.Compress(data)
+}
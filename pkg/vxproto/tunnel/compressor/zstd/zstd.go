@@ -0,0 +1,217 @@
+package zstd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// DictionaryFile is a path of the trained dictionary in the module files
+	DictionaryFile = "data/zstd.dict"
+
+	dictMagic = 0xEC30A437
+	// maxDecodedSize limits memory which is allocated to decompress a packet
+	maxDecodedSize = 1 << 30
+)
+
+// sharedDecoder is the decoder which is used concurrently, it's replaced on registering new dictionary
+// and the replaced one is closed only after the last started decoding because closing breaks them
+type sharedDecoder struct {
+	dec     *zstd.Decoder
+	users   int
+	retired bool
+}
+
+// registry keeps the trained dictionaries of the modules and the coders which use them,
+// it's shared by all connections and dictionaries are never removed because the peer may use them
+type registry struct {
+	mx       sync.Mutex
+	modules  map[string]uint32
+	dicts    map[uint32][]byte
+	encoders map[uint32]*zstd.Encoder
+	decoder  *sharedDecoder
+}
+
+var dictionaries = &registry{
+	modules:  make(map[string]uint32),
+	dicts:    make(map[uint32][]byte),
+	encoders: make(map[uint32]*zstd.Encoder),
+}
+
+// DictionaryID returns ID of the dictionary in the zstd format
+func DictionaryID(dict []byte) (uint32, error) {
+	if len(dict) < 8 || binary.LittleEndian.Uint32(dict) != dictMagic {
+		return 0, fmt.Errorf("passed data is not a zstd dictionary")
+	}
+	id := binary.LittleEndian.Uint32(dict[4:])
+	if id == 0 {
+		return 0, fmt.Errorf("zstd dictionary must have a non-zero ID")
+	}
+	return id, nil
+}
+
+// RegisterDictionary adds the trained dictionary of the module to the registry,
+// packets of the module are compressed by it if the peer has the dictionary with the same ID
+func RegisterDictionary(module string, dict []byte) (uint32, error) {
+	id, err := DictionaryID(dict)
+	if err != nil {
+		return 0, err
+	}
+	dictionaries.mx.Lock()
+	defer dictionaries.mx.Unlock()
+	if _, ok := dictionaries.dicts[id]; !ok {
+		// the decoder is initialized only by valid dictionaries
+		dec, err := zstd.NewReader(nil, zstd.WithDecoderDicts(dict))
+		if err != nil {
+			return 0, fmt.Errorf("failed to load the zstd dictionary %d: %w", id, err)
+		}
+		dec.Close()
+		dictionaries.dicts[id] = append([]byte{}, dict...)
+		dictionaries.retireDecoder()
+	}
+	dictionaries.modules[module] = id
+	return id, nil
+}
+
+// Dictionaries returns IDs of the registered dictionaries which may be used to decompress data
+func Dictionaries() []uint32 {
+	dictionaries.mx.Lock()
+	defer dictionaries.mx.Unlock()
+	ids := make([]uint32, 0, len(dictionaries.dicts))
+	for id := range dictionaries.dicts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func (r *registry) getModuleDictionary(module string) uint32 {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return r.modules[module]
+}
+
+func (r *registry) getEncoder(id uint32) (*zstd.Encoder, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	if enc, ok := r.encoders[id]; ok {
+		return enc, nil
+	}
+	opts := []zstd.EOption{zstd.WithEncoderLevel(zstd.SpeedDefault)}
+	if id != 0 {
+		dict, ok := r.dicts[id]
+		if !ok {
+			return nil, fmt.Errorf("zstd dictionary %d is not registered", id)
+		}
+		// the default level barely uses the dictionary for small packets
+		opts = []zstd.EOption{zstd.WithEncoderLevel(zstd.SpeedBetterCompression), zstd.WithEncoderDict(dict)}
+	}
+	enc, err := zstd.NewWriter(nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize the zstd encoder: %w", err)
+	}
+	r.encoders[id] = enc
+	return enc, nil
+}
+
+// acquireDecoder returns the decoder with all registered dictionaries, it must be released after decoding
+func (r *registry) acquireDecoder() (*sharedDecoder, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	if r.decoder == nil {
+		dicts := make([][]byte, 0, len(r.dicts))
+		for _, dict := range r.dicts {
+			dicts = append(dicts, dict)
+		}
+		dec, err := zstd.NewReader(nil,
+			zstd.WithDecoderDicts(dicts...),
+			zstd.WithDecoderMaxMemory(maxDecodedSize),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize the zstd decoder: %w", err)
+		}
+		r.decoder = &sharedDecoder{dec: dec}
+	}
+	r.decoder.users++
+	return r.decoder, nil
+}
+
+func (r *registry) releaseDecoder(sd *sharedDecoder) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	sd.users--
+	if sd.retired && sd.users == 0 {
+		sd.dec.Close()
+	}
+}
+
+// retireDecoder drops the current decoder to make new one with all dictionaries, it must be called under lock
+func (r *registry) retireDecoder() {
+	if r.decoder == nil {
+		return
+	}
+	r.decoder.retired = true
+	if r.decoder.users == 0 {
+		r.decoder.dec.Close()
+	}
+	r.decoder = nil
+}
+
+type Config struct {
+	// PeerDictionaries is a list of IDs of the dictionaries which the peer can use to decompress data
+	PeerDictionaries []uint32
+}
+
+// Compressor compresses data to the zstd frames, the frame contains ID of the dictionary
+// so the decompressing side chooses it automatically
+type Compressor struct {
+	peerDicts map[uint32]struct{}
+}
+
+func New(c *Config) *Compressor {
+	peerDicts := make(map[uint32]struct{}, len(c.PeerDictionaries))
+	for _, id := range c.PeerDictionaries {
+		peerDicts[id] = struct{}{}
+	}
+	return &Compressor{
+		peerDicts: peerDicts,
+	}
+}
+
+func (c *Compressor) Compress(data []byte) ([]byte, error) {
+	return c.compress(0, data)
+}
+
+// CompressModule compresses data by the dictionary of the module if the peer has it
+func (c *Compressor) CompressModule(module string, data []byte) ([]byte, error) {
+	id := dictionaries.getModuleDictionary(module)
+	if _, ok := c.peerDicts[id]; !ok {
+		id = 0
+	}
+	return c.compress(id, data)
+}
+
+func (c *Compressor) compress(id uint32, data []byte) ([]byte, error) {
+	enc, err := dictionaries.getEncoder(id)
+	if err != nil {
+		return nil, err
+	}
+	return enc.EncodeAll(data, nil), nil
+}
+
+func (c *Compressor) Decompress(compressedData []byte) ([]byte, error) {
+	sd, err := dictionaries.acquireDecoder()
+	if err != nil {
+		return nil, err
+	}
+	defer dictionaries.releaseDecoder(sd)
+	data, err := sd.dec.DecodeAll(compressedData, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the zstd frame: %w", err)
+	}
+	return data, nil
+}
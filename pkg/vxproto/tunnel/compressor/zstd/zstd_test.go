@@ -0,0 +1,148 @@
+package zstd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func loadTestDictionary(t *testing.T) []byte {
+	t.Helper()
+	dict, err := os.ReadFile("testdata/events.dict")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dict
+}
+
+func makeTestEvent() []byte {
+	return []byte(fmt.Sprintf(`{"name":"file_created","module":"file_monitor","agent_id":"%032x",`+
+		`"data":{"path":"/var/log/app7.log","pid":1234,"user":"root","result":"success"},`+
+		`"time":1660001234,"type":"event"}`, 0x5a5a))
+}
+
+func TestRoundTrip(t *testing.T) {
+	c := New(&Config{})
+	for _, data := range [][]byte{{}, []byte("short"), bytes.Repeat([]byte("compressible data "), 1024)} {
+		compressed, err := c.Compress(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := c.Decompress(compressed)
+		if err != nil {
+			t.Fatalf("failed to decompress: %v", err)
+		}
+		if !bytes.Equal(res, data) {
+			t.Fatalf("decompressed data doesn't match the original one")
+		}
+	}
+	if _, err := c.Decompress([]byte("not a zstd frame")); err == nil {
+		t.Fatalf("invalid frame must be rejected")
+	}
+}
+
+func TestModuleDictionary(t *testing.T) {
+	dict := loadTestDictionary(t)
+	id, err := RegisterDictionary("file_monitor", dict)
+	if err != nil {
+		t.Fatalf("failed to register the dictionary: %v", err)
+	}
+	found := false
+	for _, d := range Dictionaries() {
+		found = found || d == id
+	}
+	if !found {
+		t.Fatalf("registered dictionary %d isn't listed", id)
+	}
+
+	data := makeTestEvent()
+	withDict, err := New(&Config{PeerDictionaries: []uint32{id}}).CompressModule("file_monitor", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the peer which doesn't have the dictionary gets data compressed without it
+	withoutDict, err := New(&Config{}).CompressModule("file_monitor", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(withDict) >= len(withoutDict) {
+		t.Fatalf("dictionary must improve compression: %d bytes with it and %d bytes without it",
+			len(withDict), len(withoutDict))
+	}
+	for _, compressed := range [][]byte{withDict, withoutDict} {
+		res, err := New(&Config{}).Decompress(compressed)
+		if err != nil {
+			t.Fatalf("failed to decompress: %v", err)
+		}
+		if !bytes.Equal(res, data) {
+			t.Fatalf("decompressed data doesn't match the original one")
+		}
+	}
+}
+
+func TestInvalidDictionary(t *testing.T) {
+	dict := loadTestDictionary(t)
+	for name, data := range map[string][]byte{
+		"raw content": []byte("raw content dictionaries aren't supported"),
+		"zero id":     append(append([]byte{}, dict[:4]...), append([]byte{0, 0, 0, 0}, dict[8:]...)...),
+	} {
+		if _, err := RegisterDictionary("invalid", data); err == nil {
+			t.Fatalf("%s dictionary must be rejected", name)
+		}
+	}
+}
+
+// makeTestDictionary returns the test dictionary with another ID to register it as a new one
+func makeTestDictionary(t *testing.T, id uint32) []byte {
+	t.Helper()
+	dict := append([]byte{}, loadTestDictionary(t)...)
+	binary.LittleEndian.PutUint32(dict[4:], id)
+	return dict
+}
+
+func TestReplacedDecoderIsClosed(t *testing.T) {
+	compressed, err := New(&Config{}).Compress(makeTestEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unused, err := dictionaries.acquireDecoder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dictionaries.releaseDecoder(unused)
+	if _, err = RegisterDictionary("replace_unused", makeTestDictionary(t, 0x5a5a0001)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = unused.dec.DecodeAll(compressed, nil); !errors.Is(err, zstd.ErrDecoderClosed) {
+		t.Fatalf("replaced decoder must be closed: %v", err)
+	}
+
+	used, err := dictionaries.acquireDecoder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used == unused {
+		t.Fatalf("decoder must be made again after registering a dictionary")
+	}
+	if _, err = RegisterDictionary("replace_used", makeTestDictionary(t, 0x5a5a0002)); err != nil {
+		t.Fatal(err)
+	}
+	// the decoder is closed only after the last started decoding
+	if _, err = used.dec.DecodeAll(compressed, nil); err != nil {
+		t.Fatalf("replaced decoder must work until it's released: %v", err)
+	}
+	dictionaries.releaseDecoder(used)
+	if _, err = used.dec.DecodeAll(compressed, nil); !errors.Is(err, zstd.ErrDecoderClosed) {
+		t.Fatalf("released decoder must be closed: %v", err)
+	}
+
+	if _, err = New(&Config{}).Decompress(compressed); err != nil {
+		t.Fatalf("failed to decompress by new decoder: %v", err)
+	}
+}
@@ -5,31 +5,43 @@ import (
 	"sync"
 
 	"soldr/pkg/protoagent"
-	compressor "soldr/pkg/vxproto/tunnel/compressor/simple"
+	"soldr/pkg/vxproto/tunnel/compressor"
+	compressorSimple "soldr/pkg/vxproto/tunnel/compressor/simple"
 )
 
 type Config struct {
 	Key byte
+	// Compressor is the negotiated compressor of the connection, the lz4 one is used by default
+	Compressor compressor.Compressor
 }
 
 type Encryptor struct {
 	key    byte
 	keyMux *sync.RWMutex
 
-	compressor *compressor.Compressor
+	compressor compressor.Compressor
 }
 
 func New(c *Config) *Encryptor {
+	comp := c.Compressor
+	if comp == nil {
+		comp = compressorSimple.NewCompressor()
+	}
 	return &Encryptor{
 		key:    c.Key,
 		keyMux: &sync.RWMutex{},
 
-		compressor: compressor.NewCompressor(),
+		compressor: comp,
 	}
 }
 
 func (e *Encryptor) Encrypt(data []byte) ([]byte, error) {
-	compressedData, err := e.compressor.Compress(data)
+	return e.EncryptModule("", data)
+}
+
+// EncryptModule encrypts the packet of the module which may be compressed by the module dictionary
+func (e *Encryptor) EncryptModule(module string, data []byte) ([]byte, error) {
+	compressedData, err := compressor.CompressModule(e.compressor, module, data)
 	if err != nil {
 		return nil, err
 	}
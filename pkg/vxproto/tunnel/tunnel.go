@@ -2,8 +2,11 @@ package tunnel
 
 import (
 	"fmt"
+	"sync"
 
 	"soldr/pkg/protoagent"
+	tunnelAEAD "soldr/pkg/vxproto/tunnel/aead"
+	"soldr/pkg/vxproto/tunnel/compressor"
 	tunnelRC4 "soldr/pkg/vxproto/tunnel/rc4"
 	tunnelSimple "soldr/pkg/vxproto/tunnel/simple"
 )
@@ -14,17 +17,164 @@ type PackEncryptor interface {
 	Reset(config *protoagent.TunnelConfig) error
 }
 
+// ModulePackEncryptor is implemented by the pack encryptors which may compress the packet
+// by the trained dictionary of its module
+type ModulePackEncryptor interface {
+	EncryptModule(module string, data []byte) ([]byte, error)
+}
+
 type Config struct {
+	AEAD   *tunnelAEAD.Config
 	RC4    *tunnelRC4.Config
 	Simple *tunnelSimple.Config
+	// Compression is the negotiated compression of the connection, it overrides the compressor of the encryptor
+	Compression *compressor.Config
+}
+
+// Offer is a set of the tunnel features which are supported by the connecting side,
+// it's sent to the server during the connection challenge
+type Offer struct {
+	Ciphers      []string
+	Compressors  []string
+	Dictionaries []uint32
 }
 
 func NewPackEncrypter(c *Config) (PackEncryptor, error) {
+	var comp compressor.Compressor
+	if c.Compression != nil {
+		var err error
+		if comp, err = compressor.New(c.Compression); err != nil {
+			return nil, err
+		}
+	}
+	if c.AEAD != nil {
+		aeadConfig := *c.AEAD
+		if comp != nil {
+			aeadConfig.Compressor = comp
+		}
+		e, err := tunnelAEAD.New(&aeadConfig)
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
 	if c.RC4 != nil {
-		return tunnelRC4.New(c.RC4), nil
+		rc4Config := *c.RC4
+		if comp != nil {
+			rc4Config.Compressor = comp
+		}
+		return tunnelRC4.New(&rc4Config), nil
 	}
 	if c.Simple != nil {
-		return tunnelSimple.New(c.Simple), nil
+		simpleConfig := *c.Simple
+		if comp != nil {
+			simpleConfig.Compressor = comp
+		}
+		return tunnelSimple.New(&simpleConfig), nil
 	}
 	return nil, fmt.Errorf("no appropriate configuration found to initialize the pack encryptor")
 }
+
+// EncryptModule encrypts the packet of the module by the pack encryptor,
+// the packet is compressed by the module dictionary if the encryptor supports it
+func EncryptModule(e PackEncryptor, module string, data []byte) ([]byte, error) {
+	if me, ok := e.(ModulePackEncryptor); ok {
+		return me.EncryptModule(module, data)
+	}
+	return e.Encrypt(data)
+}
+
+// NewNegotiablePackEncrypter returns the pack encryptor for the connecting side (agent, browser, etc.),
+// it's initialized by the passed config and switches to the encryptor of the type which is chosen
+// by the server in the tunnel config on Reset
+func NewNegotiablePackEncrypter(c *Config) (PackEncryptor, error) {
+	e, err := NewPackEncrypter(c)
+	if err != nil {
+		return nil, err
+	}
+	return &negotiableEncryptor{current: e}, nil
+}
+
+// SupportedCiphers returns the list of AEAD ciphers which the pack encryptor may be switched to,
+// the list is sent to the server during the connection challenge
+func SupportedCiphers(e PackEncryptor) []string {
+	if _, ok := e.(*negotiableEncryptor); !ok {
+		return nil
+	}
+	return append([]string{}, tunnelAEAD.SupportedCiphers...)
+}
+
+// GetOffer returns the tunnel features which the pack encryptor may be switched to,
+// the legacy pack encryptor doesn't support negotiation so it offers nothing
+func GetOffer(e PackEncryptor) *Offer {
+	if _, ok := e.(*negotiableEncryptor); !ok {
+		return &Offer{}
+	}
+	return &Offer{
+		Ciphers:      SupportedCiphers(e),
+		Compressors:  append([]string{}, compressor.SupportedCompressors...),
+		Dictionaries: compressor.Dictionaries(),
+	}
+}
+
+type negotiableEncryptor struct {
+	current PackEncryptor
+	mx      sync.RWMutex
+}
+
+func (e *negotiableEncryptor) Encrypt(data []byte) ([]byte, error) {
+	return e.get().Encrypt(data)
+}
+
+func (e *negotiableEncryptor) Decrypt(data []byte) ([]byte, error) {
+	return e.get().Decrypt(data)
+}
+
+func (e *negotiableEncryptor) EncryptModule(module string, data []byte) ([]byte, error) {
+	return EncryptModule(e.get(), module, data)
+}
+
+func (e *negotiableEncryptor) Reset(config *protoagent.TunnelConfig) error {
+	var comp compressor.Compressor
+	if compression := config.GetCompression(); compression != nil {
+		var err error
+		comp, err = compressor.New(&compressor.Config{
+			Name:             compression.GetName(),
+			PeerDictionaries: compression.GetDictionaries(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to initialize the tunnel compressor: %w", err)
+		}
+	}
+
+	var next PackEncryptor
+	switch c := config.GetConfig().(type) {
+	case *protoagent.TunnelConfig_Aead:
+		aeadEncryptor, err := tunnelAEAD.New(&tunnelAEAD.Config{
+			Cipher:     c.Aead.GetCipher(),
+			Key:        c.Aead.GetKey(),
+			Compressor: comp,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to initialize the AEAD pack encryptor: %w", err)
+		}
+		next = aeadEncryptor
+	case *protoagent.TunnelConfig_Lua:
+		next = tunnelRC4.New(&tunnelRC4.Config{Key: c.Lua.GetKey(), Compressor: comp})
+	case *protoagent.TunnelConfig_Simple:
+		next = tunnelSimple.New(&tunnelSimple.Config{Key: byte(c.Simple.GetKey()), Compressor: comp})
+	default:
+		return fmt.Errorf("unsupported tunnel config type %T", c)
+	}
+
+	e.mx.Lock()
+	defer e.mx.Unlock()
+	e.current = next
+	return nil
+}
+
+func (e *negotiableEncryptor) get() PackEncryptor {
+	e.mx.RLock()
+	defer e.mx.RUnlock()
+	return e.current
+}
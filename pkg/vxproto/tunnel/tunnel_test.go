@@ -0,0 +1,140 @@
+package tunnel
+
+import (
+	"bytes"
+	"testing"
+
+	"soldr/pkg/protoagent"
+	tunnelAEAD "soldr/pkg/vxproto/tunnel/aead"
+	"soldr/pkg/vxproto/tunnel/compressor"
+	tunnelSimple "soldr/pkg/vxproto/tunnel/simple"
+)
+
+func TestNegotiablePackEncrypter(t *testing.T) {
+	agent, err := NewNegotiablePackEncrypter(&Config{Simple: &tunnelSimple.Config{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ciphers := SupportedCiphers(agent); len(ciphers) == 0 {
+		t.Fatalf("negotiable encryptor must advertise AEAD ciphers")
+	}
+	legacy, err := NewPackEncrypter(&Config{Simple: &tunnelSimple.Config{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ciphers := SupportedCiphers(legacy); len(ciphers) != 0 {
+		t.Fatalf("legacy encryptor must not advertise AEAD ciphers")
+	}
+
+	// the old server configures the simple tunnel
+	simpleKey := uint32(0x5a)
+	err = agent.Reset(&protoagent.TunnelConfig{
+		Config: &protoagent.TunnelConfig_Simple{
+			Simple: &protoagent.TunnelConfig_TunnelConfigSimple{Key: &simpleKey},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to switch to the simple tunnel: %v", err)
+	}
+	checkTunnel(t, newPackEncrypterOrFail(t, &Config{Simple: &tunnelSimple.Config{Key: 0x5a}}), agent)
+
+	// the new server configures the AEAD tunnel
+	key := bytes.Repeat([]byte{0x01}, tunnelAEAD.KeyLen)
+	cipher := tunnelAEAD.CipherChaCha20Poly1305
+	err = agent.Reset(&protoagent.TunnelConfig{
+		Config: &protoagent.TunnelConfig_Aead{
+			Aead: &protoagent.TunnelConfig_TunnelConfigAEAD{Cipher: &cipher, Key: key},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to switch to the AEAD tunnel: %v", err)
+	}
+	checkTunnel(t, newPackEncrypterOrFail(t, &Config{
+		AEAD: &tunnelAEAD.Config{Cipher: cipher, Key: key, IsServer: true},
+	}), agent)
+
+	if err = agent.Reset(&protoagent.TunnelConfig{}); err == nil {
+		t.Fatalf("reset by the empty config must fail")
+	}
+}
+
+func TestNegotiableCompression(t *testing.T) {
+	agent, err := NewNegotiablePackEncrypter(&Config{Simple: &tunnelSimple.Config{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	offer := GetOffer(agent)
+	name, ok := compressor.SelectCompressor(offer.Compressors)
+	if !ok || name != compressor.Zstd {
+		t.Fatalf("negotiable encryptor must prefer zstd compressor, got '%s'", name)
+	}
+	if offer := GetOffer(newPackEncrypterOrFail(t, &Config{Simple: &tunnelSimple.Config{}})); len(offer.Compressors) != 0 {
+		t.Fatalf("legacy encryptor must not advertise compressors")
+	}
+
+	key := bytes.Repeat([]byte{0x02}, tunnelAEAD.KeyLen)
+	cipher := tunnelAEAD.CipherAES256GCM
+	err = agent.Reset(&protoagent.TunnelConfig{
+		Config: &protoagent.TunnelConfig_Aead{
+			Aead: &protoagent.TunnelConfig_TunnelConfigAEAD{Cipher: &cipher, Key: key},
+		},
+		Compression: &protoagent.TunnelConfig_TunnelCompression{Name: &name},
+	})
+	if err != nil {
+		t.Fatalf("failed to switch to the AEAD tunnel with zstd compression: %v", err)
+	}
+	server := newPackEncrypterOrFail(t, &Config{
+		AEAD:        &tunnelAEAD.Config{Cipher: cipher, Key: key, IsServer: true},
+		Compression: &compressor.Config{Name: name},
+	})
+	checkTunnel(t, server, agent)
+
+	// the server which is configured by lz4 compressor can't read packets which are compressed by zstd
+	legacy := newPackEncrypterOrFail(t, &Config{
+		AEAD: &tunnelAEAD.Config{Cipher: cipher, Key: key, IsServer: true},
+	})
+	ct, err := EncryptModule(agent, "test", bytes.Repeat([]byte("packet"), 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pt, err := legacy.Decrypt(ct); err == nil && bytes.Equal(pt, bytes.Repeat([]byte("packet"), 100)) {
+		t.Fatalf("compressors of both sides must be negotiated")
+	}
+
+	unknown := "brotli"
+	err = agent.Reset(&protoagent.TunnelConfig{
+		Config: &protoagent.TunnelConfig_Aead{
+			Aead: &protoagent.TunnelConfig_TunnelConfigAEAD{Cipher: &cipher, Key: key},
+		},
+		Compression: &protoagent.TunnelConfig_TunnelCompression{Name: &unknown},
+	})
+	if err == nil {
+		t.Fatalf("reset by the unknown compressor must fail")
+	}
+}
+
+func newPackEncrypterOrFail(t *testing.T, c *Config) PackEncryptor {
+	t.Helper()
+	e, err := NewPackEncrypter(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func checkTunnel(t *testing.T, server, agent PackEncryptor) {
+	t.Helper()
+	for _, pair := range [][2]PackEncryptor{{server, agent}, {agent, server}} {
+		ct, err := pair[0].Encrypt([]byte("packet"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		pt, err := pair[1].Decrypt(ct)
+		if err != nil {
+			t.Fatalf("failed to decrypt the packet: %v", err)
+		}
+		if string(pt) != "packet" {
+			t.Fatalf("decrypted packet doesn't match the original one")
+		}
+	}
+}
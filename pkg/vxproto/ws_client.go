@@ -9,7 +9,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
 
 	obs "soldr/pkg/observability"
@@ -49,13 +48,14 @@ func (vxp *vxProto) openAgentSocket(
 	if config.Type == "aggregate" || config.Type == "browser" || config.Type == "external" {
 		return nil, nil, fmt.Errorf("connection initialization for the browser type is NYI")
 	}
-	dialer := websocket.Dialer{
-		TLSClientConfig: config.TLSConfig,
-	}
 	u, err := getAgentSocketURL(config.Host, config.ID, config.ProtocolVersion)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get the URL for the agent socket: %w", err)
 	}
+	dialer, err := newWSDialer(config.CommonConfig, u)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to prepare the dialer for the agent socket: %w", err)
+	}
 
 	ctx, cancelCtx := context.WithCancel(ctx)
 	defer func() {
@@ -103,6 +103,7 @@ func (vxp *vxProto) openAgentSocket(
 		IProtoStats:      vxp,
 		IProtoIO:         vxp,
 		connectionPolicy: newAllowPacketChecker(),
+		limiter:          newRateLimiter(vxp.getAgentRateLimits()),
 	}
 	if vxp.IMainModule != nil && connValidator != nil {
 		err := connValidator.OnConnect(ctx, socket, tunnelEncrypter, getConfigurePingeeFn(socket), agentInfo)
@@ -174,14 +175,14 @@ func openAgentSocketToInitConnection(ctx context.Context, logger *logrus.Entry,
 	if config.Type == "aggregate" || config.Type == "browser" || config.Type == "external" {
 		return nil, fmt.Errorf("connection initialization for the browser type is NYI")
 	}
-	dialer := websocket.Dialer{
-		TLSClientConfig: config.TLSConfig,
-	}
-
 	u, err := getInitConnURL(config.Host, config.ProtocolVersion)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get an init connection URL: %w", err)
 	}
+	dialer, err := newWSDialer(config.CommonConfig, u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the dialer for the init connection: %w", err)
+	}
 	ws, _, err := dialer.Dial(u.String(), nil)
 	if err != nil {
 		return nil, err
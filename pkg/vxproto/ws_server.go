@@ -499,6 +499,7 @@ func prepareAgentConnection(
 		at:               agentType,
 		auth:             &AuthenticationData{},
 		connectionPolicy: connectionPolicy,
+		limiter:          newRateLimiter(vxp.getAgentRateLimits()),
 		IConnection:      NewWSConnection(ws, false),
 		IVaildator:       vxp,
 		IMMInformator:    vxp,